@@ -265,6 +265,9 @@ func (b *SimulatedBackend) callContract(ctx context.Context, call kowala.CallMsg
 	// Set infinite balance to the fake caller account.
 	from := statedb.GetOrNewStateObject(call.From)
 	from.SetBalance(math.MaxBig256)
+	if call.Sponsor != nil {
+		statedb.GetOrNewStateObject(*call.Sponsor).SetBalance(math.MaxBig256)
+	}
 	// Execute the call.
 	msg := callmsg{call}
 
@@ -390,14 +393,16 @@ type callmsg struct {
 	kowala.CallMsg
 }
 
-func (m callmsg) From() common.Address { return m.CallMsg.From }
-func (m callmsg) Nonce() uint64        { return 0 }
-func (m callmsg) CheckNonce() bool     { return false }
-func (m callmsg) To() *common.Address  { return m.CallMsg.To }
-func (m callmsg) GasPrice() *big.Int   { return m.CallMsg.GasPrice }
-func (m callmsg) Gas() uint64          { return m.CallMsg.Gas }
-func (m callmsg) Value() *big.Int      { return m.CallMsg.Value }
-func (m callmsg) Data() []byte         { return m.CallMsg.Data }
+func (m callmsg) From() common.Address     { return m.CallMsg.From }
+func (m callmsg) Nonce() uint64            { return 0 }
+func (m callmsg) CheckNonce() bool         { return false }
+func (m callmsg) To() *common.Address      { return m.CallMsg.To }
+func (m callmsg) GasPrice() *big.Int       { return m.CallMsg.GasPrice }
+func (m callmsg) Gas() uint64              { return m.CallMsg.Gas }
+func (m callmsg) Value() *big.Int          { return m.CallMsg.Value }
+func (m callmsg) Data() []byte             { return m.CallMsg.Data }
+func (m callmsg) FeeCap() *big.Int         { return m.CallMsg.FeeCap }
+func (m callmsg) Sponsor() *common.Address { return m.CallMsg.Sponsor }
 
 // filterBackend implements filters.Backend to support filtering for logs without
 // taking bloom-bits acceleration structures into account.
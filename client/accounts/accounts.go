@@ -98,6 +98,11 @@ type Wallet interface {
 	// the account in a keystore).
 	SignTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
 
+	// SignSponsorTx requests the wallet to sign the given sponsored transaction as
+	// its sponsor, agreeing to pay its fees. The transaction must already be signed
+	// by its sender.
+	SignSponsorTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
 	// SignProposal requests the wallet to sign the given proposal.
 	SignProposal(account Account, proposal *types.Proposal, chainID *big.Int) (*types.Proposal, error)
 
@@ -118,6 +123,11 @@ type Wallet interface {
 	// or optionally with the aid of any location metadata from the embedded URL field.
 	SignTxWithPassphrase(account Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
 
+	// SignSponsorTxWithPassphrase requests the wallet to sign the given sponsored
+	// transaction as its sponsor, with the given passphrase as extra authentication
+	// information.
+	SignSponsorTxWithPassphrase(account Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
 	NewKeyedTransactor(account Account, auth string) (*TransactOpts, error)
 }
 
@@ -281,6 +281,21 @@ func (ks *KeyStore) SignTx(a accounts.Account, tx *types.Transaction, chainID *b
 	return types.SignTx(tx, types.NewAndromedaSigner(chainID), unlockedKey.PrivateKey)
 }
 
+// SignSponsorTx signs the given sponsored transaction as its sponsor with the
+// requested account.
+func (ks *KeyStore) SignSponsorTx(a accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Look up the key to sign with and abort if it cannot be found
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	unlockedKey, found := ks.unlocked[a.Address]
+	if !found {
+		return nil, ErrLocked
+	}
+
+	return types.SignSponsorTx(tx, types.NewAndromedaSigner(chainID), unlockedKey.PrivateKey)
+}
+
 // SignVote signs the given vote with the requested account.
 func (ks *KeyStore) SignVote(a accounts.Account, vote *types.Vote, chainID *big.Int) (*types.Vote, error) {
 	// Look up the key to sign with and abort if it cannot be found
@@ -333,6 +348,19 @@ func (ks *KeyStore) SignTxWithPassphrase(a accounts.Account, passphrase string,
 	return types.SignTx(tx, types.NewAndromedaSigner(chainID), key.PrivateKey)
 }
 
+// SignSponsorTxWithPassphrase signs the sponsored transaction as its sponsor if
+// the private key matching the given address can be decrypted with the given
+// passphrase.
+func (ks *KeyStore) SignSponsorTxWithPassphrase(a accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	_, key, err := ks.getDecryptedKey(a, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroKey(key.PrivateKey)
+
+	return types.SignSponsorTx(tx, types.NewAndromedaSigner(chainID), key.PrivateKey)
+}
+
 // Unlock unlocks the given account indefinitely.
 func (ks *KeyStore) Unlock(a accounts.Account, passphrase string) error {
 	return ks.TimedUnlock(a, passphrase, 0)
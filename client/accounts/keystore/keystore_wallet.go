@@ -97,6 +97,20 @@ func (w *keystoreWallet) SignTx(account accounts.Account, tx *types.Transaction,
 	return w.keystore.SignTx(account, tx, chainID)
 }
 
+// SignSponsorTx implements accounts.Wallet, attempting to sign the given
+// sponsored transaction as its sponsor with the given account.
+func (w *keystoreWallet) SignSponsorTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Make sure the requested account is contained within
+	if account.Address != w.account.Address {
+		return nil, accounts.ErrUnknownAccount
+	}
+	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
+		return nil, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.SignSponsorTx(account, tx, chainID)
+}
+
 func (w *keystoreWallet) SignProposal(account accounts.Account, proposal *types.Proposal, chainID *big.Int) (*types.Proposal, error) {
 	// Make sure the requested account is contained within
 	if account.Address != w.account.Address {
@@ -149,6 +163,21 @@ func (w *keystoreWallet) SignTxWithPassphrase(account accounts.Account, passphra
 	return w.keystore.SignTxWithPassphrase(account, passphrase, tx, chainID)
 }
 
+// SignSponsorTxWithPassphrase implements accounts.Wallet, attempting to sign the
+// given sponsored transaction as its sponsor with the given account using
+// passphrase as extra authentication.
+func (w *keystoreWallet) SignSponsorTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	// Make sure the requested account is contained within
+	if account.Address != w.account.Address {
+		return nil, accounts.ErrUnknownAccount
+	}
+	if account.URL != (accounts.URL{}) && account.URL != w.account.URL {
+		return nil, accounts.ErrUnknownAccount
+	}
+	// Account seems valid, request the keystore to sign
+	return w.keystore.SignSponsorTxWithPassphrase(account, passphrase, tx, chainID)
+}
+
 func (w *keystoreWallet) NewKeyedTransactor(account accounts.Account, auth string) (*accounts.TransactOpts, error) {
 	// Make sure the requested account is contained within
 	if account.Address != w.account.Address {
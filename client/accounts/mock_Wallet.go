@@ -187,6 +187,52 @@ func (_m *MockWallet) SignProposal(account Account, proposal *types.Proposal, ch
 	return r0, r1
 }
 
+// SignSponsorTx provides a mock function with given fields: account, tx, chainID
+func (_m *MockWallet) SignSponsorTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	ret := _m.Called(account, tx, chainID)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(Account, *types.Transaction, *big.Int) *types.Transaction); ok {
+		r0 = rf(account, tx, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Account, *types.Transaction, *big.Int) error); ok {
+		r1 = rf(account, tx, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SignSponsorTxWithPassphrase provides a mock function with given fields: account, passphrase, tx, chainID
+func (_m *MockWallet) SignSponsorTxWithPassphrase(account Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	ret := _m.Called(account, passphrase, tx, chainID)
+
+	var r0 *types.Transaction
+	if rf, ok := ret.Get(0).(func(Account, string, *types.Transaction, *big.Int) *types.Transaction); ok {
+		r0 = rf(account, passphrase, tx, chainID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Transaction)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(Account, string, *types.Transaction, *big.Int) error); ok {
+		r1 = rf(account, passphrase, tx, chainID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SignTx provides a mock function with given fields: account, tx, chainID
 func (_m *MockWallet) SignTx(account Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
 	ret := _m.Called(account, tx, chainID)
@@ -555,6 +555,20 @@ func (w *wallet) SignTxWithPassphrase(account accounts.Account, passphrase strin
 	return w.SignTx(account, tx, chainID)
 }
 
+// SignSponsorTx implements accounts.Wallet, however sponsoring transactions is
+// not supported by the device firmwares, so this method will always return an
+// error.
+func (w *wallet) SignSponsorTx(account accounts.Account, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return nil, accounts.ErrNotSupported
+}
+
+// SignSponsorTxWithPassphrase implements accounts.Wallet, however sponsoring
+// transactions is not supported by the device firmwares, so this method will
+// always return an error.
+func (w *wallet) SignSponsorTxWithPassphrase(account accounts.Account, passphrase string, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return w.SignSponsorTx(account, tx, chainID)
+}
+
 func (w *wallet) NewKeyedTransactor(account accounts.Account, auth string) (*accounts.TransactOpts, error) {
 	return nil, nil
 }
\ No newline at end of file
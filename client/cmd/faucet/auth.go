@@ -0,0 +1,348 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/crypto"
+)
+
+// fundRequest is a funding request as received through the websocket or the
+// HTTP API.
+type fundRequest struct {
+	URL      string `json:"url"`      // Social post, or plain text holding the address to fund
+	Tier     uint   `json:"tier"`     // Funding tier requested
+	Captcha  string `json:"captcha"`  // Recaptcha response, if enabled
+	Token    string `json:"token"`    // Access token for the token authentication mode
+	Currency string `json:"currency"` // Currency to fund, kusd if empty
+}
+
+// identity is the result of authenticating a funding request.
+type identity struct {
+	username string         // Name of the user to rate limit
+	avatar   string         // Avatar URL to make the UI nicer
+	address  common.Address // Kowala address to fund
+}
+
+// authenticator verifies funding requests for one authentication mode.
+type authenticator interface {
+	// matches returns whether the request is meant for this authenticator.
+	matches(req *fundRequest) bool
+
+	// authenticate verifies the request, returning the identity to fund.
+	authenticate(req *fundRequest) (*identity, error)
+
+	// local returns whether the request is verified without any remote service,
+	// in which case captchas are not required either.
+	local() bool
+}
+
+// newAuthenticators creates the authenticators of the comma separated list of
+// authentication modes, in the same order they will be tried.
+func newAuthenticators(modes string, tokensFile string, allowlistFile string) ([]authenticator, error) {
+	var auths []authenticator
+	for _, mode := range strings.Split(modes, ",") {
+		switch strings.TrimSpace(mode) {
+		case "":
+		case "twitter":
+			auths = append(auths, &socialAuth{prefix: "https://twitter.com/", auth: authTwitter})
+		case "googleplus":
+			auths = append(auths, &socialAuth{prefix: "https://plus.google.com/", auth: authGooglePlus})
+		case "facebook":
+			auths = append(auths, &socialAuth{prefix: "https://www.facebook.com/", auth: authFacebook})
+		case "token":
+			tokens, err := readLines(tokensFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read auth tokens: %v", err)
+			}
+			auths = append(auths, newTokenAuth(tokens))
+		case "allowlist":
+			addresses, err := readLines(allowlistFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read auth allowlist: %v", err)
+			}
+			auth, err := newAllowlistAuth(addresses)
+			if err != nil {
+				return nil, err
+			}
+			auths = append(auths, auth)
+		case "noauth":
+			auths = append(auths, noAuth{})
+		default:
+			return nil, fmt.Errorf("unknown authentication mode %q", mode)
+		}
+	}
+	if len(auths) == 0 {
+		return nil, errors.New("no authentication mode enabled")
+	}
+	return auths, nil
+}
+
+// readLines reads the non empty lines of a file, ignoring # comments.
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines, scanner.Err()
+}
+
+// socialAuth authenticates requests linking to a public post of a social
+// network holding the address to fund.
+type socialAuth struct {
+	prefix string
+	auth   func(url string) (string, string, common.Address, error)
+}
+
+func (a *socialAuth) matches(req *fundRequest) bool {
+	return strings.HasPrefix(req.URL, a.prefix)
+}
+
+func (a *socialAuth) local() bool {
+	return false
+}
+
+func (a *socialAuth) authenticate(req *fundRequest) (*identity, error) {
+	username, avatar, address, err := a.auth(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &identity{username: username, avatar: avatar, address: address}, nil
+}
+
+// tokenAuth authenticates requests carrying one of the configured access tokens.
+// Funding timeouts are tracked per token, so every token acts as a user.
+type tokenAuth struct {
+	tokens map[string]struct{}
+}
+
+func newTokenAuth(tokens []string) *tokenAuth {
+	auth := &tokenAuth{tokens: make(map[string]struct{})}
+	for _, token := range tokens {
+		auth.tokens[token] = struct{}{}
+	}
+	return auth
+}
+
+func (a *tokenAuth) matches(req *fundRequest) bool {
+	return req.Token != ""
+}
+
+func (a *tokenAuth) local() bool {
+	return true
+}
+
+func (a *tokenAuth) authenticate(req *fundRequest) (*identity, error) {
+	if _, ok := a.tokens[req.Token]; !ok {
+		return nil, errors.New("Invalid access token")
+	}
+	address, err := addressFromText(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	// Don't leak the token itself into the logs and the database
+	username := fmt.Sprintf("%x@token", crypto.Keccak256([]byte(req.Token))[:4])
+	return &identity{username: username, address: address}, nil
+}
+
+// allowlistAuth authenticates requests funding one of the configured addresses.
+type allowlistAuth struct {
+	addresses map[common.Address]struct{}
+}
+
+func newAllowlistAuth(addresses []string) (*allowlistAuth, error) {
+	auth := &allowlistAuth{addresses: make(map[common.Address]struct{})}
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return nil, fmt.Errorf("invalid allowlisted address %q", address)
+		}
+		auth.addresses[common.HexToAddress(address)] = struct{}{}
+	}
+	return auth, nil
+}
+
+func (a *allowlistAuth) matches(req *fundRequest) bool {
+	_, err := addressFromText(req.URL)
+	return err == nil
+}
+
+func (a *allowlistAuth) local() bool {
+	return true
+}
+
+func (a *allowlistAuth) authenticate(req *fundRequest) (*identity, error) {
+	address, err := addressFromText(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := a.addresses[address]; !ok {
+		return nil, errors.New("Address not allowed to request funds")
+	}
+	return &identity{username: address.Hex() + "@allowlist", address: address}, nil
+}
+
+// noAuth interprets a faucet request as a plain Kowala address, without actually
+// performing any remote authentication. This mode is prone to Byzantine attack,
+// so only ever use for truly private networks.
+type noAuth struct{}
+
+func (noAuth) matches(req *fundRequest) bool {
+	return true
+}
+
+func (noAuth) local() bool {
+	return false
+}
+
+func (noAuth) authenticate(req *fundRequest) (*identity, error) {
+	username, avatar, address, err := authNoAuth(req.URL)
+	if err != nil {
+		return nil, err
+	}
+	return &identity{username: username, avatar: avatar, address: address}, nil
+}
+
+// addressFromText extracts the first Kowala address found in the text.
+func addressFromText(text string) (common.Address, error) {
+	address := common.HexToAddress(regexp.MustCompile("0x[0-9a-fA-F]{40}").FindString(text))
+	if address == (common.Address{}) {
+		return common.Address{}, errors.New("No Kowala address found to fund")
+	}
+	return address, nil
+}
+
+// authTwitter tries to authenticate a faucet request using Twitter posts, returning
+// the username, avatar URL and Kowala address to fund on success.
+func authTwitter(url string) (string, string, common.Address, error) {
+	// Ensure the user specified a meaningful URL, no fancy nonsense
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "status" {
+		return "", "", common.Address{}, errors.New("Invalid Twitter status URL")
+	}
+	// Twitter's API isn't really friendly with direct links. Still, we don't
+	// want to do ask read permissions from users, so just load the public posts and
+	// scrape it for the Kowala address and profile URL.
+	res, err := http.Get(url)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	defer res.Body.Close()
+
+	// Resolve the username from the final redirect, no intermediate junk
+	parts = strings.Split(res.Request.URL.String(), "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "status" {
+		return "", "", common.Address{}, errors.New("Invalid Twitter status URL")
+	}
+	username := parts[len(parts)-3]
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
+	if address == (common.Address{}) {
+		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
+	}
+	var avatar string
+	if parts = regexp.MustCompile("src=\"([^\"]+twimg.com/profile_images[^\"]+)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
+		avatar = parts[1]
+	}
+	return username + "@twitter", avatar, address, nil
+}
+
+// authGooglePlus tries to authenticate a faucet request using GooglePlus posts,
+// returning the username, avatar URL and Kowala address to fund on success.
+func authGooglePlus(url string) (string, string, common.Address, error) {
+	// Ensure the user specified a meaningful URL, no fancy nonsense
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "posts" {
+		return "", "", common.Address{}, errors.New("Invalid Google+ post URL")
+	}
+	username := parts[len(parts)-3]
+
+	// Google's API isn't really friendly with direct links. Still, we don't
+	// want to do ask read permissions from users, so just load the public posts and
+	// scrape it for the Kowala address and profile URL.
+	res, err := http.Get(url)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
+	if address == (common.Address{}) {
+		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
+	}
+	var avatar string
+	if parts = regexp.MustCompile("src=\"([^\"]+googleusercontent.com[^\"]+photo.jpg)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
+		avatar = parts[1]
+	}
+	return username + "@google+", avatar, address, nil
+}
+
+// authFacebook tries to authenticate a faucet request using Facebook posts,
+// returning the username, avatar URL and Kowala address to fund on success.
+func authFacebook(url string) (string, string, common.Address, error) {
+	// Ensure the user specified a meaningful URL, no fancy nonsense
+	parts := strings.Split(url, "/")
+	if len(parts) < 4 || parts[len(parts)-2] != "posts" {
+		return "", "", common.Address{}, errors.New("Invalid Facebook post URL")
+	}
+	username := parts[len(parts)-3]
+
+	// Facebook's Graph API isn't really friendly with direct links. Still, we don't
+	// want to do ask read permissions from users, so just load the public posts and
+	// scrape it for the Kowala address and profile URL.
+	res, err := http.Get(url)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	defer res.Body.Close()
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
+	if address == (common.Address{}) {
+		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
+	}
+	var avatar string
+	if parts = regexp.MustCompile("src=\"([^\"]+fbcdn.net[^\"]+)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
+		avatar = parts[1]
+	}
+	return username + "@facebook", avatar, address, nil
+}
+
+// authNoAuth tries to interpret a faucet request as a plain Kowala address,
+// without actually performing any remote authentication. This mode is prone to
+// Byzantine attack, so only ever use for truly private networks.
+func authNoAuth(url string) (string, string, common.Address, error) {
+	address, err := addressFromText(url)
+	if err != nil {
+		return "", "", common.Address{}, err
+	}
+	return address.Hex() + "@noauth", "", address, nil
+}
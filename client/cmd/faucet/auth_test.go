@@ -0,0 +1,103 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+)
+
+func TestAuthenticators(t *testing.T) {
+	addr := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	auth, err := newAllowlistAuth([]string{addr.Hex()})
+	if err != nil {
+		t.Fatalf("failed to create allowlist authenticator: %v", err)
+	}
+	if id, err := auth.authenticate(&fundRequest{URL: "fund " + addr.Hex() + " please"}); err != nil || id.address != addr {
+		t.Errorf("allowlisted address rejected: %v", err)
+	}
+	if _, err := auth.authenticate(&fundRequest{URL: "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a"}); err == nil {
+		t.Errorf("address outside of the allowlist accepted")
+	}
+	if _, err := newAllowlistAuth([]string{"not an address"}); err == nil {
+		t.Errorf("invalid allowlisted address accepted")
+	}
+
+	tokens := newTokenAuth([]string{"secret", "other"})
+	if tokens.matches(&fundRequest{URL: addr.Hex()}) {
+		t.Errorf("token authenticator matched request without token")
+	}
+	if _, err := tokens.authenticate(&fundRequest{URL: addr.Hex(), Token: "wrong"}); err == nil {
+		t.Errorf("invalid token accepted")
+	}
+	id, err := tokens.authenticate(&fundRequest{URL: addr.Hex(), Token: "secret"})
+	if err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+	if id.address != addr {
+		t.Errorf("funded address mismatch: have %x, want %x", id.address, addr)
+	}
+	if strings.Contains(id.username, "secret") || strings.Contains(id.username, common.Bytes2Hex([]byte("secret"))) {
+		t.Errorf("token leaked into the username %q", id.username)
+	}
+	// every token acts as a different user
+	if other, err := tokens.authenticate(&fundRequest{URL: addr.Hex(), Token: "other"}); err != nil || other.username == id.username {
+		t.Errorf("tokens share the username %q", id.username)
+	}
+
+	if id, err := (noAuth{}).authenticate(&fundRequest{URL: addr.Hex()}); err != nil || id.address != addr {
+		t.Errorf("plain address rejected without authentication: %v", err)
+	}
+	if _, err := (noAuth{}).authenticate(&fundRequest{URL: "no address"}); err == nil {
+		t.Errorf("request without address accepted")
+	}
+}
+
+func TestNewAuthenticators(t *testing.T) {
+	dir, err := ioutil.TempDir("", "faucet-auth")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokensFile := dir + "/tokens"
+	if err := ioutil.WriteFile(tokensFile, []byte("# access tokens\nsecret\n\n"), 0600); err != nil {
+		t.Fatalf("failed to write tokens: %v", err)
+	}
+	auths, err := newAuthenticators("twitter, token,noauth", tokensFile, "")
+	if err != nil {
+		t.Fatalf("failed to create authenticators: %v", err)
+	}
+	if len(auths) != 3 {
+		t.Fatalf("authenticators mismatch: have %d, want 3", len(auths))
+	}
+	if !auths[0].matches(&fundRequest{URL: "https://twitter.com/user/status/1"}) || auths[0].local() {
+		t.Errorf("twitter authenticator mismatch")
+	}
+	if tokens, ok := auths[1].(*tokenAuth); !ok || len(tokens.tokens) != 1 {
+		t.Errorf("token authenticator mismatch: have %#v", auths[1])
+	}
+	if _, ok := auths[2].(noAuth); !ok {
+		t.Errorf("noauth authenticator mismatch: have %#v", auths[2])
+	}
+
+	tests := []struct {
+		modes     string
+		tokens    string
+		allowlist string
+	}{
+		{"", "", ""},                        // no mode enabled
+		{"github", "", ""},                  // unknown mode
+		{"token", dir + "/missing", ""},     // missing tokens file
+		{"allowlist", "", dir + "/missing"}, // missing allowlist file
+		{"allowlist", "", tokensFile},       // invalid allowlisted address
+	}
+	for i, tt := range tests {
+		if _, err := newAuthenticators(tt.modes, tt.tokens, tt.allowlist); err == nil {
+			t.Errorf("test %d: authenticators created for %q", i, tt.modes)
+		}
+	}
+}
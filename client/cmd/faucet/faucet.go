@@ -15,11 +15,11 @@ import (
 	"io/ioutil"
 	"math"
 	"math/big"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -32,6 +32,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/core"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/kcoinclient"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
 	"github.com/kowala-tech/kcoin/client/knode"
 	"github.com/kowala-tech/kcoin/client/knode/downloader"
 	genesis2 "github.com/kowala-tech/kcoin/client/knode/genesis"
@@ -58,6 +59,13 @@ var (
 	payoutFlag  = flag.Int("faucet.amount", 1, "Number of kUSDs to pay out per user request")
 	minutesFlag = flag.Int("faucet.minutes", 1440, "Number of minutes to wait between funding rounds")
 	tiersFlag   = flag.Int("faucet.tiers", 3, "Number of funding tiers to enable (x3 time, x2.5 funds)")
+	ipMinsFlag  = flag.Int("faucet.ipminutes", 0, "Number of minutes to wait between funding rounds from the same IP (0 = disabled)")
+	proxyFlag   = flag.String("faucet.proxyheader", "", "Header holding the client IP set by a trusted reverse proxy, such as X-Forwarded-For")
+	authFlag    = flag.String("faucet.auth", "twitter,googleplus,facebook", "Comma separated authentication modes (twitter, googleplus, facebook, token, allowlist, noauth)")
+
+	musdPayoutFlag  = flag.Int("faucet.musd.amount", 0, "Number of mUSDs to pay out per user request (0 = disabled)")
+	musdMinutesFlag = flag.Int("faucet.musd.minutes", 1440, "Number of minutes to wait between mUSD funding rounds")
+	musdModeFlag    = flag.String("faucet.musd.mode", musdTransferMode, "How to dispense mUSD (transfer from the faucet account, or mint through MultiSig proposals)")
 
 	accJSONFlag = flag.String("account.json", "", "Key json file to fund user requests with")
 	accPassFlag = flag.String("account.pass", "", "Decryption password to access faucet funds")
@@ -65,7 +73,10 @@ var (
 	captchaToken  = flag.String("captcha.token", "", "Recaptcha site key to authenticate client side")
 	captchaSecret = flag.String("captcha.secret", "", "Recaptcha secret key to authenticate server side")
 
-	noauthFlag = flag.Bool("noauth", false, "Enables funding requests without authentication")
+	authTokensFlag    = flag.String("auth.tokens", "", "File with the access tokens accepted by the token authentication mode, one per line")
+	authAllowlistFlag = flag.String("auth.allowlist", "", "File with the addresses allowed by the allowlist authentication mode, one per line")
+
+	noauthFlag = flag.Bool("noauth", false, "Enables funding requests without authentication (same as adding noauth to -faucet.auth)")
 	logFlag    = flag.Int("verbosity", 3, "Log level to use for Kowala and the faucet")
 )
 
@@ -104,6 +115,22 @@ func main() {
 			periods[i] = strings.TrimSuffix(periods[i], "s")
 		}
 	}
+	// Assemble the enabled authentication modes
+	modes := *authFlag
+	if *noauthFlag {
+		modes += ",noauth"
+	}
+	auths, err := newAuthenticators(modes, *authTokensFlag, *authAllowlistFlag)
+	if err != nil {
+		log.Crit("Failed to set up faucet authentication", "err", err)
+	}
+	var noauth bool
+	for _, auth := range auths {
+		if _, ok := auth.(noAuth); ok {
+			noauth = true
+		}
+	}
+
 	// Load up and render the faucet website
 	tmpl, err := Asset("faucet.html")
 	if err != nil {
@@ -115,7 +142,7 @@ func main() {
 		"Amounts":   amounts,
 		"Periods":   periods,
 		"Recaptcha": *captchaToken,
-		"NoAuth":    *noauthFlag,
+		"NoAuth":    noauth,
 	})
 	if err != nil {
 		log.Crit("Failed to render the faucet template", "err", err)
@@ -150,7 +177,7 @@ func main() {
 	ks.Unlock(acc, pass)
 
 	// Assemble and start the faucet light service
-	faucet, err := newFaucet(genesis, *kcoinPortFlag, enodes, networkID(), *statsFlag, ks, website.Bytes())
+	faucet, err := newFaucet(genesis, *kcoinPortFlag, enodes, networkID(), *statsFlag, ks, auths, website.Bytes())
 	if err != nil {
 		log.Crit("Failed to start faucet", "err", err)
 	}
@@ -229,15 +256,19 @@ type faucet struct {
 	nonce    uint64             // Current pending nonce of the faucet
 	price    *big.Int           // Current gas price to issue funds with
 
-	conns    []*websocket.Conn    // Currently live websocket connections
-	timeouts map[string]time.Time // History of users and their funding timeouts
-	reqs     []*request           // Currently pending funding requests
-	update   chan struct{}        // Channel to signal request updates
+	auths     []authenticator // Enabled authentication modes, in order of precedence
+	store     *fundingStore   // Persistent history of users, addresses and IPs and their funding timeouts
+	musd      *musdDispenser  // Dispenser of mUSD mining tokens, nil if disabled
+	musdStore *fundingStore   // Persistent history of mUSD funding timeouts, kept apart from the kUSD ones
+
+	conns  []*websocket.Conn // Currently live websocket connections
+	reqs   []*request        // Currently pending funding requests
+	update chan struct{}     // Channel to signal request updates
 
 	lock sync.RWMutex // Lock protecting the faucet's internals
 }
 
-func newFaucet(genesis *core.Genesis, port int, enodes []*discv5.Node, network uint64, stats string, ks *keystore.KeyStore, index []byte) (*faucet, error) {
+func newFaucet(genesis *core.Genesis, port int, enodes []*discv5.Node, network uint64, stats string, ks *keystore.KeyStore, auths []authenticator, index []byte) (*faucet, error) {
 	// Assemble the raw devp2p protocol stack
 	stack, err := node.New(&node.Config{
 		Name:    "kcoin",
@@ -284,20 +315,45 @@ func newFaucet(genesis *core.Genesis, port int, enodes []*discv5.Node, network u
 	}
 	client := kcoinclient.NewClient(api)
 
+	// Open the funding history so rate limits survive restarts
+	db, err := stack.OpenDatabase("funding", 16, 16)
+	if err != nil {
+		stack.Stop()
+		return nil, err
+	}
+
+	// Assemble the mUSD dispenser if enabled
+	var dispenser *musdDispenser
+	if *musdPayoutFlag > 0 {
+		account, err := accounts.NewWalletAccount(ks.Wallets()[0], ks.Accounts()[0])
+		if err != nil {
+			stack.Stop()
+			return nil, err
+		}
+		if dispenser, err = newMUSDDispenser(*musdModeFlag, client, genesis.Config.ChainID, account); err != nil {
+			stack.Stop()
+			return nil, err
+		}
+	}
+
 	return &faucet{
-		config:   genesis.Config,
-		stack:    stack,
-		client:   client,
-		index:    index,
-		keystore: ks,
-		account:  ks.Accounts()[0],
-		timeouts: make(map[string]time.Time),
-		update:   make(chan struct{}, 1),
+		config:    genesis.Config,
+		stack:     stack,
+		client:    client,
+		index:     index,
+		keystore:  ks,
+		account:   ks.Accounts()[0],
+		auths:     auths,
+		store:     newFundingStore(db),
+		musd:      dispenser,
+		musdStore: newFundingStore(kcoindb.NewTable(db, musdCurrency+"-")),
+		update:    make(chan struct{}, 1),
 	}, nil
 }
 
 // close terminates the Kowala connection and tears down the faucet.
 func (f *faucet) close() error {
+	f.store.close()
 	return f.stack.Stop()
 }
 
@@ -308,6 +364,8 @@ func (f *faucet) listenAndServe(port int) error {
 
 	http.HandleFunc("/", f.webHandler)
 	http.Handle("/api", websocket.Handler(f.apiHandler))
+	http.HandleFunc("/api/fund", f.fundHandler)
+	http.HandleFunc("/api/stats", f.statsHandler)
 
 	return http.ListenAndServe(fmt.Sprintf(":%d", port), nil)
 }
@@ -339,25 +397,14 @@ func (f *faucet) apiHandler(conn *websocket.Conn) {
 	}()
 	// Gather the initial stats from the network to report
 	var (
-		head    *types.Header
-		balance *big.Int
-		nonce   uint64
-		err     error
+		stats map[string]interface{}
+		head  *types.Header
+		err   error
 	)
 	for {
 		// Attempt to retrieve the stats, may error on no faucet connectivity
-		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
-		head, err = f.client.HeaderByNumber(ctx, nil)
-		if err == nil {
-			balance, err = f.client.BalanceAt(ctx, f.account.Address, head.Number)
-			if err == nil {
-				nonce, err = f.client.NonceAt(ctx, f.account.Address, nil)
-			}
-		}
-		cancel()
-
-		// If stats retrieval failed, wait a bit and retry
-		if err != nil {
+		if stats, head, err = f.stats(); err != nil {
+			// If stats retrieval failed, wait a bit and retry
 
 			// @NOTE (rgeraldes) - Unmarshalling errors are probably due to this return message:
 			// https://github.com/kowala-tech/kcoin/client/blob/dev/internal/kcoinapi/api.go#L698
@@ -373,12 +420,7 @@ func (f *faucet) apiHandler(conn *websocket.Conn) {
 		break
 	}
 	// Send over the initial stats and the latest header
-	if err = send(conn, map[string]interface{}{
-		"funds":    balance.Div(balance, kcoin),
-		"funded":   nonce,
-		"peers":    f.stack.Server().PeerCount(),
-		"requests": f.reqs,
-	}, 3*time.Second); err != nil {
+	if err = send(conn, stats, 3*time.Second); err != nil {
 		log.Warn("Failed to send initial stats to client", "err", err)
 		return
 	}
@@ -387,161 +429,241 @@ func (f *faucet) apiHandler(conn *websocket.Conn) {
 		return
 	}
 	// Keep reading requests from the websocket until the connection breaks
+	ip := remoteIP(conn.Request())
 	for {
-		// Fetch the next funding request and validate against github
-		var msg struct {
-			URL     string `json:"url"`
-			Tier    uint   `json:"tier"`
-			Captcha string `json:"captcha"`
-		}
+		var msg fundRequest
 		if err = websocket.JSON.Receive(conn, &msg); err != nil {
 			return
 		}
-		if !*noauthFlag && !strings.HasPrefix(msg.URL, "https://gist.github.com/") && !strings.HasPrefix(msg.URL, "https://twitter.com/") &&
-			!strings.HasPrefix(msg.URL, "https://plus.google.com/") && !strings.HasPrefix(msg.URL, "https://www.facebook.com/") {
-			if err = sendError(conn, errors.New("URL doesn't link to supported services")); err != nil {
-				log.Warn("Failed to send URL error to client", "err", err)
+		result, err := f.fund(&msg, ip)
+		if err != nil {
+			if err = sendError(conn, err); err != nil {
+				log.Warn("Failed to send funding error to client", "err", err)
 				return
 			}
 			continue
 		}
-		if msg.Tier >= uint(*tiersFlag) {
-			if err = sendError(conn, errors.New("Invalid funding tier requested")); err != nil {
-				log.Warn("Failed to send tier error to client", "err", err)
-				return
-			}
-			continue
+		if err = sendSuccess(conn, result); err != nil {
+			log.Warn("Failed to send funding success to client", "err", err)
+			return
 		}
-		log.Info("Faucet funds requested", "url", msg.URL, "tier", msg.Tier)
+	}
+}
+
+// fundHandler handles funding requests through the plain HTTP JSON API.
+func (f *faucet) fundHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+		return
+	}
+	var msg fundRequest
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid funding request: " + err.Error()})
+		return
+	}
+	result, err := f.fund(&msg, remoteIP(r))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"success": result})
+}
 
-		// If captcha verifications are enabled, make sure we're not dealing with a robot
-		if *captchaToken != "" {
-			form := url.Values{}
-			form.Add("secret", *captchaSecret)
-			form.Add("response", msg.Captcha)
+// statsHandler reports the current faucet stats through the plain HTTP JSON API.
+func (f *faucet) statsHandler(w http.ResponseWriter, r *http.Request) {
+	stats, _, err := f.stats()
+	if err != nil {
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "Faucet offline: " + err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusOK, stats)
+}
 
-			res, err := http.PostForm("https://www.google.com/recaptcha/api/siteverify", form)
-			if err != nil {
-				if err = sendError(conn, err); err != nil {
-					log.Warn("Failed to send captcha post error to client", "err", err)
-					return
-				}
-				continue
-			}
-			var result struct {
-				Success bool            `json:"success"`
-				Errors  json.RawMessage `json:"error-codes"`
-			}
-			err = json.NewDecoder(res.Body).Decode(&result)
-			res.Body.Close()
-			if err != nil {
-				if err = sendError(conn, err); err != nil {
-					log.Warn("Failed to send captcha decode error to client", "err", err)
-					return
-				}
-				continue
-			}
-			if !result.Success {
-				log.Warn("Captcha verification failed", "err", string(result.Errors))
-				if err = sendError(conn, errors.New("Beep-bop, you're a robot!")); err != nil {
-					log.Warn("Failed to send captcha failure to client", "err", err)
-					return
-				}
-				continue
-			}
+// stats retrieves the current faucet stats and the latest header from the network.
+func (f *faucet) stats() (map[string]interface{}, *types.Header, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	head, err := f.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	balance, err := f.client.BalanceAt(ctx, f.account.Address, head.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce, err := f.client.NonceAt(ctx, f.account.Address, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	stats := map[string]interface{}{
+		"funds":  balance.Div(balance, kcoin),
+		"funded": nonce,
+		"peers":  f.stack.Server().PeerCount(),
+	}
+	if f.musd != nil {
+		tokens, err := f.musd.token.BalanceOf(f.account.Address)
+		if err != nil {
+			return nil, nil, err
 		}
-		// Retrieve the Kowala address to fund, the requesting user and a profile picture
-		var (
-			username string
-			avatar   string
-			address  common.Address
-		)
-		switch {
-		case strings.HasPrefix(msg.URL, "https://gist.github.com/"):
-			if err = sendError(conn, errors.New("GitHub authentication discontinued at the official request of GitHub")); err != nil {
-				log.Warn("Failed to send GitHub deprecation to client", "err", err)
-				return
-			}
-			continue
-		case strings.HasPrefix(msg.URL, "https://twitter.com/"):
-			username, avatar, address, err = authTwitter(msg.URL)
-		case strings.HasPrefix(msg.URL, "https://plus.google.com/"):
-			username, avatar, address, err = authGooglePlus(msg.URL)
-		case strings.HasPrefix(msg.URL, "https://www.facebook.com/"):
-			username, avatar, address, err = authFacebook(msg.URL)
-		case *noauthFlag:
-			username, avatar, address, err = authNoAuth(msg.URL)
-		default:
-			err = errors.New("Something funky happened, please open an issue at https://github.com/kowala-tech/kcoin/client/issues")
+		stats["musdFunds"] = tokens.Div(tokens, kcoin)
+	}
+	f.lock.RLock()
+	stats["requests"] = f.reqs
+	f.lock.RUnlock()
+
+	return stats, head, nil
+}
+
+// fund validates and authenticates a funding request originating from the given
+// IP, and issues the funding transaction if none of the rate limits is hit.
+func (f *faucet) fund(msg *fundRequest, ip string) (string, error) {
+	if strings.HasPrefix(msg.URL, "https://gist.github.com/") {
+		return "", errors.New("GitHub authentication discontinued at the official request of GitHub")
+	}
+	var auth authenticator
+	for _, candidate := range f.auths {
+		if candidate.matches(msg) {
+			auth = candidate
+			break
 		}
-		if err != nil {
-			if err = sendError(conn, err); err != nil {
-				log.Warn("Failed to send prefix error to client", "err", err)
-				return
-			}
-			continue
+	}
+	if auth == nil {
+		return "", errors.New("URL doesn't link to supported services")
+	}
+	if msg.Tier >= uint(*tiersFlag) {
+		return "", errors.New("Invalid funding tier requested")
+	}
+	// Every currency has its own payout and its own funding history
+	var (
+		payout  = *payoutFlag
+		minutes = *minutesFlag
+		store   = f.store
+	)
+	switch msg.Currency {
+	case "", kusdCurrency:
+		msg.Currency = kusdCurrency
+	case musdCurrency:
+		if f.musd == nil {
+			return "", errors.New("mUSD funding disabled")
 		}
-		log.Info("Faucet request valid", "url", msg.URL, "tier", msg.Tier, "user", username, "address", address)
+		payout, minutes, store = *musdPayoutFlag, *musdMinutesFlag, f.musdStore
+	default:
+		return "", errors.New("Invalid funding currency requested")
+	}
+	log.Info("Faucet funds requested", "url", msg.URL, "tier", msg.Tier, "currency", msg.Currency, "ip", ip)
 
-		// Ensure the user didn't request funds too recently
-		f.lock.Lock()
-		var (
-			fund    bool
-			timeout time.Time
-		)
-		if timeout = f.timeouts[username]; time.Now().After(timeout) {
-			// User wasn't funded recently, create the funding transaction
-			amount := new(big.Int).Mul(big.NewInt(int64(*payoutFlag)), kcoin)
-			amount = new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(msg.Tier)), nil))
-			amount = new(big.Int).Div(amount, new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(msg.Tier)), nil))
-
-			tx := types.NewTransaction(f.nonce+uint64(len(f.reqs)), address, amount, 21000, f.price, nil)
-			signed, err := f.keystore.SignTx(f.account, tx, f.config.ChainID)
-			if err != nil {
-				f.lock.Unlock()
-				if err = sendError(conn, err); err != nil {
-					log.Warn("Failed to send transaction creation error to client", "err", err)
-					return
-				}
-				continue
-			}
-			// Submit the transaction and mark as funded if successful
-			if err := f.client.SendTransaction(context.Background(), signed); err != nil {
-				f.lock.Unlock()
-				if err = sendError(conn, err); err != nil {
-					log.Warn("Failed to send transaction transmission error to client", "err", err)
-					return
-				}
-				continue
-			}
-			f.reqs = append(f.reqs, &request{
-				Avatar:  avatar,
-				Account: address,
-				Time:    time.Now(),
-				Tx:      signed,
-			})
-			f.timeouts[username] = time.Now().Add(time.Duration(*minutesFlag*int(math.Pow(3, float64(msg.Tier)))) * time.Minute)
-			fund = true
+	// If captcha verifications are enabled, make sure we're not dealing with a robot
+	if *captchaToken != "" && !auth.local() {
+		if err := verifyCaptcha(msg.Captcha); err != nil {
+			return "", err
 		}
-		f.lock.Unlock()
+	}
+	// Retrieve the Kowala address to fund, the requesting user and a profile picture
+	id, err := auth.authenticate(msg)
+	if err != nil {
+		return "", err
+	}
+	log.Info("Faucet request valid", "url", msg.URL, "tier", msg.Tier, "user", id.username, "address", id.address)
 
-		// Send an error if too frequent funding, othewise a success
-		if !fund {
-			if err = sendError(conn, fmt.Errorf("%s left until next allowance", common.PrettyDuration(timeout.Sub(time.Now())))); err != nil { // nolint: gosimple
-				log.Warn("Failed to send funding error to client", "err", err)
-				return
-			}
-			continue
+	// Ensure neither the user, nor the address or the IP requested funds too recently
+	if *ipMinsFlag == 0 {
+		ip = ""
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if timeout := store.timeout(id.username, id.address, ip); time.Now().Before(timeout) {
+		return "", fmt.Errorf("%s left until next allowance", common.PrettyDuration(timeout.Sub(time.Now()))) // nolint: gosimple
+	}
+	// User wasn't funded recently, create the funding transaction
+	amount := new(big.Int).Mul(big.NewInt(int64(payout)), kcoin)
+	amount = new(big.Int).Mul(amount, new(big.Int).Exp(big.NewInt(5), big.NewInt(int64(msg.Tier)), nil))
+	amount = new(big.Int).Div(amount, new(big.Int).Exp(big.NewInt(2), big.NewInt(int64(msg.Tier)), nil))
+
+	var signed *types.Transaction
+	if msg.Currency == musdCurrency {
+		// Token transfers and mint proposals are submitted by the bindings
+		tx, err := f.musd.dispense(id.address, amount, f.nonce+uint64(len(f.reqs)), f.price)
+		if err != nil {
+			return "", err
 		}
-		if err = sendSuccess(conn, fmt.Sprintf("Funding request accepted for %s into %s", username, address.Hex())); err != nil {
-			log.Warn("Failed to send funding success to client", "err", err)
-			return
+		signed = tx
+	} else {
+		tx := types.NewTransaction(f.nonce+uint64(len(f.reqs)), id.address, amount, 21000, f.price, nil)
+		if signed, err = f.keystore.SignTx(f.account, tx, f.config.ChainID); err != nil {
+			return "", err
 		}
-		select {
-		case f.update <- struct{}{}:
-		default:
+		// Submit the transaction and mark as funded if successful
+		if err := f.client.SendTransaction(context.Background(), signed); err != nil {
+			return "", err
+		}
+	}
+	f.reqs = append(f.reqs, &request{
+		Avatar:  id.avatar,
+		Account: id.address,
+		Time:    time.Now(),
+		Tx:      signed,
+	})
+	timeout := time.Now().Add(time.Duration(minutes*int(math.Pow(3, float64(msg.Tier)))) * time.Minute)
+	if err := store.setTimeout(id.username, id.address, ip, timeout, timeout, time.Now().Add(time.Duration(*ipMinsFlag)*time.Minute)); err != nil {
+		log.Error("Failed to persist funding timeouts", "user", id.username, "address", id.address, "err", err)
+	}
+	select {
+	case f.update <- struct{}{}:
+	default:
+	}
+	if msg.Currency == musdCurrency {
+		if f.musd.mode == musdMintMode {
+			return fmt.Sprintf("mUSD mint proposed for %s into %s, pending confirmation by the MultiSig owners", id.username, id.address.Hex()), nil
+		}
+		return fmt.Sprintf("mUSD funding request accepted for %s into %s", id.username, id.address.Hex()), nil
+	}
+	return fmt.Sprintf("Funding request accepted for %s into %s", id.username, id.address.Hex()), nil
+}
+
+// verifyCaptcha checks a recaptcha response against the Google servers.
+func verifyCaptcha(response string) error {
+	form := url.Values{}
+	form.Add("secret", *captchaSecret)
+	form.Add("response", response)
+
+	res, err := http.PostForm("https://www.google.com/recaptcha/api/siteverify", form)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	var result struct {
+		Success bool            `json:"success"`
+		Errors  json.RawMessage `json:"error-codes"`
+	}
+	if err = json.NewDecoder(res.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Success {
+		log.Warn("Captcha verification failed", "err", string(result.Errors))
+		return errors.New("Beep-bop, you're a robot!")
+	}
+	return nil
+}
+
+// remoteIP returns the IP the HTTP request originates from. Behind a trusted
+// reverse proxy, that's the last address the proxy appended to its header, as
+// the ones before it come from the client.
+func remoteIP(r *http.Request) string {
+	if *proxyFlag != "" {
+		if header := r.Header.Get(*proxyFlag); header != "" {
+			ips := strings.Split(header, ",")
+			return strings.TrimSpace(ips[len(ips)-1])
 		}
 	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
 }
 
 // loop keeps waiting for interesting events and pushes them out to connected
@@ -660,120 +782,11 @@ func sendSuccess(conn *websocket.Conn, msg string) error {
 	return send(conn, map[string]string{"success": msg}, time.Second)
 }
 
-// authTwitter tries to authenticate a faucet request using Twitter posts, returning
-// the username, avatar URL and Kowala address to fund on success.
-func authTwitter(url string) (string, string, common.Address, error) {
-	// Ensure the user specified a meaningful URL, no fancy nonsense
-	parts := strings.Split(url, "/")
-	if len(parts) < 4 || parts[len(parts)-2] != "status" {
-		return "", "", common.Address{}, errors.New("Invalid Twitter status URL")
-	}
-	// Twitter's API isn't really friendly with direct links. Still, we don't
-	// want to do ask read permissions from users, so just load the public posts and
-	// scrape it for the Kowala address and profile URL.
-	res, err := http.Get(url)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	defer res.Body.Close()
-
-	// Resolve the username from the final redirect, no intermediate junk
-	parts = strings.Split(res.Request.URL.String(), "/")
-	if len(parts) < 4 || parts[len(parts)-2] != "status" {
-		return "", "", common.Address{}, errors.New("Invalid Twitter status URL")
-	}
-	username := parts[len(parts)-3]
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
-	if address == (common.Address{}) {
-		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
-	}
-	var avatar string
-	if parts = regexp.MustCompile("src=\"([^\"]+twimg.com/profile_images[^\"]+)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
-		avatar = parts[1]
-	}
-	return username + "@twitter", avatar, address, nil
-}
-
-// authGooglePlus tries to authenticate a faucet request using GooglePlus posts,
-// returning the username, avatar URL and Kowala address to fund on success.
-func authGooglePlus(url string) (string, string, common.Address, error) {
-	// Ensure the user specified a meaningful URL, no fancy nonsense
-	parts := strings.Split(url, "/")
-	if len(parts) < 4 || parts[len(parts)-2] != "posts" {
-		return "", "", common.Address{}, errors.New("Invalid Google+ post URL")
-	}
-	username := parts[len(parts)-3]
-
-	// Google's API isn't really friendly with direct links. Still, we don't
-	// want to do ask read permissions from users, so just load the public posts and
-	// scrape it for the Kowala address and profile URL.
-	res, err := http.Get(url)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
-	if address == (common.Address{}) {
-		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
-	}
-	var avatar string
-	if parts = regexp.MustCompile("src=\"([^\"]+googleusercontent.com[^\"]+photo.jpg)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
-		avatar = parts[1]
-	}
-	return username + "@google+", avatar, address, nil
-}
-
-// authFacebook tries to authenticate a faucet request using Facebook posts,
-// returning the username, avatar URL and Kowala address to fund on success.
-func authFacebook(url string) (string, string, common.Address, error) {
-	// Ensure the user specified a meaningful URL, no fancy nonsense
-	parts := strings.Split(url, "/")
-	if len(parts) < 4 || parts[len(parts)-2] != "posts" {
-		return "", "", common.Address{}, errors.New("Invalid Facebook post URL")
-	}
-	username := parts[len(parts)-3]
-
-	// Facebook's Graph API isn't really friendly with direct links. Still, we don't
-	// want to do ask read permissions from users, so just load the public posts and
-	// scrape it for the Kowala address and profile URL.
-	res, err := http.Get(url)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	defer res.Body.Close()
-
-	body, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return "", "", common.Address{}, err
-	}
-	address := common.HexToAddress(string(regexp.MustCompile("0x[0-9a-fA-F]{40}").Find(body)))
-	if address == (common.Address{}) {
-		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
-	}
-	var avatar string
-	if parts = regexp.MustCompile("src=\"([^\"]+fbcdn.net[^\"]+)\"").FindStringSubmatch(string(body)); len(parts) == 2 {
-		avatar = parts[1]
-	}
-	return username + "@facebook", avatar, address, nil
-}
-
-// authNoAuth tries to interpret a faucet request as a plain Kowala address,
-// without actually performing any remote authentication. This mode is prone to
-// Byzantine attack, so only ever use for truly private networks.
-func authNoAuth(url string) (string, string, common.Address, error) {
-	address := common.HexToAddress(regexp.MustCompile("0x[0-9a-fA-F]{40}").FindString(url))
-	if address == (common.Address{}) {
-		return "", "", common.Address{}, errors.New("No Kowala address found to fund")
+// writeJSON replies to a plain HTTP API request with the JSON encoded value.
+func writeJSON(w http.ResponseWriter, code int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Warn("Failed to send HTTP API reply", "err", err)
 	}
-	return address.Hex() + "@noauth", "", address, nil
 }
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+)
+
+func TestFundHandler(t *testing.T) {
+	addr := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	auth, err := newAllowlistAuth([]string{addr.Hex()})
+	if err != nil {
+		t.Fatalf("failed to create allowlist authenticator: %v", err)
+	}
+	f := &faucet{
+		auths:  []authenticator{newTokenAuth([]string{"secret"}), auth},
+		store:  newFundingStore(kcoindb.NewMemDatabase()),
+		update: make(chan struct{}, 1),
+	}
+	// the address was funded recently, so no request reaches the chain
+	if err := f.store.setTimeout(addr.Hex()+"@allowlist", addr, "", time.Now().Add(time.Hour), time.Now().Add(time.Hour), time.Time{}); err != nil {
+		t.Fatalf("failed to store timeouts: %v", err)
+	}
+
+	tests := []struct {
+		method string
+		body   string
+		code   int
+		err    string
+	}{
+		{http.MethodGet, "", http.StatusMethodNotAllowed, "Method not allowed"},
+		{http.MethodPost, "{", http.StatusBadRequest, "Invalid funding request"},
+		{http.MethodPost, `{"url": "https://example.com/post"}`, http.StatusBadRequest, "URL doesn't link to supported services"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `", "token": "wrong"}`, http.StatusBadRequest, "Invalid access token"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `", "tier": 99}`, http.StatusBadRequest, "Invalid funding tier requested"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `", "currency": "btc"}`, http.StatusBadRequest, "Invalid funding currency requested"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `", "currency": "musd"}`, http.StatusBadRequest, "mUSD funding disabled"},
+		{http.MethodPost, `{"url": "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a"}`, http.StatusBadRequest, "Address not allowed to request funds"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `"}`, http.StatusBadRequest, "left until next allowance"},
+		{http.MethodPost, `{"url": "` + addr.Hex() + `", "token": "secret"}`, http.StatusBadRequest, "left until next allowance"},
+	}
+	for i, tt := range tests {
+		res := httptest.NewRecorder()
+		f.fundHandler(res, httptest.NewRequest(tt.method, "/api/fund", strings.NewReader(tt.body)))
+
+		if res.Code != tt.code {
+			t.Errorf("test %d: status mismatch: have %d, want %d", i, res.Code, tt.code)
+		}
+		if ct := res.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("test %d: content type mismatch: have %q, want application/json", i, ct)
+		}
+		var reply map[string]string
+		if err := json.NewDecoder(res.Body).Decode(&reply); err != nil {
+			t.Errorf("test %d: failed to decode reply: %v", i, err)
+			continue
+		}
+		if !strings.Contains(reply["error"], tt.err) {
+			t.Errorf("test %d: error mismatch: have %q, want %q", i, reply["error"], tt.err)
+		}
+	}
+}
+
+func TestRemoteIP(t *testing.T) {
+	defer func(header string) { *proxyFlag = header }(*proxyFlag)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/fund", nil)
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1, 198.51.100.7")
+
+	// the header is set by the client unless a trusted proxy is configured
+	*proxyFlag = ""
+	if ip := remoteIP(req); ip != "192.0.2.1" {
+		t.Errorf("IP mismatch without a proxy: have %s, want 192.0.2.1", ip)
+	}
+	*proxyFlag = "X-Forwarded-For"
+	if ip := remoteIP(req); ip != "198.51.100.7" {
+		t.Errorf("IP mismatch behind a proxy: have %s, want 198.51.100.7", ip)
+	}
+	req.Header.Del("X-Forwarded-For")
+	if ip := remoteIP(req); ip != "192.0.2.1" {
+		t.Errorf("IP mismatch without the proxy header: have %s, want 192.0.2.1", ip)
+	}
+}
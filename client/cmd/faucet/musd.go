@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/abi/bind"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/token"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoinclient"
+)
+
+const (
+	kusdCurrency = "kusd" // Native currency, the default one
+	musdCurrency = "musd" // Mining token required to join the validators
+
+	musdTransferMode = "transfer" // Transfer mUSD from the faucet account
+	musdMintMode     = "mint"     // Submit MultiSig proposals minting new mUSD
+)
+
+// musdTransactor issues the mUSD token transfers of the faucet account.
+type musdTransactor interface {
+	Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int, data []byte, customFallback string) (*types.Transaction, error)
+}
+
+// musdDispenser hands out mUSD mining tokens to prospective validators, either
+// transferring them from the faucet account or submitting mint proposals to the
+// MultiSig wallet the faucet account is an owner of. Mint proposals are only
+// executed once enough owners of the wallet confirm them.
+type musdDispenser struct {
+	mode      string
+	minter    consensus.Minter
+	token     token.Token
+	transfers musdTransactor
+	account   accounts.WalletAccount
+	chainID   *big.Int
+}
+
+// newMUSDDispenser creates an mUSD dispenser on top of the consensus bindings.
+func newMUSDDispenser(mode string, client *kcoinclient.Client, chainID *big.Int, account accounts.WalletAccount) (*musdDispenser, error) {
+	if mode != musdTransferMode && mode != musdMintMode {
+		return nil, fmt.Errorf("unknown mUSD dispensing mode %q", mode)
+	}
+	binding, err := consensus.Binding(client, chainID)
+	if err != nil {
+		return nil, err
+	}
+	musd, err := consensus.NewMUSD(client, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return &musdDispenser{
+		mode:      mode,
+		minter:    binding,
+		token:     musd,
+		transfers: musd.MiningToken,
+		account:   account,
+		chainID:   chainID,
+	}, nil
+}
+
+// dispense sends amount mUSD to the address, using the given faucet account
+// nonce. The returned transaction is the one issued by the faucet account: the
+// token transfer, or the mint proposal pending the confirmation of the owners.
+func (d *musdDispenser) dispense(to common.Address, amount *big.Int, nonce uint64, price *big.Int) (*types.Transaction, error) {
+	var (
+		account = d.account.Account()
+		signed  *types.Transaction
+	)
+	sign := func(_ types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		if address != account.Address {
+			return nil, errors.New("not authorized to sign this account")
+		}
+		tx, err := d.account.SignTx(account, tx, d.chainID)
+		if err != nil {
+			return nil, err
+		}
+		signed = tx
+		return tx, nil
+	}
+
+	var err error
+	switch d.mode {
+	case musdTransferMode:
+		_, err = d.transfers.Transfer(&bind.TransactOpts{
+			From:     account.Address,
+			Nonce:    new(big.Int).SetUint64(nonce),
+			GasPrice: price,
+			Signer:   sign,
+		}, to, amount, []byte{}, "")
+	case musdMintMode:
+		_, err = d.minter.Mint(&accounts.TransactOpts{
+			From:     account.Address,
+			Nonce:    new(big.Int).SetUint64(nonce),
+			GasPrice: price,
+			Signer:   sign,
+		}, to, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if signed == nil {
+		return nil, errors.New("mUSD transaction not signed")
+	}
+	return signed, nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/abi/bind"
+	"github.com/kowala-tech/kcoin/client/accounts/keystore"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/ownership"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+var musdTestToken = common.HexToAddress("0x6f04441A6eD440Cc139a4E33402b438C27E97F4B")
+
+// testMUSDTransactor signs the token transfers without sending them.
+type testMUSDTransactor struct {
+	nonces []uint64
+}
+
+func (t *testMUSDTransactor) Transfer(opts *bind.TransactOpts, to common.Address, value *big.Int, data []byte, customFallback string) (*types.Transaction, error) {
+	t.nonces = append(t.nonces, opts.Nonce.Uint64())
+	tx := types.NewTransaction(opts.Nonce.Uint64(), musdTestToken, new(big.Int), 100000, opts.GasPrice, nil)
+	return opts.Signer(nil, opts.From, tx)
+}
+
+// testMinter signs the mint proposals without sending them.
+type testMinter struct {
+	nonces []uint64
+}
+
+func (m *testMinter) MultiSigWalletContract() *ownership.MultiSigWallet {
+	return nil
+}
+
+func (m *testMinter) Mint(opts *accounts.TransactOpts, to common.Address, value *big.Int) (common.Hash, error) {
+	m.nonces = append(m.nonces, opts.Nonce.Uint64())
+	tx, err := opts.Signer(nil, opts.From, types.NewTransaction(opts.Nonce.Uint64(), musdTestToken, new(big.Int), 100000, opts.GasPrice, nil))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return tx.Hash(), nil
+}
+
+func (m *testMinter) Confirm(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error) {
+	return common.Hash{}, nil
+}
+
+func newTestWalletAccount(t *testing.T) (accounts.WalletAccount, func()) {
+	dir, err := ioutil.TempDir("", "faucet-keystore")
+	if err != nil {
+		t.Fatalf("failed to create temporary directory: %v", err)
+	}
+	ks := keystore.NewKeyStore(dir, 2, 1)
+	account, err := ks.NewAccount("")
+	if err != nil {
+		t.Fatalf("failed to create account: %v", err)
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		t.Fatalf("failed to unlock account: %v", err)
+	}
+	walletAccount, err := accounts.NewWalletAccount(ks.Wallets()[0], account)
+	if err != nil {
+		t.Fatalf("failed to create wallet account: %v", err)
+	}
+	return walletAccount, func() { os.RemoveAll(dir) }
+}
+
+func TestMUSDFunding(t *testing.T) {
+	account, cleanup := newTestWalletAccount(t)
+	defer cleanup()
+
+	addr := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+	auth, err := newAllowlistAuth([]string{addr.Hex()})
+	if err != nil {
+		t.Fatalf("failed to create allowlist authenticator: %v", err)
+	}
+
+	for _, mode := range []string{musdTransferMode, musdMintMode} {
+		var (
+			transfers = new(testMUSDTransactor)
+			minter    = new(testMinter)
+		)
+		f := &faucet{
+			auths:     []authenticator{auth},
+			store:     newFundingStore(kcoindb.NewMemDatabase()),
+			musdStore: newFundingStore(kcoindb.NewMemDatabase()),
+			nonce:     5,
+			reqs:      []*request{{Tx: types.NewTransaction(5, addr, new(big.Int), 21000, new(big.Int), nil)}},
+			update:    make(chan struct{}, 1),
+			musd: &musdDispenser{
+				mode:      mode,
+				minter:    minter,
+				transfers: transfers,
+				account:   account,
+				chainID:   params.TestChainConfig.ChainID,
+			},
+		}
+		result, err := f.fund(&fundRequest{URL: addr.Hex(), Currency: musdCurrency}, "")
+		if err != nil {
+			t.Fatalf("%s: failed to fund: %v", mode, err)
+		}
+		// the dispensed transaction follows the pending requests of the faucet
+		nonces := transfers.nonces
+		if mode == musdMintMode {
+			nonces = minter.nonces
+		}
+		if len(nonces) != 1 || nonces[0] != 6 {
+			t.Errorf("%s: nonces mismatch: have %v, want [6]", mode, nonces)
+		}
+		if len(f.reqs) != 2 || f.reqs[1].Tx.Nonce() != 6 || f.reqs[1].Account != addr {
+			t.Errorf("%s: pending request mismatch: have %v", mode, f.reqs)
+		}
+		if pending := strings.Contains(result, "pending confirmation"); pending != (mode == musdMintMode) {
+			t.Errorf("%s: result mismatch: have %q", mode, result)
+		}
+		// the kUSD funding history is kept apart
+		if _, err := f.fund(&fundRequest{URL: addr.Hex(), Currency: musdCurrency}, ""); err == nil || !strings.Contains(err.Error(), "left until next allowance") {
+			t.Errorf("%s: error mismatch: have %v, want rate limit", mode, err)
+		}
+		if timeout := f.store.timeout(addr.Hex()+"@allowlist", addr, ""); !timeout.IsZero() {
+			t.Errorf("%s: kUSD timeout set by mUSD funding: %v", mode, timeout)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+)
+
+var (
+	userTimeoutPrefix    = []byte("user:") // userTimeoutPrefix + username -> funding timeout
+	addressTimeoutPrefix = []byte("addr:") // addressTimeoutPrefix + address -> funding timeout
+	ipTimeoutPrefix      = []byte("ip:")   // ipTimeoutPrefix + ip -> funding timeout
+)
+
+// fundingStore keeps the funding timeouts of users, addresses and IPs in a
+// database, so that the rate limits survive faucet restarts.
+type fundingStore struct {
+	db   kcoindb.Database
+	lock sync.Mutex
+}
+
+// newFundingStore creates a funding store on top of the given database.
+func newFundingStore(db kcoindb.Database) *fundingStore {
+	return &fundingStore{db: db}
+}
+
+// timeout returns the latest funding timeout of the user, the address and the
+// IP. Empty IPs are not rate limited.
+func (s *fundingStore) timeout(username string, address common.Address, ip string) time.Time {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var timeout time.Time
+	for _, key := range fundingKeys(username, address, ip) {
+		if t := s.get(key); t.After(timeout) {
+			timeout = t
+		}
+	}
+	return timeout
+}
+
+// setTimeout stores the funding timeout of the user, the address and the IP.
+func (s *fundingStore) setTimeout(username string, address common.Address, ip string, userTimeout, addressTimeout, ipTimeout time.Time) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	batch := s.db.NewBatch()
+	for i, key := range fundingKeys(username, address, ip) {
+		timeout := []time.Time{userTimeout, addressTimeout, ipTimeout}[i]
+
+		blob := make([]byte, 8)
+		binary.BigEndian.PutUint64(blob, uint64(timeout.UnixNano()))
+		if err := batch.Put(key, blob); err != nil {
+			return err
+		}
+	}
+	return batch.Write()
+}
+
+// close flushes and closes the underlying database.
+func (s *fundingStore) close() {
+	s.db.Close()
+}
+
+// get retrieves a single funding timeout, returning the zero time if none was
+// stored yet.
+func (s *fundingStore) get(key []byte) time.Time {
+	blob, err := s.db.Get(key)
+	if err != nil || len(blob) != 8 {
+		return time.Time{}
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(blob)))
+}
+
+// fundingKeys returns the database keys of the user, the address and the IP.
+func fundingKeys(username string, address common.Address, ip string) [][]byte {
+	keys := [][]byte{
+		append(append([]byte{}, userTimeoutPrefix...), username...),
+		append(append([]byte{}, addressTimeoutPrefix...), address.Bytes()...),
+	}
+	if ip != "" {
+		keys = append(keys, append(append([]byte{}, ipTimeoutPrefix...), ip...))
+	}
+	return keys
+}
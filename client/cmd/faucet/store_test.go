@@ -0,0 +1,43 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+)
+
+func TestFundingStoreTimeouts(t *testing.T) {
+	db := kcoindb.NewMemDatabase()
+	store := newFundingStore(db)
+
+	var (
+		addr  = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		other = common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+		now   = time.Now()
+	)
+	if timeout := store.timeout("alice", addr, "10.0.0.1"); !timeout.IsZero() {
+		t.Fatalf("unexpected timeout before funding: %v", timeout)
+	}
+	if err := store.setTimeout("alice", addr, "10.0.0.1", now.Add(time.Hour), now.Add(time.Hour), now.Add(2*time.Hour)); err != nil {
+		t.Fatalf("failed to store timeouts: %v", err)
+	}
+	tests := []struct {
+		username string
+		address  common.Address
+		ip       string
+		timeout  time.Time
+	}{
+		{"alice", other, "", now.Add(time.Hour)},            // same user
+		{"bob", addr, "", now.Add(time.Hour)},               // same address
+		{"bob", other, "10.0.0.1", now.Add(2 * time.Hour)},  // same IP
+		{"bob", other, "10.0.0.2", time.Time{}},             // nothing in common
+		{"alice", addr, "10.0.0.1", now.Add(2 * time.Hour)}, // latest timeout wins
+	}
+	for i, tt := range tests {
+		if timeout := store.timeout(tt.username, tt.address, tt.ip); !timeout.Equal(tt.timeout) {
+			t.Errorf("test %d: timeout mismatch: have %v, want %v", i, timeout, tt.timeout)
+		}
+	}
+}
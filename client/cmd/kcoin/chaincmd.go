@@ -12,7 +12,9 @@ import (
 	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/client/console"
 	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
 	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/core/state/pruner"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/event"
 	"github.com/kowala-tech/kcoin/client/kcoindb"
@@ -56,11 +58,21 @@ It expects the genesis file as argument.`,
 			utils.GCModeFlag,
 			utils.CacheDatabaseFlag,
 			utils.CacheGCFlag,
+			utils.ImportVerifyFlag,
+			utils.ImportFirstFlag,
+			utils.ImportLastFlag,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
-The import command imports blocks from an RLP-encoded form. The form can be one file
-with several RLP-encoded blocks, or several files can be used.
+The import command imports blocks from a chain export, optionally gzip compressed.
+The form can be one file with several RLP-encoded blocks, or several files can be used.
+
+Exports carry the commit of every block and snapshots of the validator set. With
+--verify, every block is only inserted once its commit is proven to be signed by
+more than two thirds of the validator set known to the node. Validators don't
+put the signed pre-commits of the parent into the blocks they propose yet, so
+chains they produced fail the verification, as do legacy exports of bare
+blocks. The --first and --last flags restrict the import to a range of blocks.
 
 If only one file is used, import error will result in failure. If several files are used,
 processing will proceed even if an individual RLP-file import failure occurs.`,
@@ -77,7 +89,9 @@ processing will proceed even if an individual RLP-file import failure occurs.`,
 		},
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
-Requires a first argument of the file to write to.
+Requires a first argument of the file to write to, which is gzip compressed if
+its name ends with .gz. The export carries the commit of every block and
+snapshots of the validator set, so that it can be verified on import.
 Optional second and third arguments control the first and
 last block to write. In this mode, the file will be appended
 if already existing.`,
@@ -137,6 +151,34 @@ The first argument must be the directory containing the blockchain to download f
 		Category: "BLOCKCHAIN COMMANDS",
 		Description: `
 Remove blockchain and state databases`,
+	}
+	pruneCommand = cli.Command{
+		Action:    utils.MigrateFlags(pruneChain),
+		Name:      "prune",
+		Aliases:   []string{"removestate"},
+		Usage:     "Prune stale state and move old blocks into the ancient store",
+		ArgsUsage: " ",
+		Flags: []cli.Flag{
+			utils.DataDirFlag,
+			utils.AncientFlag,
+			utils.CacheFlag,
+			utils.PruneRecentFlag,
+			utils.PruneBloomSizeFlag,
+			utils.AncientThresholdFlag,
+			utils.NoCompactionFlag,
+		},
+		Category: "BLOCKCHAIN COMMANDS",
+		Description: `
+The prune command deletes all the state trie nodes that are not reachable from
+the state of the head block, the recent blocks before it and the genesis block.
+The reachable state is marked in a bloom filter of --prune.bloomsize megabytes,
+a smaller filter keeps more of the unreachable state around. The bodies and
+receipts of the blocks older than the ancient threshold are then moved out of
+the database and into the append-only ancient store.
+
+The node must not be running while pruning. A running node moves the old blocks
+into the ancient store itself with --ancient.freeze, pruning the state is only
+done offline.`,
 	}
 	dumpCommand = cli.Command{
 		Action:    utils.MigrateFlags(dump),
@@ -228,14 +270,18 @@ func importChain(ctx *cli.Context) error {
 	}()
 	// Import the chain
 	start := time.Now()
-
+	opts := core.ImportOptions{
+		Verify: ctx.GlobalBool(utils.ImportVerifyFlag.Name),
+		First:  ctx.GlobalUint64(utils.ImportFirstFlag.Name),
+		Last:   ctx.GlobalUint64(utils.ImportLastFlag.Name),
+	}
 	if len(ctx.Args()) == 1 {
-		if err := utils.ImportChain(chain, ctx.Args().First()); err != nil {
+		if err := utils.ImportChain(chain, ctx.Args().First(), opts); err != nil {
 			log.Error("Import error", "err", err)
 		}
 	} else {
 		for _, arg := range ctx.Args() {
-			if err := utils.ImportChain(chain, arg); err != nil {
+			if err := utils.ImportChain(chain, arg, opts); err != nil {
 				log.Error("Import error", "file", arg, "err", err)
 			}
 		}
@@ -409,6 +455,49 @@ func copyDb(ctx *cli.Context) error {
 	return nil
 }
 
+// pruneChain deletes the unreachable state and freezes old blocks into the
+// ancient store.
+func pruneChain(ctx *cli.Context) error {
+	recent := ctx.GlobalUint64(utils.PruneRecentFlag.Name)
+	if recent == 0 {
+		utils.Fatalf("--%s must be at least 1", utils.PruneRecentFlag.Name)
+	}
+	stack, _ := makeConfigNode(ctx)
+	chainDb := utils.MakeChainDatabase(ctx, stack)
+	defer chainDb.Close()
+
+	start := time.Now()
+	roots, err := pruner.RetainedRoots(chainDb, recent)
+	if err != nil {
+		utils.Fatalf("Failed to collect retained states: %v", err)
+	}
+	stats, err := pruner.Prune(chainDb, roots, ctx.GlobalUint64(utils.PruneBloomSizeFlag.Name)*1024*1024)
+	if err != nil {
+		utils.Fatalf("Pruning failed: %v", err)
+	}
+	fmt.Printf("Pruned %d state entries (%v) in %v, %d states retained.\n", stats.Deleted, stats.Size, time.Since(start), stats.Retained)
+
+	start = time.Now()
+	frozen, err := rawdb.FreezeAncients(chainDb, ctx.GlobalUint64(utils.AncientThresholdFlag.Name))
+	if err != nil {
+		utils.Fatalf("Failed to move blocks into the ancient store: %v", err)
+	}
+	fmt.Printf("Moved %d blocks into the ancient store in %v.\n\n", frozen, time.Since(start))
+
+	if ctx.GlobalIsSet(utils.NoCompactionFlag.Name) {
+		return nil
+	}
+	// Compact the entire database to actually release the disk space
+	start = time.Now()
+	fmt.Println("Compacting entire database...")
+	if err = chainDb.(*kcoindb.LDBDatabase).LDB().CompactRange(util.Range{}); err != nil {
+		utils.Fatalf("Compaction failed: %v", err)
+	}
+	fmt.Printf("Compaction done in %v.\n\n", time.Since(start))
+
+	return nil
+}
+
 func removeDB(ctx *cli.Context) error {
 	stack, _ := makeConfigNode(ctx)
 
@@ -0,0 +1,295 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kowala-tech/kcoin/client/cmd/utils"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/common/hexutil"
+	"github.com/kowala-tech/kcoin/client/common/math"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/knode"
+	"github.com/kowala-tech/kcoin/client/node"
+	"github.com/kowala-tech/kcoin/client/rpc"
+	"gopkg.in/urfave/cli.v1"
+)
+
+var (
+	governanceAttachFlag = cli.StringFlag{
+		Name:  "attach",
+		Value: node.DefaultIPCEndpoint(clientIdentifier),
+		Usage: "API endpoint to attach to",
+	}
+	governanceFromFlag = cli.StringFlag{
+		Name:  "from",
+		Usage: "Governor account signing the transaction (unlocked on the node)",
+	}
+	governanceToFlag = cli.StringFlag{
+		Name:  "to",
+		Usage: "Destination of the MultiSig transaction",
+	}
+	governanceValueFlag = cli.StringFlag{
+		Name:  "value",
+		Value: "0",
+		Usage: "Value transferred by the MultiSig transaction",
+	}
+	governanceDataFlag = cli.StringFlag{
+		Name:  "data",
+		Usage: "Hex encoded call data of the MultiSig transaction",
+	}
+	governancePendingFlag = cli.BoolFlag{
+		Name:  "pending",
+		Usage: "List the pending transactions only",
+	}
+	governanceExecutedFlag = cli.BoolFlag{
+		Name:  "executed",
+		Usage: "List the executed transactions only",
+	}
+
+	governanceCommand = cli.Command{
+		Name:     "governance",
+		Usage:    "Administer the system contracts through the MultiSig wallet",
+		Category: "GOVERNANCE COMMANDS",
+		Description: `
+
+The system contracts are owned by a MultiSig wallet, whose owners - the
+governors - submit and confirm the transactions calling them. A transaction is
+executed once confirmed by the required number of governors.
+
+The commands attach to a running node, which signs the transactions with the
+unlocked account of the governor given with --from.`,
+		Subcommands: []cli.Command{
+			{
+				Name:   "info",
+				Usage:  "Print the governors and the system contract addresses",
+				Action: utils.MigrateFlags(governanceInfo),
+				Flags:  []cli.Flag{governanceAttachFlag},
+			},
+			{
+				Name:   "list",
+				Usage:  "List the MultiSig transactions with their decoded calls",
+				Action: utils.MigrateFlags(governanceList),
+				Flags: []cli.Flag{
+					governanceAttachFlag,
+					governancePendingFlag,
+					governanceExecutedFlag,
+				},
+			},
+			{
+				Name:   "submit",
+				Usage:  "Submit an arbitrary MultiSig transaction",
+				Action: utils.MigrateFlags(governanceSubmit),
+				Flags: []cli.Flag{
+					governanceAttachFlag,
+					governanceFromFlag,
+					governanceToFlag,
+					governanceValueFlag,
+					governanceDataFlag,
+				},
+			},
+			{
+				Name:      "confirm",
+				Usage:     "Confirm a MultiSig transaction",
+				ArgsUsage: "<id>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_confirm", parseBig)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "revoke",
+				Usage:     "Revoke the confirmation of a MultiSig transaction",
+				ArgsUsage: "<id>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_revoke", parseBig)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "execute",
+				Usage:     "Execute a confirmed MultiSig transaction",
+				ArgsUsage: "<id>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_execute", parseBig)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "set-max-validators",
+				Usage:     "Submit a change of the maximum number of validators",
+				ArgsUsage: "<max>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_setMaxValidators", parseBig)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "set-base-deposit",
+				Usage:     "Submit a change of the base deposit of the validators",
+				ArgsUsage: "<deposit>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_setBaseDeposit", parseBig)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "pause",
+				Usage:     "Submit the pause of a system contract",
+				ArgsUsage: "<ValidatorMgr|OracleMgr>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_pause", parseContract)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+			{
+				Name:      "unpause",
+				Usage:     "Submit the unpause of a system contract",
+				ArgsUsage: "<ValidatorMgr|OracleMgr>",
+				Action:    utils.MigrateFlags(governanceTransact("governance_unpause", parseContract)),
+				Flags:     []cli.Flag{governanceAttachFlag, governanceFromFlag},
+			},
+		},
+	}
+)
+
+// attachGovernance attaches to the node exposing the governance API.
+func attachGovernance(ctx *cli.Context) *rpc.Client {
+	client, err := dialRPC(ctx.String(governanceAttachFlag.Name))
+	if err != nil {
+		utils.Fatalf("Unable to attach to kcoin node: %v", err)
+	}
+	return client
+}
+
+// governanceFrom returns the governor account given with --from.
+func governanceFrom(ctx *cli.Context) common.Address {
+	from := ctx.String(governanceFromFlag.Name)
+	if !common.IsHexAddress(from) {
+		utils.Fatalf("A valid governor account must be given with --%s", governanceFromFlag.Name)
+	}
+	return common.HexToAddress(from)
+}
+
+func governanceInfo(ctx *cli.Context) error {
+	client := attachGovernance(ctx)
+	defer client.Close()
+
+	var info knode.GovernanceInfo
+	if err := client.Call(&info, "governance_info"); err != nil {
+		utils.Fatalf("Failed to retrieve the governance info: %v", err)
+	}
+	fmt.Printf("MultiSig wallet:        %s\n", info.Contracts.MultiSigWallet.Hex())
+	fmt.Printf("Required confirmations: %v\n", info.RequiredConfirmations.ToInt())
+	for _, governor := range info.Governors {
+		fmt.Printf("Governor:               %s\n", governor.Hex())
+	}
+	fmt.Printf("ValidatorMgr:           %s\n", info.Contracts.ValidatorMgr.Hex())
+	fmt.Printf("OracleMgr:              %s\n", info.Contracts.OracleMgr.Hex())
+	fmt.Printf("MiningToken:            %s\n", info.Contracts.MiningToken.Hex())
+	return nil
+}
+
+func governanceList(ctx *cli.Context) error {
+	client := attachGovernance(ctx)
+	defer client.Close()
+
+	pending, executed := ctx.Bool(governancePendingFlag.Name), ctx.Bool(governanceExecutedFlag.Name)
+	if !pending && !executed {
+		pending, executed = true, true
+	}
+	var txs []*knode.RPCMultiSigTransaction
+	if err := client.Call(&txs, "governance_transactions", pending, executed); err != nil {
+		utils.Fatalf("Failed to list the MultiSig transactions: %v", err)
+	}
+	for _, tx := range txs {
+		status := "pending"
+		if tx.Executed {
+			status = "executed"
+		}
+		fmt.Printf("#%v %s: %s, %d confirmation(s)\n", tx.ID.ToInt(), status, describeCall(tx), len(tx.Confirmations))
+	}
+	return nil
+}
+
+// describeCall describes the call of a MultiSig transaction, decoded if it
+// calls a system contract.
+func describeCall(tx *knode.RPCMultiSigTransaction) string {
+	if tx.Call == nil {
+		desc := fmt.Sprintf("%s value %v data %s", tx.Destination.Hex(), tx.Value.ToInt(), tx.Data)
+		if tx.Contract != "" {
+			desc = tx.Contract + " " + desc
+		}
+		return desc
+	}
+	args := make([]string, len(tx.Call.Args))
+	for i, arg := range tx.Call.Args {
+		args[i] = fmt.Sprintf("%s=%s", arg.Name, arg.Value)
+	}
+	return fmt.Sprintf("%s.%s(%s)", tx.Contract, tx.Call.Method, strings.Join(args, ", "))
+}
+
+func governanceSubmit(ctx *cli.Context) error {
+	to := ctx.String(governanceToFlag.Name)
+	if !common.IsHexAddress(to) {
+		utils.Fatalf("A valid destination must be given with --%s", governanceToFlag.Name)
+	}
+	value, ok := math.ParseBig256(ctx.String(governanceValueFlag.Name))
+	if !ok {
+		utils.Fatalf("Invalid value %q", ctx.String(governanceValueFlag.Name))
+	}
+	var data hexutil.Bytes
+	if s := ctx.String(governanceDataFlag.Name); s != "" {
+		var err error
+		if data, err = hexutil.Decode(s); err != nil {
+			utils.Fatalf("Invalid call data: %v", err)
+		}
+	}
+
+	client := attachGovernance(ctx)
+	defer client.Close()
+
+	var hash common.Hash
+	args := knode.SubmitMultiSigArgs{
+		From:  governanceFrom(ctx),
+		To:    common.HexToAddress(to),
+		Value: (*hexutil.Big)(value),
+		Data:  data,
+	}
+	if err := client.Call(&hash, "governance_submit", args); err != nil {
+		utils.Fatalf("Failed to submit the MultiSig transaction: %v", err)
+	}
+	fmt.Printf("Transaction: %s\n", hash.Hex())
+	return nil
+}
+
+// governanceTransact returns the action of a subcommand calling a governance
+// method with the governor account and a single argument.
+func governanceTransact(method string, parse func(string) (interface{}, error)) func(*cli.Context) error {
+	return func(ctx *cli.Context) error {
+		if len(ctx.Args()) != 1 {
+			utils.Fatalf("This command requires an argument.")
+		}
+		arg, err := parse(ctx.Args().First())
+		if err != nil {
+			utils.Fatalf("Invalid argument: %v", err)
+		}
+		from := governanceFrom(ctx)
+
+		client := attachGovernance(ctx)
+		defer client.Close()
+
+		var hash common.Hash
+		if err := client.Call(&hash, method, from, arg); err != nil {
+			utils.Fatalf("Failed to transact: %v", err)
+		}
+		fmt.Printf("Transaction: %s\n", hash.Hex())
+		return nil
+	}
+}
+
+// parseBig parses a decimal or hex number argument.
+func parseBig(s string) (interface{}, error) {
+	n, ok := math.ParseBig256(s)
+	if !ok || n.Sign() < 0 {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return (*hexutil.Big)(n), nil
+}
+
+// parseContract parses the name of a system contract that can be paused.
+func parseContract(s string) (interface{}, error) {
+	switch s {
+	case consensus.ValidatorMgrContract, consensus.OracleMgrContract:
+		return s, nil
+	}
+	return nil, fmt.Errorf("unknown contract %q", s)
+}
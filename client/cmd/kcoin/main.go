@@ -43,6 +43,9 @@ var (
 		utils.BootnodesV4Flag,
 		utils.BootnodesV5Flag,
 		utils.DataDirFlag,
+		utils.AncientFlag,
+		utils.AncientFreezeFlag,
+		utils.AncientThresholdFlag,
 		utils.KeyStoreDirFlag,
 		utils.NoUSBFlag,
 		utils.TxPoolNoLocalsFlag,
@@ -54,6 +57,8 @@ var (
 		utils.TxPoolGlobalSlotsFlag,
 		utils.TxPoolAccountQueueFlag,
 		utils.TxPoolGlobalQueueFlag,
+		utils.TxPoolSystemSlotsFlag,
+		utils.TxPoolSystemAccountSlotsFlag,
 		utils.TxPoolLifetimeFlag,
 		utils.FastSyncFlag,
 		utils.LightModeFlag,
@@ -73,11 +78,15 @@ var (
 		utils.CoinbaseFlag,
 		utils.GasPriceFlag,
 		utils.ValidatorDepositFlag,
+		utils.ValidatorAutoRedeemFlag,
 		utils.ValidationEnabledFlag,
 		utils.TargetGasLimitFlag,
 		utils.NATFlag,
 		utils.NoDiscoverFlag,
 		utils.NetrestrictFlag,
+		utils.SentriesFlag,
+		utils.SentryFlag,
+		utils.SentryValidatorsFlag,
 		utils.NodeKeyFileFlag,
 		utils.NodeKeyHexFlag,
 		utils.DevModeFlag,
@@ -109,6 +118,11 @@ var (
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
+		utils.GraphQLEnabledFlag,
+		utils.GraphQLListenAddrFlag,
+		utils.GraphQLPortFlag,
+		utils.GraphQLCORSDomainFlag,
+		utils.GraphQLVirtualHostsFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 	}
@@ -137,9 +151,12 @@ func init() {
 		exportPreimagesCommand,
 		copydbCommand,
 		removedbCommand,
+		pruneCommand,
 		dumpCommand,
 		// See monitorcmd.go:
 		monitorCommand,
+		// See governancecmd.go:
+		governanceCommand,
 		// See accountcmd.go:
 		accountCommand,
 		walletCommand,
@@ -188,7 +205,7 @@ func init() {
 		utils.SetupMetrics(ctx)
 
 		// Start system runtime metrics collection
-		go metrics.CollectProcessMetrics(3*time.Second, ctx.GlobalString(utils.MetricsPrometheusAddressFlag.Name), ctx.GlobalString(utils.MetricsPrometheusSubsystemFlag.Name))
+		go metrics.CollectProcessMetrics(3 * time.Second)
 
 		go version.Checker(ctx.GlobalString(utils.VersionRepository.Name))
 
@@ -22,6 +22,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/core/state"
 	"github.com/kowala-tech/kcoin/client/core/vm"
 	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/graphql"
 	"github.com/kowala-tech/kcoin/client/kcoindb"
 	"github.com/kowala-tech/kcoin/client/knode"
 	"github.com/kowala-tech/kcoin/client/knode/downloader"
@@ -29,6 +30,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/log"
 	"github.com/kowala-tech/kcoin/client/metrics"
 	"github.com/kowala-tech/kcoin/client/metrics/influxdb"
+	"github.com/kowala-tech/kcoin/client/metrics/prometheus"
 	"github.com/kowala-tech/kcoin/client/node"
 	"github.com/kowala-tech/kcoin/client/p2p"
 	"github.com/kowala-tech/kcoin/client/p2p/discover"
@@ -99,6 +101,10 @@ var (
 		Usage: "Data directory for the databases and keystore",
 		Value: DirectoryString{node.DefaultDataDir()},
 	}
+	AncientFlag = DirectoryFlag{
+		Name:  "datadir.ancient",
+		Usage: "Directory for the ancient block store (default = inside the chaindata)",
+	}
 	KeyStoreDirFlag = DirectoryFlag{
 		Name:  "keystore",
 		Usage: "Directory for the keystore (default = inside the datadir)",
@@ -158,6 +164,25 @@ var (
 		Usage: `Blockchain garbage collection mode ("full", "archive")`,
 		Value: "full",
 	}
+	PruneRecentFlag = cli.Uint64Flag{
+		Name:  "prune.recent",
+		Usage: "Number of recent block states kept when pruning",
+		Value: 128,
+	}
+	PruneBloomSizeFlag = cli.Uint64Flag{
+		Name:  "prune.bloomsize",
+		Usage: "Megabytes of memory allocated to the filter of the reachable state when pruning",
+		Value: 256,
+	}
+	AncientThresholdFlag = cli.Uint64Flag{
+		Name:  "ancient.threshold",
+		Usage: "Number of recent blocks kept out of the ancient store",
+		Value: 90000,
+	}
+	AncientFreezeFlag = cli.BoolFlag{
+		Name:  "ancient.freeze",
+		Usage: "Move the blocks older than the ancient threshold into the ancient store while running",
+	}
 	LightServFlag = cli.IntFlag{
 		Name:  "lightserv",
 		Usage: "Maximum percentage of time allowed for serving LES requests (0-90)",
@@ -217,6 +242,16 @@ var (
 		Usage: "Maximum number of non-executable transaction slots for all accounts",
 		Value: knode.DefaultConfig.TxPool.GlobalQueue,
 	}
+	TxPoolSystemSlotsFlag = cli.Uint64Flag{
+		Name:  "txpool.systemslots",
+		Usage: "Number of transaction slots reserved for system transactions of validators",
+		Value: knode.DefaultConfig.TxPool.SystemSlots,
+	}
+	TxPoolSystemAccountSlotsFlag = cli.Uint64Flag{
+		Name:  "txpool.systemaccountslots",
+		Usage: "Maximum number of reserved slots taken by the system transactions of an account",
+		Value: knode.DefaultConfig.TxPool.SystemAccountSlots,
+	}
 	TxPoolLifetimeFlag = cli.DurationFlag{
 		Name:  "txpool.lifetime",
 		Usage: "Maximum amount of time non-executable transaction are queued",
@@ -254,6 +289,10 @@ var (
 		Usage: "Deposit at stake",
 		// @TODO (rgeraldes) - default could be set to the minimum required
 	}
+	ValidatorAutoRedeemFlag = cli.BoolFlag{
+		Name:  "deposit.autoredeem",
+		Usage: "Redeem the deposits as soon as their freeze period ends",
+	}
 
 	TargetGasLimitFlag = cli.Uint64Flag{
 		Name:  "targetgaslimit",
@@ -306,6 +345,18 @@ var (
 		Name:  "stats",
 		Usage: "Reporting URL of a stats service (nodename:secret@host:port)",
 	}
+	ImportVerifyFlag = cli.BoolFlag{
+		Name:  "verify",
+		Usage: "Verify the commit of every imported block against the validator set",
+	}
+	ImportFirstFlag = cli.Uint64Flag{
+		Name:  "first",
+		Usage: "First block number to import",
+	}
+	ImportLastFlag = cli.Uint64Flag{
+		Name:  "last",
+		Usage: "Last block number to import (0 = no limit)",
+	}
 	NoCompactionFlag = cli.BoolFlag{
 		Name:  "nocompaction",
 		Usage: "Disables db compaction after import",
@@ -340,6 +391,31 @@ var (
 		Usage: "API's offered over the HTTP-RPC interface",
 		Value: "",
 	}
+	// GraphQL settings
+	GraphQLEnabledFlag = cli.BoolFlag{
+		Name:  "graphql",
+		Usage: "Enable the GraphQL server",
+	}
+	GraphQLListenAddrFlag = cli.StringFlag{
+		Name:  "graphql.addr",
+		Usage: "GraphQL server listening interface",
+		Value: node.DefaultGraphQLHost,
+	}
+	GraphQLPortFlag = cli.IntFlag{
+		Name:  "graphql.port",
+		Usage: "GraphQL server listening port",
+		Value: node.DefaultGraphQLPort,
+	}
+	GraphQLCORSDomainFlag = cli.StringFlag{
+		Name:  "graphql.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin requests (browser enforced)",
+		Value: "",
+	}
+	GraphQLVirtualHostsFlag = cli.StringFlag{
+		Name:  "graphql.vhosts",
+		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
+		Value: strings.Join(node.DefaultConfig.GraphQLVirtualHosts, ","),
+	}
 	IPCDisabledFlag = cli.BoolFlag{
 		Name:  "ipcdisable",
 		Usage: "Disable the IPC-RPC server",
@@ -433,6 +509,18 @@ var (
 		Name:  "netrestrict",
 		Usage: "Restricts network communication to the given IP networks (CIDR masks)",
 	}
+	SentriesFlag = cli.StringFlag{
+		Name:  "sentries",
+		Usage: "Comma separated enode URLs of the sentries to connect the validator through (disables discovery)",
+	}
+	SentryFlag = cli.BoolFlag{
+		Name:  "sentry",
+		Usage: "Relay the consensus messages of private validators (sentry node)",
+	}
+	SentryValidatorsFlag = cli.StringFlag{
+		Name:  "sentry.validators",
+		Usage: "Comma separated enode URLs of the private validators the sentry relays for",
+	}
 
 	// ATM the url is left to the user and deployment to
 	JSpathFlag = cli.StringFlag{
@@ -573,6 +661,32 @@ func setBootstrapNodes(ctx *cli.Context, cfg *p2p.Config) {
 	}
 }
 
+// setSentries configures the sentry topology, either the sentries of a private
+// validator or the validators behind a sentry.
+func setSentries(ctx *cli.Context, cfg *p2p.Config) {
+	if ctx.GlobalIsSet(SentriesFlag.Name) {
+		cfg.SentryNodes = parseNodes(ctx, SentriesFlag)
+	}
+	if ctx.GlobalIsSet(SentryValidatorsFlag.Name) {
+		cfg.PrivateNodes = parseNodes(ctx, SentryValidatorsFlag)
+	}
+}
+
+// parseNodes parses the comma separated enode URLs of a flag.
+func parseNodes(ctx *cli.Context, flag cli.StringFlag) []*discover.Node {
+	urls := strings.Split(ctx.GlobalString(flag.Name), ",")
+
+	nodes := make([]*discover.Node, 0, len(urls))
+	for _, url := range urls {
+		node, err := discover.ParseNode(strings.TrimSpace(url))
+		if err != nil {
+			Fatalf("Option %q: invalid enode %q: %v", flag.Name, url, err)
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
 // setBootstrapNodesV5 creates a list of bootstrap nodes from the command line
 // flags, reverting to pre-configured ones if none have been specified.
 func setBootstrapNodesV5(ctx *cli.Context, cfg *p2p.Config) {
@@ -671,6 +785,26 @@ func setHTTP(ctx *cli.Context, cfg *node.Config) {
 	}
 }
 
+// setGraphQL creates the GraphQL listener interface string from the set
+// command line flags, returning empty if the GraphQL endpoint is disabled.
+func setGraphQL(ctx *cli.Context, cfg *node.Config) {
+	if ctx.GlobalBool(GraphQLEnabledFlag.Name) && cfg.GraphQLHost == "" {
+		cfg.GraphQLHost = "127.0.0.1"
+		if ctx.GlobalIsSet(GraphQLListenAddrFlag.Name) {
+			cfg.GraphQLHost = ctx.GlobalString(GraphQLListenAddrFlag.Name)
+		}
+	}
+	if ctx.GlobalIsSet(GraphQLPortFlag.Name) {
+		cfg.GraphQLPort = ctx.GlobalInt(GraphQLPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(GraphQLCORSDomainFlag.Name) {
+		cfg.GraphQLCors = splitAndTrim(ctx.GlobalString(GraphQLCORSDomainFlag.Name))
+	}
+	if ctx.GlobalIsSet(GraphQLVirtualHostsFlag.Name) {
+		cfg.GraphQLVirtualHosts = splitAndTrim(ctx.GlobalString(GraphQLVirtualHostsFlag.Name))
+	}
+}
+
 // setWS creates the WebSocket RPC listener interface string from the set
 // command line flags, returning empty if the HTTP endpoint is disabled.
 func setWS(ctx *cli.Context, cfg *node.Config) {
@@ -772,6 +906,9 @@ func setDeposit(ctx *cli.Context, cfg *knode.Config) {
 	if ctx.GlobalIsSet(ValidatorDepositFlag.Name) {
 		cfg.Deposit = new(big.Int).SetUint64(ctx.GlobalUint64(ValidatorDepositFlag.Name))
 	}
+	if ctx.GlobalIsSet(ValidatorAutoRedeemFlag.Name) {
+		cfg.AutoRedeem = ctx.GlobalBool(ValidatorAutoRedeemFlag.Name)
+	}
 }
 
 // MakePasswordList reads password lines from the file specified by the global --password flag.
@@ -797,6 +934,7 @@ func SetP2PConfig(ctx *cli.Context, cfg *p2p.Config) {
 	setNAT(ctx, cfg)
 	setBootstrapNodes(ctx, cfg)
 	setBootstrapNodesV5(ctx, cfg)
+	setSentries(ctx, cfg)
 	setListenAddress(ctx, cfg)
 	setDiscoveryV5Address(ctx, cfg)
 
@@ -838,6 +976,7 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	SetP2PConfig(ctx, &cfg.P2P)
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
+	setGraphQL(ctx, cfg)
 	setWS(ctx, cfg)
 	setNodeUserIdent(ctx, cfg)
 
@@ -896,6 +1035,12 @@ func setTxPool(ctx *cli.Context, cfg *core.TxPoolConfig) {
 	if ctx.GlobalIsSet(TxPoolGlobalQueueFlag.Name) {
 		cfg.GlobalQueue = ctx.GlobalUint64(TxPoolGlobalQueueFlag.Name)
 	}
+	if ctx.GlobalIsSet(TxPoolSystemSlotsFlag.Name) {
+		cfg.SystemSlots = ctx.GlobalUint64(TxPoolSystemSlotsFlag.Name)
+	}
+	if ctx.GlobalIsSet(TxPoolSystemAccountSlotsFlag.Name) {
+		cfg.SystemAccountSlots = ctx.GlobalUint64(TxPoolSystemAccountSlotsFlag.Name)
+	}
 	if ctx.GlobalIsSet(TxPoolLifetimeFlag.Name) {
 		cfg.Lifetime = ctx.GlobalDuration(TxPoolLifetimeFlag.Name)
 	}
@@ -949,6 +1094,9 @@ func SetKowalaConfig(ctx *cli.Context, stack *node.Node, cfg *knode.Config) {
 	setCoinbase(ctx, ks, cfg)
 	setDeposit(ctx, cfg)
 	setGPO(ctx, &cfg.GPO)
+	if ctx.GlobalIsSet(SentryFlag.Name) || ctx.GlobalIsSet(SentryValidatorsFlag.Name) {
+		cfg.Sentry = true
+	}
 	setTxPool(ctx, &cfg.TxPool)
 
 	switch {
@@ -973,6 +1121,12 @@ func SetKowalaConfig(ctx *cli.Context, stack *node.Node, cfg *knode.Config) {
 		cfg.DatabaseCache = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheDatabaseFlag.Name) / 100
 	}
 	cfg.DatabaseHandles = makeDatabaseHandles()
+	if ctx.GlobalIsSet(AncientFlag.Name) {
+		cfg.DatabaseAncient = ctx.GlobalString(AncientFlag.Name)
+	}
+	if ctx.GlobalBool(AncientFreezeFlag.Name) {
+		cfg.AncientThreshold = ctx.GlobalUint64(AncientThresholdFlag.Name)
+	}
 
 	if gcmode := ctx.GlobalString(GCModeFlag.Name); gcmode != "full" && gcmode != "archive" {
 		Fatalf("--%s must be either 'full' or 'archive'", GCModeFlag.Name)
@@ -1040,6 +1194,20 @@ func RegisterKowalaStatsService(stack *node.Node, url string) {
 	}
 }
 
+// RegisterGraphQLService adds the GraphQL server, serving the chain data of the
+// Kowala service, to the given node.
+func RegisterGraphQLService(stack *node.Node, endpoint string, cors, vhosts []string) {
+	if err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		var kowalaServ *knode.Kowala
+		if err := ctx.Service(&kowalaServ); err != nil {
+			return nil, err
+		}
+		return graphql.New(kowalaServ, endpoint, cors, vhosts)
+	}); err != nil {
+		Fatalf("Failed to register the GraphQL service: %v", err)
+	}
+}
+
 // SetupNetwork configures the system for either the main net or some test network.
 func SetupNetwork(ctx *cli.Context) {
 	// TODO(fjl): move target gas limit into config
@@ -1058,6 +1226,19 @@ func SetupMetrics(ctx *cli.Context) {
 			hosttag      = ctx.GlobalString(MetricsInfluxDBHostTagFlag.Name)
 		)
 
+		if address := ctx.GlobalString(MetricsPrometheusAddressFlag.Name); address != "" {
+			namespace := "eth"
+			if subsystem := ctx.GlobalString(MetricsPrometheusSubsystemFlag.Name); subsystem != "" {
+				namespace += "_" + subsystem
+			}
+			log.Info("Starting Prometheus metrics", "address", address, "namespace", namespace)
+			go func() {
+				if err := prometheus.ListenAndServe(address, namespace, metrics.DefaultRegistry); err != nil {
+					log.Error("Prometheus metrics server failed", "err", err)
+				}
+			}()
+		}
+
 		if enableExport {
 			log.Info("Enabling metrics export to InfluxDB")
 			go influxdb.InfluxDBWithTags(metrics.DefaultRegistry, 10*time.Second, endpoint, database, username, password, "geth.", map[string]string{
@@ -1073,11 +1254,15 @@ func MakeChainDatabase(ctx *cli.Context, stack *node.Node) kcoindb.Database {
 		cache   = ctx.GlobalInt(CacheFlag.Name) * ctx.GlobalInt(CacheDatabaseFlag.Name) / 100
 		handles = makeDatabaseHandles()
 	)
-	name := "chaindata"
+	var (
+		chainDb kcoindb.Database
+		err     error
+	)
 	if ctx.GlobalBool(LightModeFlag.Name) {
-		name = "lightchaindata"
+		chainDb, err = stack.OpenDatabase("lightchaindata", cache, handles)
+	} else {
+		chainDb, err = stack.OpenDatabaseWithAncients("chaindata", cache, handles, ctx.GlobalString(AncientFlag.Name))
 	}
-	chainDb, err := stack.OpenDatabase(name, cache, handles)
 	if err != nil {
 		Fatalf("Could not open database: %v", err)
 	}
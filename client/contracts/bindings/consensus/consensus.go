@@ -55,6 +55,8 @@ type Consensus interface {
 	Join(walletAccount accounts.WalletAccount, amount *big.Int) error
 	Leave(walletAccount accounts.WalletAccount) error
 	RedeemDeposits(walletAccount accounts.WalletAccount) error
+	RotateKey(walletAccount accounts.WalletAccount, signingKey common.Address, activeFrom *big.Int) error
+	SigningKey(code common.Address, blockNumber *big.Int) (common.Address, error)
 	ValidatorsChecksum() (ValidatorsChecksum, error)
 	Validators() (types.Voters, error)
 	GetValidatorCount() (*big.Int, error)
@@ -64,6 +66,7 @@ type Consensus interface {
 	IsValidator(address common.Address) (bool, error)
 	MinimumDeposit() (*big.Int, error)
 	Token() token.Token
+	Governance() Governor
 	Minter
 }
 
@@ -136,6 +139,7 @@ type consensus struct {
 	initMint       sync.Once
 	multiSigWallet *ownership.MultiSigWallet
 	oracle         *oracle.OracleMgr
+	governance     *governance
 }
 
 // Binding returns a binding to the current consensus engine
@@ -155,12 +159,18 @@ func Binding(contractBackend bind.ContractBackend, chainID *big.Int) (*consensus
 		return nil, err
 	}
 
+	governance, err := GovernanceBinding(contractBackend, chainID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &consensus{
 		manager:         manager,
 		managerAddr:     addr,
 		mtoken:          mUSD,
 		chainID:         chainID,
 		contractBackend: contractBackend,
+		governance:      governance,
 	}, nil
 }
 
@@ -197,6 +207,25 @@ func (consensus *consensus) RedeemDeposits(walletAccount accounts.WalletAccount)
 	return nil
 }
 
+// RotateKey binds the signing key to the deposit of the validator from the
+// given block number on.
+func (consensus *consensus) RotateKey(walletAccount accounts.WalletAccount, signingKey common.Address, activeFrom *big.Int) error {
+	log.Warn(fmt.Sprintf("Rotating the signing key on the network %v from block %v. Account %q, key %q",
+		consensus.chainID.String(), activeFrom.String(), walletAccount.Account().Address.String(), signingKey.String()))
+	_, err := consensus.manager.RotateKey(transactOpts(walletAccount, consensus.chainID), signingKey, activeFrom)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SigningKey returns the key the validator signs the election of the given
+// block number with.
+func (consensus *consensus) SigningKey(code common.Address, blockNumber *big.Int) (common.Address, error) {
+	return consensus.manager.GetSigningKey(&bind.CallOpts{}, code, blockNumber)
+}
+
 func (consensus *consensus) ValidatorsChecksum() (ValidatorsChecksum, error) {
 	return consensus.manager.ValidatorsChecksum(&bind.CallOpts{})
 }
@@ -263,6 +292,10 @@ func (consensus *consensus) Token() token.Token {
 	return consensus.mtoken
 }
 
+func (consensus *consensus) Governance() Governor {
+	return consensus.governance
+}
+
 //Minter interface implementation
 
 func (consensus *consensus) MintInit() error {
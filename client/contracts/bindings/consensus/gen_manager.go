@@ -16,10 +16,10 @@ import (
 )
 
 // ValidatorMgrABI is the input ABI used to generate the binding from.
-const ValidatorMgrABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getMinimumDeposit\",\"outputs\":[{\"name\":\"deposit\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"freezePeriod\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"maxNumValidators\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"superNodeAmount\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"miningTokenAddr\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_from\",\"type\":\"address\"},{\"name\":\"_value\",\"type\":\"uint256\"},{\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"registerValidator\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getDepositAtIndex\",\"outputs\":[{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"availableAt\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"baseDeposit\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"deregisterValidator\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getValidatorCount\",\"outputs\":[{\"name\":\"count\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isSuperNode\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getDepositCount\",\"outputs\":[{\"name\":\"count\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"_hasAvailability\",\"outputs\":[{\"name\":\"available\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"max\",\"type\":\"uint256\"}],\"name\":\"setMaxValidators\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"releaseDeposits\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"validatorsChecksum\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"deposit\",\"type\":\"uint256\"}],\"name\":\"setBaseDeposit\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isGenesisValidator\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getValidatorAtIndex\",\"outputs\":[{\"name\":\"code\",\"type\":\"address\"},{\"name\":\"deposit\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isValidator\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"_baseDeposit\",\"type\":\"uint256\"},{\"name\":\"_maxNumValidators\",\"type\":\"uint256\"},{\"name\":\"_freezePeriod\",\"type\":\"uint256\"},{\"name\":\"_miningTokenAddr\",\"type\":\"address\"},{\"name\":\"_superNodeAmount\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Pause\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Unpause\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"name\":\"previousOwner\",\"type\":\"address\"}],\"name\":\"OwnershipRenounced\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"}]"
+const ValidatorMgrABI = "[{\"constant\":true,\"inputs\":[],\"name\":\"getMinimumDeposit\",\"outputs\":[{\"name\":\"deposit\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"freezePeriod\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"signingKey\",\"type\":\"address\"},{\"name\":\"activeFrom\",\"type\":\"uint256\"}],\"name\":\"rotateKey\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"maxNumValidators\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"superNodeAmount\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"miningTokenAddr\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_from\",\"type\":\"address\"},{\"name\":\"_value\",\"type\":\"uint256\"},{\"name\":\"_data\",\"type\":\"bytes\"}],\"name\":\"registerValidator\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getDepositAtIndex\",\"outputs\":[{\"name\":\"amount\",\"type\":\"uint256\"},{\"name\":\"availableAt\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"unpause\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"paused\",\"outputs\":[{\"name\":\"\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"baseDeposit\",\"outputs\":[{\"name\":\"\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"deregisterValidator\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getValidatorCount\",\"outputs\":[{\"name\":\"count\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isSuperNode\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"pause\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"name\":\"\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"getDepositCount\",\"outputs\":[{\"name\":\"count\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"_hasAvailability\",\"outputs\":[{\"name\":\"available\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"max\",\"type\":\"uint256\"}],\"name\":\"setMaxValidators\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[],\"name\":\"releaseDeposits\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[],\"name\":\"validatorsChecksum\",\"outputs\":[{\"name\":\"\",\"type\":\"bytes32\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"deposit\",\"type\":\"uint256\"}],\"name\":\"setBaseDeposit\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isGenesisValidator\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"},{\"name\":\"blockNumber\",\"type\":\"uint256\"}],\"name\":\"getSigningKey\",\"outputs\":[{\"name\":\"key\",\"type\":\"address\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"index\",\"type\":\"uint256\"}],\"name\":\"getValidatorAtIndex\",\"outputs\":[{\"name\":\"code\",\"type\":\"address\"},{\"name\":\"deposit\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"constant\":false,\"inputs\":[{\"name\":\"_newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"constant\":true,\"inputs\":[{\"name\":\"code\",\"type\":\"address\"}],\"name\":\"isValidator\",\"outputs\":[{\"name\":\"isIndeed\",\"type\":\"bool\"}],\"payable\":false,\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"name\":\"_baseDeposit\",\"type\":\"uint256\"},{\"name\":\"_maxNumValidators\",\"type\":\"uint256\"},{\"name\":\"_freezePeriod\",\"type\":\"uint256\"},{\"name\":\"_miningTokenAddr\",\"type\":\"address\"},{\"name\":\"_superNodeAmount\",\"type\":\"uint256\"}],\"payable\":false,\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Pause\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[],\"name\":\"Unpause\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"name\":\"previousOwner\",\"type\":\"address\"}],\"name\":\"OwnershipRenounced\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"}]"
 
 // ValidatorMgrBin is the compiled bytecode used for deploying new contracts.
-const ValidatorMgrBin = `608060405260008060146101000a81548160ff02191690831515021790555034801561002a57600080fd5b5060405160a080611c848339810180604052810190808051906020019092919080519060200190929190805190602001909291908051906020019092919080519060200190929190505050336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550600184101515156100c557600080fd5b846001819055508360028190555062015180830260038190555081600560006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550806006819055505050505050611b498061013b6000396000f300608060405260043610610154576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff168063035cf142146101595780630a3cb663146101845780632086ca25146101af57806326833148146101da57806327378a8c146102055780633e83a2831461025c5780633ed0a373146102ef5780633f4ba83a146103375780635c975abb1461034e578063694746251461037d5780636a911ccf146103a85780637071688a146103bf578063715018a6146103ea5780637d0e81bf146104015780638456cb591461045c5780638da5cb5b146104735780639363a141146104ca57806397584b3e146104f55780639bb2ea5a14610524578063aded41ec14610551578063b774cb1e14610568578063c22a933c1461059b578063cefddda9146105c8578063e7a60a9c14610623578063f2fde38b14610697578063facd743b146106da575b600080fd5b34801561016557600080fd5b5061016e610735565b6040518082815260200191505060405180910390f35b34801561019057600080fd5b50610199610808565b6040518082815260200191505060405180910390f35b3480156101bb57600080fd5b506101c461080e565b6040518082815260200191505060405180910390f35b3480156101e657600080fd5b506101ef610814565b6040518082815260200191505060405180910390f35b34801561021157600080fd5b5061021a61081a565b604051808273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b34801561026857600080fd5b506102ed600480360381019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290505050610840565b005b3480156102fb57600080fd5b5061031a600480360381019080803590602001909291905050506108ce565b604051808381526020018281526020019250505060405180910390f35b34801561034357600080fd5b5061034c610946565b005b34801561035a57600080fd5b50610363610a04565b604051808215151515815260200191505060405180910390f35b34801561038957600080fd5b50610392610a17565b6040518082815260200191505060405180910390f35b3480156103b457600080fd5b506103bd610a1d565b005b3480156103cb57600080fd5b506103d4610a58565b6040518082815260200191505060405180910390f35b3480156103f657600080fd5b506103ff610a65565b005b34801561040d57600080fd5b50610442600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610b67565b604051808215151515815260200191505060405180910390f35b34801561046857600080fd5b50610471610bfb565b005b34801561047f57600080fd5b50610488610cbb565b604051808273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b3480156104d657600080fd5b506104df610ce0565b6040518082815260200191505060405180910390f35b34801561050157600080fd5b5061050a610d2d565b604051808215151515815260200191505060405180910390f35b34801561053057600080fd5b5061054f60048036038101908080359060200190929190505050610d40565b005b34801561055d57600080fd5b50610566610de4565b005b34801561057457600080fd5b5061057d611006565b60405180826000191660001916815260200191505060405180910390f35b3480156105a757600080fd5b506105c66004803603810190808035906020019092919050505061100c565b005b3480156105d457600080fd5b50610609600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611071565b604051808215151515815260200191505060405180910390f35b34801561062f57600080fd5b5061064e600480360381019080803590602001909291905050506110ca565b604051808373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020018281526020019250505060405180910390f35b3480156106a357600080fd5b506106d8600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611181565b005b3480156106e657600080fd5b5061071b600480360381019080803573ffffffffffffffffffffffffffffffffffffffff1690602001909291905050506111e8565b604051808215151515815260200191505060405180910390f35b600080610740610d2d565b1561074f576001549150610804565b60076000600860016008805490500381548110151561076a57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020905060018160020160018360020180549050038154811015156107ee57fe5b9060005260206000209060020201600001540191505b5090565b60035481565b60025481565b60065481565b600560009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60408051908101604052808473ffffffffffffffffffffffffffffffffffffffff16815260200183815250600960008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550602082015181600101559050506108c9611241565b505050565b6000806000600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002018481548110151561092257fe5b90600052602060002090600202019050806000015481600101549250925050915091565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161415156109a157600080fd5b600060149054906101000a900460ff1615156109bc57600080fd5b60008060146101000a81548160ff0219169083151502179055507f7805862f689e2f13df9f062ff482ad3ad112aca9e0847911ed832e158c525b3360405160405180910390a1565b600060149054906101000a900460ff1681565b60015481565b600060149054906101000a900460ff16151515610a3957600080fd5b610a42336111e8565b1515610a4d57600080fd5b610a56336112ff565b565b6000600880549050905090565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610ac057600080fd5b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff167ff8df31144d9c2f0f6b59d69b8b98abd5459d07f2742c4df920b25aae33c6482060405160405180910390a260008060006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550565b600080610b73836111e8565b1515610b825760009150610bf5565b600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002019050600654816001838054905003815481101515610bde57fe5b906000526020600020906002020160000154101591505b50919050565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610c5657600080fd5b600060149054906101000a900460ff16151515610c7257600080fd5b6001600060146101000a81548160ff0219169083151502179055507f6985a02210a168e66602d3235cb6db0e70f92b3ba4d376a33c0f3d9434bff62560405160405180910390a1565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020180549050905090565b6000806008805490506002540311905090565b6000806000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610d9e57600080fd5b600880549050831015610dd85782600880549050039150600090505b81811015610dd757610dca611471565b8080600101915050610dba565b5b82600281905550505050565b600080600080600060149054906101000a900460ff16151515610e0657600080fd5b6000935060009250600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020191505b818054905083108015610e86575060008284815481101515610e7157fe5b90600052602060002090600202016001015414155b15610ee8578183815481101515610e9957fe5b906000526020600020906002020160010154421015610eb757610ee8565b8183815481101515610ec557fe5b906000526020600020906002020160000154840193508280600101935050610e53565b610ef233846114bd565b600084111561100057600560009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1690508073ffffffffffffffffffffffffffffffffffffffff1663a9059cbb33866040518363ffffffff167c0100000000000000000000000000000000000000000000000000000000028152600401808373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200182815260200192505050602060405180830381600087803b158015610fc357600080fd5b505af1158015610fd7573d6000803e3d6000fd5b505050506040513d6020811015610fed57600080fd5b8101908080519060200190929190505050505b50505050565b60045481565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614151561106757600080fd5b8060018190555050565b6000600760008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160019054906101000a900460ff169050919050565b60008060006008848154811015156110de57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169250600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020905080600201600182600201805490500381548110151561116757fe5b906000526020600020906002020160000154915050915091565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161415156111dc57600080fd5b6111e5816115aa565b50565b6000600760008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160009054906101000a900460ff169050919050565b600060149054906101000a900460ff1615151561125d57600080fd5b61128b600960000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166111e8565b15151561129757600080fd5b61129f610735565b600960010154101515156112b257600080fd5b6112ba610d2d565b15156112c9576112c8611471565b5b6112fd600960000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166009600101546116a4565b565b600080600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209150816000015490505b6001600880549050038110156113fc5760086001820181548110151561136d57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166008828154811015156113a757fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550808060010191505061134b565b60088054809190600190036114119190611a6b565b5060008260010160006101000a81548160ff021916908315150217905550600354420182600201600184600201805490500381548110151561144f57fe5b90600052602060002090600202016001018190555061146c6119e8565b505050565b6114bb600860016008805490500381548110151561148b57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166112ff565b565b6000806000808414156114cf576115a3565b600760008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209250600091508390505b826002018054905081101561159157826002018181548110151561153857fe5b9060005260206000209060020201836002018381548110151561155757fe5b9060005260206000209060020201600082015481600001556001820154816001015590505081806001019250508080600101915050611518565b8183600201816115a19190611a97565b505b5050505050565b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16141515156115e657600080fd5b8073ffffffffffffffffffffffffffffffffffffffff166000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a3806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b600080600080600760008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209350600160088790806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555003846000018190555060018460010160006101000a81548160ff021916908315150217905550600043141561179f5760018460010160016101000a81548160ff0219169083151502179055505b8360020160408051908101604052808781526020016000815250908060018154018082558091505090600182039060005260206000209060020201600090919290919091506000820151816000015560208201518160010155505050836000015492505b60008311156119d8576007600060086001860381548110151561182257fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002091508160020160018360020180549050038154811015156118a457fe5b906000526020600020906002020190508060000154851115156118c6576119d8565b6008600184038154811015156118d857fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1660088481548110151561191257fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508560086001850381548110151561196d57fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550828260000181905550600183038460000181905550828060019003935050611803565b6119e06119e8565b505050505050565b6008604051808280548015611a5257602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019060010190808311611a08575b5050915050604051809103902060048160001916905550565b815481835581811115611a9257818360005260206000209182019101611a919190611ac9565b5b505050565b815481835581811115611ac457600202816002028360005260206000209182019101611ac39190611aee565b5b505050565b611aeb91905b80821115611ae7576000816000905550600101611acf565b5090565b90565b611b1a91905b80821115611b1657600080820160009055600182016000905550600201611af4565b5090565b905600a165627a7a723058207dd26f211d5ef32cf5de0f2849e80983c2c7d293a235510a1a843d3aad4a341c0029`
+const ValidatorMgrBin = `608060405260008060146101000a81548160ff02191690831515021790555034801561002a57600080fd5b5060405160a080611e4d8339810180604052810190808051906020019092919080519060200190929190805190602001909291908051906020019092919080519060200190929190505050336000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550600184101515156100c557600080fd5b846001819055508360028190555062015180830260038190555081600560006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550806006819055505050505050611d128061013b6000396000f300608060405260043610610154576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff168063035cf142146101595780630a3cb663146101845780632086ca25146101af57806326833148146101da57806327378a8c146102055780633e83a2831461025c5780633ed0a373146102ef5780633f4ba83a146103375780635c975abb1461034e578063694746251461037d5780636a911ccf146103a85780637071688a146103bf578063715018a6146103ea5780637d0e81bf146104015780638456cb591461045c5780638da5cb5b146104735780639363a141146104ca57806397584b3e146104f55780639bb2ea5a14610524578063aded41ec14610551578063b774cb1e14610568578063c22a933c1461059b578063cefddda9146105c8578063e7a60a9c14610623578063f2fde38b14610697578063facd743b146106da575b611b49565b34801561016557600080fd5b5061016e610735565b6040518082815260200191505060405180910390f35b34801561019057600080fd5b50610199610808565b6040518082815260200191505060405180910390f35b3480156101bb57600080fd5b506101c461080e565b6040518082815260200191505060405180910390f35b3480156101e657600080fd5b506101ef610814565b6040518082815260200191505060405180910390f35b34801561021157600080fd5b5061021a61081a565b604051808273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b34801561026857600080fd5b506102ed600480360381019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190803590602001908201803590602001908080601f0160208091040260200160405190810160405280939291908181526020018383808284378201915050505050509192919290505050610840565b005b3480156102fb57600080fd5b5061031a600480360381019080803590602001909291905050506108ce565b604051808381526020018281526020019250505060405180910390f35b34801561034357600080fd5b5061034c610946565b005b34801561035a57600080fd5b50610363610a04565b604051808215151515815260200191505060405180910390f35b34801561038957600080fd5b50610392610a17565b6040518082815260200191505060405180910390f35b3480156103b457600080fd5b506103bd610a1d565b005b3480156103cb57600080fd5b506103d4610a58565b6040518082815260200191505060405180910390f35b3480156103f657600080fd5b506103ff610a65565b005b34801561040d57600080fd5b50610442600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610b67565b604051808215151515815260200191505060405180910390f35b34801561046857600080fd5b50610471610bfb565b005b34801561047f57600080fd5b50610488610cbb565b604051808273ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b3480156104d657600080fd5b506104df610ce0565b6040518082815260200191505060405180910390f35b34801561050157600080fd5b5061050a610d2d565b604051808215151515815260200191505060405180910390f35b34801561053057600080fd5b5061054f60048036038101908080359060200190929190505050610d40565b005b34801561055d57600080fd5b50610566610de4565b005b34801561057457600080fd5b5061057d611006565b60405180826000191660001916815260200191505060405180910390f35b3480156105a757600080fd5b506105c66004803603810190808035906020019092919050505061100c565b005b3480156105d457600080fd5b50610609600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611071565b604051808215151515815260200191505060405180910390f35b34801561062f57600080fd5b5061064e600480360381019080803590602001909291905050506110ca565b604051808373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020018281526020019250505060405180910390f35b3480156106a357600080fd5b506106d8600480360381019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611181565b005b3480156106e657600080fd5b5061071b600480360381019080803573ffffffffffffffffffffffffffffffffffffffff1690602001909291905050506111e8565b604051808215151515815260200191505060405180910390f35b600080610740610d2d565b1561074f576001549150610804565b60076000600860016008805490500381548110151561076a57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020905060018160020160018360020180549050038154811015156107ee57fe5b9060005260206000209060020201600001540191505b5090565b60035481565b60025481565b60065481565b600560009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60408051908101604052808473ffffffffffffffffffffffffffffffffffffffff16815260200183815250600960008201518160000160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550602082015181600101559050506108c9611241565b505050565b6000806000600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002018481548110151561092257fe5b90600052602060002090600202019050806000015481600101549250925050915091565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161415156109a157600080fd5b600060149054906101000a900460ff1615156109bc57600080fd5b60008060146101000a81548160ff0219169083151502179055507f7805862f689e2f13df9f062ff482ad3ad112aca9e0847911ed832e158c525b3360405160405180910390a1565b600060149054906101000a900460ff1681565b60015481565b600060149054906101000a900460ff16151515610a3957600080fd5b610a42336111e8565b1515610a4d57600080fd5b610a56336112ff565b565b6000600880549050905090565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610ac057600080fd5b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff167ff8df31144d9c2f0f6b59d69b8b98abd5459d07f2742c4df920b25aae33c6482060405160405180910390a260008060006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550565b600080610b73836111e8565b1515610b825760009150610bf5565b600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206002019050600654816001838054905003815481101515610bde57fe5b906000526020600020906002020160000154101591505b50919050565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610c5657600080fd5b600060149054906101000a900460ff16151515610c7257600080fd5b6001600060146101000a81548160ff0219169083151502179055507f6985a02210a168e66602d3235cb6db0e70f92b3ba4d376a33c0f3d9434bff62560405160405180910390a1565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b6000600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020180549050905090565b6000806008805490506002540311905090565b6000806000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff16141515610d9e57600080fd5b600880549050831015610dd85782600880549050039150600090505b81811015610dd757610dca611471565b8080600101915050610dba565b5b82600281905550505050565b600080600080600060149054906101000a900460ff16151515610e0657600080fd5b6000935060009250600760003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060020191505b818054905083108015610e86575060008284815481101515610e7157fe5b90600052602060002090600202016001015414155b15610ee8578183815481101515610e9957fe5b906000526020600020906002020160010154421015610eb757610ee8565b8183815481101515610ec557fe5b906000526020600020906002020160000154840193508280600101935050610e53565b610ef233846114bd565b600084111561100057600560009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1690508073ffffffffffffffffffffffffffffffffffffffff1663a9059cbb33866040518363ffffffff167c0100000000000000000000000000000000000000000000000000000000028152600401808373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200182815260200192505050602060405180830381600087803b158015610fc357600080fd5b505af1158015610fd7573d6000803e3d6000fd5b505050506040513d6020811015610fed57600080fd5b8101908080519060200190929190505050505b50505050565b60045481565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff1614151561106757600080fd5b8060018190555050565b6000600760008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160019054906101000a900460ff169050919050565b60008060006008848154811015156110de57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff169250600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020905080600201600182600201805490500381548110151561116757fe5b906000526020600020906002020160000154915050915091565b6000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff161415156111dc57600080fd5b6111e5816115aa565b50565b6000600760008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060010160009054906101000a900460ff169050919050565b600060149054906101000a900460ff1615151561125d57600080fd5b61128b600960000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166111e8565b15151561129757600080fd5b61129f610735565b600960010154101515156112b257600080fd5b6112ba610d2d565b15156112c9576112c8611471565b5b6112fd600960000160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166009600101546116a4565b565b600080600760008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209150816000015490505b6001600880549050038110156113fc5760086001820181548110151561136d57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166008828154811015156113a757fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550808060010191505061134b565b60088054809190600190036114119190611a6b565b5060008260010160006101000a81548160ff021916908315150217905550600354420182600201600184600201805490500381548110151561144f57fe5b90600052602060002090600202016001018190555061146c6119e8565b505050565b6114bb600860016008805490500381548110151561148b57fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff166112ff565b565b6000806000808414156114cf576115a3565b600760008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209250600091508390505b826002018054905081101561159157826002018181548110151561153857fe5b9060005260206000209060020201836002018381548110151561155757fe5b9060005260206000209060020201600082015481600001556001820154816001015590505081806001019250508080600101915050611518565b8183600201816115a19190611a97565b505b5050505050565b600073ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16141515156115e657600080fd5b8073ffffffffffffffffffffffffffffffffffffffff166000809054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff167f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e060405160405180910390a3806000806101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555050565b600080600080600760008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000209350600160088790806001815401808255809150509060018203906000526020600020016000909192909190916101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555003846000018190555060018460010160006101000a81548160ff021916908315150217905550600043141561179f5760018460010160016101000a81548160ff0219169083151502179055505b8360020160408051908101604052808781526020016000815250908060018154018082558091505090600182039060005260206000209060020201600090919290919091506000820151816000015560208201518160010155505050836000015492505b60008311156119d8576007600060086001860381548110151561182257fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002091508160020160018360020180549050038154811015156118a457fe5b906000526020600020906002020190508060000154851115156118c6576119d8565b6008600184038154811015156118d857fe5b9060005260206000200160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1660088481548110151561191257fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055508560086001850381548110151561196d57fe5b9060005260206000200160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff160217905550828260000181905550600183038460000181905550828060019003935050611803565b6119e06119e8565b505050505050565b6008604051808280548015611a5257602002820191906000526020600020905b8160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019060010190808311611a08575b5050915050604051809103902060048160001916905550565b815481835581811115611a9257818360005260206000209182019101611a919190611ac9565b5b505050565b815481835581811115611ac457600202816002028360005260206000209182019101611ac39190611aee565b5b505050565b611aeb91905b80821115611ae7576000816000905550600101611acf565b5090565b90565b611b1a91905b80821115611b1657600080820160009055600182016000905550600201611af4565b5090565b905600a165627a7a723058207dd26f211d5ef32cf5de0f2849e80983c2c7d293a235510a1a843d3aad4a341c00295b60043610611b91576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff168063162a5b1b14611b96578063d080bad714611ca0575b600080fd5b34611b915760005474010000000000000000000000000000000000000000900460ff16611b91573373ffffffffffffffffffffffffffffffffffffffff16600052600760205260406000206001015460ff1615611b915760043573ffffffffffffffffffffffffffffffffffffffff168015611b9157803314611c43578073ffffffffffffffffffffffffffffffffffffffff16600052600760205260406000206001015460ff16611b91575b60243543811115611b91573373ffffffffffffffffffffffffffffffffffffffff16600052600b602052604060002080600201548015611c8f574310611c915780600101548155611c91565b505b80600201829055600101829055005b34611b915760043573ffffffffffffffffffffffffffffffffffffffff168073ffffffffffffffffffffffffffffffffffffffff16600052600b602052604060002080600201548015611cfe5760243510611d005760010154611d09565b505b5480611d095750805b60005260206000f3`
 
 // DeployValidatorMgr deploys a new Kowala contract, binding an instance of ValidatorMgr to it.
 func DeployValidatorMgr(auth *bind.TransactOpts, backend bind.ContractBackend, _baseDeposit *big.Int, _maxNumValidators *big.Int, _freezePeriod *big.Int, _miningTokenAddr common.Address, _superNodeAmount *big.Int) (common.Address, *types.Transaction, *ValidatorMgr, error) {
@@ -342,6 +342,32 @@ func (_ValidatorMgr *ValidatorMgrCallerSession) GetMinimumDeposit() (*big.Int, e
 	return _ValidatorMgr.Contract.GetMinimumDeposit(&_ValidatorMgr.CallOpts)
 }
 
+// GetSigningKey is a free data retrieval call binding the contract method 0xd080bad7.
+//
+// Solidity: function getSigningKey(code address, blockNumber uint256) constant returns(key address)
+func (_ValidatorMgr *ValidatorMgrCaller) GetSigningKey(opts *bind.CallOpts, code common.Address, blockNumber *big.Int) (common.Address, error) {
+	var (
+		ret0 = new(common.Address)
+	)
+	out := ret0
+	err := _ValidatorMgr.contract.Call(opts, out, "getSigningKey", code, blockNumber)
+	return *ret0, err
+}
+
+// GetSigningKey is a free data retrieval call binding the contract method 0xd080bad7.
+//
+// Solidity: function getSigningKey(code address, blockNumber uint256) constant returns(key address)
+func (_ValidatorMgr *ValidatorMgrSession) GetSigningKey(code common.Address, blockNumber *big.Int) (common.Address, error) {
+	return _ValidatorMgr.Contract.GetSigningKey(&_ValidatorMgr.CallOpts, code, blockNumber)
+}
+
+// GetSigningKey is a free data retrieval call binding the contract method 0xd080bad7.
+//
+// Solidity: function getSigningKey(code address, blockNumber uint256) constant returns(key address)
+func (_ValidatorMgr *ValidatorMgrCallerSession) GetSigningKey(code common.Address, blockNumber *big.Int) (common.Address, error) {
+	return _ValidatorMgr.Contract.GetSigningKey(&_ValidatorMgr.CallOpts, code, blockNumber)
+}
+
 // GetValidatorAtIndex is a free data retrieval call binding the contract method 0xe7a60a9c.
 //
 // Solidity: function getValidatorAtIndex(index uint256) constant returns(code address, deposit uint256)
@@ -743,6 +769,27 @@ func (_ValidatorMgr *ValidatorMgrTransactorSession) RenounceOwnership() (*types.
 	return _ValidatorMgr.Contract.RenounceOwnership(&_ValidatorMgr.TransactOpts)
 }
 
+// RotateKey is a paid mutator transaction binding the contract method 0x162a5b1b.
+//
+// Solidity: function rotateKey(signingKey address, activeFrom uint256) returns()
+func (_ValidatorMgr *ValidatorMgrTransactor) RotateKey(opts *bind.TransactOpts, signingKey common.Address, activeFrom *big.Int) (*types.Transaction, error) {
+	return _ValidatorMgr.contract.Transact(opts, "rotateKey", signingKey, activeFrom)
+}
+
+// RotateKey is a paid mutator transaction binding the contract method 0x162a5b1b.
+//
+// Solidity: function rotateKey(signingKey address, activeFrom uint256) returns()
+func (_ValidatorMgr *ValidatorMgrSession) RotateKey(signingKey common.Address, activeFrom *big.Int) (*types.Transaction, error) {
+	return _ValidatorMgr.Contract.RotateKey(&_ValidatorMgr.TransactOpts, signingKey, activeFrom)
+}
+
+// RotateKey is a paid mutator transaction binding the contract method 0x162a5b1b.
+//
+// Solidity: function rotateKey(signingKey address, activeFrom uint256) returns()
+func (_ValidatorMgr *ValidatorMgrTransactorSession) RotateKey(signingKey common.Address, activeFrom *big.Int) (*types.Transaction, error) {
+	return _ValidatorMgr.Contract.RotateKey(&_ValidatorMgr.TransactOpts, signingKey, activeFrom)
+}
+
 // SetBaseDeposit is a paid mutator transaction binding the contract method 0xc22a933c.
 //
 // Solidity: function setBaseDeposit(deposit uint256) returns()
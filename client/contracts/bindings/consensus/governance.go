@@ -0,0 +1,353 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/abi"
+	"github.com/kowala-tech/kcoin/client/accounts/abi/bind"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/common/hexutil"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/oracle"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/ownership"
+)
+
+// Names of the system contracts administered by the MultiSig wallet
+const (
+	MultiSigWalletContract = "MultiSigWallet"
+	ValidatorMgrContract   = "ValidatorMgr"
+	OracleMgrContract      = "OracleMgr"
+	MiningTokenContract    = "MiningToken"
+)
+
+var errUnknownContract = errors.New("unknown system contract")
+
+// Governor is a gateway to the MultiSig wallet owning the system contracts. Any
+// call to a system contract restricted to its owner goes through a MultiSig
+// transaction, which is executed once confirmed by enough governors.
+type Governor interface {
+	Governors() ([]common.Address, error)
+	RequiredConfirmations() (*big.Int, error)
+	Contracts() SystemContracts
+	Transaction(id *big.Int) (*MultiSigTransaction, error)
+	Transactions(pending, executed bool) ([]*MultiSigTransaction, error)
+	Submit(opts *accounts.TransactOpts, destination common.Address, value *big.Int, data []byte) (common.Hash, error)
+	Confirm(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error)
+	Revoke(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error)
+	Execute(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error)
+
+	// Typed helpers submitting system contract parameter changes
+	SetMaxValidators(opts *accounts.TransactOpts, max *big.Int) (common.Hash, error)
+	SetBaseDeposit(opts *accounts.TransactOpts, deposit *big.Int) (common.Hash, error)
+	Pause(opts *accounts.TransactOpts, contract string) (common.Hash, error)
+	Unpause(opts *accounts.TransactOpts, contract string) (common.Hash, error)
+}
+
+// SystemContracts holds the addresses of the system contracts.
+type SystemContracts struct {
+	MultiSigWallet common.Address `json:"multiSigWallet"`
+	ValidatorMgr   common.Address `json:"validatorMgr"`
+	OracleMgr      common.Address `json:"oracleMgr"`
+	MiningToken    common.Address `json:"miningToken"`
+}
+
+// MultiSigTransaction is a transaction submitted to the MultiSig wallet, along
+// with its call data decoded if it calls a system contract.
+type MultiSigTransaction struct {
+	ID            *big.Int
+	Destination   common.Address
+	Contract      string // Name of the system contract called, empty if unknown
+	Value         *big.Int
+	Data          []byte
+	Call          *ContractCall // Decoded call data, nil if undecodable
+	Executed      bool
+	Confirmations []common.Address
+}
+
+// ContractCall is the call data of a transaction decoded against the ABI of
+// the contract called.
+type ContractCall struct {
+	Method string        `json:"method"`
+	Args   []ContractArg `json:"args"`
+}
+
+// ContractArg is a decoded argument of a contract call.
+type ContractArg struct {
+	Name  string `json:"name"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// systemContract is a system contract the call data is decoded for.
+type systemContract struct {
+	name string
+	abi  abi.ABI
+}
+
+type governance struct {
+	multiSigWallet *ownership.MultiSigWallet
+	addrs          SystemContracts
+	contracts      map[common.Address]*systemContract
+}
+
+// GovernanceBinding returns a binding to the MultiSig wallet of the network.
+func GovernanceBinding(contractBackend bind.ContractBackend, chainID *big.Int) (*governance, error) {
+	var (
+		addrs SystemContracts
+		ok    bool
+		id    = chainID.Uint64()
+	)
+	if addrs.MultiSigWallet, ok = mapMultiSigWalletToAddr[id]; !ok {
+		return nil, bindings.ErrNoAddress
+	}
+	if addrs.ValidatorMgr, ok = mapValidatorMgrToAddr[id]; !ok {
+		return nil, bindings.ErrNoAddress
+	}
+	if addrs.OracleMgr, ok = mapOracleToAddr[id]; !ok {
+		return nil, bindings.ErrNoAddress
+	}
+	if addrs.MiningToken, ok = mapMiningTokenToAddr[id]; !ok {
+		return nil, bindings.ErrNoAddress
+	}
+	return NewGovernance(contractBackend, addrs)
+}
+
+// NewGovernance returns a binding to the MultiSig wallet owning the given
+// system contracts.
+func NewGovernance(contractBackend bind.ContractBackend, addrs SystemContracts) (*governance, error) {
+	multiSigWallet, err := ownership.NewMultiSigWallet(addrs.MultiSigWallet, contractBackend)
+	if err != nil {
+		return nil, err
+	}
+
+	gov := &governance{
+		multiSigWallet: multiSigWallet,
+		addrs:          addrs,
+		contracts:      make(map[common.Address]*systemContract),
+	}
+	for _, contract := range []struct {
+		name string
+		addr common.Address
+		abi  string
+	}{
+		{MultiSigWalletContract, addrs.MultiSigWallet, ownership.MultiSigWalletABI},
+		{ValidatorMgrContract, addrs.ValidatorMgr, ValidatorMgrABI},
+		{OracleMgrContract, addrs.OracleMgr, oracle.OracleMgrABI},
+		{MiningTokenContract, addrs.MiningToken, MiningTokenABI},
+	} {
+		parsed, err := abi.JSON(strings.NewReader(contract.abi))
+		if err != nil {
+			return nil, err
+		}
+		gov.contracts[contract.addr] = &systemContract{name: contract.name, abi: parsed}
+	}
+
+	return gov, nil
+}
+
+func (gov *governance) Governors() ([]common.Address, error) {
+	return gov.multiSigWallet.GetOwners(&bind.CallOpts{})
+}
+
+func (gov *governance) RequiredConfirmations() (*big.Int, error) {
+	return gov.multiSigWallet.Required(&bind.CallOpts{})
+}
+
+func (gov *governance) Contracts() SystemContracts {
+	return gov.addrs
+}
+
+func (gov *governance) Transaction(id *big.Int) (*MultiSigTransaction, error) {
+	count, err := gov.multiSigWallet.TransactionCount(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	if id.Sign() < 0 || id.Cmp(count) >= 0 {
+		return nil, fmt.Errorf("unknown MultiSig transaction %v", id)
+	}
+
+	transaction, err := gov.multiSigWallet.Transactions(&bind.CallOpts{}, id)
+	if err != nil {
+		return nil, err
+	}
+	confirmations, err := gov.multiSigWallet.GetConfirmations(&bind.CallOpts{}, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tx := &MultiSigTransaction{
+		ID:            id,
+		Destination:   transaction.Destination,
+		Value:         transaction.Value,
+		Data:          transaction.Data,
+		Executed:      transaction.Executed,
+		Confirmations: confirmations,
+	}
+	tx.Contract, tx.Call = gov.decode(transaction.Destination, transaction.Data)
+
+	return tx, nil
+}
+
+func (gov *governance) Transactions(pending, executed bool) ([]*MultiSigTransaction, error) {
+	count, err := gov.multiSigWallet.GetTransactionCount(&bind.CallOpts{}, pending, executed)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := gov.multiSigWallet.GetTransactionIds(&bind.CallOpts{}, common.Big0, count, pending, executed)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*MultiSigTransaction, len(ids))
+	for i, id := range ids {
+		if txs[i], err = gov.Transaction(id); err != nil {
+			return nil, err
+		}
+	}
+
+	return txs, nil
+}
+
+func (gov *governance) Submit(opts *accounts.TransactOpts, destination common.Address, value *big.Int, data []byte) (common.Hash, error) {
+	if value == nil {
+		value = common.Big0
+	}
+
+	tx, err := gov.multiSigWallet.SubmitTransaction(toBind(opts), destination, value, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), err
+}
+
+func (gov *governance) Confirm(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error) {
+	tx, err := gov.multiSigWallet.ConfirmTransaction(toBind(opts), transactionID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), err
+}
+
+func (gov *governance) Revoke(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error) {
+	tx, err := gov.multiSigWallet.RevokeConfirmation(toBind(opts), transactionID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), err
+}
+
+func (gov *governance) Execute(opts *accounts.TransactOpts, transactionID *big.Int) (common.Hash, error) {
+	tx, err := gov.multiSigWallet.ExecuteTransaction(toBind(opts), transactionID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return tx.Hash(), err
+}
+
+func (gov *governance) SetMaxValidators(opts *accounts.TransactOpts, max *big.Int) (common.Hash, error) {
+	return gov.submitCall(opts, gov.addrs.ValidatorMgr, "setMaxValidators", max)
+}
+
+func (gov *governance) SetBaseDeposit(opts *accounts.TransactOpts, deposit *big.Int) (common.Hash, error) {
+	return gov.submitCall(opts, gov.addrs.ValidatorMgr, "setBaseDeposit", deposit)
+}
+
+func (gov *governance) Pause(opts *accounts.TransactOpts, contract string) (common.Hash, error) {
+	addr, err := gov.pausable(contract)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return gov.submitCall(opts, addr, "pause")
+}
+
+func (gov *governance) Unpause(opts *accounts.TransactOpts, contract string) (common.Hash, error) {
+	addr, err := gov.pausable(contract)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return gov.submitCall(opts, addr, "unpause")
+}
+
+// pausable returns the address of a system contract that can be paused.
+func (gov *governance) pausable(contract string) (common.Address, error) {
+	switch contract {
+	case ValidatorMgrContract:
+		return gov.addrs.ValidatorMgr, nil
+	case OracleMgrContract:
+		return gov.addrs.OracleMgr, nil
+	}
+	return common.Address{}, fmt.Errorf("%v: %q can't be paused", errUnknownContract, contract)
+}
+
+// submitCall submits a MultiSig transaction calling the given method of a
+// system contract.
+func (gov *governance) submitCall(opts *accounts.TransactOpts, addr common.Address, method string, args ...interface{}) (common.Hash, error) {
+	contract, ok := gov.contracts[addr]
+	if !ok {
+		return common.Hash{}, errUnknownContract
+	}
+
+	data, err := contract.abi.Pack(method, args...)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	return gov.Submit(opts, addr, common.Big0, data)
+}
+
+// decode returns the name of the system contract a transaction is destined to,
+// and its call data decoded against the contract ABI.
+func (gov *governance) decode(destination common.Address, data []byte) (string, *ContractCall) {
+	contract, ok := gov.contracts[destination]
+	if !ok {
+		return "", nil
+	}
+	if len(data) < 4 {
+		return contract.name, nil
+	}
+
+	method, err := contract.abi.MethodById(data)
+	if err != nil {
+		return contract.name, nil
+	}
+	values, err := method.Inputs.UnpackValues(data[4:])
+	if err != nil || len(values) != len(method.Inputs) {
+		return contract.name, nil
+	}
+
+	call := &ContractCall{
+		Method: method.Name,
+		Args:   make([]ContractArg, len(values)),
+	}
+	for i, input := range method.Inputs {
+		call.Args[i] = ContractArg{
+			Name:  input.Name,
+			Type:  input.Type.String(),
+			Value: formatArg(values[i]),
+		}
+	}
+
+	return contract.name, call
+}
+
+// formatArg formats a decoded call argument for display.
+func formatArg(value interface{}) string {
+	switch v := value.(type) {
+	case *big.Int:
+		return v.String()
+	case common.Address:
+		return v.Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
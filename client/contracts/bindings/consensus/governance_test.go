@@ -0,0 +1,168 @@
+package consensus_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/abi/bind"
+	"github.com/kowala-tech/kcoin/client/accounts/abi/bind/backends"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/knode/genesis"
+	"github.com/stretchr/testify/suite"
+)
+
+var (
+	secondGovernor, _ = crypto.GenerateKey()
+	oracleMgrAddr     = common.HexToAddress("0x2c3DA02A82D11D649857AaE537920D8cA368cAB5")
+)
+
+type GovernanceSuite struct {
+	suite.Suite
+	backend      *backends.SimulatedBackend
+	governor     consensus.Governor
+	validatorMgr *consensus.ValidatorMgr
+}
+
+func TestGovernanceSuite(t *testing.T) {
+	suite.Run(t, new(GovernanceSuite))
+}
+
+func (suite *GovernanceSuite) BeforeTest(suiteName, testName string) {
+	req := suite.Require()
+
+	// two governors, both required to execute a transaction
+	opts := getDefaultOpts()
+	opts.Governance.Governors = append(opts.Governance.Governors, getAddress(secondGovernor).Hex())
+	opts.Governance.NumConfirmations = 2
+	opts.PrefundedAccounts = append(opts.PrefundedAccounts, genesis.PrefundedAccount{
+		Address: getAddress(secondGovernor).Hex(),
+		Balance: 10,
+	})
+
+	genesis, err := genesis.Generate(opts)
+	req.NoError(err)
+	suite.backend = backends.NewSimulatedBackend(genesis.Alloc)
+
+	governor, err := consensus.NewGovernance(suite.backend, consensus.SystemContracts{
+		MultiSigWallet: multiSigAddr,
+		ValidatorMgr:   validatorMgrAddr,
+		OracleMgr:      oracleMgrAddr,
+		MiningToken:    tokenAddr,
+	})
+	req.NoError(err)
+	suite.governor = governor
+
+	mgr, err := consensus.NewValidatorMgr(validatorMgrAddr, suite.backend)
+	req.NoError(err)
+	suite.validatorMgr = mgr
+}
+
+func (suite *GovernanceSuite) TestGovernors() {
+	req := suite.Require()
+
+	governors, err := suite.governor.Governors()
+	req.NoError(err)
+	req.Equal([]common.Address{getAddress(governor), getAddress(secondGovernor)}, governors)
+
+	required, err := suite.governor.RequiredConfirmations()
+	req.NoError(err)
+	req.Equal(big.NewInt(2), required)
+}
+
+func (suite *GovernanceSuite) TestSetMaxValidators() {
+	req := suite.Require()
+
+	_, err := suite.governor.SetMaxValidators(transactOpts(governor), big.NewInt(5))
+	req.NoError(err)
+	suite.backend.Commit()
+
+	txs, err := suite.governor.Transactions(true, false)
+	req.NoError(err)
+	req.Len(txs, 1)
+	tx := txs[0]
+	req.Zero(tx.ID.Sign())
+	req.Equal(validatorMgrAddr, tx.Destination)
+	req.Equal(consensus.ValidatorMgrContract, tx.Contract)
+	req.False(tx.Executed)
+	req.Equal([]common.Address{getAddress(governor)}, tx.Confirmations)
+	req.Equal(&consensus.ContractCall{
+		Method: "setMaxValidators",
+		Args:   []consensus.ContractArg{{Name: "max", Type: "uint256", Value: "5"}},
+	}, tx.Call)
+
+	_, err = suite.governor.Confirm(transactOpts(secondGovernor), tx.ID)
+	req.NoError(err)
+	suite.backend.Commit()
+
+	max, err := suite.validatorMgr.MaxNumValidators(&bind.CallOpts{})
+	req.NoError(err)
+	req.Equal(big.NewInt(5), max)
+
+	txs, err = suite.governor.Transactions(false, true)
+	req.NoError(err)
+	req.Len(txs, 1)
+	req.True(txs[0].Executed)
+}
+
+func (suite *GovernanceSuite) TestRevoke() {
+	req := suite.Require()
+
+	_, err := suite.governor.Pause(transactOpts(governor), consensus.ValidatorMgrContract)
+	req.NoError(err)
+	suite.backend.Commit()
+
+	_, err = suite.governor.Revoke(transactOpts(governor), common.Big0)
+	req.NoError(err)
+	suite.backend.Commit()
+
+	tx, err := suite.governor.Transaction(common.Big0)
+	req.NoError(err)
+	req.Empty(tx.Confirmations)
+	req.Equal("pause", tx.Call.Method)
+
+	// a revoked confirmation doesn't count towards the execution
+	_, err = suite.governor.Confirm(transactOpts(secondGovernor), common.Big0)
+	req.NoError(err)
+	suite.backend.Commit()
+
+	paused, err := suite.validatorMgr.Paused(&bind.CallOpts{})
+	req.NoError(err)
+	req.False(paused)
+}
+
+func (suite *GovernanceSuite) TestPause_NotPausable() {
+	_, err := suite.governor.Pause(transactOpts(governor), consensus.MiningTokenContract)
+	suite.Require().Error(err)
+}
+
+func (suite *GovernanceSuite) TestSubmit_UnknownDestination() {
+	req := suite.Require()
+
+	destination := getAddress(user)
+	_, err := suite.governor.Submit(transactOpts(governor), destination, common.Big0, []byte{0x01, 0x02})
+	req.NoError(err)
+	suite.backend.Commit()
+
+	tx, err := suite.governor.Transaction(common.Big0)
+	req.NoError(err)
+	req.Equal(destination, tx.Destination)
+	req.Empty(tx.Contract)
+	req.Nil(tx.Call)
+	req.Equal([]byte{0x01, 0x02}, tx.Data)
+
+	_, err = suite.governor.Transaction(common.Big1)
+	req.Error(err)
+}
+
+// transactOpts returns the options to transact with the given private key.
+func transactOpts(key *ecdsa.PrivateKey) *accounts.TransactOpts {
+	opts := bind.NewKeyedTransactor(key)
+	return &accounts.TransactOpts{
+		From:   opts.From,
+		Signer: accounts.SignerFn(opts.Signer),
+	}
+}
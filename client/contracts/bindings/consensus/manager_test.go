@@ -25,6 +25,7 @@ var (
 	deregistered, _  = crypto.GenerateKey()
 	user, _          = crypto.GenerateKey()
 	governor, _      = crypto.GenerateKey()
+	signingKey, _    = crypto.GenerateKey()
 	author, _        = crypto.HexToECDSA("bfef37ae9ac5d5e7ebbbefc19f4e1f572a7ca7aa0d28e527b7d62950951cc5eb")
 	validatorMgrAddr = common.HexToAddress("0x161ad311F1D66381C17641b1B73042a4CA731F9f")
 	multiSigAddr     = common.HexToAddress("0xA143ac5ec5D95f16aFD5Fc3B09e0aDaf360ffC9e")
@@ -589,6 +590,75 @@ func (suite *ValidatorMgrSuite) TestReleaseDeposits_UnlockedDeposit() {
 	req.Equal(new(big.Int).Add(initialBalance, deposit.Amount), finalBalance)
 }
 
+func (suite *ValidatorMgrSuite) TestRotateKey_WhenPaused() {
+	req := suite.Require()
+
+	suite.pauseService()
+
+	req.Error(suite.rotateKey(validator, getAddress(signingKey), big.NewInt(10)), "cannot rotate the key because the service is paused")
+}
+
+func (suite *ValidatorMgrSuite) TestRotateKey_NotValidator() {
+	req := suite.Require()
+
+	req.Error(suite.rotateKey(user, getAddress(signingKey), big.NewInt(10)), "cannot rotate the key of a non-validator")
+}
+
+func (suite *ValidatorMgrSuite) TestRotateKey_ToValidator() {
+	req := suite.Require()
+
+	deposit := new(big.Int).Mul(new(big.Int).SetUint64(suite.opts.Consensus.BaseDeposit), new(big.Int).SetUint64(params.Kcoin))
+	req.NoError(suite.registerValidator(user, deposit))
+	suite.backend.Commit()
+
+	req.Error(suite.rotateKey(validator, getAddress(user), big.NewInt(10)), "cannot rotate to the key of another validator")
+	req.Error(suite.rotateKey(validator, common.Address{}, big.NewInt(10)), "cannot rotate to the zero address")
+}
+
+func (suite *ValidatorMgrSuite) TestRotateKey_PastBlock() {
+	req := suite.Require()
+
+	suite.backend.Commit()
+
+	req.Error(suite.rotateKey(validator, getAddress(signingKey), common.Big1), "cannot rotate the key at a past block")
+}
+
+func (suite *ValidatorMgrSuite) TestRotateKey() {
+	req := suite.Require()
+
+	code := getAddress(validator)
+	req.Equal(code, suite.getSigningKey(code, big.NewInt(100)))
+
+	req.NoError(suite.rotateKey(validator, getAddress(signingKey), big.NewInt(10)))
+	suite.backend.Commit()
+
+	req.Equal(code, suite.getSigningKey(code, big.NewInt(9)))
+	req.Equal(getAddress(signingKey), suite.getSigningKey(code, big.NewInt(10)))
+
+	// a pending rotation is replaced
+	req.NoError(suite.rotateKey(validator, getAddress(deregistered), big.NewInt(5)))
+	suite.backend.Commit()
+
+	req.Equal(code, suite.getSigningKey(code, big.NewInt(4)))
+	req.Equal(getAddress(deregistered), suite.getSigningKey(code, big.NewInt(10)))
+}
+
+func (suite *ValidatorMgrSuite) TestRotateKey_Active() {
+	req := suite.Require()
+
+	code := getAddress(validator)
+	req.NoError(suite.rotateKey(validator, getAddress(signingKey), big.NewInt(2)))
+	suite.backend.Commit()
+	suite.backend.Commit()
+
+	// the active key is kept until the next rotation
+	req.NoError(suite.rotateKey(validator, code, big.NewInt(10)))
+	suite.backend.Commit()
+
+	req.Equal(getAddress(signingKey), suite.getSigningKey(code, big.NewInt(9)))
+	req.Equal(code, suite.getSigningKey(code, big.NewInt(10)))
+}
+
 func (suite *ValidatorMgrSuite) mintTokens(governor *ecdsa.PrivateKey, to *ecdsa.PrivateKey, numTokens *big.Int) {
 	req := suite.Require()
 
@@ -628,6 +698,21 @@ func (suite *ValidatorMgrSuite) releaseDeposits(user *ecdsa.PrivateKey) error {
 	return err
 }
 
+func (suite *ValidatorMgrSuite) rotateKey(user *ecdsa.PrivateKey, signingKey common.Address, activeFrom *big.Int) error {
+	transactOpts := bind.NewKeyedTransactor(user)
+	_, err := suite.validatorMgr.RotateKey(transactOpts, signingKey, activeFrom)
+	return err
+}
+
+func (suite *ValidatorMgrSuite) getSigningKey(code common.Address, blockNumber *big.Int) common.Address {
+	req := suite.Require()
+
+	key, err := suite.validatorMgr.GetSigningKey(&bind.CallOpts{}, code, blockNumber)
+	req.NoError(err)
+
+	return key
+}
+
 func (suite *ValidatorMgrSuite) pauseService() {
 	req := suite.Require()
 
@@ -0,0 +1,33 @@
+package token
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/crypto"
+)
+
+// transferSelectors are the method ids of the ERC20 and ERC223 token transfers.
+var transferSelectors = [][]byte{
+	crypto.Keccak256([]byte("transfer(address,uint256)"))[:4],
+	crypto.Keccak256([]byte("transfer(address,uint256,bytes)"))[:4],
+	crypto.Keccak256([]byte("transfer(address,uint256,bytes,string)"))[:4],
+}
+
+// DecodeTransfer returns the recipient and the value of a token transfer call.
+func DecodeTransfer(data []byte) (common.Address, *big.Int, bool) {
+	if len(data) < 4+2*common.HashLength {
+		return common.Address{}, nil, false
+	}
+
+	for _, selector := range transferSelectors {
+		if bytes.Equal(data[:4], selector) {
+			to := common.BytesToAddress(data[4 : 4+common.HashLength])
+			value := new(big.Int).SetBytes(data[4+common.HashLength : 4+2*common.HashLength])
+			return to, value, true
+		}
+	}
+
+	return common.Address{}, nil, false
+}
@@ -0,0 +1,33 @@
+package token
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeTransfer(t *testing.T) {
+	recipient := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+	value, _ := new(big.Int).SetString("12345678901234567890123", 10)
+	args := append(common.LeftPadBytes(recipient.Bytes(), common.HashLength), common.LeftPadBytes(value.Bytes(), common.HashLength)...)
+
+	for _, method := range []string{"transfer(address,uint256)", "transfer(address,uint256,bytes)", "transfer(address,uint256,bytes,string)"} {
+		data := append(crypto.Keccak256([]byte(method))[:4], args...)
+
+		to, amount, ok := DecodeTransfer(data)
+		assert.True(t, ok, method)
+		assert.Equal(t, recipient, to, method)
+		assert.Equal(t, value, amount, method)
+	}
+
+	approve := append(crypto.Keccak256([]byte("approve(address,uint256)"))[:4], args...)
+	_, _, ok := DecodeTransfer(approve)
+	assert.False(t, ok)
+
+	short := append(crypto.Keccak256([]byte("transfer(address,uint256)"))[:4], args[:common.HashLength]...)
+	_, _, ok = DecodeTransfer(short)
+	assert.False(t, ok)
+}
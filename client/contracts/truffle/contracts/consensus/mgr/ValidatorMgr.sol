@@ -39,6 +39,14 @@ contract ValidatorMgr is Pausable {
         //bytes data;
         //bytes4 sig;
     }
+
+    // KeyRotation binds a new signing key to the deposit of a validator from
+    // a given block number on.
+    struct KeyRotation {
+        address previousKey;
+        address signingKey;
+        uint activeFrom;
+    }
     
     mapping (address => Validator) private validatorRegistry;
     
@@ -48,6 +56,8 @@ contract ValidatorMgr is Pausable {
 
     TKN tkn;
 
+    mapping (address => KeyRotation) private keyRotations;
+
     modifier onlyWithMinDeposit {
         require(tkn.value >= getMinimumDeposit());
         _;
@@ -256,6 +266,41 @@ contract ValidatorMgr is Pausable {
         _deleteValidator(msg.sender);
     }
 
+    /**
+     * @dev Binds a new signing key to the deposit of the validator from the given
+            block number on. A rotation that isn't active yet is replaced.
+     * @param signingKey address of the new signing key
+     * @param activeFrom block number from which the validator signs with the new key
+     */
+    function rotateKey(address signingKey, uint activeFrom) public whenNotPaused onlyValidator {
+        require(signingKey != address(0));
+        require(signingKey == msg.sender || !isValidator(signingKey));
+        require(activeFrom > block.number);
+
+        KeyRotation storage rotation = keyRotations[msg.sender];
+        if (rotation.activeFrom != 0 && block.number >= rotation.activeFrom) {
+            rotation.previousKey = rotation.signingKey;
+        }
+        rotation.signingKey = signingKey;
+        rotation.activeFrom = activeFrom;
+    }
+
+    /**
+     * @dev Get the key that a validator signs with at the given block number
+     * @param code Address of a Validator.
+     * @param blockNumber block number
+     */
+    function getSigningKey(address code, uint blockNumber) public view returns (address key) {
+        KeyRotation storage rotation = keyRotations[code];
+        if (rotation.activeFrom != 0 && blockNumber >= rotation.activeFrom) {
+            return rotation.signingKey;
+        }
+        if (rotation.previousKey != address(0)) {
+            return rotation.previousKey;
+        }
+        return code;
+    }
+
     /**
      * @dev remove deposit
      * @param code address of a Validator
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+func TestFreezeAncients(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ancient-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := kcoindb.NewLDBDatabaseWithAncients(filepath.Join(dir, "chaindata"), filepath.Join(dir, "ancient"), 0, 0)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	genesis := (&Genesis{Config: params.TestChainConfig}).MustCommit(db)
+	blocks := makeCommittedChain(db, genesis, 10, 0)
+	chain, err := NewBlockChain(db, nil, params.TestChainConfig, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	// Blocks #0 to #7 are at least 3 blocks behind the head
+	frozen, err := rawdb.FreezeAncients(db, 3)
+	if err != nil {
+		t.Fatalf("failed to freeze ancients: %v", err)
+	}
+	if frozen != 8 {
+		t.Fatalf("frozen block count mismatch: have %d, want %d", frozen, 8)
+	}
+	if frozen, err := rawdb.FreezeAncients(db, 3); err != nil || frozen != 0 {
+		t.Fatalf("second freeze mismatch: have %d (%v), want 0", frozen, err)
+	}
+	// All the blocks and receipts must still be served through the database
+	chain, err = NewBlockChain(db, nil, params.TestChainConfig, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to reopen blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if chain.CurrentBlock().Hash() != blocks[len(blocks)-1].Hash() {
+		t.Fatalf("head block mismatch after freezing")
+	}
+	for _, block := range append([]*types.Block{genesis}, blocks...) {
+		have := chain.GetBlockByNumber(block.NumberU64())
+		if have == nil {
+			t.Fatalf("block #%d missing after freezing", block.NumberU64())
+		}
+		if have.Hash() != chain.GetHeaderByNumber(block.NumberU64()).Hash() || len(have.Transactions()) != len(block.Transactions()) {
+			t.Errorf("block #%d mismatch after freezing", block.NumberU64())
+		}
+		if !chain.HasBlock(have.Hash(), have.NumberU64()) {
+			t.Errorf("block #%d reported as missing", have.NumberU64())
+		}
+	}
+	if items, _ := db.Ancients(); items != 8 {
+		t.Errorf("ancient item count mismatch: have %d, want %d", items, 8)
+	}
+	// Non canonical hashes must not be served from the ancient store
+	if rawdb.HasBody(db, blocks[len(blocks)-1].Hash(), 2) {
+		t.Errorf("ancient body served for a mismatching hash")
+	}
+}
+
+func TestFreezeAncientsWhileRunning(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ancient-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	db, err := kcoindb.NewLDBDatabaseWithAncients(filepath.Join(dir, "chaindata"), filepath.Join(dir, "ancient"), 0, 0)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	genesis := (&Genesis{Config: params.TestChainConfig}).MustCommit(db)
+	blocks := makeCommittedChain(db, genesis, 10, 0)
+	chain, err := NewBlockChain(db, nil, params.TestChainConfig, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	chain.Stop()
+
+	// A chain freezing the blocks 3 behind the head moves them on start
+	cacheConfig := &CacheConfig{TrieNodeLimit: 256 * 1024 * 1024, TrieTimeLimit: 5 * time.Minute, AncientThreshold: 3}
+	chain, err = NewBlockChain(db, cacheConfig, params.TestChainConfig, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to reopen blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		if items, _ := db.Ancients(); items == 8 {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			items, _ := db.Ancients()
+			t.Fatalf("ancient item count mismatch: have %d, want %d", items, 8)
+		}
+	}
+	if block := chain.GetBlockByNumber(1); block == nil || block.Hash() != blocks[0].Hash() {
+		t.Errorf("frozen block #1 not served")
+	}
+}
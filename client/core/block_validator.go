@@ -67,6 +67,13 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	if hash := types.DeriveSha(block.Transactions()); hash != header.TxHash {
 		return fmt.Errorf("transaction root hash mismatch: have %x, want %x", hash, header.TxHash)
 	}
+	if !v.config.IsTypedTx(header.Number) {
+		for _, tx := range block.Transactions() {
+			if tx.Typed() {
+				return ErrTypedTxNotActive
+			}
+		}
+	}
 	return nil
 }
 
@@ -4,7 +4,6 @@ package core
 import (
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
 	mrand "math/rand"
 	"sync"
@@ -43,6 +42,7 @@ const (
 	maxTimeFutureBlocks = 30
 	badBlockLimit       = 10
 	triesInMemory       = 128
+	freezeInterval      = time.Minute
 
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	BlockChainVersion = 3
@@ -54,6 +54,8 @@ type CacheConfig struct {
 	Disabled      bool          // Whether to disable trie write caching (archive node)
 	TrieNodeLimit int           // Memory limit (MB) at which to flush the current in-memory trie to disk
 	TrieTimeLimit time.Duration // Time limit after which to flush the current in-memory trie to disk
+
+	AncientThreshold uint64 // Blocks behind the head moved into the ancient store while running (0 = disabled)
 }
 
 // BlockChain represents the canonical chain given a database with a genesis
@@ -95,6 +97,8 @@ type BlockChain struct {
 	currentBlock     atomic.Value // Current head of the block chain
 	currentFastBlock atomic.Value // Current head of the fast-sync chain (may be above the block chain!)
 
+	currentIrreversible atomic.Value // Latest block of the chain with a valid commit
+
 	stateCache   state.Database // State database to reuse between imports (contains state cache)
 	bodyCache    *lru.Cache     // Cache for the most recent block bodies
 	bodyRLPCache *lru.Cache     // Cache for the most recent block bodies in RLP encoded format
@@ -176,6 +180,15 @@ func NewBlockChain(db kcoindb.Database, cacheConfig *CacheConfig, chainConfig *p
 	}
 	// Take ownership of this particular state
 	go bc.update()
+
+	if threshold := cacheConfig.AncientThreshold; threshold > 0 {
+		if _, ok := db.(kcoindb.AncientWriter); ok {
+			bc.wg.Add(1)
+			go bc.freeze(threshold)
+		} else {
+			log.Warn("Database has no ancient store, old blocks are kept", "threshold", threshold)
+		}
+	}
 	return bc, nil
 }
 
@@ -211,6 +224,19 @@ func (bc *BlockChain) loadLastState() error {
 	// Everything seems to be fine, set as the head block
 	bc.currentBlock.Store(currentBlock)
 
+	// Restore the last known irreversible block, its commit was verified when
+	// written. Blocks rewound off the canonical chain since are dropped.
+	irreversible := bc.genesisBlock
+	if hash := rawdb.ReadIrreversibleBlockHash(bc.db); hash != (common.Hash{}) {
+		if number := rawdb.ReadHeaderNumber(bc.db, hash); number != nil && *number <= currentBlock.NumberU64() && rawdb.ReadCanonicalHash(bc.db, *number) == hash {
+			if block := bc.GetBlock(hash, *number); block != nil {
+				irreversible = block
+			}
+		}
+	}
+	bc.currentIrreversible.Store(irreversible)
+	bc.updateIrreversible(currentBlock)
+
 	// Restore the last known head header
 	currentHeader := currentBlock.Header()
 	if head := rawdb.ReadHeadHeaderHash(bc.db); head != (common.Hash{}) {
@@ -328,6 +354,65 @@ func (bc *BlockChain) CurrentFastBlock() *types.Block {
 	return bc.currentFastBlock.Load().(*types.Block)
 }
 
+// CurrentCommittedBlock retrieves the latest block of the canonical chain whose
+// commit is recorded in the chain. As the commit of a block is only included in
+// its child, this is the parent of the current head block (or the genesis).
+func (bc *BlockChain) CurrentCommittedBlock() *types.Block {
+	head := bc.CurrentBlock()
+	if head.NumberU64() == 0 {
+		return head
+	}
+	return bc.GetBlock(head.ParentHash(), head.NumberU64()-1)
+}
+
+// CurrentIrreversibleBlock retrieves the latest block of the canonical chain
+// that the validators are proved to have committed, by a valid commit in its
+// child. The chain never forks below this block.
+func (bc *BlockChain) CurrentIrreversibleBlock() *types.Block {
+	if block, ok := bc.currentIrreversible.Load().(*types.Block); ok {
+		return block
+	}
+	return bc.genesisBlock
+}
+
+// updateIrreversible moves the irreversible block on to the parent of the new
+// head block, if the head block carries a valid commit of it.
+func (bc *BlockChain) updateIrreversible(head *types.Block) {
+	if head.NumberU64() == 0 {
+		bc.currentIrreversible.Store(head)
+		rawdb.WriteIrreversibleBlockHash(bc.db, head.Hash())
+		return
+	}
+	if head.NumberU64()-1 <= bc.CurrentIrreversibleBlock().NumberU64() {
+		return
+	}
+	parent := bc.GetBlock(head.ParentHash(), head.NumberU64()-1)
+	if parent == nil {
+		return
+	}
+	if err := bc.verifyCommit(parent, head.LastCommit()); err != nil {
+		log.Trace("Block not proved committed", "number", parent.Number(), "hash", parent.Hash(), "err", err)
+		return
+	}
+	bc.currentIrreversible.Store(parent)
+	rawdb.WriteIrreversibleBlockHash(bc.db, parent.Hash())
+}
+
+// GetCommit retrieves the commit of a canonical block, the pre-commits that the
+// validators cast for it, as recorded in the following block. Returns nil if
+// the block is not canonical or not committed yet.
+func (bc *BlockChain) GetCommit(hash common.Hash) *types.Commit {
+	number := bc.hc.GetBlockNumber(hash)
+	if number == nil || rawdb.ReadCanonicalHash(bc.db, *number) != hash {
+		return nil
+	}
+	child := bc.GetBlockByNumber(*number + 1)
+	if child == nil || child.ParentHash() != hash {
+		return nil
+	}
+	return child.LastCommit()
+}
+
 // SetProcessor sets the processor required for making state modifications.
 func (bc *BlockChain) SetProcessor(processor Processor) {
 	bc.procmu.Lock()
@@ -411,35 +496,6 @@ func (bc *BlockChain) repair(head **types.Block) error {
 	}
 }
 
-// Export writes the active chain to the given writer.
-func (bc *BlockChain) Export(w io.Writer) error {
-	return bc.ExportN(w, uint64(0), bc.CurrentBlock().NumberU64())
-}
-
-// ExportN writes a subset of the active chain to the given writer.
-func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
-	bc.mu.RLock()
-	defer bc.mu.RUnlock()
-
-	if first > last {
-		return fmt.Errorf("export failed: first (%d) is greater than last (%d)", first, last)
-	}
-	log.Info("Exporting batch of blocks", "count", last-first+1)
-
-	for nr := first; nr <= last; nr++ {
-		block := bc.GetBlockByNumber(nr)
-		if block == nil {
-			return fmt.Errorf("export failed on #%d: not found", nr)
-		}
-
-		if err := block.EncodeRLP(w); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 // insert injects a new head block into the current block chain. This method
 // assumes that the block is indeed a true head. It will also reset the head
 // header and the head fast sync block to this very same block if they are older
@@ -455,6 +511,7 @@ func (bc *BlockChain) insert(block *types.Block) {
 	rawdb.WriteHeadBlockHash(bc.db, block.Hash())
 
 	bc.currentBlock.Store(block)
+	bc.updateIrreversible(block)
 
 	// If the block is better than our head or is on a different chain, force update heads
 	if updateHeads {
@@ -847,6 +904,11 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 		log.Trace("Block existed", "hash", block.Hash())
 		return NonStatTy, nil
 	}
+	// Committed blocks are final, never fork the chain below them
+	if err := bc.checkCommitted(block); err != nil {
+		bc.reportBlock(block, receipts, err)
+		return NonStatTy, err
+	}
 
 	currentBlock := bc.CurrentBlock()
 
@@ -931,6 +993,35 @@ func (bc *BlockChain) WriteBlockWithState(block *types.Block, receipts []*types.
 	return status, nil
 }
 
+// checkCommitted ensures that the block doesn't fork the chain below the latest
+// block with a valid commit, as reverting a block committed by the validators
+// would rewrite the history that the network already agreed on.
+func (bc *BlockChain) checkCommitted(block *types.Block) error {
+	committed := bc.CurrentIrreversibleBlock()
+	number := committed.NumberU64()
+	if number == 0 {
+		return nil
+	}
+	if block.NumberU64() <= number {
+		return ErrCommittedBlockReverted
+	}
+	// Walk the ancestry of the block back to the height of the committed block
+	hash, height := block.ParentHash(), block.NumberU64()-1
+	for height > number {
+		header := bc.GetHeader(hash, height)
+		if header == nil {
+			return consensus.ErrUnknownAncestor
+		}
+		hash, height = header.ParentHash, height-1
+	}
+	if hash != committed.Hash() {
+		log.Error("Fork below committed block detected", "number", block.Number(), "hash", block.Hash(),
+			"committed", number, "committedhash", committed.Hash(), "forkhash", hash)
+		return ErrCommittedBlockReverted
+	}
+	return nil
+}
+
 func (bc *BlockChain) doReorg(block *types.Block, currentBlock *types.Block, batch kcoindb.Batch, state *state.StateDB) (WriteStatus, error) {
 	// Reorganise the chain if the parent is not the head block
 	if IsHead(block, currentBlock) {
@@ -1037,6 +1128,13 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 			bc.reportBlock(block, nil, ErrBlacklistedHash)
 			return i, events, coalescedLogs, ErrBlacklistedHash
 		}
+		// If the block forks the chain below a committed block, abort before processing it
+		if !bc.HasBlock(block.Hash(), block.NumberU64()) {
+			if err := bc.checkCommitted(block); err == ErrCommittedBlockReverted {
+				bc.reportBlock(block, nil, err)
+				return i, events, coalescedLogs, err
+			}
+		}
 		// Wait for the block's verification to complete
 		bstart := time.Now()
 
@@ -1336,12 +1434,37 @@ func (bc *BlockChain) update() {
 	}
 }
 
+// freeze periodically moves the blocks at least threshold blocks behind the head
+// out of the key-value store and into the ancient store of the database.
+func (bc *BlockChain) freeze(threshold uint64) {
+	defer bc.wg.Done()
+
+	ticker := time.NewTicker(freezeInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := rawdb.FreezeAncients(bc.db, threshold); err != nil {
+			log.Error("Failed to move blocks into the ancient store", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-bc.quit:
+			return
+		}
+	}
+}
+
+// BadBlock is a block that the client refused to import, along with the reason.
+type BadBlock struct {
+	Block *types.Block
+	Err   error
+}
+
 // BadBlocks returns a list of the last 'bad blocks' that the client has seen on the network
-func (bc *BlockChain) BadBlocks() []*types.Block {
-	blocks := make([]*types.Block, 0, bc.badBlocks.Len())
+func (bc *BlockChain) BadBlocks() []*BadBlock {
+	blocks := make([]*BadBlock, 0, bc.badBlocks.Len())
 	for _, hash := range bc.badBlocks.Keys() {
 		if blk, exist := bc.badBlocks.Peek(hash); exist {
-			block := blk.(*types.Block)
+			block := blk.(*BadBlock)
 			blocks = append(blocks, block)
 		}
 	}
@@ -1349,13 +1472,13 @@ func (bc *BlockChain) BadBlocks() []*types.Block {
 }
 
 // addBadBlock adds a bad block to the bad-block LRU cache
-func (bc *BlockChain) addBadBlock(block *types.Block) {
-	bc.badBlocks.Add(block.Hash(), block)
+func (bc *BlockChain) addBadBlock(block *types.Block, err error) {
+	bc.badBlocks.Add(block.Hash(), &BadBlock{Block: block, Err: err})
 }
 
 // reportBlock logs a bad block error.
 func (bc *BlockChain) reportBlock(block *types.Block, receipts types.Receipts, err error) {
-	bc.addBadBlock(block)
+	bc.addBadBlock(block, err)
 
 	var receiptString string
 	for _, receipt := range receipts {
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+// makeCommittedChain creates a chain of n blocks on top of parent, every one of
+// them carrying a commit of its parent. The seed makes forks differ.
+func makeCommittedChain(db kcoindb.Database, parent *types.Block, n int, seed byte) []*types.Block {
+	blocks, _ := GenerateChain(params.TestChainConfig, parent, konsensus.NewFaker(), db, n, func(i int, b *BlockGen) {
+		parent := b.PrevBlock(i - 1)
+		vote := types.NewVote(parent.Number(), parent.Hash(), 1, types.PreCommit)
+		b.SetLastCommit(&types.Commit{PreCommits: types.Votes{vote}, FirstPreCommit: vote})
+		b.SetExtra([]byte{seed})
+	})
+	return blocks
+}
+
+// newCommitTestChain creates a blockchain of n blocks, every one of them carrying
+// a commit of its parent.
+func newCommitTestChain(t *testing.T, n int) (*BlockChain, []*types.Block) {
+	db := kcoindb.NewMemDatabase()
+	genesis := (&Genesis{Config: params.TestChainConfig}).MustCommit(db)
+
+	blocks := makeCommittedChain(db, genesis, n, 0)
+	chain, err := NewBlockChain(db, nil, params.TestChainConfig, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	return chain, blocks
+}
+
+func TestCurrentCommittedBlock(t *testing.T) {
+	chain, blocks := newCommitTestChain(t, 3)
+	defer chain.Stop()
+
+	if committed := chain.CurrentCommittedBlock(); committed.Hash() != blocks[1].Hash() {
+		t.Fatalf("committed block mismatch: have #%d, want #%d", committed.NumberU64(), blocks[1].NumberU64())
+	}
+	commit := chain.GetCommit(blocks[1].Hash())
+	if commit == nil {
+		t.Fatalf("missing commit of block #%d", blocks[1].NumberU64())
+	}
+	if commit.First().BlockHash() != blocks[1].Hash() || commit.Round() != 1 {
+		t.Errorf("commit mismatch: have hash %x round %d", commit.First().BlockHash(), commit.Round())
+	}
+	if commit := chain.GetCommit(blocks[2].Hash()); commit != nil {
+		t.Errorf("head block reported as committed")
+	}
+	if commit := chain.GetCommit(types.EmptyRootHash); commit != nil {
+		t.Errorf("unknown block reported as committed")
+	}
+}
+
+func TestCurrentCommittedBlockGenesis(t *testing.T) {
+	chain, _ := newCommitTestChain(t, 0)
+	defer chain.Stop()
+
+	if committed := chain.CurrentCommittedBlock(); committed.NumberU64() != 0 {
+		t.Errorf("committed block mismatch: have #%d, want genesis", committed.NumberU64())
+	}
+}
+
+func TestCurrentIrreversibleBlock(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+
+	chain, blocks := env.newChain(t, 4, 3)
+	defer chain.Stop()
+	if irreversible := chain.CurrentIrreversibleBlock(); irreversible.Hash() != blocks[2].Hash() {
+		t.Errorf("irreversible block mismatch: have #%d, want #%d", irreversible.NumberU64(), blocks[2].NumberU64())
+	}
+
+	// Two of three pre-commits are no quorum
+	chain, _ = env.newChain(t, 4, 2)
+	defer chain.Stop()
+	if irreversible := chain.CurrentIrreversibleBlock(); irreversible.NumberU64() != 0 {
+		t.Errorf("irreversible block mismatch: have #%d, want genesis", irreversible.NumberU64())
+	}
+}
+
+func TestIrreversibleBlockPersisted(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+
+	chain, blocks := env.newChain(t, 4, 3)
+	if hash := rawdb.ReadIrreversibleBlockHash(chain.db); hash != blocks[2].Hash() {
+		t.Fatalf("stored irreversible block mismatch: have %x, want %x", hash, blocks[2].Hash())
+	}
+	chain.Stop()
+
+	// The irreversible block is restored on restart
+	chain, err := NewBlockChain(chain.db, nil, env.config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to reopen blockchain: %v", err)
+	}
+	defer chain.Stop()
+	if irreversible := chain.CurrentIrreversibleBlock(); irreversible.Hash() != blocks[2].Hash() {
+		t.Fatalf("restored irreversible block mismatch: have #%d, want #%d", irreversible.NumberU64(), blocks[2].NumberU64())
+	}
+
+	// Rewinding below it drops it
+	if err := chain.SetHead(1); err != nil {
+		t.Fatalf("failed to rewind blockchain: %v", err)
+	}
+	if irreversible := chain.CurrentIrreversibleBlock(); irreversible.NumberU64() != 0 {
+		t.Errorf("irreversible block mismatch after rewind: have #%d, want genesis", irreversible.NumberU64())
+	}
+}
+
+func TestRejectForkBelowCommittedBlock(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+	chain, blocks := env.newChain(t, 4, 3)
+	defer chain.Stop()
+
+	// A longer fork from the genesis would revert the committed blocks
+	fork := env.makeChain(t, chain.db, chain.Genesis(), 6, 3, 1)
+	if _, err := chain.InsertChain(fork); err != ErrCommittedBlockReverted {
+		t.Fatalf("fork below committed block error mismatch: have %v, want %v", err, ErrCommittedBlockReverted)
+	}
+	if head := chain.CurrentBlock(); head.Hash() != blocks[3].Hash() {
+		t.Fatalf("head reverted to #%d [%x…]", head.NumberU64(), head.Hash().Bytes()[:4])
+	}
+	bad := chain.BadBlocks()
+	if len(bad) != 1 || bad[0].Block.Hash() != fork[0].Hash() || bad[0].Err != ErrCommittedBlockReverted {
+		t.Fatalf("fork not reported as bad block: %v", bad)
+	}
+
+	// A longer fork from the committed block only replaces the uncommitted head
+	fork = env.makeChain(t, chain.db, blocks[2], 2, 3, 2)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork above committed block: %v", err)
+	}
+	if head := chain.CurrentBlock(); head.Hash() != fork[1].Hash() {
+		t.Errorf("head mismatch: have #%d [%x…], want #%d [%x…]", head.NumberU64(), head.Hash().Bytes()[:4], fork[1].NumberU64(), fork[1].Hash().Bytes()[:4])
+	}
+}
+
+func TestAllowForkBelowUnverifiedCommit(t *testing.T) {
+	// The commits of the chain are not signed, so they prove nothing
+	chain, _ := newCommitTestChain(t, 4)
+	defer chain.Stop()
+
+	fork := makeCommittedChain(chain.db, chain.Genesis(), 6, 1)
+	if _, err := chain.InsertChain(fork); err != nil {
+		t.Fatalf("failed to insert fork: %v", err)
+	}
+	if head := chain.CurrentBlock(); head.Hash() != fork[5].Hash() {
+		t.Errorf("head mismatch: have #%d [%x…], want #%d [%x…]", head.NumberU64(), head.Hash().Bytes()[:4], fork[5].NumberU64(), fork[5].Hash().Bytes()[:4])
+	}
+}
@@ -0,0 +1,387 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/log"
+	"github.com/kowala-tech/kcoin/client/rlp"
+)
+
+const (
+	// ExportVersion is the version of the chain export format written by Export.
+	ExportVersion = 1
+
+	// exportMagic marks the start of a versioned chain export, telling it apart
+	// from the legacy stream of bare blocks.
+	exportMagic = "kcoin-chain-export"
+
+	// exportSnapshotInterval is the number of blocks between two validator set
+	// snapshots in a chain export.
+	exportSnapshotInterval = 1024
+
+	// importBatchSize is the number of blocks inserted at once when importing
+	// without verification.
+	importBatchSize = 2500
+)
+
+var (
+	// ErrUnsupportedExportVersion is returned if a chain export was written in a
+	// format version unknown to this node.
+	ErrUnsupportedExportVersion = errors.New("unsupported chain export version")
+
+	// ErrExportMismatch is returned if a chain export belongs to another network.
+	ErrExportMismatch = errors.New("chain export belongs to another network")
+
+	// ErrUnverifiableExport is returned if verification is requested for a
+	// legacy chain export that carries no commits.
+	ErrUnverifiableExport = errors.New("legacy chain export carries no commits to verify")
+
+	// ErrMissingCommit is returned if a block to be verified has no commit.
+	ErrMissingCommit = errors.New("missing block commit")
+
+	// ErrInvalidCommit is returned if a block commit does not prove that the
+	// validator set committed the block.
+	ErrInvalidCommit = errors.New("invalid block commit")
+
+	// ErrValidatorSnapshotMismatch is returned if a validator set snapshot of a
+	// chain export differs from the validator set known to the node.
+	ErrValidatorSnapshotMismatch = errors.New("validator set snapshot mismatch")
+)
+
+// gzipMagic is the header of a gzip stream, used to detect compressed exports.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// exportHeader is the first item of a versioned chain export.
+type exportHeader struct {
+	Magic   string
+	Version uint64
+	ChainID *big.Int
+	Genesis common.Hash
+	First   uint64
+	Last    uint64
+}
+
+// exportEntry is a single exported block along with the proof that it was
+// committed.
+type exportEntry struct {
+	Block *types.Block
+
+	// Commit holds the pre-commits of the validators on the block, if known.
+	Commit *types.Commit `rlp:"nil"`
+
+	// Validators is the validator set that voted on the block. It is only
+	// present periodically and whenever the set changes.
+	Validators []common.Address
+}
+
+// ImportOptions holds the settings of a chain import.
+type ImportOptions struct {
+	Verify bool   // Verify the commit of every block before inserting it
+	First  uint64 // First block number to import
+	Last   uint64 // Last block number to import, zero for no limit
+}
+
+// Export writes the committed part of the active chain to the given writer.
+func (bc *BlockChain) Export(w io.Writer) error {
+	return bc.ExportN(w, uint64(0), bc.CurrentCommittedBlock().NumberU64())
+}
+
+// ExportN writes a subset of the active chain to the given writer, along with
+// the block commits and snapshots of the validator set.
+func (bc *BlockChain) ExportN(w io.Writer, first uint64, last uint64) error {
+	bc.mu.RLock()
+	defer bc.mu.RUnlock()
+
+	if first > last {
+		return fmt.Errorf("export failed: first (%d) is greater than last (%d)", first, last)
+	}
+	log.Info("Exporting batch of blocks", "count", last-first+1)
+
+	header := &exportHeader{
+		Magic:   exportMagic,
+		Version: ExportVersion,
+		ChainID: bc.chainConfig.ChainID,
+		Genesis: bc.genesisBlock.Hash(),
+		First:   first,
+		Last:    last,
+	}
+	if err := rlp.Encode(w, header); err != nil {
+		return err
+	}
+	var snapshot []common.Address
+	for nr := first; nr <= last; nr++ {
+		block := bc.GetBlockByNumber(nr)
+		if block == nil {
+			return fmt.Errorf("export failed on #%d: not found", nr)
+		}
+		entry := &exportEntry{Block: block, Commit: bc.GetCommit(block.Hash())}
+
+		// Snapshot the validator set if it changed or is due. The state of old
+		// blocks may be gone, in which case the snapshot is left out.
+		if nr > 0 {
+			if validators, err := bc.validatorsAt(block.ParentHash(), nr-1); err == nil {
+				if nr == first || nr%exportSnapshotInterval == 0 || !sameValidators(validators, snapshot) {
+					entry.Validators, snapshot = validators, validators
+				}
+			}
+		}
+		if err := rlp.Encode(w, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Import inserts the blocks of a chain export read from the given reader into
+// the chain, and returns the number of blocks inserted. Both versioned and
+// legacy exports are accepted, optionally gzip compressed. The import stops at
+// the next batch once the stop channel is closed.
+func (bc *BlockChain) Import(r io.Reader, opts ImportOptions, stop <-chan struct{}) (int, error) {
+	stream, header, err := newExportStream(r)
+	if err != nil {
+		return 0, err
+	}
+	if header == nil {
+		if opts.Verify {
+			return 0, ErrUnverifiableExport
+		}
+	} else if err := checkExportHeader(bc, header); err != nil {
+		return 0, err
+	}
+	var (
+		batch    = make([]*types.Block, 0, importBatchSize)
+		inserted int
+	)
+	flush := func() error {
+		missing := missingBlocks(bc, batch)
+		batch = batch[:0]
+		if len(missing) == 0 {
+			return nil
+		}
+		if _, err := bc.InsertChain(missing); err != nil {
+			return err
+		}
+		inserted += len(missing)
+		return nil
+	}
+	for {
+		select {
+		case <-stop:
+			return inserted, errors.New("interrupted")
+		default:
+		}
+		entry := new(exportEntry)
+		if header == nil {
+			entry.Block = new(types.Block)
+			err = stream.Decode(entry.Block)
+		} else {
+			var raw []byte
+			if raw, err = stream.Raw(); err == nil {
+				// Appended exports carry a header for every segment
+				if next, ok := decodeExportHeader(raw); ok {
+					if err := checkExportHeader(bc, next); err != nil {
+						return inserted, err
+					}
+					continue
+				}
+				err = rlp.DecodeBytes(raw, entry)
+			}
+		}
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return inserted, err
+		}
+		number := entry.Block.NumberU64()
+		// The genesis block is never imported, it's matched by the header instead
+		if number == 0 || number < opts.First {
+			continue
+		}
+		if opts.Last != 0 && number > opts.Last {
+			break
+		}
+		batch = append(batch, entry.Block)
+		if opts.Verify && !bc.HasBlock(entry.Block.Hash(), number) {
+			// Verification needs the parent state, insert block by block
+			if err := bc.verifyExported(entry); err != nil {
+				return inserted, fmt.Errorf("block #%d: %v", number, err)
+			}
+		}
+		if opts.Verify || len(batch) == cap(batch) {
+			if err := flush(); err != nil {
+				return inserted, fmt.Errorf("block #%d: %v", number, err)
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return inserted, err
+	}
+	return inserted, nil
+}
+
+// verifyExported checks that the exported block was committed by the validator
+// set at its parent, which must already be part of the chain.
+func (bc *BlockChain) verifyExported(entry *exportEntry) error {
+	block := entry.Block
+	if entry.Commit == nil {
+		return ErrMissingCommit
+	}
+	validators, err := bc.validatorsAt(block.ParentHash(), block.NumberU64()-1)
+	if err != nil {
+		return err
+	}
+	if len(entry.Validators) > 0 && !sameValidators(entry.Validators, validators) {
+		return ErrValidatorSnapshotMismatch
+	}
+	return VerifyCommit(types.NewAndromedaSigner(bc.chainConfig.ChainID), block, entry.Commit, validators)
+}
+
+// verifyCommit checks that the commit proves that the validator set at the
+// parent of the block, which must be part of the chain, committed the block.
+func (bc *BlockChain) verifyCommit(block *types.Block, commit *types.Commit) error {
+	if commit == nil {
+		return ErrMissingCommit
+	}
+	validators, err := bc.validatorsAt(block.ParentHash(), block.NumberU64()-1)
+	if err != nil {
+		return err
+	}
+	return VerifyCommit(types.NewAndromedaSigner(bc.chainConfig.ChainID), block, commit, validators)
+}
+
+// validatorsAt returns the validator set as seen by the state of the given block.
+func (bc *BlockChain) validatorsAt(hash common.Hash, number uint64) ([]common.Address, error) {
+	header := bc.GetHeader(hash, number)
+	if header == nil {
+		return nil, fmt.Errorf("unknown block #%d [%x…]", number, hash[:4])
+	}
+	statedb, err := bc.StateAt(header.Root)
+	if err != nil {
+		return nil, err
+	}
+	return ValidatorsAt(bc.chainConfig, header, statedb)
+}
+
+// VerifyCommit checks that the commit holds valid pre-commits on the block from
+// more than two thirds of the given validators.
+func VerifyCommit(signer types.Signer, block *types.Block, commit *types.Commit, validators []common.Address) error {
+	if commit.First() == nil || commit.First().BlockHash() != block.Hash() {
+		return ErrInvalidCommit
+	}
+	members := make(map[common.Address]bool, len(validators))
+	for _, validator := range validators {
+		members[validator] = true
+	}
+	voted := make(map[common.Address]bool)
+	for _, vote := range commit.Commits() {
+		if vote == nil {
+			continue
+		}
+		if vote.Type() != types.PreCommit || vote.BlockHash() != block.Hash() || vote.BlockNumber() == nil || vote.BlockNumber().Cmp(block.Number()) != 0 {
+			return ErrInvalidCommit
+		}
+		voter, err := types.VoteSender(signer, vote)
+		if err != nil {
+			return fmt.Errorf("%v: %v", ErrInvalidCommit, err)
+		}
+		if !members[voter] {
+			return fmt.Errorf("%v: %x is not a validator", ErrInvalidCommit, voter)
+		}
+		voted[voter] = true
+	}
+	if !TwoThirdsPlusOneVoteQuorum(len(voted), len(validators)) {
+		return fmt.Errorf("%v: %d of %d validators voted", ErrInvalidCommit, len(voted), len(validators))
+	}
+	return nil
+}
+
+// newExportStream unwraps a possibly compressed chain export and reads its
+// header. The returned header is nil for legacy exports.
+func newExportStream(r io.Reader) (*rlp.Stream, *exportHeader, error) {
+	buffered := bufio.NewReader(r)
+	if magic, err := buffered.Peek(len(gzipMagic)); err == nil && bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			return nil, nil, err
+		}
+		buffered = bufio.NewReader(gz)
+	}
+	stream := rlp.NewStream(buffered, 0)
+
+	// Legacy exports start right away with a block, whose first field is a list
+	first, err := stream.Raw()
+	if err == io.EOF {
+		return stream, nil, nil
+	} else if err != nil {
+		return nil, nil, err
+	}
+	header, ok := decodeExportHeader(first)
+	if !ok {
+		stream = rlp.NewStream(io.MultiReader(bytes.NewReader(first), buffered), 0)
+		return stream, nil, nil
+	}
+	return stream, header, nil
+}
+
+// decodeExportHeader decodes the given item as the header of a versioned chain
+// export, reporting whether it is one.
+func decodeExportHeader(item []byte) (*exportHeader, bool) {
+	header := new(exportHeader)
+	if err := rlp.DecodeBytes(item, header); err != nil || header.Magic != exportMagic {
+		return nil, false
+	}
+	return header, true
+}
+
+// checkExportHeader ensures that the chain export can be imported into the chain.
+func checkExportHeader(bc *BlockChain, header *exportHeader) error {
+	if header.Version != ExportVersion {
+		return fmt.Errorf("%v: %d", ErrUnsupportedExportVersion, header.Version)
+	}
+	if header.Genesis != bc.genesisBlock.Hash() || header.ChainID.Cmp(bc.chainConfig.ChainID) != 0 {
+		return ErrExportMismatch
+	}
+	return nil
+}
+
+// missingBlocks returns the blocks of the batch, starting with the first one
+// that is not yet part of the chain.
+func missingBlocks(chain *BlockChain, blocks []*types.Block) []*types.Block {
+	head := chain.CurrentBlock()
+	for i, block := range blocks {
+		// If we're behind the chain head, only check block, state is available at head
+		if head.NumberU64() > block.NumberU64() {
+			if !chain.HasBlock(block.Hash(), block.NumberU64()) {
+				return blocks[i:]
+			}
+			continue
+		}
+		// If we're above the chain head, state availability is a must
+		if !chain.HasBlockAndState(block.Hash(), block.NumberU64()) {
+			return blocks[i:]
+		}
+	}
+	return nil
+}
+
+// sameValidators reports whether both validator sets hold the same validators
+// in the same order.
+func sameValidators(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+	"github.com/kowala-tech/kcoin/client/rlp"
+)
+
+// exportTestValidatorMgr is a minimal validator manager: called with a bare
+// selector it returns the validator count held in slot 0, otherwise it returns
+// the validator held in slot index+1.
+var exportTestValidatorMgr = common.FromHex("600436146016576004356001015460005260206000f35b6000546000526020" + "6000f3")
+
+// exportTestEnv is a network whose validators are known to the state.
+type exportTestEnv struct {
+	config  *params.ChainConfig
+	genesis *Genesis
+	keys    []*ecdsa.PrivateKey
+}
+
+func newExportTestEnv(t *testing.T, validators int) *exportTestEnv {
+	mgr := common.HexToAddress("0x161ad311f1d66381c17641b1b73042a4ca731f9f")
+	config := *params.TestChainConfig
+	config.ValidatorMgr = &mgr
+
+	env := &exportTestEnv{config: &config}
+	storage := map[common.Hash]common.Hash{
+		common.Hash{}: common.BigToHash(big.NewInt(int64(validators))),
+	}
+	for i := 0; i < validators; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			t.Fatalf("failed to generate key: %v", err)
+		}
+		env.keys = append(env.keys, key)
+		storage[common.BigToHash(big.NewInt(int64(i+1)))] = crypto.PubkeyToAddress(key.PublicKey).Hash()
+	}
+	env.genesis = &Genesis{
+		Config: env.config,
+		Alloc:  GenesisAlloc{mgr: {Code: exportTestValidatorMgr, Storage: storage, Balance: new(big.Int)}},
+	}
+	return env
+}
+
+// newChain creates a blockchain of n blocks, every one of them carrying the
+// pre-commits of the given number of validators on its parent.
+func (env *exportTestEnv) newChain(t *testing.T, n int, signers int) (*BlockChain, []*types.Block) {
+	db := kcoindb.NewMemDatabase()
+	genesis := env.genesis.MustCommit(db)
+
+	blocks := env.makeChain(t, db, genesis, n, signers, 0)
+	return env.newBlockChain(t, blocks...), blocks
+}
+
+// makeChain creates n blocks on top of parent, every one of them carrying the
+// pre-commits of the given number of validators on its parent. The seed makes
+// forks differ.
+func (env *exportTestEnv) makeChain(t *testing.T, db kcoindb.Database, parent *types.Block, n int, signers int, seed byte) []*types.Block {
+	signer := types.NewAndromedaSigner(env.config.ChainID)
+	blocks, _ := GenerateChain(env.config, parent, konsensus.NewFaker(), db, n, func(i int, b *BlockGen) {
+		parent := b.PrevBlock(i - 1)
+		commit := &types.Commit{PreCommits: types.Votes{}}
+		for _, key := range env.keys[:signers] {
+			vote, err := types.SignVote(types.NewVote(parent.Number(), parent.Hash(), 1, types.PreCommit), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign vote: %v", err)
+			}
+			commit.PreCommits = append(commit.PreCommits, vote)
+		}
+		commit.FirstPreCommit = commit.PreCommits[0]
+		b.SetLastCommit(commit)
+		b.SetExtra([]byte{seed})
+	})
+	return blocks
+}
+
+// newBlockChain creates a fresh blockchain holding the given blocks.
+func (env *exportTestEnv) newBlockChain(t *testing.T, blocks ...*types.Block) *BlockChain {
+	db := kcoindb.NewMemDatabase()
+	env.genesis.MustCommit(db)
+
+	chain, err := NewBlockChain(db, nil, env.config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	return chain
+}
+
+func TestExportImportVerified(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+	chain, blocks := env.newChain(t, 5, 3)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.Export(&buf); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	// The head block is not committed yet, so it must not be exported
+	inserted, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true}, nil)
+	if err != nil {
+		t.Fatalf("failed to import chain: %v", err)
+	}
+	if inserted != 4 {
+		t.Fatalf("inserted block count mismatch: have %d, want %d", inserted, 4)
+	}
+	if head := imported.CurrentBlock(); head.Hash() != blocks[3].Hash() {
+		t.Fatalf("head block mismatch: have #%d, want #%d", head.NumberU64(), blocks[3].NumberU64())
+	}
+	// Importing the same export again is a no-op
+	if inserted, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true}, nil); err != nil || inserted != 0 {
+		t.Fatalf("reimport mismatch: have %d (%v), want 0", inserted, err)
+	}
+}
+
+func TestExportSnapshotsValidators(t *testing.T) {
+	env := newExportTestEnv(t, 2)
+	chain, _ := env.newChain(t, 4, 2)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.ExportN(&buf, 1, 3); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	stream := rlp.NewStream(&buf, 0)
+
+	header := new(exportHeader)
+	if err := stream.Decode(header); err != nil {
+		t.Fatalf("failed to decode header: %v", err)
+	}
+	if header.Version != ExportVersion || header.Genesis != chain.Genesis().Hash() || header.First != 1 || header.Last != 3 {
+		t.Fatalf("header mismatch: %+v", header)
+	}
+	for nr := uint64(1); nr <= 3; nr++ {
+		entry := new(exportEntry)
+		if err := stream.Decode(entry); err != nil {
+			t.Fatalf("failed to decode entry #%d: %v", nr, err)
+		}
+		if entry.Block.NumberU64() != nr || entry.Commit == nil {
+			t.Fatalf("entry #%d mismatch: have block #%d, commit %v", nr, entry.Block.NumberU64(), entry.Commit)
+		}
+		// The set never changes, so only the first block carries a snapshot
+		if want := nr == 1; (len(entry.Validators) > 0) != want {
+			t.Errorf("entry #%d snapshot mismatch: have %d validators", nr, len(entry.Validators))
+		}
+	}
+}
+
+func TestImportRejectsInsufficientCommits(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+	chain, _ := env.newChain(t, 3, 2)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.Export(&buf); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	if _, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true}, nil); err == nil {
+		t.Fatalf("import of insufficiently committed blocks succeeded")
+	}
+	if head := imported.CurrentBlock().NumberU64(); head != 0 {
+		t.Fatalf("unverified blocks inserted: head #%d", head)
+	}
+	// Without verification the blocks are taken at face value
+	if inserted, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{}, nil); err != nil || inserted != 2 {
+		t.Fatalf("unverified import mismatch: have %d (%v), want 2", inserted, err)
+	}
+}
+
+func TestImportRejectsForeignValidators(t *testing.T) {
+	env := newExportTestEnv(t, 3)
+	chain, _ := env.newChain(t, 3, 3)
+	defer chain.Stop()
+
+	// Sign the same blocks with validators the network doesn't know about
+	foreign := newExportTestEnv(t, 3)
+	foreign.config, foreign.genesis = env.config, env.genesis
+	forged, _ := foreign.newChain(t, 3, 3)
+	defer forged.Stop()
+
+	var buf bytes.Buffer
+	if err := forged.Export(&buf); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	if _, err := imported.Import(&buf, ImportOptions{Verify: true}, nil); err == nil {
+		t.Fatalf("import of blocks committed by foreign validators succeeded")
+	}
+}
+
+func TestImportRange(t *testing.T) {
+	env := newExportTestEnv(t, 1)
+	chain, blocks := env.newChain(t, 6, 1)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.Export(&buf); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	inserted, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true, Last: 3}, nil)
+	if err != nil || inserted != 3 {
+		t.Fatalf("import mismatch: have %d (%v), want 3", inserted, err)
+	}
+	if head := imported.CurrentBlock(); head.Hash() != blocks[2].Hash() {
+		t.Fatalf("head block mismatch: have #%d, want #%d", head.NumberU64(), blocks[2].NumberU64())
+	}
+	inserted, err = imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true, First: 4}, nil)
+	if err != nil || inserted != 2 {
+		t.Fatalf("import mismatch: have %d (%v), want 2", inserted, err)
+	}
+}
+
+func TestImportAppendedExport(t *testing.T) {
+	env := newExportTestEnv(t, 1)
+	chain, _ := env.newChain(t, 5, 1)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.ExportN(&buf, 0, 2); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	if err := chain.ExportN(&buf, 3, 4); err != nil {
+		t.Fatalf("failed to append to export: %v", err)
+	}
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	if inserted, err := imported.Import(&buf, ImportOptions{Verify: true}, nil); err != nil || inserted != 4 {
+		t.Fatalf("import mismatch: have %d (%v), want 4", inserted, err)
+	}
+}
+
+func TestImportLegacyExport(t *testing.T) {
+	env := newExportTestEnv(t, 1)
+	chain, blocks := env.newChain(t, 3, 1)
+	defer chain.Stop()
+
+	// Legacy exports are a gzipped stream of bare blocks
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	for _, block := range append([]*types.Block{chain.Genesis()}, blocks...) {
+		if err := block.EncodeRLP(writer); err != nil {
+			t.Fatalf("failed to encode block: %v", err)
+		}
+	}
+	writer.Close()
+
+	imported := env.newBlockChain(t)
+	defer imported.Stop()
+
+	if _, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{Verify: true}, nil); err != ErrUnverifiableExport {
+		t.Fatalf("verified legacy import error mismatch: have %v, want %v", err, ErrUnverifiableExport)
+	}
+	if inserted, err := imported.Import(bytes.NewReader(buf.Bytes()), ImportOptions{}, nil); err != nil || inserted != 3 {
+		t.Fatalf("legacy import mismatch: have %d (%v), want 3", inserted, err)
+	}
+}
+
+func TestImportForeignNetwork(t *testing.T) {
+	env := newExportTestEnv(t, 1)
+	chain, _ := env.newChain(t, 2, 1)
+	defer chain.Stop()
+
+	var buf bytes.Buffer
+	if err := chain.Export(&buf); err != nil {
+		t.Fatalf("failed to export chain: %v", err)
+	}
+	other := newExportTestEnv(t, 1)
+	imported := other.newBlockChain(t)
+	defer imported.Stop()
+
+	if _, err := imported.Import(&buf, ImportOptions{}, nil); err != ErrExportMismatch {
+		t.Fatalf("import error mismatch: have %v, want %v", err, ErrExportMismatch)
+	}
+}
@@ -45,6 +45,12 @@ func (b *BlockGen) SetCoinbase(addr common.Address) {
 	b.gasPool = new(GasPool).AddGas(b.header.GasLimit)
 }
 
+// SetLastCommit sets the commit of the parent block, carried by the
+// generated block.
+func (b *BlockGen) SetLastCommit(commit *types.Commit) {
+	b.lastCommit = commit
+}
+
 // SetExtra sets the extra data field of the generated block.
 func (b *BlockGen) SetExtra(data []byte) {
 	b.header.Extra = data
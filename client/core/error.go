@@ -29,7 +29,15 @@ var (
 	// ErrBlacklistedHash is returned if a block to import is on the blacklist.
 	ErrBlacklistedHash = errors.New("blacklisted hash")
 
+	// ErrCommittedBlockReverted is returned if a block to import forks the chain
+	// below the latest committed block, which is final and cannot be reverted.
+	ErrCommittedBlockReverted = errors.New("fork reverts committed block")
+
 	// ErrNonceTooHigh is returned if the nonce of a transaction is higher than the
 	// next one expected based on the local chain.
 	ErrNonceTooHigh = errors.New("nonce too high")
+
+	// ErrTypedTxNotActive is returned if a stable fee or sponsored transaction is
+	// processed before the typed transactions fork block of the chain.
+	ErrTypedTxNotActive = errors.New("typed transactions not active")
 )
@@ -28,6 +28,29 @@ type NewMajorityEvent struct {
 	winner common.Hash
 }
 
+// DepositRebalancedEvent is posted when a validator rejoins the election with
+// a new deposit, its previous deposit being locked for the freeze period.
+type DepositRebalancedEvent struct {
+	Validator common.Address
+	Previous  *big.Int
+	Deposit   *big.Int
+}
+
+// DepositsRedeemedEvent is posted when a validator requests the transfer of its
+// deposits past the freeze period back to its account.
+type DepositsRedeemedEvent struct {
+	Validator common.Address
+	Amount    *big.Int
+}
+
+// ValidatorOfflineEvent is posted when a validator misses the elections of too
+// many of the latest blocks.
+type ValidatorOfflineEvent struct {
+	Validator common.Address
+	Missed    uint64 // Elections missed within the window
+	Window    uint64 // Number of the latest elections considered
+}
+
 // PendingLogsEvent is posted pre mining and notifies of pending logs.
 type PendingLogsEvent struct {
 	Logs []*types.Log
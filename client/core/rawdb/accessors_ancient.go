@@ -0,0 +1,108 @@
+package rawdb
+
+import (
+	"bytes"
+	"errors"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+// errNoAncients is returned if the database is not backed by an ancient store.
+var errNoAncients = errors.New("database has no ancient store")
+
+// readAncient retrieves an item of the given kind from the ancient store
+// backing the database, if any. Only canonical blocks are frozen, so the item
+// is returned only if the frozen hash matches the requested one.
+func readAncient(db DatabaseReader, kind string, hash common.Hash, number uint64) []byte {
+	if !hasAncient(db, hash, number) {
+		return nil
+	}
+	data, _ := db.(kcoindb.AncientReader).Ancient(kind, number)
+	return data
+}
+
+// hasAncient checks whether the block of the given hash and number was moved
+// into the ancient store backing the database.
+func hasAncient(db DatabaseReader, hash common.Hash, number uint64) bool {
+	ancients, ok := db.(kcoindb.AncientReader)
+	if !ok {
+		return false
+	}
+	frozen, err := ancients.Ancient(kcoindb.AncientHashes, number)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(frozen, hash.Bytes())
+}
+
+// FreezeAncients moves the bodies and receipts of the canonical blocks that are
+// at least threshold blocks behind the head block out of the key-value store
+// and into the ancient store backing the database. It returns the number of
+// blocks that were frozen.
+func FreezeAncients(db kcoindb.Database, threshold uint64) (uint64, error) {
+	ancients, ok := db.(interface {
+		kcoindb.AncientReader
+		kcoindb.AncientWriter
+	})
+	if !ok {
+		return 0, errNoAncients
+	}
+	head := ReadHeadBlockHash(db)
+	if head == (common.Hash{}) {
+		return 0, errors.New("no head block")
+	}
+	number := ReadHeaderNumber(db, head)
+	if number == nil {
+		return 0, errors.New("unknown head block number")
+	}
+	if *number < threshold {
+		return 0, nil
+	}
+	limit := *number - threshold
+
+	frozen, err := ancients.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	if frozen > limit {
+		return 0, nil
+	}
+	start := frozen
+	for ; frozen <= limit; frozen++ {
+		hash := ReadCanonicalHash(db, frozen)
+		if hash == (common.Hash{}) {
+			return frozen - start, errors.New("missing canonical hash")
+		}
+		body, _ := db.Get(blockBodyKey(frozen, hash))
+		if len(body) == 0 {
+			return frozen - start, errors.New("missing block body")
+		}
+		receipts, _ := db.Get(blockReceiptsKey(frozen, hash))
+		if err := ancients.AppendAncient(frozen, hash.Bytes(), body, receipts); err != nil {
+			return frozen - start, err
+		}
+	}
+	if err := ancients.SyncAncients(); err != nil {
+		return frozen - start, err
+	}
+	// The frozen data is safely on disk, drop it from the key-value store
+	batch := db.NewBatch()
+	for number := start; number < frozen; number++ {
+		hash := ReadCanonicalHash(db, number)
+		batch.Delete(blockBodyKey(number, hash))
+		batch.Delete(blockReceiptsKey(number, hash))
+		if batch.ValueSize() >= kcoindb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return frozen - start, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return frozen - start, err
+	}
+	log.Info("Moved blocks into the ancient store", "from", start, "to", frozen-1)
+	return frozen - start, nil
+}
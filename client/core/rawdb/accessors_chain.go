@@ -23,6 +23,7 @@ import (
 
 	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
 	"github.com/kowala-tech/kcoin/client/log"
 	"github.com/kowala-tech/kcoin/client/rlp"
 )
@@ -92,6 +93,23 @@ func WriteHeadBlockHash(db DatabaseWriter, hash common.Hash) {
 	}
 }
 
+// ReadIrreversibleBlockHash retrieves the hash of the latest canonical block
+// proved committed by the validators.
+func ReadIrreversibleBlockHash(db DatabaseReader) common.Hash {
+	data, _ := db.Get(headIrreversibleKey)
+	if len(data) == 0 {
+		return common.Hash{}
+	}
+	return common.BytesToHash(data)
+}
+
+// WriteIrreversibleBlockHash stores the hash of the latest irreversible block.
+func WriteIrreversibleBlockHash(db DatabaseWriter, hash common.Hash) {
+	if err := db.Put(headIrreversibleKey, hash.Bytes()); err != nil {
+		log.Crit("Failed to store last irreversible block's hash", "err", err)
+	}
+}
+
 // ReadHeadFastBlockHash retrieves the hash of the current fast-sync head block.
 func ReadHeadFastBlockHash(db DatabaseReader) common.Hash {
 	data, _ := db.Get(headFastBlockKey)
@@ -191,6 +209,9 @@ func DeleteHeader(db DatabaseDeleter, hash common.Hash, number uint64) {
 // ReadBodyRLP retrieves the block body (transactions and commits) in RLP encoding.
 func ReadBodyRLP(db DatabaseReader, hash common.Hash, number uint64) rlp.RawValue {
 	data, _ := db.Get(blockBodyKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, kcoindb.AncientBodies, hash, number)
+	}
 	return data
 }
 
@@ -204,7 +225,7 @@ func WriteBodyRLP(db DatabaseWriter, hash common.Hash, number uint64, rlp rlp.Ra
 // HasBody verifies the existence of a block body corresponding to the hash.
 func HasBody(db DatabaseReader, hash common.Hash, number uint64) bool {
 	if has, err := db.Has(blockBodyKey(number, hash)); !has || err != nil {
-		return false
+		return hasAncient(db, hash, number)
 	}
 	return true
 }
@@ -275,6 +296,9 @@ func DeleteTd(db DatabaseDeleter, hash common.Hash, number uint64) {
 func ReadReceipts(db DatabaseReader, hash common.Hash, number uint64) types.Receipts {
 	// Retrieve the flattened receipt slice
 	data, _ := db.Get(blockReceiptsKey(number, hash))
+	if len(data) == 0 {
+		data = readAncient(db, kcoindb.AncientReceipts, hash, number)
+	}
 	if len(data) == 0 {
 		return nil
 	}
@@ -236,6 +236,7 @@ func TestHeadStorage(t *testing.T) {
 	blockHead := types.NewBlockWithHeader(&types.Header{Extra: []byte("test block header")})
 	blockFull := types.NewBlockWithHeader(&types.Header{Extra: []byte("test block full")})
 	blockFast := types.NewBlockWithHeader(&types.Header{Extra: []byte("test block fast")})
+	blockIrreversible := types.NewBlockWithHeader(&types.Header{Extra: []byte("test block irreversible")})
 
 	// Check that no head entries are in a pristine database
 	if entry := ReadHeadHeaderHash(db); entry != (common.Hash{}) {
@@ -247,10 +248,14 @@ func TestHeadStorage(t *testing.T) {
 	if entry := ReadHeadFastBlockHash(db); entry != (common.Hash{}) {
 		t.Fatalf("Non fast head block entry returned: %v", entry)
 	}
+	if entry := ReadIrreversibleBlockHash(db); entry != (common.Hash{}) {
+		t.Fatalf("Non irreversible block entry returned: %v", entry)
+	}
 	// Assign separate entries for the head header and block
 	WriteHeadHeaderHash(db, blockHead.Hash())
 	WriteHeadBlockHash(db, blockFull.Hash())
 	WriteHeadFastBlockHash(db, blockFast.Hash())
+	WriteIrreversibleBlockHash(db, blockIrreversible.Hash())
 
 	// Check that both heads are present, and different (i.e. two heads maintained)
 	if entry := ReadHeadHeaderHash(db); entry != blockHead.Hash() {
@@ -262,6 +267,9 @@ func TestHeadStorage(t *testing.T) {
 	if entry := ReadHeadFastBlockHash(db); entry != blockFast.Hash() {
 		t.Fatalf("Fast head block hash mismatch: have %v, want %v", entry, blockFast.Hash())
 	}
+	if entry := ReadIrreversibleBlockHash(db); entry != blockIrreversible.Hash() {
+		t.Fatalf("Irreversible block hash mismatch: have %v, want %v", entry, blockIrreversible.Hash())
+	}
 }
 
 // Tests that receipts associated with a single block can be stored and retrieved.
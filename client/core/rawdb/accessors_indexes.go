@@ -105,3 +105,60 @@ func WriteBloomBits(db DatabaseWriter, bit uint, section uint64, head common.Has
 		log.Crit("Failed to store bloom bits", "err", err)
 	}
 }
+
+// ReadBlockRewards retrieves the rewards record of a block.
+func ReadBlockRewards(db DatabaseReader, hash common.Hash, number uint64) *types.BlockRewards {
+	data, _ := db.Get(blockRewardsKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	rewards := new(types.BlockRewards)
+	if err := rlp.DecodeBytes(data, rewards); err != nil {
+		log.Error("Invalid block rewards RLP", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return rewards
+}
+
+// WriteBlockRewards stores the rewards record of a block.
+func WriteBlockRewards(db DatabaseWriter, hash common.Hash, number uint64, rewards *types.BlockRewards) {
+	data, err := rlp.EncodeToBytes(rewards)
+	if err != nil {
+		log.Crit("Failed to encode block rewards", "err", err)
+	}
+	if err := db.Put(blockRewardsKey(number, hash), data); err != nil {
+		log.Crit("Failed to store block rewards", "err", err)
+	}
+}
+
+// DeleteBlockRewards removes the rewards record of a block.
+func DeleteBlockRewards(db DatabaseDeleter, hash common.Hash, number uint64) {
+	db.Delete(blockRewardsKey(number, hash))
+}
+
+// ReadParticipation retrieves the validator participation in the election of a
+// block.
+func ReadParticipation(db DatabaseReader, hash common.Hash, number uint64) *types.Participation {
+	data, _ := db.Get(participationKey(number, hash))
+	if len(data) == 0 {
+		return nil
+	}
+	participation := new(types.Participation)
+	if err := rlp.DecodeBytes(data, participation); err != nil {
+		log.Error("Invalid participation RLP", "number", number, "hash", hash, "err", err)
+		return nil
+	}
+	return participation
+}
+
+// WriteParticipation stores the validator participation in the election of a
+// block.
+func WriteParticipation(db DatabaseWriter, hash common.Hash, number uint64, participation *types.Participation) {
+	data, err := rlp.EncodeToBytes(participation)
+	if err != nil {
+		log.Crit("Failed to encode participation", "err", err)
+	}
+	if err := db.Put(participationKey(number, hash), data); err != nil {
+		log.Crit("Failed to store participation", "err", err)
+	}
+}
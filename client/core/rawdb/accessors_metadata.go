@@ -88,3 +88,16 @@ func WritePreimages(db DatabaseWriter, number uint64, preimages map[common.Hash]
 	preimageCounter.Inc(int64(len(preimages)))
 	preimageHitCounter.Inc(int64(len(preimages)))
 }
+
+// ReadPeerBans retrieves the RLP encoded list of the banned peers.
+func ReadPeerBans(db DatabaseReader) []byte {
+	data, _ := db.Get(peerBansKey)
+	return data
+}
+
+// WritePeerBans stores the RLP encoded list of the banned peers.
+func WritePeerBans(db DatabaseWriter, data []byte) {
+	if err := db.Put(peerBansKey, data); err != nil {
+		log.Crit("Failed to store the peer bans", "err", err)
+	}
+}
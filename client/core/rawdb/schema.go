@@ -38,9 +38,15 @@ var (
 	// headFastBlockKey tracks the latest known incomplete block's hash duirng fast sync.
 	headFastBlockKey = []byte("LastFast")
 
+	// headIrreversibleKey tracks the latest known block's hash with a valid commit.
+	headIrreversibleKey = []byte("LastIrreversible")
+
 	// fastTrieProgressKey tracks the number of trie entries imported during fast sync.
 	fastTrieProgressKey = []byte("TrieSync")
 
+	// peerBansKey tracks the peers banned for misbehaving.
+	peerBansKey = []byte("PeerBans")
+
 	// Data item prefixes (use single byte to avoid mixing data types, avoid `i`, used for indexes).
 	headerPrefix       = []byte("h") // headerPrefix + num (uint64 big endian) + hash -> header
 	headerTDSuffix     = []byte("t") // headerPrefix + num (uint64 big endian) + hash + headerTDSuffix -> td
@@ -53,11 +59,16 @@ var (
 	txLookupPrefix  = []byte("l") // txLookupPrefix + hash -> transaction/receipt lookup metadata
 	bloomBitsPrefix = []byte("B") // bloomBitsPrefix + bit (uint16 big endian) + section (uint64 big endian) + hash -> bloom bits
 
+	blockRewardsPrefix  = []byte("w") // blockRewardsPrefix + num (uint64 big endian) + hash -> block rewards
+	participationPrefix = []byte("p") // participationPrefix + num (uint64 big endian) + hash -> validator participation
+
 	preimagePrefix = []byte("secure-key-")      // preimagePrefix + hash -> preimage
 	configPrefix   = []byte("ethereum-config-") // config prefix for the db
 
 	// Chain index prefixes (use `i` + single byte to avoid mixing data types).
-	BloomBitsIndexPrefix = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
+	BloomBitsIndexPrefix     = []byte("iB") // BloomBitsIndexPrefix is the data table of a chain indexer to track its progress
+	RewardsIndexPrefix       = []byte("iW") // RewardsIndexPrefix is the data table of the rewards indexer to track its progress
+	ParticipationIndexPrefix = []byte("iP") // ParticipationIndexPrefix is the data table of the participation indexer to track its progress
 
 	preimageCounter    = metrics.NewRegisteredCounter("db/preimage/total", nil)
 	preimageHitCounter = metrics.NewRegisteredCounter("db/preimage/hits", nil)
@@ -123,6 +134,16 @@ func bloomBitsKey(bit uint, section uint64, hash common.Hash) []byte {
 	return key
 }
 
+// blockRewardsKey = blockRewardsPrefix + num (uint64 big endian) + hash
+func blockRewardsKey(number uint64, hash common.Hash) []byte {
+	return append(append(blockRewardsPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
+// participationKey = participationPrefix + num (uint64 big endian) + hash
+func participationKey(number uint64, hash common.Hash) []byte {
+	return append(append(participationPrefix, encodeBlockNumber(number)...), hash.Bytes()...)
+}
+
 // preimageKey = preimagePrefix + hash
 func preimageKey(hash common.Hash) []byte {
 	return append(preimagePrefix, hash.Bytes()...)
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+// lookupCallGas is the gas allowance of the contract lookups, such as the oracle
+// price. Lookups are performed by the node and are not charged to transactions.
+const lookupCallGas = 100000
+
+var (
+	// ErrStableFeeUnsupported is returned if a stable fee transaction is sent to
+	// a network without an oracle manager.
+	ErrStableFeeUnsupported = errors.New("stable fees not supported")
+
+	// ErrStableFeePrice is returned if a stable fee transaction also sets a gas
+	// price, as the gas price is derived from the fee cap.
+	ErrStableFeePrice = errors.New("stable fee transaction with gas price")
+
+	// ErrOraclePrice is returned if the kcoin price can't be read from the oracle
+	// manager.
+	ErrOraclePrice = errors.New("oracle price unavailable")
+
+	// oraclePriceSelector is the ABI selector of the oracle manager price getter.
+	oraclePriceSelector = crypto.Keccak256([]byte("price()"))[:4]
+
+	// stableFeeUnit converts a fee cap in USD cents into kcoin wei, given a
+	// kcoin price in USD scaled by 1 kcoin: 1e18 (price scale) * 1e18 (wei per
+	// kcoin) / 100 (cents per USD).
+	stableFeeUnit = new(big.Int).Exp(big.NewInt(10), big.NewInt(34), nil)
+)
+
+// OraclePrice retrieves the kcoin price, in USD scaled by 1 kcoin, from the
+// oracle manager of the chain, as seen by the given state.
+func OraclePrice(config *params.ChainConfig, header *types.Header, statedb *state.StateDB) (*big.Int, error) {
+	return oraclePrice(vm.NewEVM(lookupContext(header), statedb, config, vm.Config{}))
+}
+
+// lookupContext returns the EVM context of the read only contract calls made
+// by the node on top of the given header.
+func lookupContext(header *types.Header) vm.Context {
+	return vm.Context{
+		CanTransfer: CanTransfer,
+		Transfer:    Transfer,
+		GetHash:     func(uint64) common.Hash { return common.Hash{} },
+		BlockNumber: new(big.Int).Set(header.Number),
+		Time:        new(big.Int).Set(header.Time),
+		GasLimit:    header.GasLimit,
+		GasPrice:    new(big.Int),
+	}
+}
+
+// oraclePrice calls the oracle manager price getter in a pristine environment,
+// so that the lookup doesn't show up in the traces of the transaction.
+func oraclePrice(evm *vm.EVM) (*big.Int, error) {
+	config := evm.ChainConfig()
+	if config.OracleMgr == nil {
+		return nil, ErrStableFeeUnsupported
+	}
+	lookup := vm.NewEVM(evm.Context, evm.StateDB, config, vm.Config{})
+	ret, _, err := lookup.StaticCall(vm.AccountRef(common.Address{}), *config.OracleMgr, oraclePriceSelector, lookupCallGas)
+	if err != nil || len(ret) != 32 {
+		return nil, ErrOraclePrice
+	}
+	price := new(big.Int).SetBytes(ret)
+	if price.Sign() <= 0 {
+		return nil, ErrOraclePrice
+	}
+	return price, nil
+}
+
+// StableFeeGasPrice converts a fee cap in USD cents into the gas price in kcoin
+// wei that spends the whole cap if all of the gas is used.
+func StableFeeGasPrice(feeCap, oraclePrice *big.Int, gasLimit uint64) *big.Int {
+	if gasLimit == 0 {
+		return new(big.Int)
+	}
+	price := new(big.Int).Mul(feeCap, stableFeeUnit)
+	return price.Div(price, new(big.Int).Mul(oraclePrice, new(big.Int).SetUint64(gasLimit)))
+}
+
+// EffectiveGasPrice returns the gas price that a transaction pays at the given
+// oracle price: the gas price derived from the fee cap for stable fee
+// transactions, zero if the oracle price is nil, and the gas price otherwise.
+func EffectiveGasPrice(tx *types.Transaction, oraclePrice *big.Int) *big.Int {
+	if feeCap := tx.FeeCap(); feeCap != nil && oraclePrice != nil {
+		return StableFeeGasPrice(feeCap, oraclePrice, tx.Gas())
+	}
+	return tx.GasPrice()
+}
+
+// EffectiveGasPricer returns the pricer of the transactions at the given oracle
+// price, to sort them by the gas price that they pay.
+func EffectiveGasPricer(oraclePrice *big.Int) types.GasPricer {
+	return func(tx *types.Transaction) *big.Int {
+		return EffectiveGasPrice(tx, oraclePrice)
+	}
+}
+
+// StableFee converts a fee in kcoin wei into USD cents, rounding up.
+func StableFee(fee, oraclePrice *big.Int) *big.Int {
+	cents := new(big.Int).Mul(fee, oraclePrice)
+	cents.Add(cents, new(big.Int).Sub(stableFeeUnit, common.Big1))
+	return cents.Div(cents, stableFeeUnit)
+}
@@ -0,0 +1,290 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+// oracleCode returns the code of a contract answering any call with price.
+func oracleCode(price *big.Int) []byte {
+	code := append([]byte{byte(vm.PUSH32)}, common.LeftPadBytes(price.Bytes(), 32)...)
+	return append(code, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+}
+
+func TestStableFeeConversion(t *testing.T) {
+	price := new(big.Int).Mul(big.NewInt(2), big.NewInt(params.Kcoin)) // 2 USD per kcoin
+
+	// 50 cents at 2 USD per kcoin are 0.25 kcoin, spread over 25000 gas
+	gasPrice := StableFeeGasPrice(big.NewInt(50), price, 25000)
+	if want := big.NewInt(1e13); gasPrice.Cmp(want) != 0 {
+		t.Errorf("gas price mismatch: have %v, want %v", gasPrice, want)
+	}
+	if gasPrice := StableFeeGasPrice(big.NewInt(50), price, 0); gasPrice.Sign() != 0 {
+		t.Errorf("gas price without gas: have %v, want 0", gasPrice)
+	}
+	fee := new(big.Int).Mul(gasPrice, big.NewInt(25000))
+	if cents := StableFee(fee, price); cents.Cmp(big.NewInt(50)) != 0 {
+		t.Errorf("fee mismatch: have %v cents, want 50", cents)
+	}
+	// Fractions of a cent are rounded up
+	if cents := StableFee(big.NewInt(1), price); cents.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("fee mismatch: have %v cents, want 1", cents)
+	}
+}
+
+func TestStableFeeTransaction(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		oracleMgr = common.HexToAddress("0x4C55B59340FF1398d6aaE362A140D6e93855D4A5")
+		price     = new(big.Int).Mul(big.NewInt(2), big.NewInt(params.Kcoin))
+		funds     = big.NewInt(params.Kcoin)
+		db        = kcoindb.NewMemDatabase()
+		config    = *params.TestChainConfig
+	)
+	config.OracleMgr = &oracleMgr
+
+	gspec := &Genesis{
+		Config: &config,
+		Alloc: GenesisAlloc{
+			sender:    {Balance: funds},
+			oracleMgr: {Code: oracleCode(price), Balance: new(big.Int)},
+		},
+	}
+	genesis := gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(config.ChainID)
+
+	tx, err := types.SignTx(types.NewStableFeeTransaction(0, &recipient, big.NewInt(1000), params.TxGas, big.NewInt(50), nil), signer, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	blocks, _ := GenerateChain(&config, genesis, konsensus.NewFaker(), db, 1, func(i int, b *BlockGen) {
+		b.AddTx(tx)
+	})
+	chain, err := NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := chain.State()
+
+	fee := new(big.Int).Mul(StableFeeGasPrice(big.NewInt(50), price, params.TxGas), new(big.Int).SetUint64(params.TxGas))
+	want := new(big.Int).Sub(funds, fee)
+	want.Sub(want, big.NewInt(1000))
+	if balance := statedb.GetBalance(sender); balance.Cmp(want) != 0 {
+		t.Errorf("sender balance mismatch: have %v, want %v", balance, want)
+	}
+	if balance := statedb.GetBalance(recipient); balance.Cmp(big.NewInt(1000)) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want 1000", balance)
+	}
+}
+
+func TestStableFeeMessageErrors(t *testing.T) {
+	var (
+		sender    = common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+		oracleMgr = common.HexToAddress("0x4C55B59340FF1398d6aaE362A140D6e93855D4A5")
+		withCode  = *params.TestChainConfig
+		noCode    = *params.TestChainConfig
+	)
+	withCode.OracleMgr = &oracleMgr
+	noCode.OracleMgr = &sender
+
+	tests := []struct {
+		config *params.ChainConfig
+		msg    Message
+		err    error
+	}{
+		{params.TestChainConfig, types.NewStableFeeMessage(sender, &sender, 0, new(big.Int), params.TxGas, big.NewInt(1), nil, false), ErrStableFeeUnsupported},
+		{&noCode, types.NewStableFeeMessage(sender, &sender, 0, new(big.Int), params.TxGas, big.NewInt(1), nil, false), ErrOraclePrice},
+		{&withCode, types.NewStableFeeMessage(sender, &sender, 0, new(big.Int), params.TxGas, big.NewInt(1), nil, false), nil},
+	}
+	for i, tt := range tests {
+		statedb, _ := state.New(common.Hash{}, state.NewDatabase(kcoindb.NewMemDatabase()))
+		statedb.SetCode(oracleMgr, oracleCode(big.NewInt(params.Kcoin)))
+		statedb.SetBalance(sender, big.NewInt(params.Kcoin))
+
+		ctx := vm.Context{
+			CanTransfer: CanTransfer,
+			Transfer:    Transfer,
+			BlockNumber: new(big.Int),
+			Time:        new(big.Int),
+			GasPrice:    new(big.Int),
+		}
+		evm := vm.NewEVM(ctx, statedb, tt.config, vm.Config{})
+		if _, _, _, err := ApplyMessage(evm, tt.msg, new(GasPool).AddGas(math.MaxUint64)); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+
+func TestTxPoolStableFeePricing(t *testing.T) {
+	var (
+		keys      = make([]*ecdsa.PrivateKey, 3)
+		recipient = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		oracleMgr = common.HexToAddress("0x4C55B59340FF1398d6aaE362A140D6e93855D4A5")
+		price     = new(big.Int).Mul(big.NewInt(2), big.NewInt(params.Kcoin)) // 2 USD per kcoin
+		db        = kcoindb.NewMemDatabase()
+		config    = *params.TestChainConfig
+		alloc     = GenesisAlloc{oracleMgr: {Code: oracleCode(price), Balance: new(big.Int)}}
+	)
+	config.OracleMgr = &oracleMgr
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = GenesisAccount{Balance: big.NewInt(params.Kcoin)}
+	}
+	gspec := &Genesis{Config: &config, Alloc: alloc}
+	gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(config.ChainID)
+
+	sign := func(tx *types.Transaction, key *ecdsa.PrivateKey) *types.Transaction {
+		signed, err := types.SignTx(tx, signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		return signed
+	}
+	stable := func(feeCap int64) *types.Transaction {
+		return sign(types.NewStableFeeTransaction(0, &recipient, big.NewInt(1), params.TxGas, big.NewInt(feeCap), nil), keys[0])
+	}
+	legacy := func(key *ecdsa.PrivateKey, gasPrice int64) *types.Transaction {
+		return sign(types.NewTransaction(0, recipient, big.NewInt(1), params.TxGas, big.NewInt(gasPrice), nil), key)
+	}
+
+	chain, err := NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	poolConfig := DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	poolConfig.GlobalSlots = 1
+	poolConfig.GlobalQueue = 1
+
+	pool := NewTxPool(poolConfig, &config, chain)
+	defer pool.Stop()
+
+	// The stable fee transaction pays 50 cents, far more than the legacy one
+	tx := stable(50)
+	if err := pool.AddRemote(tx); err != nil {
+		t.Fatalf("failed to add stable fee transaction: %v", err)
+	}
+	cheap := legacy(keys[1], params.Shannon)
+	if err := pool.AddRemote(cheap); err != nil {
+		t.Fatalf("failed to add legacy transaction: %v", err)
+	}
+	// A full pool discards the cheapest transaction at its effective gas price
+	if err := pool.AddRemote(legacy(keys[2], 2*params.Shannon)); err != nil {
+		t.Fatalf("failed to add pricier legacy transaction: %v", err)
+	}
+	if pool.all.Get(tx.Hash()) == nil {
+		t.Errorf("stable fee transaction discarded")
+	}
+	if pool.all.Get(cheap.Hash()) != nil {
+		t.Errorf("cheapest transaction not discarded")
+	}
+	// Replacing a stable fee transaction requires a price bump
+	if err := pool.AddRemote(stable(54)); err != ErrReplaceUnderpriced {
+		t.Errorf("underpriced replacement: error mismatch: have %v, want %v", err, ErrReplaceUnderpriced)
+	}
+	if err := pool.AddRemote(stable(60)); err != nil {
+		t.Errorf("failed to replace stable fee transaction: %v", err)
+	}
+
+	// Block assembly picks the stable fee transaction first
+	pending, err := pool.Pending()
+	if err != nil {
+		t.Fatalf("failed to retrieve pending transactions: %v", err)
+	}
+	txs := types.NewTransactionsByGasPricerAndNonce(signer, pending, EffectiveGasPricer(price))
+	if head := txs.Peek(); head == nil || head.FeeCap() == nil {
+		t.Errorf("first transaction mismatch: have %v, want the stable fee transaction", head)
+	}
+}
+
+func TestTypedTxActivation(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		recipient = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		oracleMgr = common.HexToAddress("0x4C55B59340FF1398d6aaE362A140D6e93855D4A5")
+		price     = new(big.Int).Mul(big.NewInt(2), big.NewInt(params.Kcoin))
+		active    = *params.TestChainConfig
+		scheduled = *params.TestChainConfig
+	)
+	active.OracleMgr = &oracleMgr
+	scheduled.OracleMgr = &oracleMgr
+	scheduled.TypedTxBlock = big.NewInt(2)
+
+	alloc := GenesisAlloc{
+		sender:    {Balance: big.NewInt(params.Kcoin)},
+		oracleMgr: {Code: oracleCode(price), Balance: new(big.Int)},
+	}
+	signer := types.NewAndromedaSigner(active.ChainID)
+	tx, err := types.SignTx(types.NewStableFeeTransaction(0, &recipient, big.NewInt(1000), params.TxGas, big.NewInt(50), nil), signer, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+
+	// Blocks holding typed transactions before the fork block are invalid
+	gendb := kcoindb.NewMemDatabase()
+	genesis := (&Genesis{Config: &active, Alloc: alloc}).MustCommit(gendb)
+	blocks, _ := GenerateChain(&active, genesis, konsensus.NewFaker(), gendb, 1, func(i int, b *BlockGen) {
+		b.AddTx(tx)
+	})
+
+	db := kcoindb.NewMemDatabase()
+	(&Genesis{Config: &scheduled, Alloc: alloc}).MustCommit(db)
+	chain, err := NewBlockChain(db, nil, &scheduled, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != ErrTypedTxNotActive {
+		t.Errorf("block import: error mismatch: have %v, want %v", err, ErrTypedTxNotActive)
+	}
+
+	// The pool only accepts typed transactions for the blocks past the fork block
+	poolConfig := DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	pool := NewTxPool(poolConfig, &scheduled, chain)
+	defer pool.Stop()
+
+	if err := pool.AddRemote(tx); err != ErrTypedTxNotActive {
+		t.Errorf("pool: error mismatch: have %v, want %v", err, ErrTypedTxNotActive)
+	}
+
+	// Messages are only applied from the fork block on
+	statedb, _ := chain.State()
+	msg := types.NewStableFeeMessage(sender, &recipient, 0, new(big.Int), params.TxGas, big.NewInt(50), nil, false)
+	for number, want := range map[int64]error{1: ErrTypedTxNotActive, 2: nil} {
+		ctx := vm.Context{
+			CanTransfer: CanTransfer,
+			Transfer:    Transfer,
+			BlockNumber: big.NewInt(number),
+			Time:        new(big.Int),
+			GasPrice:    new(big.Int),
+		}
+		evm := vm.NewEVM(ctx, statedb.Copy(), &scheduled, vm.Config{})
+		if _, _, _, err := ApplyMessage(evm, msg, new(GasPool).AddGas(math.MaxUint64)); err != want {
+			t.Errorf("block %d: error mismatch: have %v, want %v", number, err, want)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package pruner
+
+import (
+	"encoding/binary"
+
+	"github.com/kowala-tech/kcoin/client/common"
+)
+
+// bloomHashes is the number of bits set per entry, each one taken from a
+// different 8 byte slice of the entry hash.
+const bloomHashes = 4
+
+// stateBloom is a bloom filter of the trie nodes and contract codes reachable
+// from the retained state roots. Both are keyed by the hash of their content,
+// so the key itself is used as the hash of the filter. A false positive only
+// keeps an unreachable entry in the database, so the filter bounds the memory
+// used by pruning at the cost of a little leftover state.
+type stateBloom struct {
+	bits []uint64
+	size uint64 // Number of bits of the filter
+}
+
+// newStateBloom creates a bloom filter of the given size in bytes.
+func newStateBloom(size uint64) *stateBloom {
+	words := (size + 7) / 8
+	if words == 0 {
+		words = 1
+	}
+	return &stateBloom{
+		bits: make([]uint64, words),
+		size: words * 64,
+	}
+}
+
+// add inserts the hash into the filter, reporting whether it was missing.
+func (b *stateBloom) add(hash common.Hash) bool {
+	added := false
+	for i := 0; i < bloomHashes; i++ {
+		bit := binary.BigEndian.Uint64(hash[i*8:]) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			b.bits[bit/64] |= 1 << (bit % 64)
+			added = true
+		}
+	}
+	return added
+}
+
+// contains reports whether the hash may have been added to the filter.
+func (b *stateBloom) contains(hash common.Hash) bool {
+	for i := 0; i < bloomHashes; i++ {
+		bit := binary.BigEndian.Uint64(hash[i*8:]) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
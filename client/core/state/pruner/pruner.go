@@ -0,0 +1,148 @@
+// Package pruner implements offline pruning of the state trie nodes that are
+// no longer reachable from the retained state roots.
+package pruner
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+var (
+	// errNoIterator is returned if the database cannot be iterated over.
+	errNoIterator = errors.New("database does not support iteration")
+
+	// errNoRetainedState is returned if none of the roots to retain exist in
+	// the database, in which case pruning would wipe out the whole state.
+	errNoRetainedState = errors.New("no retained state root present in the database")
+)
+
+// Stats holds the outcome of a pruning run.
+type Stats struct {
+	Retained int                // Number of state roots retained
+	Marked   int                // Number of reachable trie nodes and contract codes, approximated by the filter
+	Deleted  int                // Number of unreachable entries deleted
+	Size     common.StorageSize // Total size of the deleted entries
+}
+
+// RetainedRoots returns the state roots of the genesis block and of the given
+// number of most recent canonical blocks, starting at the head block.
+func RetainedRoots(db kcoindb.Database, recent uint64) ([]common.Hash, error) {
+	head := rawdb.ReadHeadBlockHash(db)
+	if head == (common.Hash{}) {
+		return nil, errors.New("no head block")
+	}
+	number := rawdb.ReadHeaderNumber(db, head)
+	if number == nil {
+		return nil, errors.New("unknown head block number")
+	}
+	var roots []common.Hash
+	for i := uint64(0); i < recent && i <= *number; i++ {
+		n := *number - i
+		header := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, n), n)
+		if header == nil {
+			return nil, fmt.Errorf("missing canonical header #%d", n)
+		}
+		roots = append(roots, header.Root)
+	}
+	if genesis := rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, 0), 0); genesis != nil {
+		roots = append(roots, genesis.Root)
+	}
+	return roots, nil
+}
+
+// Prune deletes every trie node and contract code from the database that is
+// not reachable from one of the given state roots. Roots missing from the
+// database are skipped, but at least one of them must be present.
+//
+// The reachable entries are marked in a bloom filter of the given size in
+// bytes, so a few unreachable ones may be kept. The larger the filter, the
+// fewer of them are left behind.
+//
+// The database must not be in use by a running node while pruning.
+func Prune(db kcoindb.Database, roots []common.Hash, bloomSize uint64) (*Stats, error) {
+	iteratee, ok := db.(kcoindb.Iteratee)
+	if !ok {
+		return nil, errNoIterator
+	}
+	var (
+		stats   = new(Stats)
+		marked  = newStateBloom(bloomSize)
+		visited = make(map[common.Hash]struct{})
+		sdb     = state.NewDatabase(db)
+		start   = time.Now()
+	)
+	// Mark all the nodes reachable from the retained state roots. Roots are
+	// deduplicated exactly, as a false positive would skip a whole state.
+	for _, root := range roots {
+		if _, ok := visited[root]; ok {
+			continue
+		}
+		visited[root] = struct{}{}
+
+		statedb, err := state.New(root, sdb)
+		if err != nil {
+			log.Warn("Retained state missing, skipping", "root", root)
+			continue
+		}
+		it := state.NewNodeIterator(statedb)
+		for it.Next() {
+			if it.Hash != (common.Hash{}) && marked.add(it.Hash) {
+				stats.Marked++
+			}
+		}
+		if it.Error != nil {
+			return nil, fmt.Errorf("failed to iterate state %x: %v", root, it.Error)
+		}
+		stats.Retained++
+		log.Info("Marked retained state", "root", root, "nodes", stats.Marked)
+	}
+	if stats.Retained == 0 {
+		return nil, errNoRetainedState
+	}
+
+	// Sweep all the trie nodes and codes that were not marked. Both are keyed by
+	// the hash of their content, which tells them apart from other entries.
+	it := iteratee.NewIterator()
+	defer it.Release()
+
+	batch := db.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		if len(key) != common.HashLength {
+			continue
+		}
+		hash := common.BytesToHash(key)
+		if marked.contains(hash) {
+			continue
+		}
+		if crypto.Keccak256Hash(it.Value()) != hash {
+			continue
+		}
+		batch.Delete(key)
+		stats.Deleted++
+		stats.Size += common.StorageSize(len(key) + len(it.Value()))
+
+		if batch.ValueSize() >= kcoindb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return nil, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+	if err := batch.Write(); err != nil {
+		return nil, err
+	}
+	log.Info("Pruned state", "retained", stats.Retained, "marked", stats.Marked, "deleted", stats.Deleted, "size", stats.Size, "elapsed", common.PrettyDuration(time.Since(start)))
+	return stats, nil
+}
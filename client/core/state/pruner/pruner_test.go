@@ -0,0 +1,116 @@
+package pruner
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/stretchr/testify/require"
+)
+
+// commitState writes the given state changes on top of the parent root and
+// flushes the resulting trie to disk.
+func commitState(t *testing.T, sdb state.Database, parent common.Hash, update func(*state.StateDB)) common.Hash {
+	statedb, err := state.New(parent, sdb)
+	require.NoError(t, err)
+
+	update(statedb)
+
+	root, err := statedb.Commit(true)
+	require.NoError(t, err)
+	require.NoError(t, sdb.TrieDB().Commit(root, false))
+	return root
+}
+
+func TestPrune(t *testing.T) {
+	var (
+		db   = kcoindb.NewMemDatabase()
+		sdb  = state.NewDatabase(db)
+		keep = common.HexToAddress("0x01")
+		drop = common.HexToAddress("0x02")
+	)
+	old := commitState(t, sdb, common.Hash{}, func(statedb *state.StateDB) {
+		statedb.SetBalance(keep, big.NewInt(1))
+		statedb.SetBalance(drop, big.NewInt(2))
+		statedb.SetCode(drop, []byte{0x60, 0x00})
+		statedb.SetState(drop, common.Hash{0x01}, common.Hash{0x02})
+	})
+	head := commitState(t, sdb, old, func(statedb *state.StateDB) {
+		statedb.Suicide(drop)
+		statedb.SetBalance(keep, big.NewInt(10))
+	})
+	require.NoError(t, db.Put([]byte("unrelated-key"), []byte("value")))
+	size := db.Len()
+
+	stats, err := Prune(db, []common.Hash{head, common.HexToHash("0xdeadbeef")}, 1024*1024)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Retained)
+	require.NotZero(t, stats.Deleted)
+	require.Equal(t, size-stats.Deleted, db.Len())
+
+	// The retained state must be fully intact
+	statedb, err := state.New(head, state.NewDatabase(db))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), statedb.GetBalance(keep))
+	it := state.NewNodeIterator(statedb)
+	for it.Next() {
+	}
+	require.NoError(t, it.Error)
+
+	// The pruned state must be gone while unrelated data is kept
+	_, err = state.New(old, state.NewDatabase(db))
+	require.Error(t, err)
+	_, err = db.Get([]byte("unrelated-key"))
+	require.NoError(t, err)
+}
+
+func TestPruneWithoutRetainedState(t *testing.T) {
+	db := kcoindb.NewMemDatabase()
+	sdb := state.NewDatabase(db)
+	commitState(t, sdb, common.Hash{}, func(statedb *state.StateDB) {
+		statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(1))
+	})
+	size := db.Len()
+
+	_, err := Prune(db, []common.Hash{common.HexToHash("0xdeadbeef")}, 1024*1024)
+	require.Equal(t, errNoRetainedState, err)
+	require.Equal(t, size, db.Len())
+}
+
+func TestStateBloom(t *testing.T) {
+	bloom := newStateBloom(1024)
+
+	hashes := make([]common.Hash, 100)
+	for i := range hashes {
+		hashes[i] = crypto.Keccak256Hash(big.NewInt(int64(i)).Bytes())
+		require.True(t, bloom.add(hashes[i]))
+	}
+	for _, hash := range hashes {
+		require.True(t, bloom.contains(hash))
+		require.False(t, bloom.add(hash))
+	}
+	require.False(t, bloom.contains(crypto.Keccak256Hash([]byte("missing"))))
+}
+
+func TestPruneSaturatedBloom(t *testing.T) {
+	db := kcoindb.NewMemDatabase()
+	sdb := state.NewDatabase(db)
+	old := commitState(t, sdb, common.Hash{}, func(statedb *state.StateDB) {
+		statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(1))
+	})
+	head := commitState(t, sdb, old, func(statedb *state.StateDB) {
+		statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(2))
+	})
+
+	// A filter too small for the state keeps more entries, but never loses one
+	stats, err := Prune(db, []common.Hash{head}, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, stats.Retained)
+
+	statedb, err := state.New(head, state.NewDatabase(db))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(2), statedb.GetBalance(common.HexToAddress("0x01")))
+}
@@ -57,6 +57,14 @@ type Message interface {
 	Nonce() uint64
 	CheckNonce() bool
 	Data() []byte
+
+	// FeeCap returns the maximum fee in USD cents of stable fee messages, nil
+	// for messages paying the gas price.
+	FeeCap() *big.Int
+
+	// Sponsor returns the account paying the fees of sponsored messages, nil
+	// if the fees are paid by the sender.
+	Sponsor() *common.Address
 }
 
 // IntrinsicGas computes the 'intrinsic gas' for a message with the given data.
@@ -124,6 +132,14 @@ func (st *StateTransition) to() common.Address {
 	return *st.msg.To()
 }
 
+// payer returns the account paying the fees of the message.
+func (st *StateTransition) payer() common.Address {
+	if sponsor := st.msg.Sponsor(); sponsor != nil {
+		return *sponsor
+	}
+	return st.msg.From()
+}
+
 func (st *StateTransition) useGas(amount uint64) error {
 	if st.gas < amount {
 		return vm.ErrOutOfGas
@@ -135,7 +151,7 @@ func (st *StateTransition) useGas(amount uint64) error {
 
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
-	if st.state.GetBalance(st.msg.From()).Cmp(mgval) < 0 {
+	if st.state.GetBalance(st.payer()).Cmp(mgval) < 0 {
 		return errInsufficientBalanceForGas
 	}
 	if err := st.gp.SubGas(st.msg.Gas()); err != nil {
@@ -144,7 +160,7 @@ func (st *StateTransition) buyGas() error {
 	st.gas += st.msg.Gas()
 
 	st.initialGas = st.msg.Gas()
-	st.state.SubBalance(st.msg.From(), mgval)
+	st.state.SubBalance(st.payer(), mgval)
 	return nil
 }
 
@@ -158,9 +174,36 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	if (st.msg.FeeCap() != nil || st.msg.Sponsor() != nil) && !st.evm.ChainConfig().IsTypedTx(st.evm.BlockNumber) {
+		return ErrTypedTxNotActive
+	}
+	if err := st.convertFeeCap(); err != nil {
+		return err
+	}
 	return st.buyGas()
 }
 
+// convertFeeCap derives the gas price of stable fee messages from their fee
+// cap, converted to kcoin at the current oracle price.
+func (st *StateTransition) convertFeeCap() error {
+	feeCap := st.msg.FeeCap()
+	if feeCap == nil {
+		return nil
+	}
+	if st.msg.GasPrice().Sign() != 0 {
+		return ErrStableFeePrice
+	}
+	price, err := oraclePrice(st.evm)
+	if err != nil {
+		return err
+	}
+	st.gasPrice = StableFeeGasPrice(feeCap, price, st.msg.Gas())
+
+	// The GASPRICE opcode reports the converted price
+	st.evm.GasPrice = new(big.Int).Set(st.gasPrice)
+	return nil
+}
+
 // TransitionDb will transition the state by applying the current message and
 // returning the result including the the used gas. It returns an error if it
 // failed. An error indicates a consensus issue.
@@ -220,7 +263,7 @@ func (st *StateTransition) refundGas() {
 
 	// Return kUSD for remaining gas, exchanged at the original rate.
 	remaining := new(big.Int).Mul(new(big.Int).SetUint64(st.gas), st.gasPrice)
-	st.state.AddBalance(st.msg.From(), remaining)
+	st.state.AddBalance(st.payer(), remaining)
 
 	// Also return remaining gas to the block gas counter so it is
 	// available for the next transaction.
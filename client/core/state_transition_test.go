@@ -0,0 +1,159 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+func TestSponsoredTransaction(t *testing.T) {
+	var (
+		senderKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sponsorKey, _ = crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+		sender        = crypto.PubkeyToAddress(senderKey.PublicKey)
+		sponsor       = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		recipient     = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		value         = big.NewInt(1000)
+		gasPrice      = big.NewInt(params.Shannon)
+		funds         = big.NewInt(params.Kcoin)
+		db            = kcoindb.NewMemDatabase()
+	)
+	gspec := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc: GenesisAlloc{
+			sender:  {Balance: value},
+			sponsor: {Balance: funds},
+		},
+	}
+	genesis := gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(gspec.Config.ChainID)
+
+	tx, err := types.SignTx(types.NewSponsoredTransaction(0, &recipient, value, params.TxGas, gasPrice, nil, nil), signer, senderKey)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	if tx, err = types.SignSponsorTx(tx, signer, sponsorKey); err != nil {
+		t.Fatalf("failed to sponsor transaction: %v", err)
+	}
+	blocks, _ := GenerateChain(gspec.Config, genesis, konsensus.NewFaker(), db, 1, func(i int, b *BlockGen) {
+		b.AddTx(tx)
+	})
+	chain, err := NewBlockChain(db, nil, gspec.Config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	statedb, _ := chain.State()
+
+	if balance := statedb.GetBalance(sender); balance.Sign() != 0 {
+		t.Errorf("sender balance mismatch: have %v, want 0", balance)
+	}
+	if balance := statedb.GetBalance(recipient); balance.Cmp(value) != 0 {
+		t.Errorf("recipient balance mismatch: have %v, want %v", balance, value)
+	}
+	want := new(big.Int).Sub(funds, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(params.TxGas)))
+	if balance := statedb.GetBalance(sponsor); balance.Cmp(want) != 0 {
+		t.Errorf("sponsor balance mismatch: have %v, want %v", balance, want)
+	}
+	if nonce := statedb.GetNonce(sender); nonce != 1 {
+		t.Errorf("sender nonce mismatch: have %d, want 1", nonce)
+	}
+}
+
+func TestTxPoolSponsorFunds(t *testing.T) {
+	var (
+		sponsorKey, _ = crypto.GenerateKey()
+		sponsor       = crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		recipient     = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		gasPrice      = big.NewInt(params.Shannon)
+		fee           = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(params.TxGas))
+		senderKeys    = make([]*ecdsa.PrivateKey, 3)
+		alloc         = GenesisAlloc{
+			// funds for the fees of two and a half transactions
+			sponsor: {Balance: new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(5)), big.NewInt(2))},
+		}
+		db = kcoindb.NewMemDatabase()
+	)
+	for i := range senderKeys {
+		senderKeys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(senderKeys[i].PublicKey)] = GenesisAccount{Balance: big.NewInt(1000)}
+	}
+	gspec := &Genesis{Config: params.TestChainConfig, Alloc: alloc}
+	genesis := gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(gspec.Config.ChainID)
+
+	sponsored := func(key *ecdsa.PrivateKey, price *big.Int) *types.Transaction {
+		tx, err := types.SignTx(types.NewSponsoredTransaction(0, &recipient, big.NewInt(1), params.TxGas, price, nil, nil), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		if tx, err = types.SignSponsorTx(tx, signer, sponsorKey); err != nil {
+			t.Fatalf("failed to sponsor transaction: %v", err)
+		}
+		return tx
+	}
+
+	chain, err := NewBlockChain(db, nil, gspec.Config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	pool := NewTxPool(DefaultTxPoolConfig, gspec.Config, chain)
+	defer pool.Stop()
+
+	// The sponsor covers the fees of two transactions, but not of a third one
+	if err := pool.AddRemote(sponsored(senderKeys[0], gasPrice)); err != nil {
+		t.Fatalf("failed to add first sponsored transaction: %v", err)
+	}
+	if err := pool.AddRemote(sponsored(senderKeys[1], gasPrice)); err != nil {
+		t.Fatalf("failed to add second sponsored transaction: %v", err)
+	}
+	if err := pool.AddRemote(sponsored(senderKeys[2], gasPrice)); err != ErrInsufficientSponsorFunds {
+		t.Fatalf("third sponsored transaction: error mismatch: have %v, want %v", err, ErrInsufficientSponsorFunds)
+	}
+	// A replacement only adds the difference of the fees
+	bumped := new(big.Int).Div(new(big.Int).Mul(gasPrice, big.NewInt(120)), big.NewInt(100))
+	if err := pool.AddRemote(sponsored(senderKeys[0], bumped)); err != nil {
+		t.Fatalf("failed to replace sponsored transaction: %v", err)
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatch: have %d, want 2", pending)
+	}
+	want := new(big.Int).Mul(new(big.Int).Add(gasPrice, bumped), new(big.Int).SetUint64(params.TxGas))
+	if fees := pool.sponsorFees[sponsor]; fees == nil || fees.Cmp(want) != 0 {
+		t.Fatalf("sponsored fees mismatch: have %v, want %v", fees, want)
+	}
+
+	// Once the sponsor spends its funds, it only covers one of them
+	blocks, _ := GenerateChain(gspec.Config, genesis, konsensus.NewFaker(), db, 1, func(i int, b *BlockGen) {
+		tx, err := types.SignTx(types.NewTransaction(0, recipient, fee, params.TxGas, big.NewInt(0), nil), signer, sponsorKey)
+		if err != nil {
+			t.Fatalf("failed to sign transaction: %v", err)
+		}
+		b.AddTx(tx)
+	})
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	pool.lockedReset(genesis.Header(), blocks[0].Header())
+
+	if pending, queued := pool.Stats(); pending+queued != 1 {
+		t.Fatalf("pooled transactions mismatch: have %d, want 1", pending+queued)
+	}
+	if fees := pool.sponsorFees[sponsor]; fees == nil || (fees.Cmp(fee) != 0 && fees.Cmp(want.Sub(want, fee)) != 0) {
+		t.Fatalf("sponsored fees mismatch after demotion: have %v", fees)
+	}
+}
@@ -0,0 +1,177 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+var (
+	// isValidatorSelector is the ABI selector of the validator manager getter
+	// telling whether an account is a registered validator.
+	isValidatorSelector = crypto.Keccak256([]byte("isValidator(address)"))[:4]
+
+	// validatorCountSelector and validatorAtIndexSelector are the ABI selectors
+	// of the validator manager getters enumerating the registered validators.
+	validatorCountSelector   = crypto.Keccak256([]byte("getValidatorCount()"))[:4]
+	validatorAtIndexSelector = crypto.Keccak256([]byte("getValidatorAtIndex(uint256)"))[:4]
+
+	// signingKeySelector is the ABI selector of the validator manager getter
+	// returning the key a validator signs the election of a block with.
+	signingKeySelector = crypto.Keccak256([]byte("getSigningKey(address,uint256)"))[:4]
+
+	// minimumDepositSelector is the ABI selector of the validator manager getter
+	// returning the deposit required to join the validators.
+	minimumDepositSelector = crypto.Keccak256([]byte("getMinimumDeposit()"))[:4]
+
+	// balanceOfSelector is the ABI selector of the mining token balance getter.
+	balanceOfSelector = crypto.Keccak256([]byte("balanceOf(address)"))[:4]
+
+	// depositSelector is the ABI selector of the mining token transfer used by
+	// joining validators to send their deposit to the validator manager.
+	depositSelector = crypto.Keccak256([]byte("transfer(address,uint256,bytes,string)"))[:4]
+)
+
+var (
+	// ErrNoValidatorMgr is returned if the validator set is looked up on a chain
+	// without a validator manager.
+	ErrNoValidatorMgr = errors.New("chain has no validator manager")
+
+	errNoMiningToken = errors.New("chain has no mining token")
+)
+
+// IsDepositTx reports whether the transaction is the deposit of a joining
+// validator, transferring mining tokens to the validator manager.
+func IsDepositTx(config *params.ChainConfig, tx *types.Transaction) bool {
+	if config.ValidatorMgr == nil || config.MiningToken == nil || tx.To() == nil || *tx.To() != *config.MiningToken {
+		return false
+	}
+	// transfer(address to, ...) carries the recipient in the first argument
+	data := tx.Data()
+	return len(data) >= 4+common.HashLength &&
+		bytes.Equal(data[:4], depositSelector) &&
+		common.BytesToAddress(data[4:4+common.HashLength]) == *config.ValidatorMgr
+}
+
+// DepositValue returns the mining tokens that a deposit transaction transfers to
+// the validator manager, nil if the transaction is not a deposit.
+func DepositValue(config *params.ChainConfig, tx *types.Transaction) *big.Int {
+	if !IsDepositTx(config, tx) {
+		return nil
+	}
+	// transfer(address to, uint256 value, ...) carries the value in the second argument
+	data := tx.Data()
+	if len(data) < 4+2*common.HashLength {
+		return nil
+	}
+	return new(big.Int).SetBytes(data[4+common.HashLength : 4+2*common.HashLength])
+}
+
+// IsValidator reports whether the account is a registered validator according
+// to the validator manager, as seen by the given state.
+func IsValidator(config *params.ChainConfig, header *types.Header, statedb *state.StateDB, addr common.Address) bool {
+	if config.ValidatorMgr == nil {
+		return false
+	}
+	evm := vm.NewEVM(lookupContext(header), statedb, config, vm.Config{})
+	input := append(append([]byte{}, isValidatorSelector...), common.LeftPadBytes(addr.Bytes(), common.HashLength)...)
+
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), *config.ValidatorMgr, input, lookupCallGas)
+	if err != nil || len(ret) != common.HashLength {
+		return false
+	}
+	return ret[common.HashLength-1] == 1
+}
+
+// ValidatorsAt returns the signing keys of the registered validators for the
+// election following the given header, according to the validator manager as
+// seen by the given state. Validator managers without key rotations sign with
+// the deposit accounts.
+func ValidatorsAt(config *params.ChainConfig, header *types.Header, statedb *state.StateDB) ([]common.Address, error) {
+	if config.ValidatorMgr == nil {
+		return nil, ErrNoValidatorMgr
+	}
+	evm := vm.NewEVM(lookupContext(header), statedb, config, vm.Config{})
+	call := func(input []byte) ([]byte, error) {
+		ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), *config.ValidatorMgr, input, lookupCallGas)
+		if err != nil {
+			return nil, err
+		}
+		if len(ret) < common.HashLength {
+			return nil, errors.New("invalid validator manager response")
+		}
+		return ret, nil
+	}
+	ret, err := call(validatorCountSelector)
+	if err != nil {
+		return nil, err
+	}
+	count := new(big.Int).SetBytes(ret[:common.HashLength])
+	if !count.IsUint64() {
+		return nil, errors.New("invalid validator count")
+	}
+	validators := make([]common.Address, count.Uint64())
+	for i := range validators {
+		index := common.LeftPadBytes(new(big.Int).SetInt64(int64(i)).Bytes(), common.HashLength)
+		ret, err := call(append(append([]byte{}, validatorAtIndexSelector...), index...))
+		if err != nil {
+			return nil, err
+		}
+		code := common.BytesToAddress(ret[:common.HashLength])
+		number := common.LeftPadBytes(new(big.Int).Add(header.Number, common.Big1).Bytes(), common.HashLength)
+		validators[i] = code
+		// validator managers without key rotations have no signing keys
+		ret, err = call(append(append(append([]byte{}, signingKeySelector...), common.LeftPadBytes(code.Bytes(), common.HashLength)...), number...))
+		if err == nil {
+			if key := common.BytesToAddress(ret[:common.HashLength]); key != (common.Address{}) {
+				validators[i] = key
+			}
+		}
+	}
+	return validators, nil
+}
+
+// MinimumDeposit returns the deposit required to join the validators according
+// to the validator manager, as seen by the given state.
+func MinimumDeposit(config *params.ChainConfig, header *types.Header, statedb *state.StateDB) (*big.Int, error) {
+	if config.ValidatorMgr == nil {
+		return nil, ErrNoValidatorMgr
+	}
+	evm := vm.NewEVM(lookupContext(header), statedb, config, vm.Config{})
+
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), *config.ValidatorMgr, minimumDepositSelector, lookupCallGas)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) < common.HashLength {
+		return nil, errors.New("invalid validator manager response")
+	}
+	return new(big.Int).SetBytes(ret[:common.HashLength]), nil
+}
+
+// miningTokenBalance returns the mining token (mUSD) balance of the account, as
+// seen by the given state. The transaction pool checks the deposits against it
+// and can't go through the token binding, as its contract backends import core.
+func miningTokenBalance(config *params.ChainConfig, header *types.Header, statedb *state.StateDB, addr common.Address) (*big.Int, error) {
+	if config.MiningToken == nil {
+		return nil, errNoMiningToken
+	}
+	evm := vm.NewEVM(lookupContext(header), statedb, config, vm.Config{})
+	input := append(append([]byte{}, balanceOfSelector...), common.LeftPadBytes(addr.Bytes(), common.HashLength)...)
+
+	ret, _, err := evm.StaticCall(vm.AccountRef(common.Address{}), *config.MiningToken, input, lookupCallGas)
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) < common.HashLength {
+		return nil, errors.New("invalid mining token response")
+	}
+	return new(big.Int).SetBytes(ret[:common.HashLength]), nil
+}
@@ -0,0 +1,194 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+func TestIsDepositTx(t *testing.T) {
+	var (
+		validatorMgr = common.HexToAddress("0x80eDa603028fe504B57D14d947c8087c1798D800")
+		miningToken  = common.HexToAddress("0x6f04441A6eD440Cc139a4E33402b438C27E97F4B")
+		other        = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		config       = *params.TestChainConfig
+	)
+	config.ValidatorMgr, config.MiningToken = &validatorMgr, &miningToken
+
+	deposit := func(to common.Address) []byte {
+		return append(append([]byte{}, depositSelector...), common.LeftPadBytes(to.Bytes(), 32)...)
+	}
+	tests := []struct {
+		to   common.Address
+		data []byte
+		want bool
+	}{
+		{miningToken, deposit(validatorMgr), true},
+		{miningToken, deposit(other), false},
+		{other, deposit(validatorMgr), false},
+		{miningToken, depositSelector, false},
+	}
+	for i, tt := range tests {
+		tx := types.NewTransaction(0, tt.to, new(big.Int), 100000, big.NewInt(1), tt.data)
+		if have := IsDepositTx(&config, tx); have != tt.want {
+			t.Errorf("test %d: have %v, want %v", i, have, tt.want)
+		}
+	}
+	if IsDepositTx(params.TestChainConfig, types.NewTransaction(0, miningToken, new(big.Int), 100000, big.NewInt(1), deposit(validatorMgr))) {
+		t.Errorf("deposit detected without system contracts")
+	}
+}
+
+func TestTxPoolSystemSlots(t *testing.T) {
+	var (
+		validatorMgr = common.HexToAddress("0x80eDa603028fe504B57D14d947c8087c1798D800")
+		keys         = make([]*ecdsa.PrivateKey, 3)
+		alloc        = GenesisAlloc{
+			// Answers true to any isValidator call
+			validatorMgr: {Code: oracleCode(common.Big1), Balance: new(big.Int)},
+		}
+		db     = kcoindb.NewMemDatabase()
+		config = *params.TestChainConfig
+	)
+	config.ValidatorMgr = &validatorMgr
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = GenesisAccount{Balance: big.NewInt(params.Kcoin)}
+	}
+	(&Genesis{Config: &config, Alloc: alloc}).MustCommit(db)
+
+	chain, err := NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	poolConfig := TxPoolConfig{
+		PriceLimit:   1,
+		PriceBump:    10,
+		AccountSlots: 1,
+		GlobalSlots:  1,
+		AccountQueue: 1,
+		SystemSlots:  1,
+		Lifetime:     DefaultTxPoolConfig.Lifetime,
+		Rejournal:    DefaultTxPoolConfig.Rejournal,
+	}
+	pool := NewTxPool(poolConfig, &config, chain)
+	defer pool.Stop()
+
+	signer := types.NewAndromedaSigner(config.ChainID)
+	sign := func(key *ecdsa.PrivateKey, to common.Address, price int64) *types.Transaction {
+		tx, _ := types.SignTx(types.NewTransaction(0, to, new(big.Int), 100000, big.NewInt(price), nil), signer, key)
+		return tx
+	}
+	other := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	// Fill the pool with a well paying transaction
+	if err := pool.AddRemote(sign(keys[0], other, 10)); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	// Cheaper transactions don't make it into a full pool
+	if err := pool.AddRemote(sign(keys[1], other, 1)); err != ErrUnderpriced {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	// System transactions do, as long as there are reserved slots left
+	if err := pool.AddRemote(sign(keys[1], validatorMgr, 1)); err != nil {
+		t.Fatalf("failed to add system transaction: %v", err)
+	}
+	if err := pool.AddRemote(sign(keys[2], validatorMgr, 1)); err != ErrUnderpriced {
+		t.Fatalf("error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Fatalf("pending transactions mismatch: have %d, want 2", pending)
+	}
+
+	system, err := pool.PendingSystem()
+	if err != nil {
+		t.Fatalf("failed to retrieve system transactions: %v", err)
+	}
+	if len(system) != 1 || len(system[crypto.PubkeyToAddress(keys[1].PublicKey)]) != 1 {
+		t.Fatalf("system transactions mismatch: have %v", system)
+	}
+}
+
+func TestTxPoolDepositSlots(t *testing.T) {
+	var (
+		validatorMgr = common.HexToAddress("0x80eDa603028fe504B57D14d947c8087c1798D800")
+		miningToken  = common.HexToAddress("0x6f04441A6eD440Cc139a4E33402b438C27E97F4B")
+		keys         = make([]*ecdsa.PrivateKey, 4)
+		alloc        = GenesisAlloc{
+			// Answers a minimum deposit of 10 to any call
+			validatorMgr: {Code: oracleCode(big.NewInt(10)), Balance: new(big.Int)},
+			// Answers a balance of 100 to any call
+			miningToken: {Code: oracleCode(big.NewInt(100)), Balance: new(big.Int)},
+		}
+		db     = kcoindb.NewMemDatabase()
+		config = *params.TestChainConfig
+	)
+	config.ValidatorMgr, config.MiningToken = &validatorMgr, &miningToken
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		alloc[crypto.PubkeyToAddress(keys[i].PublicKey)] = GenesisAccount{Balance: big.NewInt(params.Kcoin)}
+	}
+	(&Genesis{Config: &config, Alloc: alloc}).MustCommit(db)
+
+	chain, err := NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	poolConfig := TxPoolConfig{
+		PriceLimit:         1,
+		PriceBump:          10,
+		AccountSlots:       1,
+		GlobalSlots:        1,
+		AccountQueue:       1,
+		SystemSlots:        4,
+		SystemAccountSlots: 1,
+		Lifetime:           DefaultTxPoolConfig.Lifetime,
+		Rejournal:          DefaultTxPoolConfig.Rejournal,
+	}
+	pool := NewTxPool(poolConfig, &config, chain)
+	defer pool.Stop()
+
+	signer := types.NewAndromedaSigner(config.ChainID)
+	deposit := func(key *ecdsa.PrivateKey, nonce uint64, value int64) *types.Transaction {
+		data := append(append([]byte{}, depositSelector...), common.LeftPadBytes(validatorMgr.Bytes(), 32)...)
+		data = append(data, common.LeftPadBytes(big.NewInt(value).Bytes(), 32)...)
+		tx, _ := types.SignTx(types.NewTransaction(nonce, miningToken, new(big.Int), 100000, big.NewInt(1), data), signer, key)
+		return tx
+	}
+	other := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	// Fill the pool with a well paying transaction
+	tx, _ := types.SignTx(types.NewTransaction(0, other, new(big.Int), 100000, big.NewInt(10), nil), signer, keys[0])
+	if err := pool.AddRemote(tx); err != nil {
+		t.Fatalf("failed to add transaction: %v", err)
+	}
+	// Deposits below the minimum deposit or above the balance get no reserved slot
+	if err := pool.AddRemote(deposit(keys[1], 0, 5)); err != ErrUnderpriced {
+		t.Fatalf("deposit below the minimum: error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	if err := pool.AddRemote(deposit(keys[1], 0, 200)); err != ErrUnderpriced {
+		t.Fatalf("deposit above the balance: error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	// A covered deposit does, within the reserved slots of the account
+	if err := pool.AddRemote(deposit(keys[1], 0, 50)); err != nil {
+		t.Fatalf("failed to add deposit: %v", err)
+	}
+	if err := pool.AddRemote(deposit(keys[1], 1, 50)); err != ErrUnderpriced {
+		t.Fatalf("deposit beyond the account slots: error mismatch: have %v, want %v", err, ErrUnderpriced)
+	}
+	if err := pool.AddRemote(deposit(keys[2], 0, 50)); err != nil {
+		t.Fatalf("failed to add deposit: %v", err)
+	}
+}
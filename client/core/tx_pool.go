@@ -44,6 +44,10 @@ var (
 	// ErrInvalidSender is returned if the transaction contains an invalid signature.
 	ErrInvalidSender = errors.New("invalid sender")
 
+	// ErrInvalidSponsor is returned if a sponsored transaction lacks a valid
+	// sponsor signature.
+	ErrInvalidSponsor = errors.New("invalid sponsor")
+
 	// ErrNonceTooLow is returned if the nonce of a transaction is lower than the
 	// one present in the local chain.
 	ErrNonceTooLow = errors.New("nonce too low")
@@ -60,6 +64,10 @@ var (
 	// is higher than the balance of the user's account.
 	ErrInsufficientFunds = errors.New("insufficient funds for gas * price + value")
 
+	// ErrInsufficientSponsorFunds is returned if the fees of a sponsored transaction
+	// are higher than the balance of the sponsor's account.
+	ErrInsufficientSponsorFunds = errors.New("insufficient sponsor funds for gas * price")
+
 	// ErrIntrinsicGas is returned if the transaction is specified to use less gas
 	// than required to start the invocation.
 	ErrIntrinsicGas = errors.New("intrinsic gas too low")
@@ -134,6 +142,9 @@ type TxPoolConfig struct {
 	GlobalSlots  uint64 // Maximum number of executable transaction slots for all accounts
 	AccountQueue uint64 // Maximum number of non-executable transaction slots permitted per account
 	GlobalQueue  uint64 // Maximum number of non-executable transaction slots for all accounts
+	SystemSlots  uint64 // Number of transaction slots reserved for system transactions of validators
+
+	SystemAccountSlots uint64 // Maximum number of reserved slots taken by the system transactions of an account
 
 	Lifetime time.Duration // Maximum amount of time non-executable transaction are queued
 }
@@ -151,6 +162,9 @@ var DefaultTxPoolConfig = TxPoolConfig{
 	GlobalSlots:  4096,
 	AccountQueue: 64,
 	GlobalQueue:  1024,
+	SystemSlots:  64,
+
+	SystemAccountSlots: 4,
 
 	Lifetime: 3 * time.Hour,
 }
@@ -171,6 +185,10 @@ func (config *TxPoolConfig) sanitize() TxPoolConfig {
 		log.Warn("Sanitizing invalid txpool price bump", "provided", conf.PriceBump, "updated", DefaultTxPoolConfig.PriceBump)
 		conf.PriceBump = DefaultTxPoolConfig.PriceBump
 	}
+	if conf.SystemAccountSlots < 1 {
+		log.Warn("Sanitizing invalid txpool system account slots", "provided", conf.SystemAccountSlots, "updated", DefaultTxPoolConfig.SystemAccountSlots)
+		conf.SystemAccountSlots = DefaultTxPoolConfig.SystemAccountSlots
+	}
 	return conf
 }
 
@@ -196,6 +214,12 @@ type TxPool struct {
 	currentState  *state.StateDB      // Current state in the blockchain head
 	pendingState  *state.ManagedState // Pending state tracking virtual nonces
 	currentMaxGas uint64              // Current gas limit for transaction caps
+	oraclePrice   *big.Int            // Current kcoin price for stable fee conversions, nil if unavailable
+	currentHead   *types.Header       // Current head of the blockchain, for contract lookups
+
+	validators  map[common.Address]bool // Validator status of the accounts seen at the current head
+	minDeposit  *big.Int                // Deposit required to join the validators at the current head, looked up lazily
+	lookupState *state.StateDB          // Copy of the current state for validator lookups, created lazily
 
 	locals  *accountSet // Set of local transaction to exempt from eviction rules
 	journal *txJournal  // Journal of local transaction to back up to disk
@@ -206,6 +230,10 @@ type TxPool struct {
 	all     *txLookup                    // All transactions to allow lookups
 	priced  *txPricedList                // All transactions sorted by price
 
+	system      map[common.Hash]common.Address // System transactions admitted into the reserved slots
+	sponsored   map[common.Hash]common.Address // Transactions whose fees are paid by a sponsor, mapped to the sponsor
+	sponsorFees map[common.Address]*big.Int    // Running total of the fees of the sponsored transactions, per sponsor
+
 	wg sync.WaitGroup // for shutdown sync
 }
 
@@ -225,11 +253,14 @@ func NewTxPool(config TxPoolConfig, chainconfig *params.ChainConfig, chain block
 		queue:       make(map[common.Address]*txList),
 		beats:       make(map[common.Address]time.Time),
 		all:         newTxLookup(),
+		system:      make(map[common.Hash]common.Address),
+		sponsored:   make(map[common.Hash]common.Address),
+		sponsorFees: make(map[common.Address]*big.Int),
 		chainHeadCh: make(chan ChainHeadEvent, chainHeadChanSize),
 		gasPrice:    new(big.Int).SetUint64(config.PriceLimit),
 	}
 	pool.locals = newAccountSet(pool.signer)
-	pool.priced = newTxPricedList(pool.all)
+	pool.priced = newTxPricedList(pool.all, pool.effectiveGasPrice)
 	pool.reset(nil, chain.CurrentBlock().Header())
 
 	// If local transactions and journaling is enabled, load from disk
@@ -403,6 +434,27 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	pool.currentState = statedb
 	pool.pendingState = state.ManageState(statedb)
 	pool.currentMaxGas = newHead.GasLimit
+	pool.currentHead = newHead
+
+	// Validators may have joined or left, look them up again
+	pool.validators = make(map[common.Address]bool)
+	pool.minDeposit = nil
+	pool.lookupState = nil
+
+	// Refresh the kcoin price used to validate stable fee transactions
+	oldPrice := pool.oraclePrice
+	pool.oraclePrice = nil
+	if pool.chainconfig.OracleMgr != nil && pool.typedTxs() {
+		if price, err := OraclePrice(pool.chainconfig, newHead, statedb.Copy()); err == nil {
+			pool.oraclePrice = price
+		} else {
+			log.Warn("Failed to retrieve the oracle price", "err", err)
+		}
+	}
+	// The gas price of the stable fee transactions follows the oracle price
+	if (oldPrice == nil) != (pool.oraclePrice == nil) || (oldPrice != nil && oldPrice.Cmp(pool.oraclePrice) != 0) {
+		pool.reprice()
+	}
 
 	// Inject any transactions discarded due to reorgs
 	log.Debug("Reinjecting stale transactions", "count", len(reinject))
@@ -414,6 +466,7 @@ func (pool *TxPool) reset(oldHead, newHead *types.Header) {
 	// have been invalidated because of another transaction (e.g.
 	// higher gas price)
 	pool.demoteUnexecutables()
+	pool.demoteUnsponsored()
 
 	// Update all accounts to the latest known pending nonce
 	for addr, list := range pool.pending {
@@ -461,7 +514,7 @@ func (pool *TxPool) SetGasPrice(price *big.Int) {
 	defer pool.mu.Unlock()
 
 	pool.gasPrice = price
-	for _, tx := range pool.priced.Cap(price, pool.locals) {
+	for _, tx := range pool.priced.Cap(price, pool.exempt()) {
 		pool.removeTx(tx.Hash(), false)
 	}
 	log.Info("Transaction pool price threshold updated", "price", price)
@@ -529,6 +582,36 @@ func (pool *TxPool) Pending() (map[common.Address]types.Transactions, error) {
 	return pending, nil
 }
 
+// PendingSystem retrieves the processable system transactions, along with the
+// transactions of their senders preceding them, groupped by origin account and
+// sorted by nonce.
+// Validators commit them ahead of the other pending transactions. The returned
+// transaction set is a copy and can be freely modified by calling code.
+func (pool *TxPool) PendingSystem() (map[common.Address]types.Transactions, error) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+
+	pending := make(map[common.Address]types.Transactions)
+	for addr := range pool.systemAccounts() {
+		list := pool.pending[addr]
+		if list == nil {
+			continue
+		}
+		// only the system transactions and the ones they depend on go first
+		txs := list.Flatten()
+		last := -1
+		for i, tx := range txs {
+			if _, ok := pool.system[tx.Hash()]; ok {
+				last = i
+			}
+		}
+		if last >= 0 {
+			pending[addr] = txs[:last+1]
+		}
+	}
+	return pending, nil
+}
+
 // local retrieves all currently known local transactions, groupped by origin
 // account and sorted by nonce. The returned transaction set is a copy and can be
 // freely modified by calling code.
@@ -545,6 +628,12 @@ func (pool *TxPool) local() map[common.Address]types.Transactions {
 	return txs
 }
 
+// typedTxs reports whether the stable fee and sponsored transactions are valid
+// in the next block.
+func (pool *TxPool) typedTxs() bool {
+	return pool.currentHead != nil && pool.chainconfig.IsTypedTx(new(big.Int).Add(pool.currentHead.Number, common.Big1))
+}
+
 // validateTx checks whether a transaction is valid according to the consensus
 // rules and adheres to some heuristic limits of the local node (price and size).
 func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
@@ -566,9 +655,48 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	if err != nil {
 		return ErrInvalidSender
 	}
+	if tx.Typed() && !pool.typedTxs() {
+		return ErrTypedTxNotActive
+	}
+	// Stable fee transactions pay the gas price derived from their fee cap
+	gasPrice, cost := tx.GasPrice(), tx.Cost()
+	if feeCap := tx.FeeCap(); feeCap != nil {
+		if feeCap.Sign() < 0 {
+			return ErrNegativeValue
+		}
+		if gasPrice.Sign() != 0 {
+			return ErrStableFeePrice
+		}
+		if pool.oraclePrice == nil {
+			return ErrStableFeeUnsupported
+		}
+		gasPrice = StableFeeGasPrice(feeCap, pool.oraclePrice, tx.Gas())
+		cost = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas()))
+		cost.Add(cost, tx.Value())
+	}
+	// Sponsored transactions split the costs between the sender, paying the
+	// value, and the sponsor, paying the fees
+	if tx.Sponsored() {
+		sponsor, err := types.TxSponsor(pool.signer, tx)
+		if err != nil {
+			return ErrInvalidSponsor
+		}
+		cost = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas()))
+		if sponsor == from {
+			cost.Add(cost, tx.Value())
+		} else {
+			// the sponsor covers the fees of all its transactions in the pool,
+			// but the one being replaced
+			fees := pool.sponsoredFees(sponsor, from, tx.Nonce())
+			if pool.currentState.GetBalance(sponsor).Cmp(fees.Add(fees, cost)) < 0 {
+				return ErrInsufficientSponsorFunds
+			}
+			cost = tx.Value()
+		}
+	}
 	// Drop non-local transactions under our own minimal accepted gas price
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
-	if !local && pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
+	if !local && pool.gasPrice.Cmp(gasPrice) > 0 {
 		return ErrUnderpriced
 	}
 	// Ensure the transaction adheres to nonce ordering
@@ -577,7 +705,7 @@ func (pool *TxPool) validateTx(tx *types.Transaction, local bool) error {
 	}
 	// Transactor should have enough funds to cover the costs
 	// cost == V + GP * GL
-	if pool.currentState.GetBalance(from).Cmp(tx.Cost()) < 0 {
+	if pool.currentState.GetBalance(from).Cmp(cost) < 0 {
 		return ErrInsufficientFunds
 	}
 	intrGas, err := IntrinsicGas(tx.Data(), tx.To() == nil, true)
@@ -611,24 +739,29 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		invalidTxCounter.Inc(1)
 		return false, err
 	}
+	// System transactions are admitted into their own slots while some are left,
+	// regardless of how full the pool is
+	from, _ := types.TxSender(pool.signer, tx) // already validated
+	system := pool.isSystemTx(from, tx) && uint64(pool.systemCount()) < pool.config.SystemSlots &&
+		uint64(pool.systemCountOf(from)) < pool.config.SystemAccountSlots
+
 	// If the transaction pool is full, discard underpriced transactions
-	if uint64(pool.all.Count()) >= pool.config.GlobalSlots+pool.config.GlobalQueue {
+	if !system && uint64(pool.all.Count()-pool.systemCount()) >= pool.config.GlobalSlots+pool.config.GlobalQueue {
 		// If the new transaction is underpriced, don't accept it
-		if !local && pool.priced.Underpriced(tx, pool.locals) {
-			log.Trace("Discarding underpriced transaction", "hash", hash, "price", tx.GasPrice())
+		if !local && pool.priced.Underpriced(tx, pool.exempt()) {
+			log.Trace("Discarding underpriced transaction", "hash", hash, "price", pool.effectiveGasPrice(tx))
 			underpricedTxCounter.Inc(1)
 			return false, ErrUnderpriced
 		}
 		// New transaction is better than our worse ones, make room for it
-		drop := pool.priced.Discard(pool.all.Count()-int(pool.config.GlobalSlots+pool.config.GlobalQueue-1), pool.locals)
+		drop := pool.priced.Discard(pool.all.Count()-len(pool.system)-int(pool.config.GlobalSlots+pool.config.GlobalQueue-1), pool.exempt())
 		for _, tx := range drop {
-			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", tx.GasPrice())
+			log.Trace("Discarding freshly underpriced transaction", "hash", tx.Hash(), "price", pool.effectiveGasPrice(tx))
 			underpricedTxCounter.Inc(1)
 			pool.removeTx(tx.Hash(), false)
 		}
 	}
 	// If the transaction is replacing an already pending one, do directly
-	from, _ := types.TxSender(pool.signer, tx) // already validated
 	if list := pool.pending[from]; list != nil && list.Overlaps(tx) {
 		// Nonce already pending, check if required price bump is met
 		inserted, old := list.Add(tx, pool.config.PriceBump)
@@ -639,12 +772,17 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		// New transaction is better, replace old one
 		if old != nil {
 			pool.all.Remove(old.Hash())
+			pool.untrackSponsor(old)
 			pool.priced.Removed()
 			pendingReplaceCounter.Inc(1)
 		}
 		pool.all.Add(tx)
 		pool.priced.Put(tx)
 		pool.journalTx(from, tx)
+		if system {
+			pool.system[hash] = from
+		}
+		pool.trackSponsor(from, tx)
 
 		log.Trace("Pooled new executable transaction", "hash", hash, "from", from, "to", tx.To())
 
@@ -663,6 +801,10 @@ func (pool *TxPool) add(tx *types.Transaction, local bool) (bool, error) {
 		pool.locals.add(from)
 	}
 	pool.journalTx(from, tx)
+	if system {
+		pool.system[hash] = from
+	}
+	pool.trackSponsor(from, tx)
 
 	log.Trace("Pooled new future transaction", "hash", hash, "from", from, "to", tx.To())
 	return replace, nil
@@ -675,7 +817,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	// Try to insert the transaction into the future queue
 	from, _ := types.TxSender(pool.signer, tx) // already validated
 	if pool.queue[from] == nil {
-		pool.queue[from] = newTxList(false)
+		pool.queue[from] = newTxList(false, pool.effectiveGasPrice)
 	}
 	inserted, old := pool.queue[from].Add(tx, pool.config.PriceBump)
 	if !inserted {
@@ -686,6 +828,7 @@ func (pool *TxPool) enqueueTx(hash common.Hash, tx *types.Transaction) (bool, er
 	// Discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
+		pool.untrackSponsor(old)
 		pool.priced.Removed()
 		queuedReplaceCounter.Inc(1)
 	}
@@ -715,7 +858,7 @@ func (pool *TxPool) journalTx(from common.Address, tx *types.Transaction) {
 func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.Transaction) bool {
 	// Try to insert the transaction into the pending queue
 	if pool.pending[addr] == nil {
-		pool.pending[addr] = newTxList(true)
+		pool.pending[addr] = newTxList(true, pool.effectiveGasPrice)
 	}
 	list := pool.pending[addr]
 
@@ -723,6 +866,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	if !inserted {
 		// An older transaction was better, discard this
 		pool.all.Remove(hash)
+		pool.untrackSponsor(tx)
 		pool.priced.Removed()
 
 		pendingDiscardCounter.Inc(1)
@@ -731,6 +875,7 @@ func (pool *TxPool) promoteTx(addr common.Address, hash common.Hash, tx *types.T
 	// Otherwise discard any previous transaction and mark this
 	if old != nil {
 		pool.all.Remove(old.Hash())
+		pool.untrackSponsor(old)
 		pool.priced.Removed()
 
 		pendingReplaceCounter.Inc(1)
@@ -864,6 +1009,8 @@ func (pool *TxPool) removeTx(hash common.Hash, outofbound bool) {
 
 	// Remove it from the list of known transactions
 	pool.all.Remove(hash)
+	pool.untrackSponsor(tx)
+	delete(pool.system, hash)
 	if outofbound {
 		pool.priced.Removed()
 	}
@@ -920,6 +1067,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			hash := tx.Hash()
 			log.Trace("Removed old queued transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.untrackSponsor(tx)
 			pool.priced.Removed()
 		}
 		// Drop all transactions that are too costly (low balance or out of gas)
@@ -928,6 +1076,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable queued transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.untrackSponsor(tx)
 			pool.priced.Removed()
 			queuedNofundsCounter.Inc(1)
 		}
@@ -944,6 +1093,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 			for _, tx := range list.Cap(int(pool.config.AccountQueue)) {
 				hash := tx.Hash()
 				pool.all.Remove(hash)
+				pool.untrackSponsor(tx)
 				pool.priced.Removed()
 				queuedRateLimitCounter.Inc(1)
 				log.Trace("Removed cap-exceeding queued transaction", "hash", hash)
@@ -958,11 +1108,14 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 	if len(promoted) > 0 {
 		go pool.txFeed.Send(NewTxsEvent{promoted})
 	}
-	// If the pending limit is overflown, start equalizing allowances
+	// If the pending limit is overflown, start equalizing allowances. System
+	// transactions live in their own slots and don't count towards the limit,
+	// but the other transactions of their senders do.
 	pending := uint64(0)
 	for _, list := range pool.pending {
 		pending += uint64(list.Len())
 	}
+	pending -= uint64(pool.pendingSystemCount())
 	if pending > pool.config.GlobalSlots {
 		pendingBeforeCap := pending
 		// Assemble a spam order to penalize large transactors first
@@ -993,6 +1146,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 							// Drop the transaction from the global pools too
 							hash := tx.Hash()
 							pool.all.Remove(hash)
+							pool.untrackSponsor(tx)
 							pool.priced.Removed()
 
 							// Update the account nonce to the dropped transaction
@@ -1015,6 +1169,7 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 						// Drop the transaction from the global pools too
 						hash := tx.Hash()
 						pool.all.Remove(hash)
+						pool.untrackSponsor(tx)
 						pool.priced.Removed()
 
 						// Update the account nonce to the dropped transaction
@@ -1029,11 +1184,13 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 		}
 		pendingRateLimitCounter.Inc(int64(pendingBeforeCap - pending))
 	}
-	// If we've queued more transactions than the hard limit, drop oldest ones
+	// If we've queued more transactions than the hard limit, drop oldest ones.
+	// Queued system transactions live in their own slots and are kept.
 	queued := uint64(0)
 	for _, list := range pool.queue {
 		queued += uint64(list.Len())
 	}
+	queued -= uint64(pool.systemCount() - pool.pendingSystemCount())
 	if queued > pool.config.GlobalQueue {
 		// Sort all accounts with queued transactions by heartbeat
 		addresses := make(addresssByHeartbeat, 0, len(pool.queue))
@@ -1051,18 +1208,12 @@ func (pool *TxPool) promoteExecutables(accounts []common.Address) {
 
 			addresses = addresses[:len(addresses)-1]
 
-			// Drop all transactions if they are less than the overflow
-			if size := uint64(list.Len()); size <= drop {
-				for _, tx := range list.Flatten() {
-					pool.removeTx(tx.Hash(), true)
-				}
-				drop -= size
-				queuedRateLimitCounter.Inc(int64(size))
-				continue
-			}
-			// Otherwise drop only last few transactions
+			// Drop the last transactions of the account, sparing the system ones
 			txs := list.Flatten()
 			for i := len(txs) - 1; i >= 0 && drop > 0; i-- {
+				if _, ok := pool.system[txs[i].Hash()]; ok {
+					continue
+				}
 				pool.removeTx(txs[i].Hash(), true)
 				drop--
 				queuedRateLimitCounter.Inc(1)
@@ -1084,6 +1235,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed old pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.untrackSponsor(tx)
 			pool.priced.Removed()
 		}
 		// Drop all transactions that are too costly (low balance or out of gas), and queue any invalids back for later
@@ -1092,6 +1244,7 @@ func (pool *TxPool) demoteUnexecutables() {
 			hash := tx.Hash()
 			log.Trace("Removed unpayable pending transaction", "hash", hash)
 			pool.all.Remove(hash)
+			pool.untrackSponsor(tx)
 			pool.priced.Removed()
 			pendingNofundsCounter.Inc(1)
 		}
@@ -1116,6 +1269,34 @@ func (pool *TxPool) demoteUnexecutables() {
 	}
 }
 
+// demoteUnsponsored removes the sponsored transactions whose sponsors can't
+// cover their fees anymore, keeping the ones with the lowest nonces.
+func (pool *TxPool) demoteUnsponsored() {
+	bySponsor := make(map[common.Address]types.Transactions)
+	for hash, sponsor := range pool.sponsored {
+		if tx := pool.all.Get(hash); tx != nil {
+			bySponsor[sponsor] = append(bySponsor[sponsor], tx)
+		}
+	}
+	for sponsor, txs := range bySponsor {
+		sort.Sort(types.TxByNonce(txs))
+
+		balance := pool.currentState.GetBalance(sponsor)
+		fees := new(big.Int)
+		for _, tx := range txs {
+			fee := pool.sponsoredFee(tx)
+			if fees.Add(fees, fee).Cmp(balance) <= 0 {
+				continue
+			}
+			fees.Sub(fees, fee)
+
+			log.Trace("Removed unsponsored transaction", "hash", tx.Hash(), "sponsor", sponsor)
+			pool.removeTx(tx.Hash(), true)
+			pendingNofundsCounter.Inc(1)
+		}
+	}
+}
+
 // addressByHeartbeat is an account address tagged with its last activity timestamp.
 type addressByHeartbeat struct {
 	address   common.Address
@@ -1228,3 +1409,218 @@ func (t *txLookup) Remove(hash common.Hash) {
 
 	delete(t.all, hash)
 }
+
+// isSystemTx reports whether the transaction is a system transaction: a call to
+// the validator manager from a registered validator or the deposit of a joining
+// validator.
+func (pool *TxPool) isSystemTx(from common.Address, tx *types.Transaction) bool {
+	config := pool.chainconfig
+	if config.ValidatorMgr == nil || tx.To() == nil {
+		return false
+	}
+	if *tx.To() == *config.ValidatorMgr {
+		return pool.isValidator(from)
+	}
+	return pool.isDeposit(from, tx)
+}
+
+// isDeposit reports whether the transaction is the deposit of a joining
+// validator holding the mining tokens required to join at the current head.
+func (pool *TxPool) isDeposit(from common.Address, tx *types.Transaction) bool {
+	value := DepositValue(pool.chainconfig, tx)
+	if value == nil {
+		return false
+	}
+	if pool.lookupState == nil {
+		pool.lookupState = pool.currentState.Copy()
+	}
+	if pool.minDeposit == nil {
+		min, err := MinimumDeposit(pool.chainconfig, pool.currentHead, pool.lookupState)
+		if err != nil {
+			log.Debug("Failed to look up the minimum deposit", "err", err)
+			return false
+		}
+		pool.minDeposit = min
+	}
+	if value.Cmp(pool.minDeposit) < 0 {
+		return false
+	}
+	balance, err := miningTokenBalance(pool.chainconfig, pool.currentHead, pool.lookupState, from)
+	return err == nil && balance.Cmp(value) >= 0
+}
+
+// isValidator reports whether the account is a registered validator at the
+// current head, caching the answer until the next head.
+func (pool *TxPool) isValidator(addr common.Address) bool {
+	if validator, ok := pool.validators[addr]; ok {
+		return validator
+	}
+	if pool.lookupState == nil {
+		pool.lookupState = pool.currentState.Copy()
+	}
+	validator := IsValidator(pool.chainconfig, pool.currentHead, pool.lookupState, addr)
+	pool.validators[addr] = validator
+	return validator
+}
+
+// systemCount returns the number of system transactions in the pool, forgetting
+// the ones dropped since the last call.
+func (pool *TxPool) systemCount() int {
+	for hash := range pool.system {
+		if pool.all.Get(hash) == nil {
+			delete(pool.system, hash)
+		}
+	}
+	return len(pool.system)
+}
+
+// pendingSystemCount returns the number of processable system transactions.
+func (pool *TxPool) pendingSystemCount() int {
+	count := 0
+	for hash, addr := range pool.system {
+		tx := pool.all.Get(hash)
+		if list := pool.pending[addr]; tx != nil && list != nil && list.txs.Get(tx.Nonce()) == tx {
+			count++
+		}
+	}
+	return count
+}
+
+// trackSponsor records the sponsor of a transaction paying its fees, if other
+// than the sender.
+func (pool *TxPool) trackSponsor(from common.Address, tx *types.Transaction) {
+	if !tx.Sponsored() {
+		return
+	}
+	if sponsor, err := types.TxSponsor(pool.signer, tx); err == nil && sponsor != from {
+		pool.sponsored[tx.Hash()] = sponsor
+
+		fees := pool.sponsorFees[sponsor]
+		if fees == nil {
+			fees = new(big.Int)
+			pool.sponsorFees[sponsor] = fees
+		}
+		fees.Add(fees, pool.sponsoredFee(tx))
+	}
+}
+
+// untrackSponsor releases the fees of a transaction dropped from the pool from
+// the running total of its sponsor.
+func (pool *TxPool) untrackSponsor(tx *types.Transaction) {
+	hash := tx.Hash()
+	sponsor, ok := pool.sponsored[hash]
+	if !ok {
+		return
+	}
+	delete(pool.sponsored, hash)
+
+	if fees := pool.sponsorFees[sponsor]; fees != nil {
+		if fees.Sub(fees, pool.sponsoredFee(tx)).Sign() <= 0 {
+			delete(pool.sponsorFees, sponsor)
+		}
+	}
+}
+
+// sponsoredFee returns the fees that the sponsor of a transaction pays at most.
+func (pool *TxPool) sponsoredFee(tx *types.Transaction) *big.Int {
+	return new(big.Int).Mul(pool.effectiveGasPrice(tx), new(big.Int).SetUint64(tx.Gas()))
+}
+
+// sponsoredFees returns the fees of the transactions in the pool paid by the
+// sponsor, but the one of the given sender and nonce.
+func (pool *TxPool) sponsoredFees(sponsor, from common.Address, nonce uint64) *big.Int {
+	fees := new(big.Int)
+	if total := pool.sponsorFees[sponsor]; total != nil {
+		fees.Set(total)
+	}
+	// Leave out the transaction being replaced, if paid by the same sponsor
+	var old *types.Transaction
+	if list := pool.pending[from]; list != nil {
+		old = list.txs.Get(nonce)
+	}
+	if old == nil {
+		if list := pool.queue[from]; list != nil {
+			old = list.txs.Get(nonce)
+		}
+	}
+	if old != nil && pool.sponsored[old.Hash()] == sponsor {
+		fees.Sub(fees, pool.sponsoredFee(old))
+	}
+	return fees
+}
+
+// effectiveGasPrice returns the gas price that a transaction pays, derived from
+// the current oracle price for stable fee transactions. Stable fee transactions
+// are priced at zero while the oracle price is unavailable.
+func (pool *TxPool) effectiveGasPrice(tx *types.Transaction) *big.Int {
+	return EffectiveGasPrice(tx, pool.oraclePrice)
+}
+
+// reprice reorders the transactions of the pool by the gas prices derived from
+// a new oracle price.
+//
+// Note, this method assumes the pool lock is held!
+func (pool *TxPool) reprice() {
+	pool.priced.Reheap()
+	for _, list := range pool.pending {
+		list.Reprice()
+	}
+	for _, list := range pool.queue {
+		list.Reprice()
+	}
+	// The fees of the stable fee transactions follow the oracle price too
+	pool.sponsorFees = make(map[common.Address]*big.Int)
+	for hash, sponsor := range pool.sponsored {
+		tx := pool.all.Get(hash)
+		if tx == nil {
+			continue
+		}
+		fees := pool.sponsorFees[sponsor]
+		if fees == nil {
+			fees = new(big.Int)
+			pool.sponsorFees[sponsor] = fees
+		}
+		fees.Add(fees, pool.sponsoredFee(tx))
+	}
+}
+
+// systemCountOf returns the number of system transactions of an account.
+func (pool *TxPool) systemCountOf(addr common.Address) int {
+	count := 0
+	for hash, from := range pool.system {
+		if from == addr && pool.all.Get(hash) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// systemAccounts returns the senders of the system transactions in the pool.
+func (pool *TxPool) systemAccounts() map[common.Address]bool {
+	pool.systemCount()
+
+	accounts := make(map[common.Address]bool, len(pool.system))
+	for _, addr := range pool.system {
+		accounts[addr] = true
+	}
+	return accounts
+}
+
+// exempt returns the set of transactions exempt from price based eviction.
+func (pool *TxPool) exempt() txExemptions {
+	return txExemptions{pool}
+}
+
+// txExemptions shields the local and the system transactions of a pool from
+// price based eviction.
+type txExemptions struct {
+	pool *TxPool
+}
+
+// containsTx checks if the transaction is local or a system transaction.
+func (e txExemptions) containsTx(tx *types.Transaction) bool {
+	if _, ok := e.pool.system[tx.Hash()]; ok {
+		return true
+	}
+	return e.pool.locals.containsTx(tx)
+}
@@ -132,17 +132,25 @@ func (ds *DataSet) Add(chunk *Chunk) error {
 		return errors.New("got a nil fragment")
 	}
 
+	if chunk.Index >= uint64(ds.meta.NChunks) {
+		return errors.Errorf("fragment index %d out of range, %d fragments", chunk.Index, ds.meta.NChunks)
+	}
+	if proof := rlpHash(chunk.Data); proof != chunk.Proof {
+		return errors.Errorf("fragment %d proof mismatch: have %x, want %x", chunk.Index, chunk.Proof, proof)
+	}
+
 	ds.l.Lock()
+	defer ds.l.Unlock()
 
-	// @TODO (rgeraldes) - validate index
-	// @TODO (rgeraldes) - check hash proof
+	if ds.data[chunk.Index] != nil {
+		// already known
+		return nil
+	}
 	ds.data[chunk.Index] = chunk
 	// @TODO (rgeraldes) - review int vs uint64
 	ds.membership.Set(int(chunk.Index))
 	ds.count++
 
-	ds.l.Unlock()
-
 	return nil
 }
 
@@ -1,3 +1,41 @@
 package types
 
+import (
+	"bytes"
+	"testing"
+)
+
 // @TODO (rgeraldes) - complete
+
+func TestDataSetAdd(t *testing.T) {
+	data := bytes.Repeat([]byte{0x01, 0x02, 0x03}, 100)
+	source := NewDataSetFromData(data, 64)
+	set := NewDataSetFromMeta(source.Metadata())
+
+	if err := set.Add(&Chunk{Index: uint64(source.Size()), Data: []byte{0x01}, Proof: rlpHash([]byte{0x01})}); err == nil {
+		t.Fatalf("fragment out of range added")
+	}
+	garbage := *source.Get(0)
+	garbage.Data = []byte{0xff}
+	if err := set.Add(&garbage); err == nil {
+		t.Fatalf("fragment with a proof mismatch added")
+	}
+	for i := 0; i < int(source.Size()); i++ {
+		if err := set.Add(source.Get(i)); err != nil {
+			t.Fatalf("failed to add fragment %d: %v", i, err)
+		}
+		// Duplicates don't count
+		if err := set.Add(source.Get(i)); err != nil {
+			t.Fatalf("failed to add duplicate fragment %d: %v", i, err)
+		}
+		if have, want := set.Count(), uint(i+1); have != want {
+			t.Fatalf("fragment count mismatch: have %d, want %d", have, want)
+		}
+	}
+	if !set.HasAll() {
+		t.Fatalf("data set incomplete")
+	}
+	if !bytes.Equal(set.Data(), data) {
+		t.Fatalf("data mismatch")
+	}
+}
@@ -0,0 +1,23 @@
+package types
+
+import "github.com/kowala-tech/kcoin/client/common"
+
+// Participation records the part taken by the validator set in the election of
+// a block, as seen by a validator.
+type Participation struct {
+	Proposer common.Address  // Proposer of the elected block
+	Round    uint64          // Round the block was committed in
+	Voters   []VoterActivity // Activity of the validator set, in set order
+}
+
+// VoterActivity is the activity of a validator in the election of a block.
+type VoterActivity struct {
+	Address      common.Address
+	PreVoted     bool // Pre-voted in any round of the election
+	PreCommitted bool // Pre-committed in any round of the election
+}
+
+// Missed reports whether the validator failed to take part in the election.
+func (a VoterActivity) Missed() bool {
+	return !a.PreVoted && !a.PreCommitted
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+)
+
+// BlockRewards records the rewards credited to the proposer of a block.
+type BlockRewards struct {
+	Proposer common.Address // Coinbase credited with the reward and the fees
+	Reward   *big.Int       // Block reward minted by the consensus engine
+	Fees     *big.Int       // Transaction fees, excluding the unpriced transactions
+
+	// Stable fee transactions whose gas price couldn't be derived, as the oracle
+	// price of the parent state wasn't available
+	UnpricedTxs uint64
+
+	// Validators whose pre-commits for the block are carried by its child, empty
+	// until the child is part of the chain
+	Participants []common.Address
+}
+
+// Total returns the reward and the fees credited to the proposer.
+func (r *BlockRewards) Total() *big.Int {
+	return new(big.Int).Add(r.Reward, r.Fees)
+}
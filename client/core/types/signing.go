@@ -94,6 +94,40 @@ func TxSender(signer Signer, tx *Transaction) (common.Address, error) {
 	return addr, nil
 }
 
+// SignSponsorTx signs the sponsored transaction as its sponsor using the given
+// signer and private key. The transaction must already be signed by its sender.
+func SignSponsorTx(tx *Transaction, signer Signer, prv *ecdsa.PrivateKey) (*Transaction, error) {
+	if !tx.Sponsored() {
+		return nil, ErrNotSponsored
+	}
+	h := signer.SponsorHash(tx)
+	sig, err := crypto.Sign(h.Bytes(), prv)
+	if err != nil {
+		return nil, err
+	}
+	return tx.WithSponsorSignature(signer, sig)
+}
+
+// TxSponsor returns the address paying the fees of a sponsored transaction.
+func TxSponsor(signer Signer, tx *Transaction) (common.Address, error) {
+	if sc := tx.sponsor.Load(); sc != nil {
+		sigCache := sc.(sigCache)
+		// If the signer used to derive the sponsor in a previous
+		// call is not the same as used current, invalidate
+		// the cache.
+		if sigCache.signer.Equal(signer) {
+			return sigCache.from, nil
+		}
+	}
+
+	addr, err := signer.Sponsor(tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	tx.sponsor.Store(sigCache{signer: signer, from: addr})
+	return addr, nil
+}
+
 func ProposalSender(signer Signer, proposal *Proposal) (common.Address, error) {
 	if sc := proposal.from.Load(); sc != nil {
 		sigCache := sc.(sigCache)
@@ -142,6 +176,10 @@ type Signer interface {
 	SignatureValues(sig []byte) (r, s, v *big.Int, err error)
 	// Hash returns the hash to be signed.
 	Hash(h Hasher) common.Hash
+	// Sponsor returns the address paying the fees of a sponsored transaction.
+	Sponsor(tx *Transaction) (common.Address, error)
+	// SponsorHash returns the hash to be signed by the sponsor of a transaction.
+	SponsorHash(tx *Transaction) common.Hash
 	// Equal returns true if the given signer is the same as the receiver.
 	Equal(Signer) bool
 }
@@ -203,6 +241,33 @@ func (s AndromedaSigner) Hash(h Hasher) common.Hash {
 	return h.HashWithData(s.chainID, uint(0), uint(0))
 }
 
+// Sponsor returns the address paying the fees of a sponsored transaction. The
+// sponsor signature is protected by the chain id just like the sender one.
+func (s AndromedaSigner) Sponsor(tx *Transaction) (common.Address, error) {
+	if !tx.Sponsored() {
+		return common.Address{}, ErrNotSponsored
+	}
+	R, S, V := tx.SponsorSignatureValues()
+	if V == nil || R == nil || S == nil {
+		return common.Address{}, ErrMissingSponsor
+	}
+	if !isProtectedV(V) {
+		return UnprotectedSigner{}.Sponsor(tx)
+	}
+	if deriveChainID(V).Cmp(s.chainID) != 0 {
+		return common.Address{}, ErrInvalidChainID
+	}
+	V = new(big.Int).Sub(V, s.chainIDMul)
+	V.Sub(V, big8)
+	return recoverPlain(s.SponsorHash(tx), R, S, V, true)
+}
+
+// SponsorHash returns the hash to be signed by the sponsor. It covers the
+// signature of the sender so that the sponsor commits to a single transaction.
+func (s AndromedaSigner) SponsorHash(tx *Transaction) common.Hash {
+	return tx.SponsorHashWithData(s.chainID, uint(0), uint(0))
+}
+
 type UnprotectedSigner struct{}
 
 func (s UnprotectedSigner) Equal(s2 Signer) bool {
@@ -229,6 +294,21 @@ func (s UnprotectedSigner) Hash(h Hasher) common.Hash {
 	return h.HashWithData()
 }
 
+func (s UnprotectedSigner) Sponsor(tx *Transaction) (common.Address, error) {
+	if !tx.Sponsored() {
+		return common.Address{}, ErrNotSponsored
+	}
+	R, S, V := tx.SponsorSignatureValues()
+	if V == nil || R == nil || S == nil {
+		return common.Address{}, ErrMissingSponsor
+	}
+	return recoverPlain(s.SponsorHash(tx), R, S, V, true)
+}
+
+func (s UnprotectedSigner) SponsorHash(tx *Transaction) common.Hash {
+	return tx.SponsorHashWithData()
+}
+
 func recoverPlain(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (common.Address, error) {
 	if Vb.BitLen() > 8 {
 		return common.Address{}, ErrInvalidSig
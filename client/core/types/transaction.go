@@ -15,6 +15,14 @@ import (
 )
 
 //go:generate gencodec -type txdata -field-override txdataMarshaling -out gen_tx_json.go
+//go:generate gencodec -type txext -field-override txextMarshaling -out gen_txext_json.go
+
+var (
+	ErrNotSponsored   = errors.New("transaction not sponsored")
+	ErrMissingSponsor = errors.New("missing sponsor signature")
+
+	errInvalidTypedTx = errors.New("invalid typed transaction fields")
+)
 
 // deriveSigner makes a *best* guess about which signer to use.
 func deriveSigner(V *big.Int) Signer {
@@ -27,6 +35,8 @@ type Transaction struct {
 	hash atomic.Value
 	size atomic.Value
 	from atomic.Value
+
+	sponsor atomic.Value
 }
 
 type txdata struct {
@@ -44,6 +54,28 @@ type txdata struct {
 
 	// This is only used when marshaling to JSON.
 	Hash *common.Hash `json:"hash" rlp:"-"`
+
+	// Fields of typed (stable fee and sponsored) transactions. It holds at
+	// most one element and is left out of the encoding of legacy transactions.
+	Typed []*txext `json:"typed,omitempty" rlp:"tail"`
+}
+
+// txext holds the fields of typed transactions.
+type txext struct {
+	FeeCap    *big.Int `json:"feeCap"    rlp:"nil"` // maximum fee in USD cents, nil if paying the gas price
+	Sponsored bool     `json:"sponsored"`           // whether the fees are paid by a sponsor
+
+	// Sponsor signature values, nil until signed by the sponsor
+	SponsorV *big.Int `json:"sponsorV" rlp:"nil"`
+	SponsorR *big.Int `json:"sponsorR" rlp:"nil"`
+	SponsorS *big.Int `json:"sponsorS" rlp:"nil"`
+}
+
+type txextMarshaling struct {
+	FeeCap   *hexutil.Big
+	SponsorV *hexutil.Big
+	SponsorR *hexutil.Big
+	SponsorS *hexutil.Big
 }
 
 type txdataMarshaling struct {
@@ -65,6 +97,29 @@ func NewContractCreation(nonce uint64, amount *big.Int, gasLimit uint64, gasPric
 	return newTransaction(nonce, nil, amount, gasLimit, gasPrice, data)
 }
 
+// NewStableFeeTransaction creates a transaction paying at most feeCap USD cents
+// in fees. The fee cap is converted to kcoin at block processing time, using
+// the price of the on-chain oracle.
+func NewStableFeeTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, feeCap *big.Int, data []byte) *Transaction {
+	tx := newTransaction(nonce, to, amount, gasLimit, nil, data)
+	tx.data.Typed = []*txext{{FeeCap: new(big.Int).Set(feeCap)}}
+	return tx
+}
+
+// NewSponsoredTransaction creates a transaction whose fees are paid by a sponsor,
+// the sender paying the value only. If the fee cap is not nil, the transaction
+// pays stable fees and the gas price must be nil. The transaction needs to be
+// signed by both the sender and the sponsor, the sponsor signing last.
+func NewSponsoredTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice, feeCap *big.Int, data []byte) *Transaction {
+	tx := newTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	ext := &txext{Sponsored: true}
+	if feeCap != nil {
+		ext.FeeCap = new(big.Int).Set(feeCap)
+	}
+	tx.data.Typed = []*txext{ext}
+	return tx
+}
+
 func newTransaction(nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte) *Transaction {
 	if len(data) > 0 {
 		data = common.CopyBytes(data)
@@ -108,6 +163,9 @@ func (tx *Transaction) EncodeRLP(w io.Writer) error {
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
 	_, size, _ := s.Kind()
 	err := s.Decode(&tx.data)
+	if err == nil && len(tx.data.Typed) > 1 {
+		err = errInvalidTypedTx
+	}
 	if err == nil {
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 	}
@@ -139,6 +197,9 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 	if !crypto.ValidateSignatureValues(V, dec.R, dec.S, false) {
 		return ErrInvalidSig
 	}
+	if len(dec.Typed) > 1 {
+		return errInvalidTypedTx
+	}
 	*tx = Transaction{data: dec}
 	return nil
 }
@@ -150,6 +211,42 @@ func (tx *Transaction) Value() *big.Int    { return new(big.Int).Set(tx.data.Amo
 func (tx *Transaction) Nonce() uint64      { return tx.data.AccountNonce }
 func (tx *Transaction) CheckNonce() bool   { return true }
 
+// FeeCap returns the maximum fee in USD cents of a stable fee transaction, or
+// nil if the fees are paid at the transaction gas price.
+func (tx *Transaction) FeeCap() *big.Int {
+	if ext := tx.ext(); ext != nil && ext.FeeCap != nil {
+		return new(big.Int).Set(ext.FeeCap)
+	}
+	return nil
+}
+
+// Typed returns whether the transaction carries the typed transaction fields of
+// the stable fee and sponsored transactions.
+func (tx *Transaction) Typed() bool { return len(tx.data.Typed) != 0 }
+
+// Sponsored returns whether the fees of the transaction are paid by a sponsor.
+func (tx *Transaction) Sponsored() bool {
+	ext := tx.ext()
+	return ext != nil && ext.Sponsored
+}
+
+// SponsorSignatureValues returns the signature values of the sponsor, nil if
+// the transaction isn't signed by a sponsor.
+func (tx *Transaction) SponsorSignatureValues() (R, S, V *big.Int) {
+	if ext := tx.ext(); ext != nil {
+		R, S, V = ext.SponsorR, ext.SponsorS, ext.SponsorV
+	}
+	return
+}
+
+// ext returns the typed transaction fields, nil for legacy transactions.
+func (tx *Transaction) ext() *txext {
+	if len(tx.data.Typed) == 0 {
+		return nil
+	}
+	return tx.data.Typed[0]
+}
+
 func (tx *Transaction) From() (*common.Address, error) {
 	if tx.data.V == nil {
 		return nil, errors.New("[invalid sender: nil V field]")
@@ -204,6 +301,32 @@ func (tx *Transaction) HashWithData(data ...interface{}) common.Hash {
 		tx.data.Amount,
 		tx.data.Payload,
 	}
+	if ext := tx.ext(); ext != nil {
+		txData = append(txData, ext.FeeCap, ext.Sponsored)
+	}
+	return rlpHash(append(txData, data...))
+}
+
+// SponsorHashWithData returns the hash signed by the sponsor, covering the
+// signature of the sender.
+func (tx *Transaction) SponsorHashWithData(data ...interface{}) common.Hash {
+	ext := tx.ext()
+	if ext == nil {
+		ext = new(txext)
+	}
+	txData := []interface{}{
+		tx.data.AccountNonce,
+		tx.data.Price,
+		tx.data.GasLimit,
+		tx.data.Recipient,
+		tx.data.Amount,
+		tx.data.Payload,
+		ext.FeeCap,
+		ext.Sponsored,
+		tx.data.V,
+		tx.data.R,
+		tx.data.S,
+	}
 	return rlpHash(append(txData, data...))
 }
 
@@ -246,12 +369,21 @@ func (tx *Transaction) AsMessage(s Signer) (Message, error) {
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		feeCap:     tx.FeeCap(),
 		checkNonce: true,
 	}
 
 	var err error
 	msg.from, err = TxSender(s, tx)
-	return msg, err
+	if err != nil || !tx.Sponsored() {
+		return msg, err
+	}
+	sponsor, err := TxSponsor(s, tx)
+	if err != nil {
+		return msg, err
+	}
+	msg.sponsor = &sponsor
+	return msg, nil
 }
 
 // WithSignature returns a new transaction with the given signature.
@@ -266,8 +398,30 @@ func (tx *Transaction) WithSignature(signer Signer, sig []byte) (*Transaction, e
 	return cpy, nil
 }
 
-// Cost returns amount + gasprice * gaslimit.
+// WithSponsorSignature returns a new sponsored transaction with the given sponsor
+// signature. This signature needs to be formatted as described in the yellow paper (v+27).
+func (tx *Transaction) WithSponsorSignature(signer Signer, sig []byte) (*Transaction, error) {
+	if !tx.Sponsored() {
+		return nil, ErrNotSponsored
+	}
+	r, s, v, err := signer.SignatureValues(sig)
+	if err != nil {
+		return nil, err
+	}
+	ext := *tx.ext()
+	ext.SponsorR, ext.SponsorS, ext.SponsorV = r, s, v
+
+	cpy := &Transaction{data: tx.data}
+	cpy.data.Typed = []*txext{&ext}
+	return cpy, nil
+}
+
+// Cost returns amount + gasprice * gaslimit, the funds required from the sender.
+// The sender of sponsored transactions only pays the amount.
 func (tx *Transaction) Cost() *big.Int {
+	if tx.Sponsored() {
+		return new(big.Int).Set(tx.data.Amount)
+	}
 	total := new(big.Int).Mul(tx.data.Price, new(big.Int).SetUint64(tx.data.GasLimit))
 	total.Add(total, tx.data.Amount)
 	return total
@@ -391,12 +545,39 @@ func (s *TxByPrice) Pop() interface{} {
 	return x
 }
 
+// GasPricer returns the gas price that a transaction pays. Stable fee
+// transactions carry no gas price, theirs derives from the oracle price.
+type GasPricer func(tx *Transaction) *big.Int
+
+// txsByGasPricer implements the heap interface over transactions sorted by the
+// gas price that they pay, most paying first.
+type txsByGasPricer struct {
+	txs   Transactions
+	price GasPricer
+}
+
+func (s txsByGasPricer) Len() int           { return len(s.txs) }
+func (s txsByGasPricer) Less(i, j int) bool { return s.price(s.txs[i]).Cmp(s.price(s.txs[j])) > 0 }
+func (s txsByGasPricer) Swap(i, j int)      { s.txs[i], s.txs[j] = s.txs[j], s.txs[i] }
+
+func (s *txsByGasPricer) Push(x interface{}) {
+	s.txs = append(s.txs, x.(*Transaction))
+}
+
+func (s *txsByGasPricer) Pop() interface{} {
+	old := s.txs
+	n := len(old)
+	x := old[n-1]
+	s.txs = old[0 : n-1]
+	return x
+}
+
 // TransactionsByPriceAndNonce represents a set of transactions that can return
 // transactions in a profit-maximizing sorted order, while supporting removing
 // entire batches of transactions for non-executable accounts.
 type TransactionsByPriceAndNonce struct {
 	txs    map[common.Address]Transactions // Per account nonce-sorted list of transactions
-	heads  TxByPrice                       // Next transaction for each unique account (price heap)
+	heads  txsByGasPricer                  // Next transaction for each unique account (price heap)
 	signer Signer                          // Signer for the set of transactions
 }
 
@@ -406,10 +587,20 @@ type TransactionsByPriceAndNonce struct {
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
 func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transactions) *TransactionsByPriceAndNonce {
+	return NewTransactionsByGasPricerAndNonce(signer, txs, (*Transaction).GasPrice)
+}
+
+// NewTransactionsByGasPricerAndNonce creates a transaction set that can retrieve
+// transactions sorted by the gas price that they pay, as told by the pricer, in
+// a nonce-honouring way.
+//
+// Note, the input map is reowned so the caller should not interact any more with
+// if after providing it to the constructor.
+func NewTransactionsByGasPricerAndNonce(signer Signer, txs map[common.Address]Transactions, price GasPricer) *TransactionsByPriceAndNonce {
 	// Initialize a price based heap with the head transactions
-	heads := make(TxByPrice, 0, len(txs))
+	heads := txsByGasPricer{txs: make(Transactions, 0, len(txs)), price: price}
 	for from, accTxs := range txs {
-		heads = append(heads, accTxs[0])
+		heads.txs = append(heads.txs, accTxs[0])
 		// Ensure the sender address is from the signer
 		acc, _ := TxSender(signer, accTxs[0])
 		txs[acc] = accTxs[1:]
@@ -429,17 +620,17 @@ func NewTransactionsByPriceAndNonce(signer Signer, txs map[common.Address]Transa
 
 // Peek returns the next transaction by price.
 func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
-	if len(t.heads) == 0 {
+	if len(t.heads.txs) == 0 {
 		return nil
 	}
-	return t.heads[0]
+	return t.heads.txs[0]
 }
 
 // Shift replaces the current best head with the next one from the same account.
 func (t *TransactionsByPriceAndNonce) Shift() {
-	acc, _ := TxSender(t.signer, t.heads[0])
+	acc, _ := TxSender(t.signer, t.heads.txs[0])
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		t.heads[0], t.txs[acc] = txs[0], txs[1:]
+		t.heads.txs[0], t.txs[acc] = txs[0], txs[1:]
 		heap.Fix(&t.heads, 0)
 	} else {
 		heap.Pop(&t.heads)
@@ -464,6 +655,8 @@ type Message struct {
 	gasLimit   uint64
 	gasPrice   *big.Int
 	data       []byte
+	feeCap     *big.Int
+	sponsor    *common.Address
 	checkNonce bool
 }
 
@@ -480,11 +673,26 @@ func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *b
 	}
 }
 
-func (m Message) From() common.Address { return m.from }
-func (m Message) To() *common.Address  { return m.to }
-func (m Message) GasPrice() *big.Int   { return m.gasPrice }
-func (m Message) Value() *big.Int      { return m.amount }
-func (m Message) Gas() uint64          { return m.gasLimit }
-func (m Message) Nonce() uint64        { return m.nonce }
-func (m Message) Data() []byte         { return m.data }
-func (m Message) CheckNonce() bool     { return m.checkNonce }
+// NewStableFeeMessage creates a message paying at most feeCap USD cents in fees.
+func NewStableFeeMessage(from common.Address, to *common.Address, nonce uint64, amount *big.Int, gasLimit uint64, feeCap *big.Int, data []byte, checkNonce bool) Message {
+	msg := NewMessage(from, to, nonce, amount, gasLimit, new(big.Int), data, checkNonce)
+	msg.feeCap = feeCap
+	return msg
+}
+
+// WithSponsor returns a copy of the message with its fees paid by the sponsor.
+func (m Message) WithSponsor(sponsor common.Address) Message {
+	m.sponsor = &sponsor
+	return m
+}
+
+func (m Message) From() common.Address     { return m.from }
+func (m Message) To() *common.Address      { return m.to }
+func (m Message) GasPrice() *big.Int       { return m.gasPrice }
+func (m Message) Value() *big.Int          { return m.amount }
+func (m Message) Gas() uint64              { return m.gasLimit }
+func (m Message) Nonce() uint64            { return m.nonce }
+func (m Message) Data() []byte             { return m.data }
+func (m Message) CheckNonce() bool         { return m.checkNonce }
+func (m Message) FeeCap() *big.Int         { return m.feeCap }
+func (m Message) Sponsor() *common.Address { return m.sponsor }
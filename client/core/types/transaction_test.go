@@ -9,6 +9,8 @@ import (
 	"github.com/kowala-tech/kcoin/client/accounts/keystore"
 	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/rlp"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,3 +41,90 @@ func TestFrom(t *testing.T) {
 
 	require.Equal(t, &account.Address, fromAddr)
 }
+
+func TestStableFeeTransactionEncoding(t *testing.T) {
+	to := common.HexToAddress("0xecf8f87f810ecf450940c9f60066b4a7a501d6a7")
+	key, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+	signer := types.NewAndromedaSigner(big.NewInt(1))
+
+	legacy := types.NewTransaction(0, to, big.NewInt(10), 21000, big.NewInt(1), nil)
+	legacyRLP, err := rlp.EncodeToBytes(legacy)
+	require.NoError(t, err)
+	require.Equal(t, "df800182520894ecf8f87f810ecf450940c9f60066b4a7a501d6a70a80808080", common.Bytes2Hex(legacyRLP), "legacy encoding changed")
+	require.Nil(t, legacy.FeeCap())
+
+	tx, err := types.SignTx(types.NewStableFeeTransaction(0, &to, big.NewInt(10), 21000, big.NewInt(50), nil), signer, key)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(50), tx.FeeCap())
+	require.Equal(t, 0, tx.GasPrice().Sign())
+
+	enc, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+	var decoded types.Transaction
+	require.NoError(t, rlp.DecodeBytes(enc, &decoded))
+	require.Equal(t, tx.Hash(), decoded.Hash())
+	require.Equal(t, big.NewInt(50), decoded.FeeCap())
+
+	from, err := types.TxSender(signer, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), from)
+
+	json, err := tx.MarshalJSON()
+	require.NoError(t, err)
+	var unmarshaled types.Transaction
+	require.NoError(t, unmarshaled.UnmarshalJSON(json))
+	require.Equal(t, tx.Hash(), unmarshaled.Hash())
+
+	// The fee cap is covered by the signature
+	require.NotEqual(t, tx.ProtectedHash(big.NewInt(1)), types.NewTransaction(0, to, big.NewInt(10), 21000, new(big.Int), nil).ProtectedHash(big.NewInt(1)))
+}
+
+func TestSponsoredTransaction(t *testing.T) {
+	to := common.HexToAddress("0xecf8f87f810ecf450940c9f60066b4a7a501d6a7")
+	senderKey, err := crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	require.NoError(t, err)
+	sponsorKey, err := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	require.NoError(t, err)
+	signer := types.NewAndromedaSigner(big.NewInt(1))
+
+	tx, err := types.SignTx(types.NewSponsoredTransaction(0, &to, big.NewInt(10), 21000, big.NewInt(2), nil, nil), signer, senderKey)
+	require.NoError(t, err)
+	require.True(t, tx.Sponsored())
+	require.Equal(t, big.NewInt(10), tx.Cost(), "the sender only pays the value")
+
+	_, err = types.TxSponsor(signer, tx)
+	require.Equal(t, types.ErrMissingSponsor, err)
+
+	sponsored, err := types.SignSponsorTx(tx, signer, sponsorKey)
+	require.NoError(t, err)
+
+	enc, err := rlp.EncodeToBytes(sponsored)
+	require.NoError(t, err)
+	var decoded types.Transaction
+	require.NoError(t, rlp.DecodeBytes(enc, &decoded))
+	require.Equal(t, sponsored.Hash(), decoded.Hash())
+	require.Nil(t, decoded.FeeCap(), "a sponsored transaction without fee cap pays the gas price")
+
+	from, err := types.TxSender(signer, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(senderKey.PublicKey), from)
+
+	sponsor, err := types.TxSponsor(signer, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, crypto.PubkeyToAddress(sponsorKey.PublicKey), sponsor)
+
+	msg, err := decoded.AsMessage(signer)
+	require.NoError(t, err)
+	require.Equal(t, &sponsor, msg.Sponsor())
+
+	// The sponsor signature is bound to the chain
+	_, err = types.TxSponsor(types.NewAndromedaSigner(big.NewInt(2)), &decoded)
+	require.Equal(t, types.ErrInvalidChainID, err)
+
+	// Legacy transactions can't be sponsored
+	legacy, err := types.SignTx(types.NewTransaction(0, to, big.NewInt(10), 21000, big.NewInt(1), nil), signer, senderKey)
+	require.NoError(t, err)
+	_, err = types.SignSponsorTx(legacy, signer, sponsorKey)
+	require.Equal(t, types.ErrNotSponsored, err)
+}
@@ -0,0 +1,528 @@
+// Package graphql provides a GraphQL interface to Kowala node data.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/common/hexutil"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/state"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+)
+
+// maxBlocksRange is the maximum number of blocks a single blocks query may
+// return, to keep the cost of a request bounded.
+const maxBlocksRange = 1024
+
+var errBlocksRange = fmt.Errorf("block range exceeds %d blocks", maxBlocksRange)
+
+// Backend is the chain data source the GraphQL queries are resolved against.
+type Backend interface {
+	ChainDb() kcoindb.Database
+	BlockChain() *core.BlockChain
+	Consensus() consensus.Consensus
+}
+
+// Long is a 64 bit unsigned integer, input as a JSON number or a 0x-prefixed
+// hexadecimal string.
+type Long uint64
+
+// ImplementsGraphQLType returns true if Long implements the provided GraphQL type.
+func (Long) ImplementsGraphQLType(name string) bool { return name == "Long" }
+
+// UnmarshalGraphQL unmarshals the provided GraphQL query data.
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	switch input := input.(type) {
+	case string:
+		value, err := hexutil.DecodeUint64(input)
+		*l = Long(value)
+		return err
+	case int32:
+		if input < 0 {
+			return fmt.Errorf("negative Long %d", input)
+		}
+		*l = Long(input)
+		return nil
+	case float64:
+		if input < 0 || input != float64(uint64(input)) {
+			return fmt.Errorf("invalid Long %v", input)
+		}
+		*l = Long(input)
+		return nil
+	}
+	return fmt.Errorf("unexpected type %T for Long", input)
+}
+
+// Account represents a Kowala account at a particular block.
+type Account struct {
+	backend Backend
+	address common.Address
+	header  *types.Header // Block after which the account state is read
+}
+
+// getState fetches the state the account is read from.
+func (a *Account) getState(ctx context.Context) (*state.StateDB, error) {
+	return a.backend.BlockChain().StateAt(a.header.Root)
+}
+
+func (a *Account) Address(ctx context.Context) (common.Address, error) {
+	return a.address, nil
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	statedb, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*statedb.GetBalance(a.address)), nil
+}
+
+func (a *Account) MusdBalance(ctx context.Context) (hexutil.Big, error) {
+	balance, err := a.backend.Consensus().Token().BalanceOf(a.address)
+	if err != nil {
+		return hexutil.Big{}, err
+	}
+	return hexutil.Big(*balance), nil
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (Long, error) {
+	statedb, err := a.getState(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(statedb.GetNonce(a.address)), nil
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	statedb, err := a.getState(ctx)
+	if err != nil {
+		return hexutil.Bytes{}, err
+	}
+	return hexutil.Bytes(statedb.GetCode(a.address)), nil
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	statedb, err := a.getState(ctx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return statedb.GetState(a.address, args.Slot), nil
+}
+
+// Log represents an individual log message.
+type Log struct {
+	backend     Backend
+	transaction *Transaction
+	log         *types.Log
+}
+
+func (l *Log) Transaction(ctx context.Context) *Transaction {
+	return l.transaction
+}
+
+func (l *Log) Account(ctx context.Context) *Account {
+	return &Account{
+		backend: l.backend,
+		address: l.log.Address,
+		header:  l.transaction.block.block.Header(),
+	}
+}
+
+func (l *Log) Index(ctx context.Context) int32 {
+	return int32(l.log.Index)
+}
+
+func (l *Log) Topics(ctx context.Context) []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(l.log.Data)
+}
+
+// Transaction represents a Kowala transaction included in a block.
+type Transaction struct {
+	backend Backend
+	tx      *types.Transaction
+	block   *Block
+	index   uint64
+}
+
+// getReceipt retrieves the receipt of the transaction.
+func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	receipts, err := t.block.getReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.index >= uint64(len(receipts)) {
+		return nil, fmt.Errorf("missing receipt of transaction %x", t.tx.Hash())
+	}
+	return receipts[t.index], nil
+}
+
+func (t *Transaction) Hash(ctx context.Context) common.Hash {
+	return t.tx.Hash()
+}
+
+func (t *Transaction) Nonce(ctx context.Context) Long {
+	return Long(t.tx.Nonce())
+}
+
+func (t *Transaction) Index(ctx context.Context) int32 {
+	return int32(t.index)
+}
+
+func (t *Transaction) From(ctx context.Context) (*Account, error) {
+	from, err := t.tx.From()
+	if err != nil {
+		return nil, err
+	}
+	return &Account{backend: t.backend, address: *from, header: t.block.block.Header()}, nil
+}
+
+func (t *Transaction) To(ctx context.Context) *Account {
+	to := t.tx.To()
+	if to == nil {
+		return nil
+	}
+	return &Account{backend: t.backend, address: *to, header: t.block.block.Header()}
+}
+
+func (t *Transaction) Value(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.tx.Value())
+}
+
+func (t *Transaction) GasPrice(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*t.tx.GasPrice())
+}
+
+func (t *Transaction) Gas(ctx context.Context) Long {
+	return Long(t.tx.Gas())
+}
+
+func (t *Transaction) InputData(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(t.tx.Data())
+}
+
+func (t *Transaction) Block(ctx context.Context) *Block {
+	return t.block
+}
+
+func (t *Transaction) Status(ctx context.Context) (Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(receipt.Status), nil
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) (Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(receipt.GasUsed), nil
+}
+
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return Long(receipt.CumulativeGasUsed), nil
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context) (*Account, error) {
+	if t.tx.To() != nil {
+		return nil, nil
+	}
+	receipt, err := t.getReceipt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{backend: t.backend, address: receipt.ContractAddress, header: t.block.block.Header()}, nil
+}
+
+func (t *Transaction) Logs(ctx context.Context) ([]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logs := make([]*Log, 0, len(receipt.Logs))
+	for _, log := range receipt.Logs {
+		logs = append(logs, &Log{backend: t.backend, transaction: t, log: log})
+	}
+	return logs, nil
+}
+
+// Vote represents a signed pre-commit of a validator.
+type Vote struct {
+	vote *types.Vote
+}
+
+func (v *Vote) Voter(ctx context.Context) (common.Address, error) {
+	return types.VoteSender(types.NewAndromedaSigner(v.vote.ChainID()), v.vote)
+}
+
+func (v *Vote) BlockNumber(ctx context.Context) Long {
+	return Long(v.vote.BlockNumber().Uint64())
+}
+
+func (v *Vote) BlockHash(ctx context.Context) common.Hash {
+	return v.vote.BlockHash()
+}
+
+func (v *Vote) Round(ctx context.Context) Long {
+	return Long(v.vote.Round())
+}
+
+// Commit represents the evidence that a block was committed.
+type Commit struct {
+	commit *types.Commit
+}
+
+func (c *Commit) Round(ctx context.Context) Long {
+	return Long(c.commit.Round())
+}
+
+func (c *Commit) Votes(ctx context.Context) []*Vote {
+	votes := make([]*Vote, 0, len(c.commit.Commits()))
+	for _, vote := range c.commit.Commits() {
+		if vote != nil {
+			votes = append(votes, &Vote{vote: vote})
+		}
+	}
+	return votes
+}
+
+// newCommit wraps the commit, if any, into a resolver.
+func newCommit(commit *types.Commit) *Commit {
+	if commit == nil || commit.First() == nil || len(commit.Commits()) == 0 {
+		return nil
+	}
+	return &Commit{commit: commit}
+}
+
+// Block represents a Kowala block.
+type Block struct {
+	backend  Backend
+	block    *types.Block
+	receipts types.Receipts // Receipts of the block, retrieved on first use
+}
+
+// newBlock wraps the block, if any, into a resolver.
+func newBlock(backend Backend, block *types.Block) *Block {
+	if block == nil {
+		return nil
+	}
+	return &Block{backend: backend, block: block}
+}
+
+// getReceipts retrieves the receipts of all the transactions in the block.
+func (b *Block) getReceipts(ctx context.Context) (types.Receipts, error) {
+	if b.receipts == nil {
+		receipts := b.backend.BlockChain().GetReceiptsByHash(b.block.Hash())
+		if receipts == nil && len(b.block.Transactions()) > 0 {
+			return nil, fmt.Errorf("missing receipts of block #%d", b.block.NumberU64())
+		}
+		b.receipts = receipts
+	}
+	return b.receipts, nil
+}
+
+func (b *Block) Number(ctx context.Context) Long {
+	return Long(b.block.NumberU64())
+}
+
+func (b *Block) Hash(ctx context.Context) common.Hash {
+	return b.block.Hash()
+}
+
+func (b *Block) Parent(ctx context.Context) *Block {
+	if b.block.NumberU64() == 0 {
+		return nil
+	}
+	return newBlock(b.backend, b.backend.BlockChain().GetBlock(b.block.ParentHash(), b.block.NumberU64()-1))
+}
+
+func (b *Block) Coinbase(ctx context.Context) common.Address {
+	return b.block.Coinbase()
+}
+
+func (b *Block) StateRoot(ctx context.Context) common.Hash {
+	return b.block.Root()
+}
+
+func (b *Block) TransactionsRoot(ctx context.Context) common.Hash {
+	return b.block.TxHash()
+}
+
+func (b *Block) ReceiptsRoot(ctx context.Context) common.Hash {
+	return b.block.ReceiptHash()
+}
+
+func (b *Block) ValidatorsHash(ctx context.Context) common.Hash {
+	return b.block.Header().ValidatorsHash
+}
+
+func (b *Block) ExtraData(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(b.block.Extra())
+}
+
+func (b *Block) GasLimit(ctx context.Context) Long {
+	return Long(b.block.GasLimit())
+}
+
+func (b *Block) GasUsed(ctx context.Context) Long {
+	return Long(b.block.GasUsed())
+}
+
+func (b *Block) Timestamp(ctx context.Context) hexutil.Big {
+	return hexutil.Big(*b.block.Time())
+}
+
+func (b *Block) LogsBloom(ctx context.Context) hexutil.Bytes {
+	return hexutil.Bytes(b.block.Bloom().Bytes())
+}
+
+func (b *Block) TransactionCount(ctx context.Context) int32 {
+	return int32(len(b.block.Transactions()))
+}
+
+func (b *Block) Transactions(ctx context.Context) []*Transaction {
+	txs := make([]*Transaction, 0, len(b.block.Transactions()))
+	for i, tx := range b.block.Transactions() {
+		txs = append(txs, &Transaction{backend: b.backend, tx: tx, block: b, index: uint64(i)})
+	}
+	return txs
+}
+
+func (b *Block) TransactionAt(ctx context.Context, args struct{ Index int32 }) *Transaction {
+	txs := b.block.Transactions()
+	if args.Index < 0 || int(args.Index) >= len(txs) {
+		return nil
+	}
+	return &Transaction{backend: b.backend, tx: txs[args.Index], block: b, index: uint64(args.Index)}
+}
+
+func (b *Block) Logs(ctx context.Context) ([]*Log, error) {
+	var logs []*Log
+	for _, tx := range b.Transactions(ctx) {
+		txLogs, err := tx.Logs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, txLogs...)
+	}
+	return logs, nil
+}
+
+func (b *Block) Account(ctx context.Context, args struct{ Address common.Address }) *Account {
+	return &Account{backend: b.backend, address: args.Address, header: b.block.Header()}
+}
+
+func (b *Block) LastCommit(ctx context.Context) *Commit {
+	return newCommit(b.block.LastCommit())
+}
+
+func (b *Block) Commit(ctx context.Context) *Commit {
+	return newCommit(b.backend.BlockChain().GetCommit(b.block.Hash()))
+}
+
+// Validator represents a member of the validator set.
+type Validator struct {
+	voter *types.Voter
+}
+
+func (v *Validator) Address(ctx context.Context) common.Address {
+	return v.voter.Address()
+}
+
+func (v *Validator) Deposit(ctx context.Context) hexutil.Big {
+	if v.voter.Deposit() == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*v.voter.Deposit())
+}
+
+// Resolver is the top-level object in the GraphQL hierarchy.
+type Resolver struct {
+	backend Backend
+}
+
+type BlockArgs struct {
+	Number *Long
+	Hash   *common.Hash
+}
+
+func (r *Resolver) Block(ctx context.Context, args BlockArgs) (*Block, error) {
+	chain := r.backend.BlockChain()
+	switch {
+	case args.Number != nil && args.Hash != nil:
+		return nil, errors.New("only one of number or hash may be specified")
+	case args.Number != nil:
+		return newBlock(r.backend, chain.GetBlockByNumber(uint64(*args.Number))), nil
+	case args.Hash != nil:
+		return newBlock(r.backend, chain.GetBlockByHash(*args.Hash)), nil
+	}
+	return newBlock(r.backend, chain.CurrentBlock()), nil
+}
+
+type BlocksArgs struct {
+	From Long
+	To   *Long
+}
+
+func (r *Resolver) Blocks(ctx context.Context, args BlocksArgs) ([]*Block, error) {
+	chain := r.backend.BlockChain()
+
+	to := Long(chain.CurrentBlock().NumberU64())
+	if args.To != nil && *args.To < to {
+		to = *args.To
+	}
+	if args.From > to {
+		return []*Block{}, nil
+	}
+	if to-args.From >= maxBlocksRange {
+		return nil, errBlocksRange
+	}
+	blocks := make([]*Block, 0, to-args.From+1)
+	for number := args.From; number <= to; number++ {
+		block := newBlock(r.backend, chain.GetBlockByNumber(uint64(number)))
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, nil
+}
+
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) *Transaction {
+	tx, blockHash, _, index := rawdb.ReadTransaction(r.backend.ChainDb(), args.Hash)
+	if tx == nil {
+		return nil
+	}
+	block := newBlock(r.backend, r.backend.BlockChain().GetBlockByHash(blockHash))
+	if block == nil {
+		return nil
+	}
+	return &Transaction{backend: r.backend, tx: tx, block: block, index: index}
+}
+
+func (r *Resolver) Validators(ctx context.Context) ([]*Validator, error) {
+	voters, err := r.backend.Consensus().Validators()
+	if err != nil {
+		return nil, err
+	}
+	validators := make([]*Validator, 0, voters.Len())
+	for i := 0; i < voters.Len(); i++ {
+		validators = append(validators, &Validator{voter: voters.At(i)})
+	}
+	return validators, nil
+}
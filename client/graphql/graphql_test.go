@@ -0,0 +1,257 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/token"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testTo      = common.HexToAddress("0x0000000000000000000000000000000000000aaa")
+	testBalance = big.NewInt(1000000000)
+)
+
+// testToken reports a mining token balance of 42 for every account.
+type testToken struct {
+	token.Token
+}
+
+func (testToken) BalanceOf(target common.Address) (*big.Int, error) { return big.NewInt(42), nil }
+
+// testConsensus serves a fixed validator set.
+type testConsensus struct {
+	consensus.Consensus
+	voters types.Voters
+}
+
+func (c *testConsensus) Validators() (types.Voters, error) { return c.voters, nil }
+func (c *testConsensus) Token() token.Token                { return testToken{} }
+
+type testBackend struct {
+	db        kcoindb.Database
+	chain     *core.BlockChain
+	consensus *testConsensus
+}
+
+func (b *testBackend) ChainDb() kcoindb.Database      { return b.db }
+func (b *testBackend) BlockChain() *core.BlockChain   { return b.chain }
+func (b *testBackend) Consensus() consensus.Consensus { return b.consensus }
+
+// newTestBackend creates a chain of three blocks, the first one transferring
+// funds and every following one carrying a signed commit of its parent.
+func newTestBackend(t *testing.T) (*testBackend, []*types.Block) {
+	config := *params.TestChainConfig
+
+	db := kcoindb.NewMemDatabase()
+	genesis := (&core.Genesis{
+		Config: &config,
+		Alloc: core.GenesisAlloc{
+			testAddr: {Balance: testBalance},
+		},
+	}).MustCommit(db)
+
+	signer := types.NewAndromedaSigner(config.ChainID)
+	blocks, _ := core.GenerateChain(&config, genesis, konsensus.NewFaker(), db, 3, func(i int, b *core.BlockGen) {
+		parent := b.PrevBlock(i - 1)
+		vote, err := types.SignVote(types.NewVote(parent.Number(), parent.Hash(), 2, types.PreCommit), signer, testKey)
+		if err != nil {
+			t.Fatalf("failed to sign vote: %v", err)
+		}
+		b.SetLastCommit(&types.Commit{PreCommits: types.Votes{vote}, FirstPreCommit: vote})
+		if i == 0 {
+			tx, err := types.SignTx(types.NewTransaction(b.TxNonce(testAddr), testTo, big.NewInt(1000), 21000, big.NewInt(1), nil), signer, testKey)
+			if err != nil {
+				t.Fatalf("failed to sign transaction: %v", err)
+			}
+			b.AddTx(tx)
+		}
+	})
+	chain, err := core.NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+	voters, err := types.NewVoters([]*types.Voter{types.NewVoter(testAddr, big.NewInt(7), new(big.Int))})
+	if err != nil {
+		t.Fatalf("failed to create voters: %v", err)
+	}
+	return &testBackend{db: db, chain: chain, consensus: &testConsensus{voters: voters}}, blocks
+}
+
+// query posts the GraphQL query to the handler and decodes the response.
+func query(t *testing.T, h http.Handler, query string, result interface{}) []interface{} {
+	body, _ := json.Marshal(map[string]string{"query": query})
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body)))
+
+	var response struct {
+		Data   json.RawMessage
+		Errors []interface{}
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response %q: %v", rec.Body.String(), err)
+	}
+	if result != nil && len(response.Data) > 0 && string(response.Data) != "null" {
+		if err := json.Unmarshal(response.Data, result); err != nil {
+			t.Fatalf("failed to decode data %s: %v", response.Data, err)
+		}
+	}
+	return response.Errors
+}
+
+func TestBlockQuery(t *testing.T) {
+	backend, blocks := newTestBackend(t)
+	defer backend.chain.Stop()
+
+	h, err := newHandler(backend)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	var result struct {
+		Block struct {
+			Number       uint64
+			Hash         common.Hash
+			Transactions []struct {
+				Hash    common.Hash
+				From    struct{ Address common.Address }
+				To      struct{ Address common.Address }
+				Value   string
+				Status  uint64
+				GasUsed uint64
+			}
+			Commit struct {
+				Round uint64
+				Votes []struct {
+					Voter     common.Address
+					BlockHash common.Hash
+				}
+			}
+			Account struct {
+				Balance          string
+				MusdBalance      string
+				TransactionCount uint64
+			}
+		}
+	}
+	errs := query(t, h, `{ block(number: 1) {
+		number hash
+		transactions { hash from { address } to { address } value status gasUsed }
+		commit { round votes { voter blockHash } }
+		account(address: "`+testTo.Hex()+`") { balance musdBalance transactionCount }
+	} }`, &result)
+	if len(errs) > 0 {
+		t.Fatalf("query failed: %v", errs)
+	}
+	block := result.Block
+	if block.Number != 1 || block.Hash != blocks[0].Hash() {
+		t.Fatalf("block mismatch: have #%d %x, want #1 %x", block.Number, block.Hash, blocks[0].Hash())
+	}
+	if len(block.Transactions) != 1 {
+		t.Fatalf("transaction count mismatch: have %d, want 1", len(block.Transactions))
+	}
+	tx := block.Transactions[0]
+	if tx.Hash != blocks[0].Transactions()[0].Hash() || tx.From.Address != testAddr || tx.To.Address != testTo {
+		t.Errorf("transaction mismatch: %+v", tx)
+	}
+	if tx.Value != "0x3e8" || tx.Status != types.ReceiptStatusSuccessful || tx.GasUsed != 21000 {
+		t.Errorf("transaction outcome mismatch: %+v", tx)
+	}
+	if block.Commit.Round != 2 || len(block.Commit.Votes) != 1 {
+		t.Fatalf("commit mismatch: %+v", block.Commit)
+	}
+	if vote := block.Commit.Votes[0]; vote.Voter != testAddr || vote.BlockHash != blocks[0].Hash() {
+		t.Errorf("vote mismatch: %+v", vote)
+	}
+	if account := block.Account; account.Balance != "0x3e8" || account.MusdBalance != "0x2a" || account.TransactionCount != 0 {
+		t.Errorf("account mismatch: %+v", account)
+	}
+}
+
+func TestTransactionQuery(t *testing.T) {
+	backend, blocks := newTestBackend(t)
+	defer backend.chain.Stop()
+
+	h, err := newHandler(backend)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	var result struct {
+		Transaction struct {
+			Index int
+			Block struct{ Number uint64 }
+			From  struct{ TransactionCount uint64 }
+		}
+	}
+	hash := blocks[0].Transactions()[0].Hash()
+	if errs := query(t, h, `{ transaction(hash: "`+hash.Hex()+`") { index block { number } from { transactionCount } } }`, &result); len(errs) > 0 {
+		t.Fatalf("query failed: %v", errs)
+	}
+	if tx := result.Transaction; tx.Index != 0 || tx.Block.Number != 1 || tx.From.TransactionCount != 1 {
+		t.Errorf("transaction mismatch: %+v", tx)
+	}
+	var missing struct{ Transaction *struct{ Index int } }
+	if errs := query(t, h, `{ transaction(hash: "`+common.Hash{}.Hex()+`") { index } }`, &missing); len(errs) > 0 || missing.Transaction != nil {
+		t.Errorf("unknown transaction mismatch: have %v (%v), want null", missing.Transaction, errs)
+	}
+}
+
+func TestBlocksQuery(t *testing.T) {
+	backend, blocks := newTestBackend(t)
+	defer backend.chain.Stop()
+
+	h, err := newHandler(backend)
+	if err != nil {
+		t.Fatalf("failed to create handler: %v", err)
+	}
+	var result struct {
+		Blocks []struct {
+			Number uint64
+			Parent struct{ Hash common.Hash }
+		}
+		Latest     struct{ Number uint64 }
+		Validators []struct {
+			Address common.Address
+			Deposit string
+		}
+	}
+	if errs := query(t, h, `{ blocks(from: 1) { number parent { hash } } latest: block { number } validators { address deposit } }`, &result); len(errs) > 0 {
+		t.Fatalf("query failed: %v", errs)
+	}
+	if len(result.Blocks) != len(blocks) {
+		t.Fatalf("block count mismatch: have %d, want %d", len(result.Blocks), len(blocks))
+	}
+	for i, block := range result.Blocks {
+		if block.Number != blocks[i].NumberU64() || block.Parent.Hash != blocks[i].ParentHash() {
+			t.Errorf("block %d mismatch: %+v", i, block)
+		}
+	}
+	if result.Latest.Number != blocks[len(blocks)-1].NumberU64() {
+		t.Errorf("latest block mismatch: have #%d, want #%d", result.Latest.Number, blocks[len(blocks)-1].NumberU64())
+	}
+	if len(result.Validators) != 1 || result.Validators[0].Address != testAddr || result.Validators[0].Deposit != "0x7" {
+		t.Errorf("validators mismatch: %+v", result.Validators)
+	}
+	// Ranges are capped at the most recent block
+	var capped struct{ Blocks []struct{ Number uint64 } }
+	if errs := query(t, h, `{ blocks(from: 2, to: 5000) { number } }`, &capped); len(errs) > 0 || len(capped.Blocks) != 2 {
+		t.Errorf("capped range mismatch: have %v (%v), want 2 blocks", capped.Blocks, errs)
+	}
+}
@@ -0,0 +1,183 @@
+package graphql
+
+const schema string = `
+    # Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+    scalar Bytes32
+    # Address is a 20 byte Kowala address, represented as 0x-prefixed hexadecimal.
+    scalar Address
+    # Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+    # An empty byte string is represented as '0x'.
+    scalar Bytes
+    # BigInt is a large integer. Input is accepted as either a JSON number or as a string.
+    # Strings may be either decimal or 0x-prefixed hexadecimal. Output values are all
+    # 0x-prefixed hexadecimal.
+    scalar BigInt
+    # Long is a 64 bit unsigned integer.
+    scalar Long
+
+    schema {
+        query: Query
+    }
+
+    # Account is a Kowala account at a particular block.
+    type Account {
+        # Address is the address owning the account.
+        address: Address!
+        # Balance is the balance of the account, in kcoin.
+        balance: BigInt!
+        # MusdBalance is the balance of mining tokens (mUSD) held by the account,
+        # read from the mining token at the latest block.
+        musdBalance: BigInt!
+        # TransactionCount is the number of transactions sent from this account,
+        # or in the case of a contract, the number of contracts created.
+        transactionCount: Long!
+        # Code contains the smart contract code for this account, if the account
+        # is a (non-self-destructed) contract.
+        code: Bytes!
+        # Storage provides access to the storage of a contract account, indexed
+        # by its 32 byte slot identifier.
+        storage(slot: Bytes32!): Bytes32!
+    }
+
+    # Log is a Kowala event log.
+    type Log {
+        # Index is the index of this log in the block.
+        index: Int!
+        # Account is the account which generated this log, as of the block the
+        # log was generated in.
+        account: Account!
+        # Topics is a list of 0-4 indexed topics for the log.
+        topics: [Bytes32!]!
+        # Data is unindexed data for this log.
+        data: Bytes!
+        # Transaction is the transaction that generated this log entry.
+        transaction: Transaction!
+    }
+
+    # Transaction is a Kowala transaction.
+    type Transaction {
+        # Hash is the hash of this transaction.
+        hash: Bytes32!
+        # Nonce is the nonce of the account this transaction was generated with.
+        nonce: Long!
+        # Index is the index of this transaction in the parent block.
+        index: Int!
+        # From is the account that sent this transaction, as of the block the
+        # transaction was included in.
+        from: Account!
+        # To is the account the transaction was sent to. This is null for
+        # contract-creating transactions.
+        to: Account
+        # Value is the value, in kcoin, sent along with this transaction.
+        value: BigInt!
+        # GasPrice is the price offered to validators for gas, in kcoin per unit.
+        gasPrice: BigInt!
+        # Gas is the maximum amount of gas this transaction can consume.
+        gas: Long!
+        # InputData is the data supplied to the target of the transaction.
+        inputData: Bytes!
+        # Block is the block this transaction was included in.
+        block: Block!
+        # Status is the return status of the transaction. This will be 1 if the
+        # transaction succeeded, or 0 if it failed.
+        status: Long!
+        # GasUsed is the amount of gas that was used processing this transaction.
+        gasUsed: Long!
+        # CumulativeGasUsed is the total gas used in the block up to and including
+        # this transaction.
+        cumulativeGasUsed: Long!
+        # CreatedContract is the account that was created by a contract creation
+        # transaction. If the transaction was not a contract creation transaction,
+        # or it has not yet been mined, this field will be null.
+        createdContract: Account
+        # Logs is a list of log entries emitted by this transaction.
+        logs: [Log!]!
+    }
+
+    # Vote is a signed pre-commit of a validator on a block.
+    type Vote {
+        # Voter is the validator that signed the vote.
+        voter: Address!
+        # BlockNumber is the number of the block voted on.
+        blockNumber: Long!
+        # BlockHash is the hash of the block voted on.
+        blockHash: Bytes32!
+        # Round is the consensus round the vote was cast in.
+        round: Long!
+    }
+
+    # Commit is the evidence that a block was committed by the validators.
+    type Commit {
+        # Round is the consensus round the block was committed in.
+        round: Long!
+        # Votes are the pre-commits of the validators on the block.
+        votes: [Vote!]!
+    }
+
+    # Block is a Kowala block.
+    type Block {
+        # Number is the number of this block, starting at 0 for the genesis block.
+        number: Long!
+        # Hash is the block hash of this block.
+        hash: Bytes32!
+        # Parent is the parent block of this block.
+        parent: Block
+        # Coinbase is the address the block proposer collects the fees to.
+        coinbase: Address!
+        # StateRoot is the hash of the state trie after this block was processed.
+        stateRoot: Bytes32!
+        # TransactionsRoot is the hash of the root of the trie of transactions in this block.
+        transactionsRoot: Bytes32!
+        # ReceiptsRoot is the hash of the trie of transaction receipts in this block.
+        receiptsRoot: Bytes32!
+        # ValidatorsHash is the hash of the validator set that proposed this block.
+        validatorsHash: Bytes32!
+        # ExtraData is an arbitrary data field supplied by the proposer.
+        extraData: Bytes!
+        # GasLimit is the maximum amount of gas that was available to transactions in this block.
+        gasLimit: Long!
+        # GasUsed is the amount of gas that was used executing transactions in this block.
+        gasUsed: Long!
+        # Timestamp is the unix timestamp at which this block was proposed.
+        timestamp: BigInt!
+        # LogsBloom is a bloom filter that can be used to check if a block may
+        # contain log entries matching a filter.
+        logsBloom: Bytes!
+        # TransactionCount is the number of transactions in this block.
+        transactionCount: Int!
+        # Transactions is a list of transactions associated with this block.
+        transactions: [Transaction!]!
+        # TransactionAt returns the transaction at the specified index, if any.
+        transactionAt(index: Int!): Transaction
+        # Logs returns all the logs emitted by the transactions of this block.
+        logs: [Log!]!
+        # Account fetches a Kowala account as of the state after this block.
+        account(address: Address!): Account!
+        # LastCommit is the commit of the parent block carried by this block.
+        lastCommit: Commit
+        # Commit is the commit of this block, carried by its child. This is null
+        # as long as the block is not committed.
+        commit: Commit
+    }
+
+    # Validator is a member of the current validator set.
+    type Validator {
+        # Address is the address of the validator.
+        address: Address!
+        # Deposit is the amount of mining tokens deposited by the validator.
+        deposit: BigInt!
+    }
+
+    type Query {
+        # Block fetches a Kowala block by number or by hash. If neither is
+        # supplied, the most recent known block is returned.
+        block(number: Long, hash: Bytes32): Block
+        # Blocks returns all the blocks between two numbers, inclusive. If
+        # to is not supplied, it defaults to the most recent known block.
+        blocks(from: Long!, to: Long): [Block!]!
+        # Transaction returns a transaction specified by its hash.
+        transaction(hash: Bytes32!): Transaction
+        # Validators returns the current validator set.
+        validators: [Validator!]!
+    }
+`
@@ -0,0 +1,115 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	graphql "github.com/graph-gophers/graphql-go"
+	"github.com/kowala-tech/kcoin/client/log"
+	"github.com/kowala-tech/kcoin/client/p2p"
+	"github.com/kowala-tech/kcoin/client/rpc"
+)
+
+// Service encapsulates a GraphQL service.
+type Service struct {
+	endpoint string       // The host:port endpoint for this service
+	cors     []string     // Allowed CORS domains
+	vhosts   []string     // Recognised vhosts
+	backend  Backend      // The backend that queries will operate on
+	handler  http.Handler // The `http.Handler` used to answer queries
+	listener net.Listener // The listening socket
+}
+
+// New constructs a new GraphQL service instance.
+func New(backend Backend, endpoint string, cors, vhosts []string) (*Service, error) {
+	return &Service{
+		endpoint: endpoint,
+		cors:     cors,
+		vhosts:   vhosts,
+		backend:  backend,
+	}, nil
+}
+
+// Protocols implements node.Service, returning the P2P network protocols used
+// by the GraphQL service (nil as it doesn't use the devp2p overlay network).
+func (s *Service) Protocols() []p2p.Protocol { return nil }
+
+// APIs implements node.Service, returning the RPC API endpoints provided by the
+// GraphQL service (nil as it doesn't provide any user callable APIs).
+func (s *Service) APIs() []rpc.API { return nil }
+
+// Start implements node.Service, starting up the GraphQL server.
+func (s *Service) Start(server *p2p.Server) error {
+	handler, err := newHandler(s.backend)
+	if err != nil {
+		return err
+	}
+	if s.listener, err = net.Listen("tcp", s.endpoint); err != nil {
+		return err
+	}
+	s.handler = rpc.NewHTTPHandlerStack(handler, s.cors, s.vhosts)
+
+	srv := &http.Server{
+		Handler:      s.handler,
+		ReadTimeout:  5 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+	go srv.Serve(s.listener)
+
+	log.Info("GraphQL endpoint opened", "url", fmt.Sprintf("http://%s", s.endpoint), "cors", strings.Join(s.cors, ","), "vhosts", strings.Join(s.vhosts, ","))
+	return nil
+}
+
+// Stop implements node.Service, terminating the GraphQL server.
+func (s *Service) Stop() error {
+	if s.listener != nil {
+		s.listener.Close()
+		s.listener = nil
+		log.Info("GraphQL endpoint closed", "url", fmt.Sprintf("http://%s", s.endpoint))
+	}
+	return nil
+}
+
+// handler answers GraphQL queries posted over HTTP.
+type handler struct {
+	schema *graphql.Schema
+}
+
+// newHandler parses the GraphQL schema and binds it to the backend.
+func newHandler(backend Backend) (*handler, error) {
+	parsed, err := graphql.ParseSchema(schema, &Resolver{backend: backend})
+	if err != nil {
+		return nil, err
+	}
+	return &handler{schema: parsed}, nil
+}
+
+// ServeHTTP serves the query posted in the request body.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+	var params struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName"`
+		Variables     map[string]interface{} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	response := h.schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
+	responseJSON, err := json.Marshal(response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(responseJSON)
+}
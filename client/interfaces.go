@@ -103,6 +103,8 @@ type CallMsg struct {
 	GasPrice *big.Int        // wei <-> gas exchange ratio
 	Value    *big.Int        // amount of wei sent along with the call
 	Data     []byte          // input data, usually an ABI-encoded contract method invocation
+	FeeCap   *big.Int        // maximum fee in USD cents of stable fee calls, replacing the gas price
+	Sponsor  *common.Address // the account paying the fees instead of the sender (nil for the sender)
 }
 
 // A ContractCaller provides contract calls, essentially transactions that are executed by
@@ -17,6 +17,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/common/math"
 	"github.com/kowala-tech/kcoin/client/core"
 	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/state"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/core/vm"
 	"github.com/kowala-tech/kcoin/client/crypto"
@@ -386,6 +387,31 @@ func (s *PrivateAccountAPI) SignTransaction(ctx context.Context, args SendTxArgs
 	return &SignTransactionResult{data, signed}, nil
 }
 
+// SignSponsorTransaction will sign the given sponsored transaction, already signed
+// by its sender, with the key associated with the sponsor address. If the given
+// passwd isn't able to decrypt the key it fails. The transaction is returned in
+// RLP-form, not broadcast to other nodes
+func (s *PrivateAccountAPI) SignSponsorTransaction(ctx context.Context, encodedTx hexutil.Bytes, sponsor common.Address, passwd string) (*SignTransactionResult, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return nil, err
+	}
+	account := accounts.Account{Address: sponsor}
+	wallet, err := s.am.Find(account)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignSponsorTxWithPassphrase(account, passwd, tx, s.b.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+	return &SignTransactionResult{data, signed}, nil
+}
+
 // signHash is a helper function that calculates a hash for the given message that can be
 // safely used to calculate a signature from.
 //
@@ -484,7 +510,8 @@ func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Add
 	return (*hexutil.Big)(state.GetBalance(address)), state.Error()
 }
 
-// GetBlockByNumber returns the requested block. When blockNr is -1 the chain head is returned. When fullTx is true all
+// GetBlockByNumber returns the requested block. When blockNr is -1 the chain head is returned, when it is -3 the
+// latest finalized block is returned. When fullTx is true all
 // transactions in the block are returned in full detail, otherwise only the transaction hash is returned.
 func (s *PublicBlockChainAPI) GetBlockByNumber(ctx context.Context, blockNr rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
 	block, err := s.b.BlockByNumber(ctx, blockNr)
@@ -511,6 +538,45 @@ func (s *PublicBlockChainAPI) GetBlockByHash(ctx context.Context, blockHash comm
 	return nil, err
 }
 
+// RPCFinality describes whether a block is final, that is whether the pre-commits of the
+// validators for it are recorded in the chain.
+type RPCFinality struct {
+	Number    *hexutil.Big   `json:"number"`
+	Hash      common.Hash    `json:"hash"`
+	Finalized bool           `json:"finalized"`
+	Round     hexutil.Uint64 `json:"round"`
+	Signers   hexutil.Uint   `json:"signers"`
+}
+
+// GetFinality returns the finality of the requested block, along with the round it was committed
+// in and the number of validators that signed its commit. Blocks not committed yet are reported
+// as not finalized, unknown blocks as nil.
+func (s *PublicBlockChainAPI) GetFinality(ctx context.Context, blockHash common.Hash) (*RPCFinality, error) {
+	block, err := s.b.GetBlock(ctx, blockHash)
+	if block == nil || err != nil {
+		return nil, err
+	}
+	finality := &RPCFinality{
+		Number: (*hexutil.Big)(block.Number()),
+		Hash:   block.Hash(),
+	}
+	commit, err := s.b.GetCommit(ctx, blockHash)
+	if commit == nil || err != nil {
+		return finality, err
+	}
+	var signers uint
+	for _, vote := range commit.Commits() {
+		if vote != nil && vote.BlockHash() == blockHash {
+			signers++
+		}
+	}
+	finality.Finalized = true
+	finality.Round = hexutil.Uint64(commit.Round())
+	finality.Signers = hexutil.Uint(signers)
+
+	return finality, nil
+}
+
 // GetCode returns the code stored at the given address in the state for the given block number.
 func (s *PublicBlockChainAPI) GetCode(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (hexutil.Bytes, error) {
 	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
@@ -541,22 +607,17 @@ type CallArgs struct {
 	GasPrice hexutil.Big     `json:"gasPrice"`
 	Value    hexutil.Big     `json:"value"`
 	Data     hexutil.Bytes   `json:"data"`
+	FeeCap   *hexutil.Big    `json:"feeCap"`  // maximum fee in USD cents, replaces the gas price
+	Sponsor  *common.Address `json:"sponsor"` // account paying the fees instead of the sender
 }
 
-func (s *PublicBlockChainAPI) doCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, vmCfg vm.Config, timeout time.Duration) ([]byte, uint64, bool, error) {
-	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
-
-	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
-	if state == nil || err != nil {
-		if err != nil {
-			log.Error("an error while the blockchain do a call. can't get a state and a header", "err", err)
-		}
-		return nil, 0, false, err
-	}
+// ToMessage converts the call arguments to a message. If no sender is given,
+// the first account of the account manager is used.
+func (args *CallArgs) ToMessage(am *accounts.Manager) types.Message {
 	// Set sender address or use a default if none specified
 	addr := args.From
-	if addr == (common.Address{}) {
-		if wallets := s.b.AccountManager().Wallets(); len(wallets) > 0 {
+	if addr == (common.Address{}) && am != nil {
+		if wallets := am.Wallets(); len(wallets) > 0 {
 			if accounts := wallets[0].Accounts(); len(accounts) > 0 {
 				addr = accounts[0].Address
 			}
@@ -572,7 +633,84 @@ func (s *PublicBlockChainAPI) doCall(ctx context.Context, args CallArgs, blockNr
 	}
 
 	// Create new call message
-	msg := types.NewMessage(addr, args.To, 0, args.Value.ToInt(), gas, gasPrice, args.Data, false)
+	var msg types.Message
+	if args.FeeCap != nil {
+		msg = types.NewStableFeeMessage(addr, args.To, 0, args.Value.ToInt(), gas, args.FeeCap.ToInt(), args.Data, false)
+	} else {
+		msg = types.NewMessage(addr, args.To, 0, args.Value.ToInt(), gas, gasPrice, args.Data, false)
+	}
+	if args.Sponsor != nil {
+		msg = msg.WithSponsor(*args.Sponsor)
+	}
+	return msg
+}
+
+// OverrideAccount indicates the overriding fields of account during the execution
+// of a message call.
+// Note, state and stateDiff can't be specified at the same time. If state is
+// set, message execution will only use the data in the given state. Otherwise
+// if stateDiff is set, all diff will be applied first and then execute the call
+// message.
+type OverrideAccount struct {
+	Nonce     *hexutil.Uint64              `json:"nonce"`
+	Code      *hexutil.Bytes               `json:"code"`
+	Balance   *hexutil.Big                 `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts.
+type StateOverride map[common.Address]OverrideAccount
+
+// Apply overrides the fields of specified accounts into the given state.
+func (diff *StateOverride) Apply(state *state.StateDB) error {
+	if diff == nil {
+		return nil
+	}
+	for addr, account := range *diff {
+		// Override account nonce.
+		if account.Nonce != nil {
+			state.SetNonce(addr, uint64(*account.Nonce))
+		}
+		// Override account(contract) code.
+		if account.Code != nil {
+			state.SetCode(addr, *account.Code)
+		}
+		// Override account balance.
+		if account.Balance != nil {
+			state.SetBalance(addr, (*big.Int)(account.Balance))
+		}
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		// Replace entire state if caller requires.
+		if account.State != nil {
+			state.SetStorage(addr, *account.State)
+		}
+		// Apply state diff into specified accounts.
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				state.SetState(addr, key, value)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *PublicBlockChainAPI) doCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride, vmCfg vm.Config, timeout time.Duration) ([]byte, uint64, bool, error) {
+	defer func(start time.Time) { log.Debug("Executing EVM call finished", "runtime", time.Since(start)) }(time.Now())
+
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		if err != nil {
+			log.Error("an error while the blockchain do a call. can't get a state and a header", "err", err)
+		}
+		return nil, 0, false, err
+	}
+	if err := overrides.Apply(state); err != nil {
+		return nil, 0, false, err
+	}
+	msg := args.ToMessage(s.b.AccountManager())
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -614,8 +752,12 @@ func (s *PublicBlockChainAPI) doCall(ctx context.Context, args CallArgs, blockNr
 
 // Call executes the given transaction on the state for the given block number.
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
-func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber) (hexutil.Bytes, error) {
-	result, _, _, err := s.doCall(ctx, args, blockNr, vm.Config{}, 5*time.Second)
+//
+// Additionally, the caller can specify a batch of contract for fields overriding.
+// Note, this function doesn't make any changes in the state/blockchain and is
+// useful to execute and retrieve values.
+func (s *PublicBlockChainAPI) Call(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, overrides *StateOverride) (hexutil.Bytes, error) {
+	result, _, _, err := s.doCall(ctx, args, blockNr, overrides, vm.Config{}, 5*time.Second)
 	return (hexutil.Bytes)(result), err
 }
 
@@ -644,7 +786,7 @@ func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (h
 	executable := func(gas uint64) bool {
 		args.Gas = hexutil.Uint64(gas)
 
-		_, _, failed, err := s.doCall(ctx, args, rpc.PendingBlockNumber, vm.Config{}, 0)
+		_, _, failed, err := s.doCall(ctx, args, rpc.PendingBlockNumber, nil, vm.Config{}, 0)
 		if err != nil || failed {
 			if err != nil {
 				log.Error("can't estimate gas limit", "err", err)
@@ -672,6 +814,49 @@ func (s *PublicBlockChainAPI) EstimateGas(ctx context.Context, args CallArgs) (h
 	return hexutil.Uint64(hi), nil
 }
 
+// RPCFeeEstimate is the fee of a transaction, both in kcoin and in USD cents at
+// the current oracle price.
+type RPCFeeEstimate struct {
+	Gas         hexutil.Uint64 `json:"gas"`
+	GasPrice    *hexutil.Big   `json:"gasPrice"`
+	Fee         *hexutil.Big   `json:"fee"`
+	FeeCap      *hexutil.Big   `json:"feeCap"`
+	OraclePrice *hexutil.Big   `json:"oraclePrice"`
+}
+
+// EstimateFee estimates the fee of the given transaction against the current
+// pending block. The fee cap is the fee converted to USD cents, which can be set
+// on stable fee transactions to pay the same fee regardless of kcoin price moves.
+func (s *PublicBlockChainAPI) EstimateFee(ctx context.Context, args CallArgs) (*RPCFeeEstimate, error) {
+	gas, err := s.EstimateGas(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	gasPrice := args.GasPrice.ToInt()
+	if gasPrice.Sign() == 0 {
+		if gasPrice, err = s.b.SuggestPrice(ctx); err != nil {
+			return nil, err
+		}
+	}
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, rpc.PendingBlockNumber)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	price, err := core.OraclePrice(s.b.ChainConfig(), header, state)
+	if err != nil {
+		return nil, err
+	}
+	fee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(uint64(gas)))
+
+	return &RPCFeeEstimate{
+		Gas:         gas,
+		GasPrice:    (*hexutil.Big)(gasPrice),
+		Fee:         (*hexutil.Big)(fee),
+		FeeCap:      (*hexutil.Big)(core.StableFee(fee, price)),
+		OraclePrice: (*hexutil.Big)(price),
+	}, nil
+}
+
 // ExecutionResult groups all structured logs emitted by the EVM
 // while replaying a transaction in debug mode as well as transaction
 // execution status, the amount of gas used and the return value
@@ -808,6 +993,8 @@ type RPCTransaction struct {
 	V                *hexutil.Big    `json:"v"`
 	R                *hexutil.Big    `json:"r"`
 	S                *hexutil.Big    `json:"s"`
+	FeeCap           *hexutil.Big    `json:"feeCap,omitempty"`
+	Sponsor          *common.Address `json:"sponsor,omitempty"`
 }
 
 // newRPCTransaction returns a transaction that will serialize to the RPC
@@ -830,6 +1017,12 @@ func newRPCTransaction(tx *types.Transaction, blockHash common.Hash, blockNumber
 		V:        (*hexutil.Big)(v),
 		R:        (*hexutil.Big)(r),
 		S:        (*hexutil.Big)(s),
+		FeeCap:   (*hexutil.Big)(tx.FeeCap()),
+	}
+	if tx.Sponsored() {
+		if sponsor, err := types.TxSponsor(signer, tx); err == nil {
+			result.Sponsor = &sponsor
+		}
 	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = blockHash
@@ -1048,6 +1241,12 @@ type SendTxArgs struct {
 	// newer name and should be preferred by clients.
 	Data  *hexutil.Bytes `json:"data"`
 	Input *hexutil.Bytes `json:"input"`
+	// Maximum fee in USD cents of stable fee transactions, whose gas price is
+	// derived from it at block processing time.
+	FeeCap *hexutil.Big `json:"feeCap"`
+	// Whether the fees are paid by a sponsor, who signs the transaction after
+	// the sender.
+	Sponsored bool `json:"sponsored"`
 }
 
 // setDefaults is a helper function that fills in default values for unspecified tx fields.
@@ -1056,6 +1255,12 @@ func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
 		args.Gas = new(hexutil.Uint64)
 		*(*uint64)(args.Gas) = 90000
 	}
+	if args.FeeCap != nil {
+		if args.GasPrice != nil && args.GasPrice.ToInt().Sign() != 0 {
+			return errors.New(`Both "gasPrice" and "feeCap" are set. Stable fee transactions derive the gas price from the fee cap.`)
+		}
+		args.GasPrice = new(hexutil.Big)
+	}
 	if args.GasPrice == nil {
 		price, err := b.SuggestPrice(ctx)
 		if err != nil {
@@ -1098,6 +1303,16 @@ func (args *SendTxArgs) toTransaction() *types.Transaction {
 	} else if args.Input != nil {
 		input = *args.Input
 	}
+	if args.Sponsored {
+		gasPrice, feeCap := (*big.Int)(args.GasPrice), (*big.Int)(args.FeeCap)
+		if feeCap != nil {
+			gasPrice = nil
+		}
+		return types.NewSponsoredTransaction(uint64(*args.Nonce), args.To, (*big.Int)(args.Value), uint64(*args.Gas), gasPrice, feeCap, input)
+	}
+	if args.FeeCap != nil {
+		return types.NewStableFeeTransaction(uint64(*args.Nonce), args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.FeeCap), input)
+	}
 	if args.To == nil {
 		return types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
 	}
@@ -1226,6 +1441,30 @@ func (s *PublicTransactionPoolAPI) SignTransaction(ctx context.Context, args Sen
 	return &SignTransactionResult{data, tx}, nil
 }
 
+// SignSponsorTransaction will sign the given sponsored transaction, already signed
+// by its sender, with the sponsor account. The node needs to have the private key
+// of the sponsor account and it needs to be unlocked.
+func (s *PublicTransactionPoolAPI) SignSponsorTransaction(ctx context.Context, encodedTx hexutil.Bytes, sponsor common.Address) (*SignTransactionResult, error) {
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(encodedTx, tx); err != nil {
+		return nil, err
+	}
+	account := accounts.Account{Address: sponsor}
+	wallet, err := s.b.AccountManager().Find(account)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := wallet.SignSponsorTx(account, tx, s.b.ChainConfig().ChainID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := rlp.EncodeToBytes(signed)
+	if err != nil {
+		return nil, err
+	}
+	return &SignTransactionResult{data, signed}, nil
+}
+
 // PendingTransactions returns the transactions that are in the transaction pool
 // and have a from address that is one of the accounts this node manages.
 func (s *PublicTransactionPoolAPI) PendingTransactions() ([]*RPCTransaction, error) {
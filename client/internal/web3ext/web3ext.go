@@ -7,6 +7,7 @@ var Modules = map[string]string{
 	"clique":     Clique_JS,
 	"debug":      Debug_JS,
 	"eth":        Eth_JS,
+	"governance": Governance_JS,
 	"mtoken":     MToken_JS,
 	"validator":  Validator_JS,
 	"net":        Net_JS,
@@ -115,12 +116,24 @@ web3._extend({
 		new web3._extend.Method({
 			name: 'exportChain',
 			call: 'admin_exportChain',
-			params: 1,
-			inputFormatter: [null]
+			params: 3,
+			inputFormatter: [null, null, null]
 		}),
 		new web3._extend.Method({
 			name: 'importChain',
 			call: 'admin_importChain',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'banPeer',
+			call: 'admin_banPeer',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'unbanPeer',
+			call: 'admin_unbanPeer',
 			params: 1
 		}),
 		new web3._extend.Method({
@@ -155,6 +168,10 @@ web3._extend({
 			name: 'nodeInfo',
 			getter: 'admin_nodeInfo'
 		}),
+		new web3._extend.Property({
+			name: 'bans',
+			getter: 'admin_bans'
+		}),
 		new web3._extend.Property({
 			name: 'peers',
 			getter: 'admin_peers'
@@ -348,6 +365,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [null, null]
 		}),
+		new web3._extend.Method({
+			name: 'traceCall',
+			call: 'debug_traceCall',
+			params: 3,
+			inputFormatter: [null, null, null]
+		}),
 		new web3._extend.Method({
 			name: 'preimage',
 			call: 'debug_preimage',
@@ -415,6 +438,22 @@ web3._extend({
 			call: 'eth_getRawTransactionByHash',
 			params: 1
 		}),
+		new web3._extend.Method({
+			name: 'getFinality',
+			call: 'eth_getFinality',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'estimateFee',
+			call: 'eth_estimateFee',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'signSponsorTransaction',
+			call: 'eth_signSponsorTransaction',
+			params: 2,
+			inputFormatter: [null, web3._extend.formatters.inputAddressFormatter]
+		}),
 		new web3._extend.Method({
 			name: 'getRawTransactionFromBlock',
 			call: function(args) {
@@ -477,6 +516,82 @@ web3._extend({
 });
 `
 
+const Governance_JS = `
+web3._extend({
+	property: 'governance',
+	methods:
+	[
+		new web3._extend.Method({
+			name: 'getTransactions',
+			call: 'governance_transactions',
+			params: 2,
+			inputFormatter: [null, null]
+		}),
+		new web3._extend.Method({
+			name: 'getTransaction',
+			call: 'governance_transaction',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'submit',
+			call: 'governance_submit',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputTransactionFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'confirm',
+			call: 'governance_confirm',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'revoke',
+			call: 'governance_revoke',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'execute',
+			call: 'governance_execute',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'setMaxValidators',
+			call: 'governance_setMaxValidators',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'setBaseDeposit',
+			call: 'governance_setBaseDeposit',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'pause',
+			call: 'governance_pause',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		}),
+		new web3._extend.Method({
+			name: 'unpause',
+			call: 'governance_unpause',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, null]
+		})
+	],
+	properties:
+	[
+		new web3._extend.Property({
+			name: 'info',
+			getter: 'governance_info'
+		})
+	]
+});
+`
+
 const Validator_JS = `
 web3._extend({
 	property: 'validator',
@@ -533,6 +648,53 @@ web3._extend({
 			name: 'redeemDeposits',
 			call: 'validator_redeemDeposits'
 		}),
+		new web3._extend.Method({
+			name: 'rotateKey',
+			call: 'validator_rotateKey',
+			params: 2,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.utils.fromDecimal]
+		}),
+		new web3._extend.Method({
+			name: 'topUpDeposit',
+			call: 'validator_topUpDeposit',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal],
+			outputFormatter: web3._extend.formatters.outputBigNumberFormatter
+		}),
+		new web3._extend.Method({
+			name: 'withdrawDeposit',
+			call: 'validator_withdrawDeposit',
+			params: 1,
+			inputFormatter: [web3._extend.utils.fromDecimal],
+			outputFormatter: web3._extend.formatters.outputBigNumberFormatter
+		}),
+		new web3._extend.Method({
+			name: 'setAutoRedeem',
+			call: 'validator_setAutoRedeem',
+			params: 1
+		}),
+		new web3._extend.Method({
+			name: 'autoRedeem',
+			call: 'validator_autoRedeem'
+		}),
+		new web3._extend.Method({
+			name: 'getParticipation',
+			call: 'validator_getParticipation',
+			params: 1,
+			inputFormatter: [web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getUptime',
+			call: 'validator_getUptime',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
+		new web3._extend.Method({
+			name: 'getRewards',
+			call: 'validator_getRewards',
+			params: 3,
+			inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputBlockNumberFormatter, web3._extend.formatters.inputBlockNumberFormatter]
+		}),
 	],
 	properties: []
 });
@@ -589,6 +751,12 @@ web3._extend({
 			params: 2,
 			inputFormatter: [web3._extend.formatters.inputTransactionFormatter, null]
 		}),
+		new web3._extend.Method({
+			name: 'signSponsorTransaction',
+			call: 'personal_signSponsorTransaction',
+			params: 3,
+			inputFormatter: [null, web3._extend.formatters.inputAddressFormatter, null]
+		}),
 	],
 	properties:
 	[
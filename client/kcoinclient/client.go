@@ -140,6 +140,9 @@ func (ec *Client) getBlock(ctx context.Context, method string, args ...interface
 	for i, tx := range body.Transactions {
 		if tx.From != nil {
 			setSenderFromServer(tx.tx, *tx.From, body.Hash)
+			if tx.Sponsor != nil {
+				setSponsorFromServer(tx.tx, *tx.Sponsor, body.Hash)
+			}
 		}
 		txs[i] = tx.tx
 	}
@@ -176,6 +179,7 @@ type txExtraInfo struct {
 	BlockNumber *string         `json:"blockNumber,omitempty"`
 	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
 	From        *common.Address `json:"from,omitempty"`
+	Sponsor     *common.Address `json:"sponsor,omitempty"`
 }
 
 func (tx *rpcTransaction) UnmarshalJSON(msg []byte) error {
@@ -198,6 +202,9 @@ func (ec *Client) TransactionByHash(ctx context.Context, hash common.Hash) (tx *
 	}
 	if json.From != nil && json.BlockHash != nil {
 		setSenderFromServer(json.tx, *json.From, *json.BlockHash)
+		if json.Sponsor != nil {
+			setSponsorFromServer(json.tx, *json.Sponsor, *json.BlockHash)
+		}
 	}
 	return json.tx, json.BlockNumber == nil, nil
 }
@@ -227,6 +234,34 @@ func (ec *Client) TransactionSender(ctx context.Context, tx *types.Transaction,
 	return meta.From, nil
 }
 
+// TransactionSponsor returns the address paying the fees of the given sponsored transaction.
+// The transaction must be known to the remote node and included in the blockchain at the
+// given block and index, like in TransactionSender.
+func (ec *Client) TransactionSponsor(ctx context.Context, tx *types.Transaction, block common.Hash, index uint) (common.Address, error) {
+	if !tx.Sponsored() {
+		return common.Address{}, types.ErrNotSponsored
+	}
+	// Try to load the address from the cache.
+	sponsor, err := types.TxSponsor(&senderFromServer{blockhash: block}, tx)
+	if err == nil {
+		return sponsor, nil
+	}
+	var meta struct {
+		Hash    common.Hash
+		Sponsor *common.Address
+	}
+	if err = ec.c.CallContext(ctx, &meta, "eth_getTransactionByBlockHashAndIndex", block, hexutil.Uint64(index)); err != nil {
+		return common.Address{}, err
+	}
+	if meta.Hash == (common.Hash{}) || meta.Hash != tx.Hash() {
+		return common.Address{}, errors.New("wrong inclusion block/index")
+	}
+	if meta.Sponsor == nil {
+		return common.Address{}, types.ErrMissingSponsor
+	}
+	return *meta.Sponsor, nil
+}
+
 // TransactionCount returns the total number of transactions in the given block.
 func (ec *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
 	var num hexutil.Uint
@@ -247,6 +282,9 @@ func (ec *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash,
 	}
 	if json.From != nil && json.BlockHash != nil {
 		setSenderFromServer(json.tx, *json.From, *json.BlockHash)
+		if json.Sponsor != nil {
+			setSponsorFromServer(json.tx, *json.Sponsor, *json.BlockHash)
+		}
 	}
 	return json.tx, err
 }
@@ -310,6 +348,12 @@ func (ec *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header)
 	return ec.c.KowalaSubscribe(ctx, ch, "newHeads", map[string]struct{}{})
 }
 
+// SubscribeNewFinalizedHead subscribes to notifications about the blocks that
+// become final, once their commit is recorded in the chain, on the given channel.
+func (ec *Client) SubscribeNewFinalizedHead(ctx context.Context, ch chan<- *types.Header) (kowala.Subscription, error) {
+	return ec.c.KowalaSubscribe(ctx, ch, "newFinalizedHeads")
+}
+
 // State Access
 
 // NetworkID returns the network ID (also known as the chain ID) for this chain.
@@ -488,6 +532,38 @@ func (ec *Client) EstimateGas(ctx context.Context, msg kowala.CallMsg) (uint64,
 	return uint64(hex), nil
 }
 
+// FeeEstimate is the fee of a transaction, both in kcoin wei and in USD cents at
+// the oracle price of the current pending block.
+type FeeEstimate struct {
+	Gas         uint64
+	GasPrice    *big.Int
+	Fee         *big.Int
+	FeeCap      *big.Int
+	OraclePrice *big.Int
+}
+
+// EstimateFee estimates the fee of the given transaction against the current
+// pending block, along with the oracle price that converts it to USD cents.
+func (ec *Client) EstimateFee(ctx context.Context, msg kowala.CallMsg) (*FeeEstimate, error) {
+	var estimate struct {
+		Gas         hexutil.Uint64 `json:"gas"`
+		GasPrice    *hexutil.Big   `json:"gasPrice"`
+		Fee         *hexutil.Big   `json:"fee"`
+		FeeCap      *hexutil.Big   `json:"feeCap"`
+		OraclePrice *hexutil.Big   `json:"oraclePrice"`
+	}
+	if err := ec.c.CallContext(ctx, &estimate, "eth_estimateFee", toCallArg(msg)); err != nil {
+		return nil, err
+	}
+	return &FeeEstimate{
+		Gas:         uint64(estimate.Gas),
+		GasPrice:    (*big.Int)(estimate.GasPrice),
+		Fee:         (*big.Int)(estimate.Fee),
+		FeeCap:      (*big.Int)(estimate.FeeCap),
+		OraclePrice: (*big.Int)(estimate.OraclePrice),
+	}, nil
+}
+
 // SendTransaction injects a signed transaction into the pending pool for execution.
 //
 // If the transaction was a contract creation use the TransactionReceipt method to get the
@@ -525,5 +601,11 @@ func toCallArg(msg kowala.CallMsg) interface{} {
 	if msg.GasPrice != nil {
 		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
 	}
+	if msg.FeeCap != nil {
+		arg["feeCap"] = (*hexutil.Big)(msg.FeeCap)
+	}
+	if msg.Sponsor != nil {
+		arg["sponsor"] = msg.Sponsor
+	}
 	return arg
 }
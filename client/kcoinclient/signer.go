@@ -8,9 +8,9 @@ import (
 	"github.com/kowala-tech/kcoin/client/core/types"
 )
 
-// senderFromServer is a types.Signer that remembers the sender address returned by the RPC
-// server. It is stored in the transaction's sender address cache to avoid an additional
-// request in TransactionSender.
+// senderFromServer is a types.Signer that remembers the sender or sponsor address returned
+// by the RPC server. It is stored in the transaction's address caches to avoid an additional
+// request in TransactionSender and TransactionSponsor.
 type senderFromServer struct {
 	addr      common.Address
 	blockhash common.Hash
@@ -23,6 +23,11 @@ func setSenderFromServer(tx *types.Transaction, addr common.Address, block commo
 	types.TxSender(&senderFromServer{addr, block}, tx)
 }
 
+func setSponsorFromServer(tx *types.Transaction, addr common.Address, block common.Hash) {
+	// Use types.TxSponsor for side-effect to store our signer into the cache.
+	types.TxSponsor(&senderFromServer{addr, block}, tx)
+}
+
 func (s *senderFromServer) Equal(other types.Signer) bool {
 	os, ok := other.(*senderFromServer)
 	return ok && os.blockhash == s.blockhash
@@ -35,9 +40,19 @@ func (s *senderFromServer) Sender(_ types.Sender) (common.Address, error) {
 	return s.addr, nil
 }
 
+func (s *senderFromServer) Sponsor(_ *types.Transaction) (common.Address, error) {
+	if s.blockhash == (common.Hash{}) {
+		return common.Address{}, errNotCached
+	}
+	return s.addr, nil
+}
+
 func (s *senderFromServer) Hash(_ types.Hasher) common.Hash {
 	panic("can't sign with senderFromServer")
 }
+func (s *senderFromServer) SponsorHash(_ *types.Transaction) common.Hash {
+	panic("can't sign with senderFromServer")
+}
 func (s *senderFromServer) SignatureValues(_ []byte) (R, S, V *big.Int, err error) {
 	panic("can't sign with senderFromServer")
 }
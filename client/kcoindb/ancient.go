@@ -0,0 +1,319 @@
+package kcoindb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// The ancient store keeps one append-only table per kind of data.
+const (
+	// AncientHashes is the table of canonical block hashes.
+	AncientHashes = "hashes"
+
+	// AncientBodies is the table of RLP encoded block bodies.
+	AncientBodies = "bodies"
+
+	// AncientReceipts is the table of RLP encoded block receipts.
+	AncientReceipts = "receipts"
+)
+
+// ancientKinds lists the tables every ancient store is made of. All tables
+// always hold the same number of items.
+var ancientKinds = []string{AncientHashes, AncientBodies, AncientReceipts}
+
+var (
+	// errOutOfOrder is returned if an item is appended with a number that is not
+	// the next one in the store.
+	errOutOfOrder = errors.New("ancient item appended out of order")
+
+	// errUnknownKind is returned if the requested ancient table does not exist.
+	errUnknownKind = errors.New("unknown ancient kind")
+
+	// errOutOfBounds is returned if the requested item is not in the store.
+	errOutOfBounds = errors.New("ancient item out of bounds")
+)
+
+// indexEntrySize is the size of a single index entry, the big endian end offset
+// of the item within the data file.
+const indexEntrySize = 8
+
+// ancientTable is an append-only flat file holding consecutive items. The items
+// live back to back in a data file, and an index file records the end offset of
+// every item.
+type ancientTable struct {
+	data  *os.File
+	index *os.File
+
+	items uint64 // Number of items stored in the table
+	size  uint64 // Size of the valid part of the data file
+}
+
+// newAncientTable opens (or creates) the table files for the given kind,
+// dropping any data that is not covered by the index.
+func newAncientTable(dir, kind string) (*ancientTable, error) {
+	index, err := os.OpenFile(filepath.Join(dir, kind+".ridx"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.OpenFile(filepath.Join(dir, kind+".rdat"), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		index.Close()
+		return nil, err
+	}
+	t := &ancientTable{data: data, index: index}
+	if err := t.repair(); err != nil {
+		t.Close()
+		return nil, err
+	}
+	return t, nil
+}
+
+// repair truncates partially written index entries and any trailing data that
+// was written without a matching index entry.
+func (t *ancientTable) repair() error {
+	stat, err := t.index.Stat()
+	if err != nil {
+		return err
+	}
+	items := uint64(stat.Size()) / indexEntrySize
+	for ; items > 0; items-- {
+		end, err := t.offset(items)
+		if err != nil {
+			return err
+		}
+		stat, err := t.data.Stat()
+		if err != nil {
+			return err
+		}
+		if end <= uint64(stat.Size()) {
+			break
+		}
+	}
+	return t.truncate(items)
+}
+
+// offset returns the end offset of the n-th item, with the zeroth item ending
+// at the start of the data file.
+func (t *ancientTable) offset(n uint64) (uint64, error) {
+	if n == 0 {
+		return 0, nil
+	}
+	var buf [indexEntrySize]byte
+	if _, err := t.index.ReadAt(buf[:], int64((n-1)*indexEntrySize)); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// truncate drops all items from the given position onwards.
+func (t *ancientTable) truncate(items uint64) error {
+	size, err := t.offset(items)
+	if err != nil {
+		return err
+	}
+	if err := t.index.Truncate(int64(items * indexEntrySize)); err != nil {
+		return err
+	}
+	if err := t.data.Truncate(int64(size)); err != nil {
+		return err
+	}
+	t.items, t.size = items, size
+	return nil
+}
+
+// append writes a new item at the end of the table.
+func (t *ancientTable) append(blob []byte) error {
+	if _, err := t.data.WriteAt(blob, int64(t.size)); err != nil {
+		return err
+	}
+	var buf [indexEntrySize]byte
+	binary.BigEndian.PutUint64(buf[:], t.size+uint64(len(blob)))
+	if _, err := t.index.WriteAt(buf[:], int64(t.items*indexEntrySize)); err != nil {
+		return err
+	}
+	t.items++
+	t.size += uint64(len(blob))
+	return nil
+}
+
+// retrieve reads the n-th item of the table.
+func (t *ancientTable) retrieve(n uint64) ([]byte, error) {
+	if n >= t.items {
+		return nil, errOutOfBounds
+	}
+	start, err := t.offset(n)
+	if err != nil {
+		return nil, err
+	}
+	end, err := t.offset(n + 1)
+	if err != nil {
+		return nil, err
+	}
+	blob := make([]byte, end-start)
+	if _, err := t.data.ReadAt(blob, int64(start)); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// Sync flushes the table files to disk.
+func (t *ancientTable) Sync() error {
+	if err := t.data.Sync(); err != nil {
+		return err
+	}
+	return t.index.Sync()
+}
+
+// Close closes the table files.
+func (t *ancientTable) Close() error {
+	var errs []error
+	if err := t.data.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := t.index.Close(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
+
+// AncientStore is an append-only flat-file store for chain data that is old
+// enough to never change again. Items are keyed by block number, starting
+// from the genesis block.
+type AncientStore struct {
+	tables map[string]*ancientTable
+	items  uint64
+	lock   sync.RWMutex
+}
+
+// NewAncientStore opens the ancient store in the given directory, creating it
+// if it does not exist yet.
+func NewAncientStore(dir string) (*AncientStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	store := &AncientStore{tables: make(map[string]*ancientTable)}
+	for _, kind := range ancientKinds {
+		table, err := newAncientTable(dir, kind)
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+		store.tables[kind] = table
+	}
+	// A crash in the middle of an append can leave the tables with different
+	// lengths, so cut all of them back to the shortest one.
+	store.items = store.tables[ancientKinds[0]].items
+	for _, table := range store.tables {
+		if table.items < store.items {
+			store.items = table.items
+		}
+	}
+	for _, table := range store.tables {
+		if err := table.truncate(store.items); err != nil {
+			store.Close()
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+// HasAncient returns whether the ancient table of the given kind holds the
+// item of the given number.
+func (s *AncientStore) HasAncient(kind string, number uint64) (bool, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if _, ok := s.tables[kind]; !ok {
+		return false, errUnknownKind
+	}
+	return number < s.items, nil
+}
+
+// Ancient retrieves the item of the given number from the ancient table of the
+// given kind.
+func (s *AncientStore) Ancient(kind string, number uint64) ([]byte, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	table, ok := s.tables[kind]
+	if !ok {
+		return nil, errUnknownKind
+	}
+	if number >= s.items {
+		return nil, errOutOfBounds
+	}
+	return table.retrieve(number)
+}
+
+// Ancients returns the number of items in the ancient store.
+func (s *AncientStore) Ancients() (uint64, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.items, nil
+}
+
+// AppendAncient appends the canonical hash, body and receipts of the block of
+// the given number. Blocks must be appended in order.
+func (s *AncientStore) AppendAncient(number uint64, hash, body, receipts []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if number != s.items {
+		return errOutOfOrder
+	}
+	blobs := map[string][]byte{
+		AncientHashes:   hash,
+		AncientBodies:   body,
+		AncientReceipts: receipts,
+	}
+	for _, kind := range ancientKinds {
+		if err := s.tables[kind].append(blobs[kind]); err != nil {
+			// Roll back the tables that were already written to.
+			for _, table := range s.tables {
+				table.truncate(s.items)
+			}
+			return err
+		}
+	}
+	s.items++
+	return nil
+}
+
+// Sync flushes all the ancient tables to disk.
+func (s *AncientStore) Sync() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for _, table := range s.tables {
+		if err := table.Sync(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes all the ancient tables.
+func (s *AncientStore) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var errs []error
+	for _, table := range s.tables {
+		if err := table.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+	return nil
+}
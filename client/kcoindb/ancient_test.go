@@ -0,0 +1,125 @@
+package kcoindb_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+)
+
+func newTestAncientStore(t *testing.T) (string, func()) {
+	dirname, err := ioutil.TempDir(os.TempDir(), "kcoindb_ancient_test_")
+	if err != nil {
+		t.Fatalf("failed to create test dir: %v", err)
+	}
+	return dirname, func() { os.RemoveAll(dirname) }
+}
+
+func TestAncientStore_AppendRetrieve(t *testing.T) {
+	dir, remove := newTestAncientStore(t)
+	defer remove()
+
+	store, err := kcoindb.NewAncientStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open ancient store: %v", err)
+	}
+	for i := uint64(0); i < 10; i++ {
+		hash, body, receipts := []byte(fmt.Sprintf("hash-%d", i)), bytes.Repeat([]byte{byte(i)}, int(i)), []byte(fmt.Sprintf("receipts-%d", i))
+		if err := store.AppendAncient(i, hash, body, receipts); err != nil {
+			t.Fatalf("append %d failed: %v", i, err)
+		}
+	}
+	if err := store.AppendAncient(20, nil, nil, nil); err == nil {
+		t.Fatalf("out of order append succeeded")
+	}
+	if err := store.Sync(); err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	store.Close()
+
+	// Reopen the store and check that all the items survived
+	store, err = kcoindb.NewAncientStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen ancient store: %v", err)
+	}
+	defer store.Close()
+
+	if items, _ := store.Ancients(); items != 10 {
+		t.Fatalf("item count mismatch: have %d, want %d", items, 10)
+	}
+	for i := uint64(0); i < 10; i++ {
+		body, err := store.Ancient(kcoindb.AncientBodies, i)
+		if err != nil {
+			t.Fatalf("retrieve %d failed: %v", i, err)
+		}
+		if want := bytes.Repeat([]byte{byte(i)}, int(i)); !bytes.Equal(body, want) {
+			t.Errorf("body %d mismatch: have %x, want %x", i, body, want)
+		}
+		receipts, err := store.Ancient(kcoindb.AncientReceipts, i)
+		if err != nil {
+			t.Fatalf("retrieve %d failed: %v", i, err)
+		}
+		if want := []byte(fmt.Sprintf("receipts-%d", i)); !bytes.Equal(receipts, want) {
+			t.Errorf("receipts %d mismatch: have %s, want %s", i, receipts, want)
+		}
+	}
+	if has, _ := store.HasAncient(kcoindb.AncientHashes, 10); has {
+		t.Errorf("item beyond the end reported as present")
+	}
+	if _, err := store.Ancient("headers", 0); err == nil {
+		t.Errorf("unknown kind retrieved")
+	}
+}
+
+func TestAncientStore_Repair(t *testing.T) {
+	dir, remove := newTestAncientStore(t)
+	defer remove()
+
+	store, err := kcoindb.NewAncientStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open ancient store: %v", err)
+	}
+	for i := uint64(0); i < 5; i++ {
+		if err := store.AppendAncient(i, []byte{byte(i)}, []byte{byte(i)}, []byte{byte(i)}); err != nil {
+			t.Fatalf("append %d failed: %v", i, err)
+		}
+	}
+	store.Close()
+
+	// Simulate a crash in the middle of an append: the receipts table misses
+	// the last item and the bodies table has a torn index entry.
+	receipts := filepath.Join(dir, kcoindb.AncientReceipts+".ridx")
+	if err := os.Truncate(receipts, 4*8); err != nil {
+		t.Fatalf("failed to truncate receipts index: %v", err)
+	}
+	bodies := filepath.Join(dir, kcoindb.AncientBodies+".ridx")
+	if err := os.Truncate(bodies, 4*8+3); err != nil {
+		t.Fatalf("failed to truncate bodies index: %v", err)
+	}
+	store, err = kcoindb.NewAncientStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen ancient store: %v", err)
+	}
+	defer store.Close()
+
+	if items, _ := store.Ancients(); items != 4 {
+		t.Fatalf("item count mismatch: have %d, want %d", items, 4)
+	}
+	// The next append must continue right after the repaired items
+	if err := store.AppendAncient(4, []byte{0xff}, []byte{0xff}, []byte{0xff}); err != nil {
+		t.Fatalf("append after repair failed: %v", err)
+	}
+	for _, kind := range []string{kcoindb.AncientHashes, kcoindb.AncientBodies, kcoindb.AncientReceipts} {
+		blob, err := store.Ancient(kind, 4)
+		if err != nil {
+			t.Fatalf("retrieve %s failed: %v", kind, err)
+		}
+		if !bytes.Equal(blob, []byte{0xff}) {
+			t.Errorf("%s mismatch: have %x, want ff", kind, blob)
+		}
+	}
+}
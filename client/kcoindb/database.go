@@ -25,10 +25,16 @@ const (
 
 var OpenFileLimit = 64
 
+// errNoAncientStore is returned when accessing ancient data of a database that
+// was opened without an ancient store.
+var errNoAncientStore = errors.New("no ancient store")
+
 type LDBDatabase struct {
 	fn string      // filename for reporting
 	db *leveldb.DB // LevelDB instance
 
+	ancients *AncientStore // Flat-file store for immutable chain data (optional)
+
 	compTimeMeter    metrics.Meter // Meter for measuring the total time spent in database compaction
 	compReadMeter    metrics.Meter // Meter for measuring the data read during compaction
 	compWriteMeter   metrics.Meter // Meter for measuring the data written during compaction
@@ -77,6 +83,23 @@ func NewLDBDatabase(file string, cache int, handles int) (*LDBDatabase, error) {
 	}, nil
 }
 
+// NewLDBDatabaseWithAncients returns a LevelDB wrapped object backed by an
+// ancient store in the given directory for immutable chain data.
+func NewLDBDatabaseWithAncients(file string, ancient string, cache int, handles int) (*LDBDatabase, error) {
+	db, err := NewLDBDatabase(file, cache, handles)
+	if err != nil {
+		return nil, err
+	}
+	ancients, err := NewAncientStore(ancient)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	db.ancients = ancients
+	db.log.Info("Opened ancient store", "path", ancient)
+	return db, nil
+}
+
 // Path returns the path to the database directory.
 func (db *LDBDatabase) Path() string {
 	return db.fn
@@ -114,6 +137,47 @@ func (db *LDBDatabase) NewIteratorWithPrefix(prefix []byte) iterator.Iterator {
 	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
 }
 
+// HasAncient returns whether an ancient item of the given kind exists.
+func (db *LDBDatabase) HasAncient(kind string, number uint64) (bool, error) {
+	if db.ancients == nil {
+		return false, nil
+	}
+	return db.ancients.HasAncient(kind, number)
+}
+
+// Ancient retrieves an ancient item of the given kind.
+func (db *LDBDatabase) Ancient(kind string, number uint64) ([]byte, error) {
+	if db.ancients == nil {
+		return nil, errNoAncientStore
+	}
+	return db.ancients.Ancient(kind, number)
+}
+
+// Ancients returns the number of items in the ancient store, zero if the
+// database has none.
+func (db *LDBDatabase) Ancients() (uint64, error) {
+	if db.ancients == nil {
+		return 0, nil
+	}
+	return db.ancients.Ancients()
+}
+
+// AppendAncient appends the data of the next block to the ancient store.
+func (db *LDBDatabase) AppendAncient(number uint64, hash, body, receipts []byte) error {
+	if db.ancients == nil {
+		return errNoAncientStore
+	}
+	return db.ancients.AppendAncient(number, hash, body, receipts)
+}
+
+// SyncAncients flushes the ancient store to disk.
+func (db *LDBDatabase) SyncAncients() error {
+	if db.ancients == nil {
+		return nil
+	}
+	return db.ancients.Sync()
+}
+
 func (db *LDBDatabase) Close() {
 	// Stop the metrics collection to avoid internal database races
 	db.quitLock.Lock()
@@ -127,6 +191,11 @@ func (db *LDBDatabase) Close() {
 		}
 		db.quitChan = nil
 	}
+	if db.ancients != nil {
+		if err := db.ancients.Close(); err != nil {
+			db.log.Error("Failed to close ancient store", "err", err)
+		}
+	}
 	err := db.db.Close()
 	if err == nil {
 		db.log.Info("Database closed")
@@ -1,5 +1,7 @@
 package kcoindb
 
+import "github.com/syndtr/goleveldb/leveldb/iterator"
+
 // Code using batches should try to add this much data to the batch.
 // The value was determined empirically.
 const IdealBatchSize = 100 * 1024
@@ -34,3 +36,32 @@ type Batch interface {
 	// Reset resets the batch for reuse
 	Reset()
 }
+
+// Iteratee wraps the NewIterator method of a backing data store.
+type Iteratee interface {
+	// NewIterator creates an iterator over the entire key space of the store.
+	NewIterator() iterator.Iterator
+}
+
+// AncientReader wraps the read methods of an ancient store, which keeps
+// immutable chain data in append-only flat files.
+type AncientReader interface {
+	// HasAncient returns whether an ancient item of the given kind exists.
+	HasAncient(kind string, number uint64) (bool, error)
+
+	// Ancient retrieves an ancient item of the given kind.
+	Ancient(kind string, number uint64) ([]byte, error)
+
+	// Ancients returns the number of items in the ancient store.
+	Ancients() (uint64, error)
+}
+
+// AncientWriter wraps the write methods of an ancient store.
+type AncientWriter interface {
+	// AppendAncient appends the canonical hash, body and receipts of the next
+	// block to the ancient store.
+	AppendAncient(number uint64, hash, body, receipts []byte) error
+
+	// SyncAncients flushes the ancient store to disk.
+	SyncAncients() error
+}
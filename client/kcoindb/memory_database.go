@@ -5,6 +5,9 @@ import (
 	"sync"
 
 	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/syndtr/goleveldb/leveldb/comparer"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/memdb"
 )
 
 /*
@@ -64,6 +67,18 @@ func (db *MemDatabase) Keys() [][]byte {
 	return keys
 }
 
+// NewIterator returns an iterator over a snapshot of the database content.
+func (db *MemDatabase) NewIterator() iterator.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	snapshot := memdb.New(comparer.DefaultComparer, 0)
+	for key, value := range db.db {
+		snapshot.Put([]byte(key), value)
+	}
+	return snapshot.NewIterator(nil)
+}
+
 func (db *MemDatabase) Delete(key []byte) error {
 	db.lock.Lock()
 	defer db.lock.Unlock()
@@ -21,6 +21,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/internal/kcoinapi"
 	"github.com/kowala-tech/kcoin/client/knode/validator"
+	"github.com/kowala-tech/kcoin/client/p2p/discover"
 	"github.com/kowala-tech/kcoin/client/params"
 	"github.com/kowala-tech/kcoin/client/rlp"
 	"github.com/kowala-tech/kcoin/client/rpc"
@@ -111,14 +112,23 @@ func (api *PrivateValidatorAPI) GetMinimumDeposit() (*big.Int, error) {
 	return api.kcoin.GetMinimumDeposit()
 }
 
+// Deposit statuses reported by validator_getDeposits.
+const (
+	depositStaked     = "staked"     // Deposit of a validator in the set
+	depositLocked     = "locked"     // Deposit in its freeze period
+	depositRedeemable = "redeemable" // Deposit past its freeze period
+)
+
 // GetDepositsResult is the result of a validator_getDeposits API call.
 type GetDepositsResult struct {
 	Deposits []depositEntry `json:"deposits"`
 }
 
 type depositEntry struct {
-	Amount      *big.Int `json:"value"`
-	AvailableAt string   `json:",omitempty"`
+	Amount          *big.Int `json:"value"`
+	AvailableAt     string   `json:",omitempty"`
+	AvailableAtUnix int64    `json:"availableAtUnix,omitempty"`
+	Status          string   `json:"status"`
 }
 
 // GetDeposits returns the validator deposits
@@ -128,29 +138,363 @@ func (api *PrivateValidatorAPI) GetDeposits(address *common.Address) (GetDeposit
 		return GetDepositsResult{}, err
 	}
 
-	return depositsToResponse(rawDeposits), nil
+	// the validator manager releases the deposits as of the block time
+	now := api.kcoin.BlockChain().CurrentBlock().Time().Int64()
+	return depositsToResponse(rawDeposits, now), nil
 }
 
-func depositsToResponse(rawDeposits []*types.Deposit) GetDepositsResult {
+func depositsToResponse(rawDeposits []*types.Deposit, now int64) GetDepositsResult {
 	deposits := make([]depositEntry, len(rawDeposits))
 
 	for i, deposit := range rawDeposits {
 		// @NOTE (rgeraldes) - zero values are not shown for this field
 		var availableAt string
 
+		status := depositStaked
 		if deposit.AvailableAtTimeUnix() != 0 {
 			availableAt = time.Unix(deposit.AvailableAtTimeUnix(), 0).String()
+			status = depositLocked
+			if deposit.AvailableAtTimeUnix() <= now {
+				status = depositRedeemable
+			}
 		}
 
 		deposits[i] = depositEntry{
-			Amount:      deposit.Amount(),
-			AvailableAt: availableAt,
+			Amount:          deposit.Amount(),
+			AvailableAt:     availableAt,
+			AvailableAtUnix: deposit.AvailableAtTimeUnix(),
+			Status:          status,
 		}
 	}
 
 	return GetDepositsResult{Deposits: deposits}
 }
 
+// TopUpDeposit raises the deposit at stake of the running validator, which
+// leaves the election and rejoins it with the new deposit without a restart.
+// The validator manager can't top up a deposit, so the cost is that of a new
+// registration: the whole new deposit, not just the amount, is locked from the
+// liquid mUSD balance of the validator account, and the previous deposit stays
+// locked until the end of its freeze period. The validator doesn't take part
+// in the elections between leaving and rejoining.
+func (api *PrivateValidatorAPI) TopUpDeposit(amount hexutil.Big) (*hexutil.Big, error) {
+	deposit, err := api.kcoin.Validator().TopUpDeposit(amount.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	api.kcoin.lock.Lock()
+	api.kcoin.deposit = deposit
+	api.kcoin.lock.Unlock()
+
+	return (*hexutil.Big)(deposit), nil
+}
+
+// WithdrawDeposit lowers the deposit at stake of the running validator by the
+// given amount, the validator leaving the election and rejoining it with the
+// lower deposit without a restart. The validator manager only releases whole
+// deposits, so the withdrawn amount isn't available right away: the lower
+// deposit is locked in full from the liquid mUSD balance of the validator
+// account, and the whole previous deposit is released at the end of its freeze
+// period.
+func (api *PrivateValidatorAPI) WithdrawDeposit(amount hexutil.Big) (*hexutil.Big, error) {
+	deposit, err := api.kcoin.Validator().WithdrawDeposit(amount.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	api.kcoin.lock.Lock()
+	api.kcoin.deposit = deposit
+	api.kcoin.lock.Unlock()
+
+	return (*hexutil.Big)(deposit), nil
+}
+
+// SetAutoRedeem enables or disables the redemption of the deposits as soon as
+// their freeze period ends.
+func (api *PrivateValidatorAPI) SetAutoRedeem(enabled bool) bool {
+	api.kcoin.Validator().SetAutoRedeem(enabled)
+	return true
+}
+
+// AutoRedeem reports whether the deposits are redeemed as soon as their freeze
+// period ends.
+func (api *PrivateValidatorAPI) AutoRedeem() bool {
+	return api.kcoin.Validator().AutoRedeem()
+}
+
+// DepositEvent is a change of the deposits of a validator.
+type DepositEvent struct {
+	Type      string         `json:"type"` // "rebalanced" or "redeemed"
+	Validator common.Address `json:"validator"`
+	Previous  *hexutil.Big   `json:"previous,omitempty"`
+	Deposit   *hexutil.Big   `json:"deposit,omitempty"`
+	Amount    *hexutil.Big   `json:"amount,omitempty"`
+}
+
+// DepositEvents creates a subscription notified of the deposit rebalances and
+// redemptions of the validator.
+func (api *PrivateValidatorAPI) DepositEvents(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		sub := api.kcoin.EventMux().Subscribe(core.DepositRebalancedEvent{}, core.DepositsRedeemedEvent{})
+		defer sub.Unsubscribe()
+
+		for {
+			select {
+			case ev, ok := <-sub.Chan():
+				if !ok {
+					return
+				}
+				switch ev := ev.Data.(type) {
+				case core.DepositRebalancedEvent:
+					notifier.Notify(rpcSub.ID, &DepositEvent{
+						Type:      "rebalanced",
+						Validator: ev.Validator,
+						Previous:  (*hexutil.Big)(ev.Previous),
+						Deposit:   (*hexutil.Big)(ev.Deposit),
+					})
+				case core.DepositsRedeemedEvent:
+					notifier.Notify(rpcSub.ID, &DepositEvent{
+						Type:      "redeemed",
+						Validator: ev.Validator,
+						Amount:    (*hexutil.Big)(ev.Amount),
+					})
+				}
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+
+	return rpcSub, nil
+}
+
+// maxRewardsRange is the maximum number of blocks of a validator_getRewards or
+// validator_getUptime lookup, longer periods being paginated by the caller.
+const maxRewardsRange = 100000
+
+// RPCBlockRewards is the rewards of a block proposed by the validator.
+type RPCBlockRewards struct {
+	Number       hexutil.Uint64   `json:"number"`
+	Hash         common.Hash      `json:"hash"`
+	Time         hexutil.Uint64   `json:"timestamp"`
+	Reward       *hexutil.Big     `json:"reward"`
+	Fees         *hexutil.Big     `json:"fees"`
+	UnpricedTxs  hexutil.Uint64   `json:"unpricedTxs"`
+	Participants []common.Address `json:"participants"`
+}
+
+// GetRewardsResult is the result of a validator_getRewards API call.
+type GetRewardsResult struct {
+	Address      common.Address     `json:"address"`
+	From         hexutil.Uint64     `json:"from"`
+	To           hexutil.Uint64     `json:"to"`
+	Blocks       []*RPCBlockRewards `json:"blocks"`       // Blocks proposed by the validator
+	Participated hexutil.Uint64     `json:"participated"` // Blocks pre-committed by the validator
+	TotalReward  *hexutil.Big       `json:"totalReward"`
+	TotalFees    *hexutil.Big       `json:"totalFees"`
+	Total        *hexutil.Big       `json:"total"`
+	UnpricedTxs  hexutil.Uint64     `json:"unpricedTxs"` // Fees of these transactions are missing from the totals
+}
+
+// GetRewards returns the rewards credited to the given validator within a range
+// of blocks, inclusive, along with their totals.
+func (api *PrivateValidatorAPI) GetRewards(address common.Address, from, to rpc.BlockNumber) (*GetRewardsResult, error) {
+	chain := api.kcoin.BlockChain()
+	start, end := resolveBlockNumber(chain, from), resolveBlockNumber(chain, to)
+	if start > end {
+		return nil, fmt.Errorf("invalid block range %d - %d", start, end)
+	}
+	if end-start >= maxRewardsRange {
+		return nil, fmt.Errorf("block range exceeds %d blocks", maxRewardsRange)
+	}
+
+	var (
+		totalReward = new(big.Int)
+		totalFees   = new(big.Int)
+		result      = &GetRewardsResult{
+			Address: address,
+			From:    hexutil.Uint64(start),
+			To:      hexutil.Uint64(end),
+			Blocks:  []*RPCBlockRewards{},
+		}
+	)
+	for number := start; number <= end; number++ {
+		header := chain.GetHeaderByNumber(number)
+		if header == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+		rewards := rawdb.ReadBlockRewards(api.kcoin.ChainDb(), header.Hash(), number)
+		if rewards == nil {
+			// not indexed yet
+			var err error
+			if rewards, err = blockRewards(chain, header); err != nil {
+				return nil, fmt.Errorf("block #%d: %v", number, err)
+			}
+		}
+
+		for _, participant := range rewards.Participants {
+			if participant == address {
+				result.Participated++
+				break
+			}
+		}
+		if rewards.Proposer != address {
+			continue
+		}
+		totalReward.Add(totalReward, rewards.Reward)
+		totalFees.Add(totalFees, rewards.Fees)
+		result.UnpricedTxs += hexutil.Uint64(rewards.UnpricedTxs)
+		result.Blocks = append(result.Blocks, &RPCBlockRewards{
+			Number:       hexutil.Uint64(number),
+			Hash:         header.Hash(),
+			Time:         hexutil.Uint64(header.Time.Uint64()),
+			Reward:       (*hexutil.Big)(rewards.Reward),
+			Fees:         (*hexutil.Big)(rewards.Fees),
+			UnpricedTxs:  hexutil.Uint64(rewards.UnpricedTxs),
+			Participants: rewards.Participants,
+		})
+	}
+	result.TotalReward = (*hexutil.Big)(totalReward)
+	result.TotalFees = (*hexutil.Big)(totalFees)
+	result.Total = (*hexutil.Big)(new(big.Int).Add(totalReward, totalFees))
+
+	return result, nil
+}
+
+// RPCVoterActivity is the activity of a validator in the election of a block.
+type RPCVoterActivity struct {
+	Address      common.Address `json:"address"`
+	PreVoted     bool           `json:"preVoted"`
+	PreCommitted bool           `json:"preCommitted"`
+	Missed       bool           `json:"missed"`
+}
+
+// RPCParticipation is the part taken by the validator set in the election of a
+// block.
+type RPCParticipation struct {
+	Number   hexutil.Uint64     `json:"number"`
+	Hash     common.Hash        `json:"hash"`
+	Proposer common.Address     `json:"proposer"`
+	Round    hexutil.Uint64     `json:"round"`
+	Voters   []RPCVoterActivity `json:"voters"`
+}
+
+// GetParticipation returns the part taken by the validator set in the election
+// of the given block. Pre-commits are indexed from the commits of the chain a
+// few blocks behind the head, pre-votes are only known to the validating nodes
+// taking part in the election.
+func (api *PrivateValidatorAPI) GetParticipation(number rpc.BlockNumber) (*RPCParticipation, error) {
+	chain := api.kcoin.BlockChain()
+	header := chain.GetHeaderByNumber(resolveBlockNumber(chain, number))
+	if header == nil {
+		return nil, fmt.Errorf("block #%d not found", number)
+	}
+	participation := rawdb.ReadParticipation(api.kcoin.ChainDb(), header.Hash(), header.Number.Uint64())
+	if participation == nil {
+		return nil, fmt.Errorf("no participation recorded for block #%d", header.Number)
+	}
+
+	result := &RPCParticipation{
+		Number:   hexutil.Uint64(header.Number.Uint64()),
+		Hash:     header.Hash(),
+		Proposer: participation.Proposer,
+		Round:    hexutil.Uint64(participation.Round),
+		Voters:   make([]RPCVoterActivity, len(participation.Voters)),
+	}
+	for i, voter := range participation.Voters {
+		result.Voters[i] = RPCVoterActivity{
+			Address:      voter.Address,
+			PreVoted:     voter.PreVoted,
+			PreCommitted: voter.PreCommitted,
+			Missed:       voter.Missed(),
+		}
+	}
+	return result, nil
+}
+
+// GetUptimeResult is the result of a validator_getUptime API call.
+type GetUptimeResult struct {
+	Address      common.Address `json:"address"`
+	From         hexutil.Uint64 `json:"from"`
+	To           hexutil.Uint64 `json:"to"`
+	Elections    hexutil.Uint64 `json:"elections"` // Recorded elections the validator was a voter of
+	Proposed     hexutil.Uint64 `json:"proposed"`
+	PreVoted     hexutil.Uint64 `json:"preVoted"`
+	PreCommitted hexutil.Uint64 `json:"preCommitted"`
+	Missed       hexutil.Uint64 `json:"missed"`
+	Unrecorded   hexutil.Uint64 `json:"unrecorded"` // Blocks without a participation record
+	Uptime       float64        `json:"uptime"`     // Share of the elections not missed
+}
+
+// GetUptime returns the participation of the given validator in the elections
+// of a range of blocks, inclusive.
+func (api *PrivateValidatorAPI) GetUptime(address common.Address, from, to rpc.BlockNumber) (*GetUptimeResult, error) {
+	chain := api.kcoin.BlockChain()
+	start, end := resolveBlockNumber(chain, from), resolveBlockNumber(chain, to)
+	if start > end {
+		return nil, fmt.Errorf("invalid block range %d - %d", start, end)
+	}
+	if end-start >= maxRewardsRange {
+		return nil, fmt.Errorf("block range exceeds %d blocks", maxRewardsRange)
+	}
+
+	result := &GetUptimeResult{
+		Address: address,
+		From:    hexutil.Uint64(start),
+		To:      hexutil.Uint64(end),
+	}
+	for number := start; number <= end; number++ {
+		hash := rawdb.ReadCanonicalHash(api.kcoin.ChainDb(), number)
+		participation := rawdb.ReadParticipation(api.kcoin.ChainDb(), hash, number)
+		if participation == nil {
+			result.Unrecorded++
+			continue
+		}
+		if participation.Proposer == address {
+			result.Proposed++
+		}
+		for _, voter := range participation.Voters {
+			if voter.Address != address {
+				continue
+			}
+			result.Elections++
+			if voter.PreVoted {
+				result.PreVoted++
+			}
+			if voter.PreCommitted {
+				result.PreCommitted++
+			}
+			if voter.Missed() {
+				result.Missed++
+			}
+			break
+		}
+	}
+	if result.Elections > 0 {
+		result.Uptime = float64(result.Elections-result.Missed) / float64(result.Elections)
+	}
+	return result, nil
+}
+
+// resolveBlockNumber returns the number of the given block of the local chain.
+func resolveBlockNumber(chain *core.BlockChain, number rpc.BlockNumber) uint64 {
+	switch number {
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
+		return chain.CurrentBlock().NumberU64()
+	case rpc.FinalizedBlockNumber:
+		return chain.CurrentCommittedBlock().NumberU64()
+	}
+	return uint64(number)
+}
+
 // IsValidating returns the validator is currently validating
 func (api *PrivateValidatorAPI) IsValidating() bool {
 	return api.kcoin.IsValidating()
@@ -167,6 +511,14 @@ func (api *PrivateValidatorAPI) RedeemDeposits() error {
 	return api.kcoin.Validator().RedeemDeposits()
 }
 
+// RotateKey binds the given signing key to the deposit of the running validator
+// from the given block number on, without leaving the election. The key must be
+// held, unlocked, by the keystore of the node. The deposit stays with the
+// validator account, which is still needed to leave and redeem the deposit.
+func (api *PrivateValidatorAPI) RotateKey(signingKey common.Address, activeFrom hexutil.Uint64) error {
+	return api.kcoin.Validator().RotateKey(signingKey, new(big.Int).SetUint64(uint64(activeFrom)))
+}
+
 // TransferArgs represents the arguments to transfer tokens.
 type TransferArgs struct {
 	From           common.Address  `json:"from"`
@@ -245,8 +597,12 @@ func (api *PublicTokenAPI) Confirm(from common.Address, transactionID *hexutil.B
 }
 
 func (api *PublicTokenAPI) getWallet(addr common.Address) (*accounts.Account, accounts.WalletAccount, error) {
-	// Look up the wallet containing the requested signer
-	for _, wallet := range api.accountMgr.Wallets() {
+	return findWallet(api.accountMgr, addr)
+}
+
+// findWallet looks up the wallet containing the requested signer.
+func findWallet(accountMgr *accounts.Manager, addr common.Address) (*accounts.Account, accounts.WalletAccount, error) {
+	for _, wallet := range accountMgr.Wallets() {
 		for _, account := range wallet.Accounts() {
 			if account.Address == addr {
 				walletAccount, err := accounts.NewWalletAccount(wallet, account)
@@ -260,6 +616,202 @@ func (api *PublicTokenAPI) getWallet(addr common.Address) (*accounts.Account, ac
 	return nil, nil, errors.New("account not found in any wallet")
 }
 
+// GovernanceInfo describes the MultiSig wallet owning the system contracts.
+type GovernanceInfo struct {
+	Governors             []common.Address          `json:"governors"`
+	RequiredConfirmations *hexutil.Big              `json:"requiredConfirmations"`
+	Contracts             consensus.SystemContracts `json:"contracts"`
+}
+
+// RPCMultiSigTransaction is a MultiSig wallet transaction, with its call data
+// decoded if it calls a system contract.
+type RPCMultiSigTransaction struct {
+	ID            *hexutil.Big            `json:"id"`
+	Destination   common.Address          `json:"destination"`
+	Contract      string                  `json:"contract,omitempty"`
+	Value         *hexutil.Big            `json:"value"`
+	Data          hexutil.Bytes           `json:"data"`
+	Call          *consensus.ContractCall `json:"call,omitempty"`
+	Executed      bool                    `json:"executed"`
+	Confirmations []common.Address        `json:"confirmations"`
+}
+
+func newRPCMultiSigTransaction(tx *consensus.MultiSigTransaction) *RPCMultiSigTransaction {
+	return &RPCMultiSigTransaction{
+		ID:            (*hexutil.Big)(tx.ID),
+		Destination:   tx.Destination,
+		Contract:      tx.Contract,
+		Value:         (*hexutil.Big)(tx.Value),
+		Data:          tx.Data,
+		Call:          tx.Call,
+		Executed:      tx.Executed,
+		Confirmations: tx.Confirmations,
+	}
+}
+
+// SubmitMultiSigArgs represents the arguments to submit a MultiSig transaction.
+type SubmitMultiSigArgs struct {
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value *hexutil.Big   `json:"value"`
+	Data  hexutil.Bytes  `json:"data"`
+}
+
+// PrivateGovernanceAPI exposes the MultiSig wallet administering the system
+// contracts. Transactions are signed by the local account of a governor.
+type PrivateGovernanceAPI struct {
+	accountMgr *accounts.Manager
+	governor   consensus.Governor
+	chainID    *big.Int
+}
+
+// NewPrivateGovernanceAPI creates a new API definition for the governance
+// methods of the Kowala service.
+func NewPrivateGovernanceAPI(accountMgr *accounts.Manager, governor consensus.Governor, chainID *big.Int) *PrivateGovernanceAPI {
+	return &PrivateGovernanceAPI{
+		accountMgr: accountMgr,
+		governor:   governor,
+		chainID:    chainID,
+	}
+}
+
+// Info returns the governors, the number of confirmations required to execute
+// a transaction and the addresses of the system contracts.
+func (api *PrivateGovernanceAPI) Info() (*GovernanceInfo, error) {
+	governors, err := api.governor.Governors()
+	if err != nil {
+		return nil, err
+	}
+	required, err := api.governor.RequiredConfirmations()
+	if err != nil {
+		return nil, err
+	}
+	return &GovernanceInfo{
+		Governors:             governors,
+		RequiredConfirmations: (*hexutil.Big)(required),
+		Contracts:             api.governor.Contracts(),
+	}, nil
+}
+
+// Transactions lists the MultiSig transactions, the pending and the executed
+// ones unless told otherwise.
+func (api *PrivateGovernanceAPI) Transactions(pending *bool, executed *bool) ([]*RPCMultiSigTransaction, error) {
+	withPending, withExecuted := true, true
+	if pending != nil {
+		withPending = *pending
+	}
+	if executed != nil {
+		withExecuted = *executed
+	}
+	txs, err := api.governor.Transactions(withPending, withExecuted)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*RPCMultiSigTransaction, len(txs))
+	for i, tx := range txs {
+		result[i] = newRPCMultiSigTransaction(tx)
+	}
+	return result, nil
+}
+
+// Transaction returns a MultiSig transaction.
+func (api *PrivateGovernanceAPI) Transaction(id hexutil.Big) (*RPCMultiSigTransaction, error) {
+	tx, err := api.governor.Transaction(id.ToInt())
+	if err != nil {
+		return nil, err
+	}
+	return newRPCMultiSigTransaction(tx), nil
+}
+
+// Submit submits an arbitrary MultiSig transaction, confirming it on behalf of
+// the submitter.
+func (api *PrivateGovernanceAPI) Submit(args SubmitMultiSigArgs) (common.Hash, error) {
+	opts, err := api.transactOpts(args.From)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Submit(opts, args.To, args.Value.ToInt(), args.Data)
+}
+
+// Confirm confirms a MultiSig transaction, executing it if it gathers enough
+// confirmations.
+func (api *PrivateGovernanceAPI) Confirm(from common.Address, id hexutil.Big) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Confirm(opts, id.ToInt())
+}
+
+// Revoke revokes the confirmation of a MultiSig transaction not executed yet.
+func (api *PrivateGovernanceAPI) Revoke(from common.Address, id hexutil.Big) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Revoke(opts, id.ToInt())
+}
+
+// Execute executes a confirmed MultiSig transaction whose execution failed.
+func (api *PrivateGovernanceAPI) Execute(from common.Address, id hexutil.Big) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Execute(opts, id.ToInt())
+}
+
+// SetMaxValidators submits a change of the maximum number of validators.
+func (api *PrivateGovernanceAPI) SetMaxValidators(from common.Address, max hexutil.Big) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.SetMaxValidators(opts, max.ToInt())
+}
+
+// SetBaseDeposit submits a change of the base deposit of the validators.
+func (api *PrivateGovernanceAPI) SetBaseDeposit(from common.Address, deposit hexutil.Big) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.SetBaseDeposit(opts, deposit.ToInt())
+}
+
+// Pause submits the pause of a system contract, ValidatorMgr or OracleMgr.
+func (api *PrivateGovernanceAPI) Pause(from common.Address, contract string) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Pause(opts, contract)
+}
+
+// Unpause submits the unpause of a system contract, ValidatorMgr or OracleMgr.
+func (api *PrivateGovernanceAPI) Unpause(from common.Address, contract string) (common.Hash, error) {
+	opts, err := api.transactOpts(from)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return api.governor.Unpause(opts, contract)
+}
+
+// transactOpts returns the options to sign the transactions of a governor with
+// its local account.
+func (api *PrivateGovernanceAPI) transactOpts(from common.Address) (*accounts.TransactOpts, error) {
+	account, walletAccount, err := findWallet(api.accountMgr, from)
+	if err != nil {
+		return nil, err
+	}
+	return &accounts.TransactOpts{
+		From: from,
+		Signer: func(signer types.Signer, address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return walletAccount.SignTx(*account, tx, api.chainID)
+		},
+	}, nil
+}
+
 // PrivateAdminAPI is the collection of Kowala full node-related APIs
 // exposed over the private admin endpoint.
 type PrivateAdminAPI struct {
@@ -272,8 +824,20 @@ func NewPrivateAdminAPI(kcoin *Kowala) *PrivateAdminAPI {
 	return &PrivateAdminAPI{kcoin: kcoin}
 }
 
-// ExportChain exports the current blockchain into a local file.
-func (api *PrivateAdminAPI) ExportChain(file string) (bool, error) {
+// ExportChain exports the committed blockchain into a local file, or a range
+// of it if the first and last block numbers are given.
+func (api *PrivateAdminAPI) ExportChain(file string, first *uint64, last *uint64) (bool, error) {
+	chain := api.kcoin.BlockChain()
+	from, to := uint64(0), chain.CurrentCommittedBlock().NumberU64()
+	if first != nil {
+		from = *first
+	}
+	if last != nil {
+		to = *last
+	}
+	if head := chain.CurrentBlock().NumberU64(); to > head {
+		return false, fmt.Errorf("last block #%d is beyond the head block #%d", to, head)
+	}
 	// Make sure we can create the file to export into
 	out, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.ModePerm)
 	if err != nil {
@@ -288,24 +852,22 @@ func (api *PrivateAdminAPI) ExportChain(file string) (bool, error) {
 	}
 
 	// Export the blockchain
-	if err := api.kcoin.BlockChain().Export(writer); err != nil {
+	if err := chain.ExportN(writer, from, to); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
-func hasAllBlocks(chain *core.BlockChain, bs []*types.Block) bool {
-	for _, b := range bs {
-		if !chain.HasBlock(b.Hash(), b.NumberU64()) {
-			return false
-		}
-	}
-
-	return true
+// ImportChainArgs represents the optional settings of a chain import.
+type ImportChainArgs struct {
+	Verify bool   `json:"verify"` // Verify the commit of every block before inserting it
+	First  uint64 `json:"first"`  // First block number to import
+	Last   uint64 `json:"last"`   // Last block number to import, zero for no limit
 }
 
-// ImportChain imports a blockchain from a local file.
-func (api *PrivateAdminAPI) ImportChain(file string) (bool, error) {
+// ImportChain imports a blockchain from a local file, which may be gzip
+// compressed.
+func (api *PrivateAdminAPI) ImportChain(file string, args *ImportChainArgs) (bool, error) {
 	// Make sure the can access the file to import
 	in, err := os.Open(file)
 	if err != nil {
@@ -313,44 +875,61 @@ func (api *PrivateAdminAPI) ImportChain(file string) (bool, error) {
 	}
 	defer in.Close()
 
-	var reader io.Reader = in
-	if strings.HasSuffix(file, ".gz") {
-		if reader, err = gzip.NewReader(reader); err != nil {
-			return false, err
-		}
+	var opts core.ImportOptions
+	if args != nil {
+		opts = core.ImportOptions{Verify: args.Verify, First: args.First, Last: args.Last}
+	}
+	if _, err := api.kcoin.BlockChain().Import(in, opts, nil); err != nil {
+		return false, err
 	}
+	return true, nil
+}
 
-	// Run actual the import in pre-configured batches
-	stream := rlp.NewStream(reader, 0)
+// Bans returns the peers banned by the node, either for misbehaving or on
+// request.
+func (api *PrivateAdminAPI) Bans() []PeerBan {
+	return api.kcoin.protocolManager.Bans()
+}
 
-	blocks, index := make([]*types.Block, 0, 2500), 0
-	for batch := 0; ; batch++ {
-		// Load a batch of blocks from the input file
-		for len(blocks) < cap(blocks) {
-			block := new(types.Block)
-			if err := stream.Decode(block); err == io.EOF {
-				break
-			} else if err != nil {
-				return false, fmt.Errorf("block %d: failed to parse: %v", index, err)
-			}
-			blocks = append(blocks, block)
-			index++
-		}
-		if len(blocks) == 0 {
-			break
-		}
+// BanPeer bans a peer, given as an enode URL or a node ID, and disconnects it.
+// The ban lasts for the given number of seconds, a day by default.
+func (api *PrivateAdminAPI) BanPeer(node string, seconds *uint64) (bool, error) {
+	id, err := parseNodeID(node)
+	if err != nil {
+		return false, err
+	}
+	duration := banDuration
+	if seconds != nil {
+		duration = time.Duration(*seconds) * time.Second
+	}
+	api.kcoin.protocolManager.BanPeer(id, "banned by the admin", duration)
+	return true, nil
+}
 
-		if hasAllBlocks(api.kcoin.BlockChain(), blocks) {
-			blocks = blocks[:0]
-			continue
-		}
-		// Import the batch and reset the buffer
-		if _, err := api.kcoin.BlockChain().InsertChain(blocks); err != nil {
-			return false, fmt.Errorf("batch %d: failed to insert: %v", batch, err)
+// UnbanPeer lifts the ban of a peer, given as an enode URL or a node ID, and
+// reports whether the peer was banned.
+func (api *PrivateAdminAPI) UnbanPeer(node string) (bool, error) {
+	id, err := parseNodeID(node)
+	if err != nil {
+		return false, err
+	}
+	return api.kcoin.protocolManager.UnbanPeer(id), nil
+}
+
+// parseNodeID parses either an enode URL or a hex node ID.
+func parseNodeID(node string) (discover.NodeID, error) {
+	if strings.HasPrefix(node, "enode://") {
+		n, err := discover.ParseNode(node)
+		if err != nil {
+			return discover.NodeID{}, fmt.Errorf("invalid enode: %v", err)
 		}
-		blocks = blocks[:0]
+		return n.ID, nil
 	}
-	return true, nil
+	id, err := discover.HexID(node)
+	if err != nil {
+		return discover.NodeID{}, fmt.Errorf("invalid node ID: %v", err)
+	}
+	return id, nil
 }
 
 // PublicDebugAPI is the collection of Kowala full node APIs exposed
@@ -375,9 +954,12 @@ func (api *PublicDebugAPI) DumpBlock(blockNr rpc.BlockNumber) (state.Dump, error
 		return stateDb.RawDump(), nil
 	}
 	var block *types.Block
-	if blockNr == rpc.LatestBlockNumber {
+	switch blockNr {
+	case rpc.LatestBlockNumber:
 		block = api.kcoin.blockchain.CurrentBlock()
-	} else {
+	case rpc.FinalizedBlockNumber:
+		block = api.kcoin.blockchain.CurrentCommittedBlock()
+	default:
 		block = api.kcoin.blockchain.GetBlockByNumber(uint64(blockNr))
 	}
 	if block == nil {
@@ -416,6 +998,7 @@ type BadBlockArgs struct {
 	Hash  common.Hash            `json:"hash"`
 	Block map[string]interface{} `json:"block"`
 	RLP   string                 `json:"rlp"`
+	Error string                 `json:"error"`
 }
 
 // GetBadBlocks returns a list of the last 'bad blocks' that the client has seen on the network
@@ -425,10 +1008,14 @@ func (api *PrivateDebugAPI) GetBadBlocks(ctx context.Context) ([]*BadBlockArgs,
 	results := make([]*BadBlockArgs, len(blocks))
 
 	var err error
-	for i, block := range blocks {
+	for i, bad := range blocks {
+		block := bad.Block
 		results[i] = &BadBlockArgs{
 			Hash: block.Hash(),
 		}
+		if bad.Err != nil {
+			results[i].Error = bad.Err.Error()
+		}
 		if rlpBytes, err := rlp.EncodeToBytes(block); err != nil {
 			results[i].RLP = err.Error() // Hacky, but hey, it works
 		} else {
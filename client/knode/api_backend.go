@@ -52,6 +52,9 @@ func (b *KowalaAPIBackend) HeaderByNumber(ctx context.Context, blockNr rpc.Block
 	if blockNr == rpc.LatestBlockNumber {
 		return b.kcoin.blockchain.CurrentBlock().Header(), nil
 	}
+	if blockNr == rpc.FinalizedBlockNumber {
+		return b.kcoin.blockchain.CurrentCommittedBlock().Header(), nil
+	}
 
 	return b.kcoin.blockchain.GetHeaderByNumber(uint64(blockNr)), nil
 }
@@ -66,6 +69,9 @@ func (b *KowalaAPIBackend) BlockByNumber(ctx context.Context, blockNr rpc.BlockN
 	if blockNr == rpc.LatestBlockNumber {
 		return b.kcoin.blockchain.CurrentBlock(), nil
 	}
+	if blockNr == rpc.FinalizedBlockNumber {
+		return b.kcoin.blockchain.CurrentCommittedBlock(), nil
+	}
 	return b.kcoin.blockchain.GetBlockByNumber(uint64(blockNr)), nil
 }
 
@@ -88,6 +94,10 @@ func (b *KowalaAPIBackend) GetBlock(ctx context.Context, hash common.Hash) (*typ
 	return b.kcoin.blockchain.GetBlockByHash(hash), nil
 }
 
+func (b *KowalaAPIBackend) GetCommit(ctx context.Context, hash common.Hash) (*types.Commit, error) {
+	return b.kcoin.blockchain.GetCommit(hash), nil
+}
+
 func (b *KowalaAPIBackend) GetReceipts(ctx context.Context, hash common.Hash) (types.Receipts, error) {
 	if number := rawdb.ReadHeaderNumber(b.kcoin.chainDb, hash); number != nil {
 		return rawdb.ReadReceipts(b.kcoin.chainDb, hash, *number), nil
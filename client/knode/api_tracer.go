@@ -60,6 +60,13 @@ type TraceConfig struct {
 	Reexec  *uint64
 }
 
+// TraceCallConfig is the config for traceCall API. It holds one more
+// field to override the state for tracing.
+type TraceCallConfig struct {
+	TraceConfig
+	StateOverrides *kcoinapi.StateOverride
+}
+
 // txTraceResult is the result of a single transaction trace.
 type txTraceResult struct {
 	Result interface{} `json:"result,omitempty"` // Trace results produced by the tracer
@@ -101,6 +108,8 @@ func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.Block
 		from = api.kcoin.validator.PendingBlock()
 	case rpc.LatestBlockNumber:
 		from = api.kcoin.blockchain.CurrentBlock()
+	case rpc.FinalizedBlockNumber:
+		from = api.kcoin.blockchain.CurrentCommittedBlock()
 	default:
 		from = api.kcoin.blockchain.GetBlockByNumber(uint64(start))
 	}
@@ -109,6 +118,8 @@ func (api *PrivateDebugAPI) TraceChain(ctx context.Context, start, end rpc.Block
 		to = api.kcoin.validator.PendingBlock()
 	case rpc.LatestBlockNumber:
 		to = api.kcoin.blockchain.CurrentBlock()
+	case rpc.FinalizedBlockNumber:
+		to = api.kcoin.blockchain.CurrentCommittedBlock()
 	default:
 		to = api.kcoin.blockchain.GetBlockByNumber(uint64(end))
 	}
@@ -346,6 +357,8 @@ func (api *PrivateDebugAPI) TraceBlockByNumber(ctx context.Context, number rpc.B
 		block = api.kcoin.validator.PendingBlock()
 	case rpc.LatestBlockNumber:
 		block = api.kcoin.blockchain.CurrentBlock()
+	case rpc.FinalizedBlockNumber:
+		block = api.kcoin.blockchain.CurrentCommittedBlock()
 	default:
 		block = api.kcoin.blockchain.GetBlockByNumber(uint64(number))
 	}
@@ -554,6 +567,62 @@ func (api *PrivateDebugAPI) TraceTransaction(ctx context.Context, hash common.Ha
 	return api.traceTx(ctx, msg, vmctx, statedb, config)
 }
 
+// TraceCall lets you trace a given kcoin_call. It collects the structured logs
+// created during the execution of EVM if the given transaction was added on
+// top of the provided block and returns them as a JSON object.
+// Accounts may be overridden through config.StateOverrides before the call.
+func (api *PrivateDebugAPI) TraceCall(ctx context.Context, args kcoinapi.CallArgs, blockNrOrHash rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	reexec := defaultTraceReexec
+	if config != nil && config.Reexec != nil {
+		reexec = *config.Reexec
+	}
+	// Fetch the block and the state that we want to trace on top of
+	var (
+		block   *types.Block
+		statedb *state.StateDB
+		err     error
+	)
+	if hash, ok := blockNrOrHash.Hash(); ok {
+		block = api.kcoin.blockchain.GetBlockByHash(hash)
+		if block == nil {
+			return nil, fmt.Errorf("block #%x not found", hash)
+		}
+	} else {
+		number, _ := blockNrOrHash.Number()
+		switch number {
+		case rpc.PendingBlockNumber:
+			block, statedb = api.kcoin.validator.Pending()
+		case rpc.LatestBlockNumber:
+			block = api.kcoin.blockchain.CurrentBlock()
+		case rpc.FinalizedBlockNumber:
+			block = api.kcoin.blockchain.CurrentCommittedBlock()
+		default:
+			block = api.kcoin.blockchain.GetBlockByNumber(uint64(number))
+		}
+		if block == nil {
+			return nil, fmt.Errorf("block #%d not found", number)
+		}
+	}
+	if statedb == nil {
+		if statedb, err = api.computeStateDB(block, reexec); err != nil {
+			return nil, err
+		}
+	}
+	// Apply the customized state rules if required.
+	var traceConfig *TraceConfig
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			return nil, err
+		}
+		traceConfig = &config.TraceConfig
+	}
+	// Execute the trace
+	msg := args.ToMessage(api.kcoin.AccountManager())
+	vmctx := core.NewEVMContext(msg, block.Header(), api.kcoin.blockchain, nil)
+
+	return api.traceTx(ctx, msg, vmctx, statedb, traceConfig)
+}
+
 // traceTx configures a new tracer according to the provided configuration, and
 // executes the given message in the provided environment. The return value will
 // be tracer dependent.
@@ -61,6 +61,9 @@ type Kowala struct {
 	bloomRequests chan chan *bloombits.Retrieval // Channel receiving bloom data retrieval requests
 	bloomIndexer  *core.ChainIndexer             // Bloom indexer operating during block imports
 
+	rewardsIndexer       *core.ChainIndexer // Rewards indexer operating during block imports
+	participationIndexer *core.ChainIndexer // Validator participation indexer operating during block imports
+
 	apiBackend *KowalaAPIBackend
 
 	validator validator.Validator // consensus validator
@@ -122,7 +125,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Kowala, error) {
 	}
 
 	vmConfig := vm.Config{EnablePreimageRecording: config.EnablePreimageRecording}
-	cacheConfig := &core.CacheConfig{Disabled: config.NoPruning, TrieNodeLimit: config.TrieCache, TrieTimeLimit: config.TrieTimeout}
+	cacheConfig := &core.CacheConfig{Disabled: config.NoPruning, TrieNodeLimit: config.TrieCache, TrieTimeLimit: config.TrieTimeout, AncientThreshold: config.AncientThreshold}
 	kcoin.blockchain, err = core.NewBlockChain(chainDb, cacheConfig, kcoin.chainConfig, kcoin.engine, vmConfig)
 	if err != nil {
 		return nil, err
@@ -134,6 +137,10 @@ func New(ctx *node.ServiceContext, config *Config) (*Kowala, error) {
 		rawdb.WriteChainConfig(chainDb, genesisHash, chainConfig)
 	}
 	kcoin.bloomIndexer.Start(kcoin.blockchain)
+	kcoin.rewardsIndexer = NewRewardsIndexer(chainDb, kcoin.blockchain)
+	kcoin.rewardsIndexer.Start(kcoin.blockchain)
+	kcoin.participationIndexer = NewParticipationIndexer(chainDb, kcoin.blockchain)
+	kcoin.participationIndexer.Start(kcoin.blockchain)
 
 	if config.TxPool.Journal != "" {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
@@ -156,8 +163,9 @@ func New(ctx *node.ServiceContext, config *Config) (*Kowala, error) {
 
 	kcoin.validator = validator.New(kcoin, kcoin.consensus, kcoin.chainConfig, kcoin.EventMux(), kcoin.engine, vmConfig)
 	kcoin.validator.SetExtra(makeExtraData(config.ExtraData))
+	kcoin.validator.SetAutoRedeem(config.AutoRedeem)
 
-	if kcoin.protocolManager, err = NewProtocolManager(kcoin.chainConfig, config.SyncMode, config.NetworkId, kcoin.eventMux, kcoin.txPool, kcoin.engine, kcoin.blockchain, chainDb, kcoin.validator); err != nil {
+	if kcoin.protocolManager, err = NewProtocolManager(kcoin.chainConfig, config.SyncMode, config.NetworkId, config.Sentry, kcoin.eventMux, kcoin.txPool, kcoin.engine, kcoin.blockchain, chainDb, kcoin.validator); err != nil {
 		return nil, err
 	}
 
@@ -185,7 +193,7 @@ func makeExtraData(extra []byte) []byte {
 
 // CreateDB creates the chain database.
 func CreateDB(ctx *node.ServiceContext, config *Config, name string) (kcoindb.Database, error) {
-	db, err := ctx.OpenDatabase(name, config.DatabaseCache, config.DatabaseHandles)
+	db, err := ctx.OpenDatabaseWithAncients(name, config.DatabaseCache, config.DatabaseHandles, config.DatabaseAncient)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +240,11 @@ func (s *Kowala) APIs() []rpc.API {
 			Version:   "1.0",
 			Service:   NewPublicTokenAPI(s.accountManager, s.consensus, s.chainConfig.ChainID),
 			Public:    false,
+		}, {
+			Namespace: "governance",
+			Version:   "1.0",
+			Service:   NewPrivateGovernanceAPI(s.accountManager, s.consensus.Governance(), s.chainConfig.ChainID),
+			Public:    false,
 		}, {
 			Namespace: "eth",
 			Version:   "1.0",
@@ -430,6 +443,8 @@ func (s *Kowala) Stop() error {
 	// could be punished
 	s.StopValidating()
 	s.bloomIndexer.Close()
+	s.rewardsIndexer.Close()
+	s.participationIndexer.Close()
 	s.blockchain.Stop()
 	s.protocolManager.Stop()
 	s.txPool.Stop()
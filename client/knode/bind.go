@@ -50,7 +50,7 @@ func (b *ContractBackend) PendingCodeAt(ctx context.Context, contract common.Add
 // call with the specified data as the input. The pending flag requests execution
 // against the pending block, not the stable head of the chain.
 func (b *ContractBackend) CallContract(ctx context.Context, msg kowala.CallMsg, blockNum *big.Int) ([]byte, error) {
-	out, err := b.bcapi.Call(ctx, toCallArgs(msg), toBlockNumber(blockNum))
+	out, err := b.bcapi.Call(ctx, toCallArgs(msg), toBlockNumber(blockNum), nil)
 	return out, err
 }
 
@@ -58,7 +58,7 @@ func (b *ContractBackend) CallContract(ctx context.Context, msg kowala.CallMsg,
 // call with the specified data as the input. The pending flag requests execution
 // against the pending block, not the stable head of the chain.
 func (b *ContractBackend) PendingCallContract(ctx context.Context, msg kowala.CallMsg) ([]byte, error) {
-	out, err := b.bcapi.Call(ctx, toCallArgs(msg), rpc.PendingBlockNumber)
+	out, err := b.bcapi.Call(ctx, toCallArgs(msg), rpc.PendingBlockNumber, nil)
 	return out, err
 }
 
@@ -75,6 +75,10 @@ func toCallArgs(msg kowala.CallMsg) kcoinapi.CallArgs {
 	if msg.Value != nil {
 		args.Value = hexutil.Big(*msg.Value)
 	}
+	if msg.FeeCap != nil {
+		args.FeeCap = (*hexutil.Big)(msg.FeeCap)
+	}
+	args.Sponsor = msg.Sponsor
 	return args
 }
 
@@ -52,14 +52,18 @@ type Config struct {
 	SkipBcVersionCheck bool `toml:"-"`
 	DatabaseHandles    int  `toml:"-"`
 	DatabaseCache      int
+	DatabaseAncient    string `toml:",omitempty"` // Ancient store directory, defaults to inside the chain database
+	AncientThreshold   uint64 `toml:",omitempty"` // Blocks behind the head moved into the ancient store while running (0 = only when pruning)
 	TrieCache          int
 	TrieTimeout        time.Duration
 
 	// consensus validation-related options
-	Coinbase  common.Address `toml:",omitempty"`
-	Deposit   *big.Int       `toml:",omitempty"`
-	ExtraData []byte         `toml:",omitempty"`
-	GasPrice  *big.Int
+	Coinbase   common.Address `toml:",omitempty"`
+	Deposit    *big.Int       `toml:",omitempty"`
+	AutoRedeem bool           `toml:",omitempty"` // Redeem the deposits as soon as their freeze period ends
+	ExtraData  []byte         `toml:",omitempty"`
+	Sentry     bool           `toml:",omitempty"` // Relay the consensus messages of the validators behind the node
+	GasPrice   *big.Int
 
 	// Transaction pool options
 	TxPool core.TxPoolConfig
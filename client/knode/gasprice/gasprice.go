@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/internal/kcoinapi"
 	"github.com/kowala-tech/kcoin/client/params"
@@ -136,36 +137,74 @@ type getBlockPricesResult struct {
 	err   error
 }
 
-type transactionsByGasPrice []*types.Transaction
+// transactionsByGasPrice sorts the transactions by the gas price that they pay
+// at the oracle price of the block.
+type transactionsByGasPrice struct {
+	txs         []*types.Transaction
+	oraclePrice *big.Int
+}
 
-func (t transactionsByGasPrice) Len() int           { return len(t) }
-func (t transactionsByGasPrice) Swap(i, j int)      { t[i], t[j] = t[j], t[i] }
-func (t transactionsByGasPrice) Less(i, j int) bool { return t[i].GasPrice().Cmp(t[j].GasPrice()) < 0 }
+func (t transactionsByGasPrice) Len() int      { return len(t.txs) }
+func (t transactionsByGasPrice) Swap(i, j int) { t.txs[i], t.txs[j] = t.txs[j], t.txs[i] }
+func (t transactionsByGasPrice) Less(i, j int) bool {
+	return core.EffectiveGasPrice(t.txs[i], t.oraclePrice).Cmp(core.EffectiveGasPrice(t.txs[j], t.oraclePrice)) < 0
+}
 
 // getBlockPrices calculates the lowest transaction gas price in a given block
 // and sends it to the result channel. If the block is empty, price is nil.
+// Stable fee transactions are ranked by the gas price derived from their fee cap
+// at the oracle price the block was assembled with, and skipped if the oracle
+// price is unavailable.
 func (gpo *Oracle) getBlockPrices(ctx context.Context, signer types.Signer, blockNum uint64, ch chan getBlockPricesResult) {
 	block, err := gpo.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNum))
 	if block == nil {
 		ch <- getBlockPricesResult{nil, err}
 		return
 	}
+	var oraclePrice *big.Int
+	for _, tx := range block.Transactions() {
+		if tx.FeeCap() != nil {
+			oraclePrice = gpo.oraclePrice(ctx, block)
+			break
+		}
+	}
 
-	blockTxs := block.Transactions()
-	txs := make([]*types.Transaction, len(blockTxs))
-	copy(txs, blockTxs)
-	sort.Sort(transactionsByGasPrice(txs))
+	txs := make([]*types.Transaction, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		if tx.FeeCap() == nil || oraclePrice != nil {
+			txs = append(txs, tx)
+		}
+	}
+	sort.Sort(transactionsByGasPrice{txs, oraclePrice})
 
 	for _, tx := range txs {
 		sender, err := types.TxSender(signer, tx)
 		if err == nil && sender != block.Coinbase() {
-			ch <- getBlockPricesResult{tx.GasPrice(), nil}
+			ch <- getBlockPricesResult{core.EffectiveGasPrice(tx, oraclePrice), nil}
 			return
 		}
 	}
 	ch <- getBlockPricesResult{nil, nil}
 }
 
+// oraclePrice returns the kcoin price of the oracle manager as seen by the
+// parent state of the block, nil if the price is unavailable.
+func (gpo *Oracle) oraclePrice(ctx context.Context, block *types.Block) *big.Int {
+	config := gpo.backend.ChainConfig()
+	if config.OracleMgr == nil || block.NumberU64() == 0 || !config.IsTypedTx(block.Number()) {
+		return nil
+	}
+	statedb, header, err := gpo.backend.StateAndHeaderByNumber(ctx, rpc.BlockNumber(block.NumberU64()-1))
+	if statedb == nil || err != nil {
+		return nil
+	}
+	price, err := core.OraclePrice(config, header, statedb)
+	if err != nil {
+		return nil
+	}
+	return price
+}
+
 type bigIntArray []*big.Int
 
 func (s bigIntArray) Len() int           { return len(s) }
@@ -27,11 +27,15 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		SkipBcVersionCheck      bool `toml:"-"`
 		DatabaseHandles         int  `toml:"-"`
 		DatabaseCache           int
+		DatabaseAncient         string `toml:",omitempty"`
+		AncientThreshold        uint64 `toml:",omitempty"`
 		TrieCache               int
 		TrieTimeout             time.Duration
 		Coinbase                common.Address `toml:",omitempty"`
 		Deposit                 *big.Int       `toml:",omitempty"`
+		AutoRedeem              bool           `toml:",omitempty"`
 		ExtraData               hexutil.Bytes  `toml:",omitempty"`
+		Sentry                  bool           `toml:",omitempty"`
 		GasPrice                *big.Int
 		TxPool                  core.TxPoolConfig
 		GPO                     gasprice.Config
@@ -49,11 +53,15 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
 	enc.DatabaseHandles = c.DatabaseHandles
 	enc.DatabaseCache = c.DatabaseCache
+	enc.DatabaseAncient = c.DatabaseAncient
+	enc.AncientThreshold = c.AncientThreshold
 	enc.TrieCache = c.TrieCache
 	enc.TrieTimeout = c.TrieTimeout
 	enc.Coinbase = c.Coinbase
 	enc.Deposit = c.Deposit
+	enc.AutoRedeem = c.AutoRedeem
 	enc.ExtraData = c.ExtraData
+	enc.Sentry = c.Sentry
 	enc.GasPrice = c.GasPrice
 	enc.TxPool = c.TxPool
 	enc.GPO = c.GPO
@@ -75,11 +83,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		SkipBcVersionCheck      *bool `toml:"-"`
 		DatabaseHandles         *int  `toml:"-"`
 		DatabaseCache           *int
+		DatabaseAncient         *string `toml:",omitempty"`
+		AncientThreshold        *uint64 `toml:",omitempty"`
 		TrieCache               *int
 		TrieTimeout             *time.Duration
 		Coinbase                *common.Address `toml:",omitempty"`
 		Deposit                 *big.Int        `toml:",omitempty"`
+		AutoRedeem              *bool           `toml:",omitempty"`
 		ExtraData               *hexutil.Bytes  `toml:",omitempty"`
+		Sentry                  *bool           `toml:",omitempty"`
 		GasPrice                *big.Int
 		TxPool                  *core.TxPoolConfig
 		GPO                     *gasprice.Config
@@ -118,6 +130,12 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.DatabaseCache != nil {
 		c.DatabaseCache = *dec.DatabaseCache
 	}
+	if dec.DatabaseAncient != nil {
+		c.DatabaseAncient = *dec.DatabaseAncient
+	}
+	if dec.AncientThreshold != nil {
+		c.AncientThreshold = *dec.AncientThreshold
+	}
 	if dec.TrieCache != nil {
 		c.TrieCache = *dec.TrieCache
 	}
@@ -130,9 +148,15 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.Deposit != nil {
 		c.Deposit = dec.Deposit
 	}
+	if dec.AutoRedeem != nil {
+		c.AutoRedeem = *dec.AutoRedeem
+	}
 	if dec.ExtraData != nil {
 		c.ExtraData = *dec.ExtraData
 	}
+	if dec.Sentry != nil {
+		c.Sentry = *dec.Sentry
+	}
 	if dec.GasPrice != nil {
 		c.GasPrice = dec.GasPrice
 	}
@@ -71,12 +71,25 @@ func (gen *generator) Generate(opts Options) (*core.Genesis, error) {
 		GasLimit:  4700000,
 		Alloc:     gen.alloc,
 		Config: &params.ChainConfig{
-			ChainID:   getNetwork(validOptions.network),
-			Konsensus: getConsensusEngine(validOptions.consensusEngine),
+			ChainID:      getNetwork(validOptions.network),
+			TypedTxBlock: new(big.Int), // new networks start with the typed transactions
+			Konsensus:    getConsensusEngine(validOptions.consensusEngine),
 		},
 		ExtraData: getExtraData(opts.ExtraData),
 	}
 
+	for _, contract := range gen.contracts {
+		addr := contract.address
+		switch contract {
+		case OracleMgrContract:
+			genesis.Config.OracleMgr = &addr
+		case ValidatorMgrContract:
+			genesis.Config.ValidatorMgr = &addr
+		case MiningTokenContract:
+			genesis.Config.MiningToken = &addr
+		}
+	}
+
 	fmt.Println("Please update the codebase with the following addresses (go bindings):")
 	for _, contract := range gen.contracts {
 		fmt.Printf("Contract: %s, Address: %s\n", contract.name, contract.address.Hex())
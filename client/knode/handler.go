@@ -33,6 +33,10 @@ const (
 	// txChanSize is the size of channel listening to NewTxsEvent.
 	// The number is referenced from the size of tx pool.
 	txChanSize = 4096
+
+	// voteSetBitsInterval is the interval the changes of the local vote sets
+	// are announced to the peers at.
+	voteSetBitsInterval = 50 * time.Millisecond
 )
 
 // errIncompatibleConfig is returned if the requested protocols and configs are
@@ -40,7 +44,17 @@ const (
 var errIncompatibleConfig = errors.New("incompatible configuration")
 
 func errResp(code errCode, format string, v ...interface{}) error {
-	return fmt.Errorf("%v - %v", code, fmt.Sprintf(format, v...))
+	return &protocolError{code: code, msg: fmt.Sprintf(format, v...)}
+}
+
+// protocolError is a violation of the kcoin protocol by a peer.
+type protocolError struct {
+	code errCode
+	msg  string
+}
+
+func (err *protocolError) Error() string {
+	return fmt.Sprintf("%v - %v", err.code, err.msg)
 }
 
 type ProtocolManager struct {
@@ -57,7 +71,11 @@ type ProtocolManager struct {
 	downloader *downloader.Downloader
 	fetcher    *fetcher.Fetcher
 	validator  validator.Validator
+	sentry     bool         // Whether consensus messages are relayed for the validators behind the node
+	relay      *sentryRelay // Checks of the relayed consensus messages, on sentry nodes
 	peers      *peerSet
+	reputation *reputations
+	voteSets   *voteSets // Votes of the latest elections known locally
 
 	SubProtocols []p2p.Protocol
 
@@ -80,7 +98,7 @@ type ProtocolManager struct {
 
 // NewProtocolManager returns a new kowala sub protocol manager. The Kowala sub protocol manages peers capable
 // with the kowala network.
-func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, networkID uint64, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb kcoindb.Database, validator validator.Validator) (*ProtocolManager, error) {
+func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, networkID uint64, sentry bool, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb kcoindb.Database, validator validator.Validator) (*ProtocolManager, error) {
 	// Create the protocol manager with the base fields
 	manager := &ProtocolManager{
 		networkID:   networkID,
@@ -88,13 +106,19 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		txpool:      txpool,
 		blockchain:  blockchain,
 		validator:   validator,
+		sentry:      sentry,
 		chainconfig: config,
 		peers:       newPeerSet(),
+		reputation:  newReputations(chaindb),
+		voteSets:    newVoteSets(),
 		newPeerCh:   make(chan *peer),
 		noMorePeers: make(chan struct{}),
 		txsyncCh:    make(chan *txsync),
 		quitSync:    make(chan struct{}),
 	}
+	if sentry {
+		manager.relay = newSentryRelay(blockchain)
+	}
 	// Figure out whether to allow fast sync or not
 	if mode == downloader.FastSync && blockchain.CurrentBlock().NumberU64() > 0 {
 		log.Warn("Blockchain not empty, fast sync disabled")
@@ -128,7 +152,9 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 			},
 			PeerInfo: func(id discover.NodeID) interface{} {
 				if p := manager.peers.Peer(fmt.Sprintf("%x", id[:8])); p != nil {
-					return p.Info()
+					info := p.Info()
+					info.Score = manager.reputation.score(id)
+					return info
 				}
 				return nil
 			},
@@ -138,7 +164,7 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		return nil, errIncompatibleConfig
 	}
 	// Construct the different synchronisation mechanisms
-	manager.downloader = downloader.New(mode, chaindb, manager.eventMux, blockchain, nil, manager.removePeer)
+	manager.downloader = downloader.New(mode, chaindb, manager.eventMux, blockchain, nil, manager.dropPeer)
 
 	verifyHeader := func(header *types.Header) error {
 		return engine.VerifyHeader(blockchain, header, true)
@@ -155,7 +181,7 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 		atomic.StoreUint32(&manager.acceptTxs, 1) // Mark initial sync done on any fetcher import
 		return manager.blockchain.InsertChain(blocks)
 	}
-	manager.fetcher = fetcher.New(blockchain.GetBlockByHash, verifyHeader, manager.BroadcastBlock, heighter, inserter, manager.removePeer)
+	manager.fetcher = fetcher.New(blockchain.GetBlockByHash, verifyHeader, manager.BroadcastBlock, heighter, inserter, manager.dropPeer)
 
 	return manager, nil
 }
@@ -173,12 +199,51 @@ func (pm *ProtocolManager) removePeer(id string) {
 	if err := pm.peers.Unregister(id); err != nil {
 		log.Error("Peer removal failed", "peer", id, "err", err)
 	}
+	peerVotes.remove(id)
 	// Hard disconnect at the networking layer
 	if peer != nil {
 		peer.Peer.Disconnect(p2p.DiscUselessPeer)
 	}
 }
 
+// dropPeer removes a peer the downloader or the fetcher found misbehaving.
+func (pm *ProtocolManager) dropPeer(id string) {
+	if peer := pm.peers.Peer(id); peer != nil {
+		pm.penalize(peer, penaltyInvalidSync, "dropped by the synchronisation")
+	}
+	pm.removePeer(id)
+}
+
+// penalize accounts a misbehaviour of a peer, and bans the peer once it
+// misbehaved too often. Trusted peers, such as the sentries relaying the
+// messages of the public network, are never penalized.
+func (pm *ProtocolManager) penalize(p *peer, penalty int, reason string) {
+	if p.Peer.Info().Network.Trusted {
+		return
+	}
+	p.Log().Debug("Kowala peer misbehaving", "penalty", penalty, "reason", reason)
+	if pm.reputation.penalize(p.ID(), penalty, reason) {
+		p.Log().Warn("Banning misbehaving Kowala peer", "reason", reason, "duration", banDuration)
+		pm.removePeer(p.id)
+	}
+}
+
+// BanPeer bans a peer for the given duration and disconnects it.
+func (pm *ProtocolManager) BanPeer(id discover.NodeID, reason string, duration time.Duration) {
+	pm.reputation.ban(id, reason, duration)
+	pm.removePeer(fmt.Sprintf("%x", id[:8]))
+}
+
+// UnbanPeer lifts the ban of a peer, reporting whether it was banned.
+func (pm *ProtocolManager) UnbanPeer(id discover.NodeID) bool {
+	return pm.reputation.unban(id)
+}
+
+// Bans returns the peers banned by the node.
+func (pm *ProtocolManager) Bans() []PeerBan {
+	return pm.reputation.list()
+}
+
 func (pm *ProtocolManager) Start(maxPeers int) {
 	pm.maxPeers = maxPeers
 
@@ -248,6 +313,10 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	if pm.peers.Len() >= pm.maxPeers && !p.Peer.Info().Network.Trusted {
 		return p2p.DiscTooManyPeers
 	}
+	if ban := pm.reputation.banned(p.ID()); ban != nil {
+		p.Log().Debug("Refusing banned Kowala peer", "reason", ban.Reason, "expires", time.Unix(int64(ban.Expires), 0))
+		return p2p.DiscUselessPeer
+	}
 	p.Log().Debug("Kowala peer connected", "name", p.Name())
 
 	// Execute the Kowala handshake
@@ -283,6 +352,9 @@ func (pm *ProtocolManager) handle(p *peer) error {
 	for {
 		if err := pm.handleMsg(p); err != nil {
 			p.Log().Debug("Kowala message handling failed", "err", err)
+			if _, ok := err.(*protocolError); ok {
+				pm.penalize(p, penaltyInvalidMsg, err.Error())
+			}
 			return err
 		}
 	}
@@ -619,7 +691,7 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		pm.txpool.AddRemotes(txs)
 
 	case msg.Code == ProposalMsg:
-		if !pm.validator.Validating() {
+		if !pm.validator.Validating() && !pm.sentry {
 			break
 		}
 		// Retrieve and decode the propagated proposal
@@ -627,13 +699,29 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		if err := msg.Decode(&proposal); err != nil {
 			return errResp(ErrDecode, "msg %v: %v", msg, err)
 		}
+
+		p.MarkProposal(proposal.Hash())
+		if pm.sentry {
+			if err := pm.relay.checkProposal(&proposal); err != nil {
+				if misbehaving(err) {
+					pm.penalize(p, penaltyInvalidProposal, err.Error())
+				}
+				break
+			}
+			pm.eventMux.Post(core.NewProposalEvent{Proposal: &proposal})
+		}
+		if !pm.validator.Validating() {
+			break
+		}
 		if err := pm.validator.AddProposal(&proposal); err != nil {
-			// ignore
+			if misbehaving(err) {
+				pm.penalize(p, penaltyInvalidProposal, err.Error())
+			}
 			break
 		}
 
 	case msg.Code == VoteMsg:
-		if !pm.validator.Validating() {
+		if !pm.validator.Validating() && !pm.sentry {
 			break
 		}
 		// Retrieve and decode the propagated vote
@@ -643,26 +731,79 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		}
 
 		p.MarkVote(vote.Hash())
+		votesInMeter.Mark(1)
+		peerVotes.mark(p.id)
+		if pm.sentry {
+			if err := pm.relay.checkVote(&vote); err != nil {
+				if misbehaving(err) {
+					pm.penalize(p, penaltyInvalidVote, err.Error())
+				}
+				break
+			}
+			pm.eventMux.Post(core.NewVoteEvent{Vote: &vote})
+		}
+		if !pm.validator.Validating() {
+			break
+		}
+		index, err := pm.validator.VoterIndex(&vote)
+		if err != nil {
+			if misbehaving(err) {
+				pm.penalize(p, penaltyInvalidVote, err.Error())
+			}
+			break
+		}
+		p.MarkVoteBit(voteSetKeyOf(&vote), index)
 		if err := pm.validator.AddVote(&vote); err != nil {
 			// ignore
 			break
 		}
 
-	case msg.Code == BlockFragmentMsg:
+	case msg.Code == VoteSetBitsMsg:
 		if !pm.validator.Validating() {
 			break
 		}
+		// Retrieve and decode the votes known to the peer
+		var request voteSetBitsData
+		if err := msg.Decode(&request); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		if request.BlockNumber == nil || !request.Type.IsValid() || len(request.Votes) > maxVoteSetBits/8 {
+			return errResp(ErrDecode, "invalid vote set bits: number %v, type %d, %d bytes", request.BlockNumber, request.Type, len(request.Votes))
+		}
+		p.MarkVoteBits(voteSetKey{request.BlockNumber.Uint64(), request.Round, request.Type}, request.Votes)
+
+	case msg.Code == BlockFragmentMsg:
+		if !pm.validator.Validating() && !pm.sentry {
+			break
+		}
 
 		// Retrieve and decode the propagated block fragment
 		var request blockFragmentData
 		if err := msg.Decode(&request); err != nil {
 			return errResp(ErrDecode, "msg %v: %v", msg, err)
 		}
+		if request.Data == nil {
+			return errResp(ErrDecode, "msg %v: missing block fragment", msg)
+		}
 
 		p.MarkFragment(request.Data.Proof)
+		if pm.sentry {
+			if err := pm.relay.checkFragment(request.BlockNumber, request.Round, request.Data); err != nil {
+				if misbehaving(err) {
+					pm.penalize(p, penaltyInvalidFragment, err.Error())
+				}
+				break
+			}
+			pm.eventMux.Post(core.NewBlockFragmentEvent{BlockNumber: request.BlockNumber, Round: request.Round, Data: request.Data})
+		}
+		if !pm.validator.Validating() {
+			break
+		}
 		if err := pm.validator.AddBlockFragment(request.BlockNumber, request.Round, request.Data); err != nil {
 			log.Error("error while adding a new block fragment", "err", err, "round", request.Round, "block", request.BlockNumber, "fragment", request.Data)
-			// ignore
+			if misbehaving(err) {
+				pm.penalize(p, penaltyInvalidFragment, err.Error())
+			}
 			break
 		}
 
@@ -672,6 +813,16 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 	return nil
 }
 
+// misbehaving reports whether the validator rejected a consensus message for
+// being invalid on its own, rather than for not fitting the ongoing election.
+func misbehaving(err error) bool {
+	switch err {
+	case validator.ErrInvalidSignature, validator.ErrNotVoter, validator.ErrInvalidBlockFragment:
+		return true
+	}
+	return false
+}
+
 // BroadcastBlock will either propagate a block to a subset of it's peers, or
 // will only announce it's availability (depending what's requested).
 func (pm *ProtocolManager) BroadcastBlock(block *types.Block, propagate bool) {
@@ -733,7 +884,7 @@ func (pm *ProtocolManager) proposalBroadcastLoop() {
 	for obj := range pm.proposalSub.Chan() {
 		switch ev := obj.Data.(type) {
 		case core.NewProposalEvent:
-			for _, peer := range pm.peers.Peers() {
+			for _, peer := range pm.peers.PeersWithoutProposal(ev.Proposal.Hash()) {
 				peer.SendNewProposal(ev.Proposal)
 			}
 		case core.NewBlockFragmentEvent:
@@ -745,14 +896,48 @@ func (pm *ProtocolManager) proposalBroadcastLoop() {
 }
 
 // Vote broadcast loop
+//
+// Votes are only sent to the peers that miss them, as far as the votes they
+// got from us, from other peers or announced in their vote set bitmaps tell.
+// The changes of the local vote sets are announced to the peers periodically,
+// so that they stop relaying the votes we already have.
 func (pm *ProtocolManager) voteBroadcastLoop() {
-	for obj := range pm.voteSub.Chan() {
-		switch ev := obj.Data.(type) {
-		case core.NewVoteEvent:
-			peers := pm.peers.PeersWithoutVote(ev.Vote.Hash())
-			for _, peer := range peers {
+	announce := time.NewTicker(voteSetBitsInterval)
+	defer announce.Stop()
+
+	changed := make(map[voteSetKey]struct{})
+	for {
+		select {
+		case obj, ok := <-pm.voteSub.Chan():
+			if !ok {
+				return
+			}
+			ev, ok := obj.Data.(core.NewVoteEvent)
+			if !ok {
+				continue
+			}
+			// Votes relayed by sentries are out of the local elections,
+			// so only their hashes tell which peers have them
+			key := voteSetKeyOf(ev.Vote)
+			index, err := pm.validator.VoterIndex(ev.Vote)
+			if err != nil {
+				index = -1
+			} else if pm.voteSets.mark(key, index) {
+				changed[key] = struct{}{}
+			}
+			for _, peer := range pm.peers.PeersWithoutVote(ev.Vote.Hash(), key, index) {
+				peer.MarkVoteBit(key, index)
 				peer.SendVote(ev.Vote)
 			}
+
+		case <-announce.C:
+			for key := range changed {
+				bits := pm.voteSets.bits(key)
+				for _, peer := range pm.peers.Peers() {
+					peer.SendVoteSetBits(key, bits)
+				}
+				delete(changed, key)
+			}
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package knode
 
 import (
+	"sync"
+
 	"github.com/kowala-tech/kcoin/client/metrics"
 	"github.com/kowala-tech/kcoin/client/p2p"
 )
@@ -38,8 +40,68 @@ var (
 	miscInTrafficMeter        = metrics.NewRegisteredMeter("eth/misc/in/traffic", nil)
 	miscOutPacketsMeter       = metrics.NewRegisteredMeter("eth/misc/out/packets", nil)
 	miscOutTrafficMeter       = metrics.NewRegisteredMeter("eth/misc/out/traffic", nil)
+
+	// Consensus votes received from all of the peers
+	votesInMeter = metrics.NewRegisteredMeter("validator/votes/in", nil)
+
+	// Consensus votes received per peer
+	peerVotes = newPeerVoteCounters("validator/votes/in/peer/", maxPeerVoteCounters, metrics.DefaultRegistry)
 )
 
+// maxPeerVoteCounters is the maximum number of peers with a vote counter. The
+// votes of the peers past it are only counted in the total.
+const maxPeerVoteCounters = 64
+
+// peerVoteCounters counts the consensus votes received per peer, for a bounded
+// number of peers at a time. The counter of a peer is dropped along with it.
+type peerVoteCounters struct {
+	prefix   string
+	limit    int
+	registry metrics.Registry
+
+	counters map[string]metrics.Counter
+	lock     sync.Mutex
+}
+
+func newPeerVoteCounters(prefix string, limit int, registry metrics.Registry) *peerVoteCounters {
+	return &peerVoteCounters{
+		prefix:   prefix,
+		limit:    limit,
+		registry: registry,
+		counters: make(map[string]metrics.Counter),
+	}
+}
+
+// mark accounts for a consensus vote received from the given peer.
+func (c *peerVoteCounters) mark(id string) {
+	if !metrics.Enabled {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	counter, ok := c.counters[id]
+	if !ok {
+		if len(c.counters) >= c.limit {
+			return
+		}
+		counter = metrics.NewRegisteredCounter(c.prefix+id, c.registry)
+		c.counters[id] = counter
+	}
+	counter.Inc(1)
+}
+
+// remove drops the vote counter of a disconnected peer.
+func (c *peerVoteCounters) remove(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if _, ok := c.counters[id]; ok {
+		c.registry.Unregister(c.prefix + id)
+		delete(c.counters, id)
+	}
+}
+
 // meteredMsgReadWriter is a wrapper around a p2p.MsgReadWriter, capable of
 // accumulating the above defined metrics based on the data stream contents.
 type meteredMsgReadWriter struct {
@@ -0,0 +1,48 @@
+package knode
+
+import (
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/metrics"
+)
+
+func TestPeerVoteCounters(t *testing.T) {
+	enabled := metrics.Enabled
+	metrics.Enabled = true
+	defer func() { metrics.Enabled = enabled }()
+
+	registry := metrics.NewRegistry()
+	counters := newPeerVoteCounters("votes/", 2, registry)
+
+	count := func(id string) int64 {
+		counter, ok := registry.Get("votes/" + id).(metrics.Counter)
+		if !ok {
+			return -1
+		}
+		return counter.Count()
+	}
+
+	counters.mark("a")
+	counters.mark("a")
+	counters.mark("b")
+	counters.mark("c") // past the limit
+	if have := count("a"); have != 2 {
+		t.Errorf("peer a: have %d votes, want 2", have)
+	}
+	if have := count("b"); have != 1 {
+		t.Errorf("peer b: have %d votes, want 1", have)
+	}
+	if have := count("c"); have != -1 {
+		t.Errorf("peer c: have %d votes, want no counter", have)
+	}
+
+	// dropping a peer frees its counter for another one
+	counters.remove("a")
+	if have := count("a"); have != -1 {
+		t.Errorf("dropped peer a: have %d votes, want no counter", have)
+	}
+	counters.mark("c")
+	if have := count("c"); have != 1 {
+		t.Errorf("peer c: have %d votes, want 1", have)
+	}
+}
@@ -0,0 +1,135 @@
+package knode
+
+import (
+	"errors"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+const (
+	// participationSectionSize is the number of blocks of a participation index
+	// section. As for the rewards, sections are kept short so that the parent
+	// states holding the validator sets are still available.
+	participationSectionSize = 64
+
+	// participationConfirms is the number of confirmation blocks before a
+	// participation section is processed. The commit of a block is carried by
+	// its child, so at least one is needed.
+	participationConfirms = 8
+
+	// participationThrottling is the time to wait between processing two
+	// consecutive index sections.
+	participationThrottling = 10 * time.Millisecond
+)
+
+var errMissingCommit = errors.New("missing commit")
+
+// ParticipationIndexer implements a core.ChainIndexer, recording the part taken
+// by the validator set in the election of every canonical block. Pre-commits
+// are taken from the commit of the block, pre-votes are never part of the chain
+// and are only known from the records of a validating node.
+type ParticipationIndexer struct {
+	db    kcoindb.Database // database instance to write index data into
+	chain *core.BlockChain // blockchain the commits and the validator sets are read from
+
+	batch kcoindb.Batch // batch of the records of the section being processed
+}
+
+// NewParticipationIndexer returns a chain indexer that records the validator
+// participation in the elections of the canonical chain blocks.
+func NewParticipationIndexer(db kcoindb.Database, chain *core.BlockChain) *core.ChainIndexer {
+	backend := &ParticipationIndexer{
+		db:    db,
+		chain: chain,
+	}
+	table := kcoindb.NewTable(db, string(rawdb.ParticipationIndexPrefix))
+
+	return core.NewChainIndexer(db, table, backend, participationSectionSize, participationConfirms, participationThrottling, "participation")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new participation section.
+func (p *ParticipationIndexer) Reset(section uint64, lastSectionHead common.Hash) error {
+	p.batch = p.db.NewBatch()
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, recording the participation in
+// the election of a block.
+func (p *ParticipationIndexer) Process(header *types.Header) {
+	if header.Number.Sign() == 0 {
+		return
+	}
+	participation, err := blockParticipation(p.chain, header)
+	if err != nil {
+		// the block is reported as unrecorded, unless a validating node recorded it
+		log.Error("Failed to index validator participation", "number", header.Number, "hash", header.Hash(), "err", err)
+		return
+	}
+	// the pre-votes seen by a validating node are kept on top of the commit
+	if local := rawdb.ReadParticipation(p.db, header.Hash(), header.Number.Uint64()); local != nil {
+		preVoted := make(map[common.Address]bool, len(local.Voters))
+		for _, voter := range local.Voters {
+			preVoted[voter.Address] = voter.PreVoted
+		}
+		for i, voter := range participation.Voters {
+			participation.Voters[i].PreVoted = preVoted[voter.Address]
+		}
+	}
+	rawdb.WriteParticipation(p.batch, header.Hash(), header.Number.Uint64(), participation)
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the section records.
+func (p *ParticipationIndexer) Commit() error {
+	return p.batch.Write()
+}
+
+// blockParticipation derives the participation in the election of a block from
+// its commit, carried by its child, and the validator set at its parent.
+func blockParticipation(chain *core.BlockChain, header *types.Header) (*types.Participation, error) {
+	commit := chain.GetCommit(header.Hash())
+	if commit == nil {
+		return nil, errMissingCommit
+	}
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return nil, errMissingBlock
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return nil, err
+	}
+	validators, err := core.ValidatorsAt(chain.Config(), parent, statedb)
+	if err != nil {
+		return nil, err
+	}
+
+	signer := types.NewAndromedaSigner(chain.Config().ChainID)
+	committed := make(map[common.Address]bool)
+	for _, vote := range commit.Commits() {
+		if vote == nil || vote.BlockHash() != header.Hash() {
+			continue
+		}
+		if address, err := types.VoteSender(signer, vote); err == nil {
+			committed[address] = true
+		}
+	}
+
+	participation := &types.Participation{
+		Proposer: header.Coinbase,
+		Round:    commit.Round(),
+		Voters:   make([]types.VoterActivity, len(validators)),
+	}
+	for i, address := range validators {
+		participation.Voters[i] = types.VoterActivity{
+			Address:      address,
+			PreCommitted: committed[address],
+		}
+	}
+	return participation, nil
+}
@@ -0,0 +1,129 @@
+package knode
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+// participationTestValidatorMgr is a minimal validator manager: called with a
+// bare selector it returns the validator count held in slot 0, otherwise it
+// returns the validator held in slot index+1.
+var participationTestValidatorMgr = common.FromHex("600436146016576004356001015460005260206000f35b6000546000526020" + "6000f3")
+
+func TestParticipationIndexer(t *testing.T) {
+	var (
+		mgr    = common.HexToAddress("0x161ad311f1d66381c17641b1b73042a4ca731f9f")
+		db     = kcoindb.NewMemDatabase()
+		config = *params.TestChainConfig
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		voters = make([]common.Address, len(keys))
+	)
+	config.ValidatorMgr = &mgr
+
+	storage := map[common.Hash]common.Hash{
+		common.Hash{}: common.BigToHash(big.NewInt(int64(len(keys)))),
+	}
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		voters[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		storage[common.BigToHash(big.NewInt(int64(i+1)))] = voters[i].Hash()
+	}
+	gspec := &core.Genesis{
+		Config: &config,
+		Alloc:  core.GenesisAlloc{mgr: {Code: participationTestValidatorMgr, Storage: storage, Balance: new(big.Int)}},
+	}
+	genesis := gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(config.ChainID)
+
+	// the first two validators pre-commit every block, in round 1
+	blocks, _ := core.GenerateChain(&config, genesis, konsensus.NewFaker(), db, 3, func(i int, b *core.BlockGen) {
+		parent := b.PrevBlock(i - 1)
+		commit := &types.Commit{PreCommits: types.Votes{}}
+		for _, key := range keys[:2] {
+			vote, err := types.SignVote(types.NewVote(parent.Number(), parent.Hash(), 1, types.PreCommit), signer, key)
+			if err != nil {
+				t.Fatalf("failed to sign vote: %v", err)
+			}
+			commit.PreCommits = append(commit.PreCommits, vote)
+		}
+		commit.FirstPreCommit = commit.PreCommits[0]
+		b.SetLastCommit(commit)
+	})
+	chain, err := core.NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+
+	// a validating node saw the third validator pre-voting the second block
+	rawdb.WriteParticipation(db, blocks[1].Hash(), 2, &types.Participation{
+		Proposer: blocks[1].Coinbase(),
+		Voters: []types.VoterActivity{
+			{Address: voters[0]},
+			{Address: voters[1]},
+			{Address: voters[2], PreVoted: true},
+		},
+	})
+
+	// index the blocks as the participation indexer does
+	indexer := &ParticipationIndexer{db: db, chain: chain}
+	if err := indexer.Reset(0, common.Hash{}); err != nil {
+		t.Fatalf("failed to reset indexer: %v", err)
+	}
+	for _, block := range blocks {
+		indexer.Process(block.Header())
+	}
+	if err := indexer.Commit(); err != nil {
+		t.Fatalf("failed to commit index: %v", err)
+	}
+
+	tests := []struct {
+		block    *types.Block
+		preVoted []bool
+	}{
+		{blocks[0], []bool{false, false, false}},
+		{blocks[1], []bool{false, false, true}},
+	}
+	for _, tt := range tests {
+		participation := rawdb.ReadParticipation(db, tt.block.Hash(), tt.block.NumberU64())
+		if participation == nil {
+			t.Fatalf("block #%d: participation not indexed", tt.block.NumberU64())
+		}
+		if participation.Round != 1 {
+			t.Errorf("block #%d: round mismatch: have %d, want 1", tt.block.NumberU64(), participation.Round)
+		}
+		if len(participation.Voters) != len(voters) {
+			t.Fatalf("block #%d: voter count mismatch: have %d, want %d", tt.block.NumberU64(), len(participation.Voters), len(voters))
+		}
+		for i, voter := range participation.Voters {
+			if voter.Address != voters[i] {
+				t.Errorf("block #%d voter %d: address mismatch: have %x, want %x", tt.block.NumberU64(), i, voter.Address, voters[i])
+			}
+			if voter.PreCommitted != (i < 2) {
+				t.Errorf("block #%d voter %d: pre-commit mismatch: have %v, want %v", tt.block.NumberU64(), i, voter.PreCommitted, i < 2)
+			}
+			if voter.PreVoted != tt.preVoted[i] {
+				t.Errorf("block #%d voter %d: pre-vote mismatch: have %v, want %v", tt.block.NumberU64(), i, voter.PreVoted, tt.preVoted[i])
+			}
+		}
+	}
+	// the head block isn't committed yet
+	if participation := rawdb.ReadParticipation(db, blocks[2].Hash(), 3); participation != nil {
+		t.Errorf("uncommitted head participation indexed: %+v", participation)
+	}
+}
@@ -40,6 +40,7 @@ const (
 	// above some healthy uncle limit, so use that.
 	maxQueuedAnns = 4
 
+	maxKnownProposals = 1024 // Maximum proposal hashes to keep in the known list (prevent DOS)
 	maxKnownVotes     = 1024 // Maximum vote hashes to keep in the known list (prevent DOS)
 	maxKnownFragments = 1024 // Maximum vote hashes to keep in the known list (prevent DOS)
 
@@ -52,6 +53,7 @@ type PeerInfo struct {
 	Version     int      `json:"version"` // Ethereum protocol version negotiated
 	BlockNumber *big.Int `json:"number"`  // Block number of the peer's blockchain
 	Head        string   `json:"head"`    // SHA3 hash of the peer's best owned block
+	Score       int      `json:"score"`   // Misbehaviour score of the peer, banned at 100
 }
 
 // propEvent is a block propagation, waiting for its turn in the broadcast queue.
@@ -71,10 +73,12 @@ type peer struct {
 	head        common.Hash
 	lock        sync.RWMutex
 
-	knownTxs       *set.Set // Set of transaction hashes known to be known by this peer
-	knownBlocks    *set.Set // Set of block hashes known to be known by this peer
-	knownVotes     *set.Set // set of vote hashes known to be known by this peer
-	knownFragments *set.Set // set of fragment hashes known to be known by this peer
+	knownTxs       *set.Set  // Set of transaction hashes known to be known by this peer
+	knownBlocks    *set.Set  // Set of block hashes known to be known by this peer
+	knownProposals *set.Set  // set of proposal hashes known to be known by this peer
+	knownVotes     *set.Set  // set of vote hashes known to be known by this peer
+	knownVoteSets  *voteSets // votes of the latest elections known to be known by this peer
+	knownFragments *set.Set  // set of fragment hashes known to be known by this peer
 
 	queuedTxs   chan []*types.Transaction // Queue of transactions to broadcast to the peer
 	queuedProps chan *propEvent           // Queue of blocks to broadcast to the peer
@@ -90,7 +94,9 @@ func newPeer(version int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
 		id:             fmt.Sprintf("%x", p.ID().Bytes()[:8]),
 		knownTxs:       set.New(),
 		knownBlocks:    set.New(),
+		knownProposals: set.New(),
 		knownVotes:     set.New(),
+		knownVoteSets:  newVoteSets(),
 		knownFragments: set.New(),
 		queuedTxs:      make(chan []*types.Transaction, maxQueuedTxs),
 		queuedProps:    make(chan *propEvent, maxQueuedProps),
@@ -174,6 +180,16 @@ func (p *peer) MarkBlock(hash common.Hash) {
 	p.knownBlocks.Add(hash)
 }
 
+// MarkProposal marks a proposal as known for the peer, ensuring that the
+// proposal will never be propagated to this particular peer.
+func (p *peer) MarkProposal(hash common.Hash) {
+	// If we reached the memory allowance, drop a previously known proposal hash
+	for p.knownProposals.Size() >= maxKnownProposals {
+		p.knownProposals.Pop()
+	}
+	p.knownProposals.Add(hash)
+}
+
 // MarkVote marks a vote as known for the peer, ensuring that the block will
 // never be propagated to this particular peer.
 func (p *peer) MarkVote(hash common.Hash) {
@@ -184,6 +200,18 @@ func (p *peer) MarkVote(hash common.Hash) {
 	p.knownVotes.Add(hash)
 }
 
+// MarkVoteBit marks the vote of the voter at the given index of the validator
+// set as known for the peer, ensuring that no vote of that voter for the vote
+// set will be propagated to this particular peer.
+func (p *peer) MarkVoteBit(key voteSetKey, index int) {
+	p.knownVoteSets.mark(key, index)
+}
+
+// MarkVoteBits marks the votes of a bitmap announced by the peer as known.
+func (p *peer) MarkVoteBits(key voteSetKey, bits voteBits) {
+	p.knownVoteSets.merge(key, bits)
+}
+
 // MarkFragment marks a block fragment as known for the peer, ensuring that the
 // fragment will never be propagated to this particular peer.
 func (p *peer) MarkFragment(hash common.Hash) {
@@ -269,18 +297,35 @@ func (p *peer) AsyncSendNewBlock(block *types.Block) {
 	}
 }
 
-// SendNewBlock propagates a proposal to a remote peer.
+// SendNewProposal propagates a proposal to a remote peer.
 func (p *peer) SendNewProposal(proposal *types.Proposal) error {
+	p.MarkProposal(proposal.Hash())
 	return p2p.Send(p.rw, ProposalMsg, proposal)
 }
 
-// SendNewBlock propagates a vote to a remote peer.
+// SendVote propagates a vote to a remote peer.
 func (p *peer) SendVote(vote *types.Vote) error {
+	p.MarkVote(vote.Hash())
 	return p2p.Send(p.rw, VoteMsg, vote)
 }
 
+// SendVoteSetBits announces the votes of a vote set known to the local node.
+// Peers running a protocol version without vote set bitmaps are skipped.
+func (p *peer) SendVoteSetBits(key voteSetKey, bits voteBits) error {
+	if p.version < protocol.Kcoin2 {
+		return nil
+	}
+	return p2p.Send(p.rw, VoteSetBitsMsg, voteSetBitsData{
+		BlockNumber: new(big.Int).SetUint64(key.blockNumber),
+		Round:       key.round,
+		Type:        key.voteType,
+		Votes:       bits,
+	})
+}
+
 // SendBlockFragment propagates a block fragment to a remote peer.
 func (p *peer) SendBlockFragment(blockNumber *big.Int, round uint64, data *types.BlockFragment) error {
+	p.MarkFragment(data.Proof)
 	return p2p.Send(p.rw, BlockFragmentMsg, blockFragmentData{blockNumber, round, data})
 }
 
@@ -518,15 +563,31 @@ func (ps *peerSet) PeersWithoutTx(hash common.Hash) []*peer {
 	return list
 }
 
-// PeersWithoutVote retrieves a list of peers that do not have a given vote
-// in their set of known hashes.
-func (ps *peerSet) PeersWithoutVote(hash common.Hash) []*peer {
+// PeersWithoutProposal retrieves a list of peers that do not have a given
+// proposal in their set of known hashes.
+func (ps *peerSet) PeersWithoutProposal(hash common.Hash) []*peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		if !p.knownProposals.Has(hash) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
+
+// PeersWithoutVote retrieves a list of peers that neither have a given vote in
+// their set of known hashes, nor the vote of its voter in their known vote sets.
+// A negative voter index only checks the known hashes.
+func (ps *peerSet) PeersWithoutVote(hash common.Hash, key voteSetKey, index int) []*peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
 
 	list := make([]*peer, 0, len(ps.peers))
 	for _, p := range ps.peers {
-		if !p.knownVotes.Has(hash) {
+		if !p.knownVotes.Has(hash) && !p.knownVoteSets.has(key, index) {
 			list = append(list, p)
 		}
 	}
@@ -33,6 +33,7 @@ const (
 	VoteMsg          = 0x12
 	ElectionMsg      = 0x13
 	BlockFragmentMsg = 0x14
+	VoteSetBitsMsg   = 0x15
 )
 
 type errCode int
@@ -66,7 +67,6 @@ var errorToString = map[int]string{
 	ErrSuspendedPeer:           "Suspended peer",
 }
 
-
 type txPool interface {
 	// AddRemotes should add the given transactions to the pool.
 	AddRemotes([]*types.Transaction) []error
@@ -175,3 +175,12 @@ type blockFragmentData struct {
 	Round       uint64
 	Data        *types.BlockFragment
 }
+
+// voteSetBitsData is the network packet that is sent to let the peers know the
+// votes of an election round a node has, one bit per validator of the set.
+type voteSetBitsData struct {
+	BlockNumber *big.Int
+	Round       uint64
+	Type        types.VoteType
+	Votes       []byte
+}
@@ -8,6 +8,7 @@ import (
 // Constants to match up protocol versions and messages
 const (
 	Kcoin1 = 1
+	Kcoin2 = 2 // vote set bitmaps
 
 	// Official short name of the protocol used during capability negotiation.
 	ProtocolName = "kcoin"
@@ -32,7 +33,7 @@ var Constants = struct {
 	strconv.Itoa(Kcoin1),
 	strings.ToUpper(ProtocolName) + strconv.Itoa(Kcoin1),         // ProtocolNameUpper+ProtocolVersionStr
 	[]byte(strings.ToUpper(ProtocolName) + strconv.Itoa(Kcoin1)), // ProtocolNameUpper+ProtocolVersionStr
-	[]uint{Kcoin1},
-	[]uint64{21},
+	[]uint{Kcoin2, Kcoin1},
+	[]uint64{22, 21},
 	10 * 1024 * 1024,
 }
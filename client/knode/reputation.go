@@ -0,0 +1,204 @@
+package knode
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/log"
+	"github.com/kowala-tech/kcoin/client/p2p/discover"
+	"github.com/kowala-tech/kcoin/client/rlp"
+)
+
+const (
+	penaltyInvalidMsg      = 25 // Undecodable or unexpected message
+	penaltyInvalidSync     = 25 // Peer dropped by the downloader or the fetcher
+	penaltyInvalidProposal = 20 // Proposal with a bad signature or out of the validator set
+	penaltyInvalidVote     = 10 // Vote with a bad signature or out of the validator set
+	penaltyInvalidFragment = 10 // Block fragment not matching its proof
+
+	banThreshold   = 100            // Misbehaviour score getting a peer banned
+	banDuration    = 24 * time.Hour // Time a peer stays banned for misbehaving
+	scoreDecayTime = time.Minute    // Time a misbehaviour point takes to be forgiven
+)
+
+// PeerBan is a peer refused by the node, for misbehaving or on request.
+type PeerBan struct {
+	ID      discover.NodeID `json:"id"`
+	Reason  string          `json:"reason"`
+	Expires uint64          `json:"expires"` // Unix time the ban is lifted at
+}
+
+// score is the misbehaviour score of a peer, as of a given time.
+type score struct {
+	value   int
+	updated time.Time
+}
+
+// current returns the score decayed until the given time.
+func (s *score) current(now time.Time) int {
+	value := s.value - int(now.Sub(s.updated)/scoreDecayTime)
+	if value < 0 {
+		return 0
+	}
+	return value
+}
+
+// reputations keeps track of the misbehaviour of the peers, across their
+// connections, and of the bans of the repeat offenders. The bans are persisted
+// in the database of the node.
+type reputations struct {
+	db     kcoindb.Database
+	scores map[discover.NodeID]*score
+	bans   map[discover.NodeID]*PeerBan
+	lock   sync.Mutex
+}
+
+// newReputations creates a reputation tracker, loading the bans still in force
+// from the database.
+func newReputations(db kcoindb.Database) *reputations {
+	rep := &reputations{
+		db:     db,
+		scores: make(map[discover.NodeID]*score),
+		bans:   make(map[discover.NodeID]*PeerBan),
+	}
+	if data := rawdb.ReadPeerBans(db); len(data) > 0 {
+		var bans []*PeerBan
+		if err := rlp.DecodeBytes(data, &bans); err != nil {
+			log.Error("Invalid peer bans in the database", "err", err)
+		}
+		now := uint64(time.Now().Unix())
+		for _, ban := range bans {
+			if ban.Expires > now {
+				rep.bans[ban.ID] = ban
+			}
+		}
+	}
+	return rep
+}
+
+// score returns the current misbehaviour score of a peer.
+func (rep *reputations) score(id discover.NodeID) int {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	if s, ok := rep.scores[id]; ok {
+		return s.current(time.Now())
+	}
+	return 0
+}
+
+// penalize adds a penalty to the score of a peer, banning it if the score
+// reaches the ban threshold. It reports whether the peer got banned.
+func (rep *reputations) penalize(id discover.NodeID, penalty int, reason string) bool {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	now := time.Now()
+	s, ok := rep.scores[id]
+	if !ok {
+		s = new(score)
+		rep.scores[id] = s
+	}
+	s.value, s.updated = s.current(now)+penalty, now
+
+	// Drop the scores forgiven by now, to keep the memory in check
+	for other, s := range rep.scores {
+		if s.current(now) == 0 {
+			delete(rep.scores, other)
+		}
+	}
+	if s.value < banThreshold {
+		return false
+	}
+	delete(rep.scores, id)
+	rep.setBan(id, reason, banDuration)
+	return true
+}
+
+// ban bans a peer for the given duration, replacing any ban in force.
+func (rep *reputations) ban(id discover.NodeID, reason string, duration time.Duration) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	rep.setBan(id, reason, duration)
+}
+
+// setBan bans a peer and persists the bans. It must be called with the lock
+// held.
+func (rep *reputations) setBan(id discover.NodeID, reason string, duration time.Duration) {
+	rep.bans[id] = &PeerBan{
+		ID:      id,
+		Reason:  reason,
+		Expires: uint64(time.Now().Add(duration).Unix()),
+	}
+	rep.store()
+}
+
+// unban lifts the ban of a peer, reporting whether it was banned.
+func (rep *reputations) unban(id discover.NodeID) bool {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	_, ok := rep.bans[id]
+	delete(rep.bans, id)
+	delete(rep.scores, id)
+	if ok {
+		rep.store()
+	}
+	return ok
+}
+
+// banned returns the ban in force of a peer, nil if the peer isn't banned.
+func (rep *reputations) banned(id discover.NodeID) *PeerBan {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	ban, ok := rep.bans[id]
+	if !ok {
+		return nil
+	}
+	if ban.Expires <= uint64(time.Now().Unix()) {
+		delete(rep.bans, id)
+		rep.store()
+		return nil
+	}
+	return ban
+}
+
+// list returns the bans in force, the ones expiring first first.
+func (rep *reputations) list() []PeerBan {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	now := uint64(time.Now().Unix())
+	bans := make([]PeerBan, 0, len(rep.bans))
+	for _, ban := range rep.bans {
+		if ban.Expires > now {
+			bans = append(bans, *ban)
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool { return bans[i].Expires < bans[j].Expires })
+	return bans
+}
+
+// store persists the bans in force. It must be called with the lock held.
+func (rep *reputations) store() {
+	now := uint64(time.Now().Unix())
+	bans := make([]*PeerBan, 0, len(rep.bans))
+	for id, ban := range rep.bans {
+		if ban.Expires <= now {
+			delete(rep.bans, id)
+			continue
+		}
+		bans = append(bans, ban)
+	}
+	data, err := rlp.EncodeToBytes(bans)
+	if err != nil {
+		log.Error("Failed to encode the peer bans", "err", err)
+		return
+	}
+	rawdb.WritePeerBans(rep.db, data)
+}
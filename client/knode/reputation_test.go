@@ -0,0 +1,89 @@
+package knode
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/p2p/discover"
+)
+
+func TestReputationBansRepeatOffenders(t *testing.T) {
+	rep := newReputations(kcoindb.NewMemDatabase())
+	id := discover.NodeID{1}
+
+	for i := 0; i < banThreshold/penaltyInvalidVote-1; i++ {
+		if rep.penalize(id, penaltyInvalidVote, "invalid vote") {
+			t.Fatalf("peer banned after %d invalid votes", i+1)
+		}
+	}
+	if have, want := rep.score(id), banThreshold-penaltyInvalidVote; have != want {
+		t.Fatalf("score mismatch: have %d, want %d", have, want)
+	}
+	if rep.banned(id) != nil {
+		t.Fatalf("peer banned below the threshold")
+	}
+	if !rep.penalize(id, penaltyInvalidVote, "invalid vote") {
+		t.Fatalf("peer not banned at the threshold")
+	}
+	ban := rep.banned(id)
+	if ban == nil {
+		t.Fatalf("ban not in force")
+	}
+	if ban.Reason != "invalid vote" {
+		t.Errorf("ban reason mismatch: have %q, want %q", ban.Reason, "invalid vote")
+	}
+	if rep.score(id) != 0 {
+		t.Errorf("score kept after the ban")
+	}
+}
+
+func TestReputationScoreDecay(t *testing.T) {
+	rep := newReputations(kcoindb.NewMemDatabase())
+	id := discover.NodeID{1}
+
+	rep.penalize(id, penaltyInvalidMsg, "invalid message")
+	rep.scores[id].updated = rep.scores[id].updated.Add(-10 * scoreDecayTime)
+	if have, want := rep.score(id), penaltyInvalidMsg-10; have != want {
+		t.Fatalf("decayed score mismatch: have %d, want %d", have, want)
+	}
+	rep.scores[id].updated = rep.scores[id].updated.Add(-penaltyInvalidMsg * scoreDecayTime)
+	if have := rep.score(id); have != 0 {
+		t.Fatalf("score not forgiven: have %d", have)
+	}
+	// Forgiven scores are dropped on the next penalty
+	rep.penalize(discover.NodeID{2}, penaltyInvalidVote, "invalid vote")
+	if _, ok := rep.scores[id]; ok {
+		t.Fatalf("forgiven score kept")
+	}
+}
+
+func TestReputationBansPersisted(t *testing.T) {
+	db := kcoindb.NewMemDatabase()
+	rep := newReputations(db)
+
+	banned, expired := discover.NodeID{1}, discover.NodeID{2}
+	rep.ban(banned, "on request", time.Hour)
+	rep.ban(expired, "on request", -time.Second)
+
+	rep = newReputations(db)
+	if rep.banned(banned) == nil {
+		t.Fatalf("ban lost on reload")
+	}
+	if rep.banned(expired) != nil {
+		t.Fatalf("expired ban in force")
+	}
+	if bans := rep.list(); len(bans) != 1 || bans[0].ID != banned {
+		t.Fatalf("ban list mismatch: %v", bans)
+	}
+
+	if !rep.unban(banned) {
+		t.Fatalf("ban not lifted")
+	}
+	if rep.unban(banned) {
+		t.Fatalf("lifted ban lifted again")
+	}
+	if rep = newReputations(db); rep.banned(banned) != nil {
+		t.Fatalf("lifted ban in force after reload")
+	}
+}
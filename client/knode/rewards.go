@@ -0,0 +1,148 @@
+package knode
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+const (
+	// rewardsSectionSize is the number of blocks of a rewards index section.
+	// Sections are kept short so that the parent states used to price the
+	// stable fee transactions are still available when a section is processed.
+	rewardsSectionSize = 64
+
+	// rewardsConfirms is the number of confirmation blocks before a rewards
+	// section is processed.
+	rewardsConfirms = 8
+
+	// rewardsThrottling is the time to wait between processing two consecutive
+	// index sections.
+	rewardsThrottling = 10 * time.Millisecond
+)
+
+var errMissingBlock = errors.New("missing block")
+
+// RewardsIndexer implements a core.ChainIndexer, recording the rewards credited
+// to the proposer of every canonical block.
+type RewardsIndexer struct {
+	db    kcoindb.Database // database instance to write index data into
+	chain *core.BlockChain // blockchain the blocks and the states are read from
+
+	batch kcoindb.Batch // batch of the records of the section being processed
+}
+
+// NewRewardsIndexer returns a chain indexer that records the rewards of the
+// canonical chain blocks.
+func NewRewardsIndexer(db kcoindb.Database, chain *core.BlockChain) *core.ChainIndexer {
+	backend := &RewardsIndexer{
+		db:    db,
+		chain: chain,
+	}
+	table := kcoindb.NewTable(db, string(rawdb.RewardsIndexPrefix))
+
+	return core.NewChainIndexer(db, table, backend, rewardsSectionSize, rewardsConfirms, rewardsThrottling, "rewards")
+}
+
+// Reset implements core.ChainIndexerBackend, starting a new rewards section.
+func (r *RewardsIndexer) Reset(section uint64, lastSectionHead common.Hash) error {
+	r.batch = r.db.NewBatch()
+	return nil
+}
+
+// Process implements core.ChainIndexerBackend, recording the rewards of a block.
+func (r *RewardsIndexer) Process(header *types.Header) {
+	rewards, err := blockRewards(r.chain, header)
+	if err != nil {
+		// the rewards are derived from the chain on lookup instead
+		log.Error("Failed to index block rewards", "number", header.Number, "hash", header.Hash(), "err", err)
+		return
+	}
+	rawdb.WriteBlockRewards(r.batch, header.Hash(), header.Number.Uint64(), rewards)
+}
+
+// Commit implements core.ChainIndexerBackend, writing out the section records.
+func (r *RewardsIndexer) Commit() error {
+	return r.batch.Write()
+}
+
+// blockRewards derives the rewards credited to the proposer of a block.
+func blockRewards(chain *core.BlockChain, header *types.Header) (*types.BlockRewards, error) {
+	rewards := &types.BlockRewards{
+		Proposer: header.Coinbase,
+		Reward:   new(big.Int),
+		Fees:     new(big.Int),
+	}
+	if header.Number.Sign() == 0 {
+		return rewards, nil
+	}
+	rewards.Reward.Set(konsensus.AndromedaBlockReward)
+
+	block := chain.GetBlock(header.Hash(), header.Number.Uint64())
+	if block == nil {
+		return nil, errMissingBlock
+	}
+	receipts := chain.GetReceiptsByHash(block.Hash())
+	if len(receipts) != len(block.Transactions()) {
+		return nil, errMissingBlock
+	}
+
+	// @NOTE - stable fee transactions are priced at the oracle price of the
+	// parent state, which is off if the price is updated within the block
+	var oraclePrice *big.Int
+	for i, tx := range block.Transactions() {
+		price := tx.GasPrice()
+		if feeCap := tx.FeeCap(); feeCap != nil {
+			if oraclePrice == nil {
+				oraclePrice = parentOraclePrice(chain, header)
+			}
+			if oraclePrice.Sign() == 0 {
+				rewards.UnpricedTxs++
+				continue
+			}
+			price = core.StableFeeGasPrice(feeCap, oraclePrice, tx.Gas())
+		}
+		rewards.Fees.Add(rewards.Fees, price.Mul(price, new(big.Int).SetUint64(receipts[i].GasUsed)))
+	}
+
+	// the pre-commits on the block are carried by its child
+	if commit := chain.GetCommit(block.Hash()); commit != nil {
+		signer := types.NewAndromedaSigner(chain.Config().ChainID)
+		for _, vote := range commit.Commits() {
+			if vote == nil || vote.BlockHash() != block.Hash() {
+				continue
+			}
+			if address, err := types.VoteSender(signer, vote); err == nil {
+				rewards.Participants = append(rewards.Participants, address)
+			}
+		}
+	}
+
+	return rewards, nil
+}
+
+// parentOraclePrice returns the oracle price of the parent state of a block,
+// zero if the state isn't available.
+func parentOraclePrice(chain *core.BlockChain, header *types.Header) *big.Int {
+	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64()-1)
+	if parent == nil {
+		return new(big.Int)
+	}
+	statedb, err := chain.StateAt(parent.Root)
+	if err != nil {
+		return new(big.Int)
+	}
+	price, err := core.OraclePrice(chain.Config(), header, statedb)
+	if err != nil {
+		return new(big.Int)
+	}
+	return price
+}
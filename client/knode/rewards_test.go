@@ -0,0 +1,127 @@
+package knode
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+func TestBlockRewards(t *testing.T) {
+	var (
+		key, _    = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+		sender    = crypto.PubkeyToAddress(key.PublicKey)
+		proposer  = common.HexToAddress("0x1000000000000000000000000000000000000000")
+		recipient = common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+		oracleMgr = common.HexToAddress("0x4C55B59340FF1398d6aaE362A140D6e93855D4A5")
+		price     = new(big.Int).Mul(big.NewInt(2), big.NewInt(params.Kcoin))
+		db        = kcoindb.NewMemDatabase()
+		config    = *params.TestChainConfig
+	)
+	config.OracleMgr = &oracleMgr
+
+	// oracle manager answering any call with the price
+	oracleCode := append([]byte{byte(vm.PUSH32)}, common.LeftPadBytes(price.Bytes(), 32)...)
+	oracleCode = append(oracleCode, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+
+	gspec := &core.Genesis{
+		Config: &config,
+		Alloc: core.GenesisAlloc{
+			sender:    {Balance: big.NewInt(params.Kcoin)},
+			oracleMgr: {Code: oracleCode, Balance: new(big.Int)},
+		},
+	}
+	genesis := gspec.MustCommit(db)
+	signer := types.NewAndromedaSigner(config.ChainID)
+
+	plainTx, err := types.SignTx(types.NewTransaction(0, recipient, big.NewInt(1000), params.TxGas, big.NewInt(2), nil), signer, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+	stableTx, err := types.SignTx(types.NewStableFeeTransaction(1, &recipient, big.NewInt(1000), params.TxGas, big.NewInt(50), nil), signer, key)
+	if err != nil {
+		t.Fatalf("failed to sign transaction: %v", err)
+	}
+
+	blocks, _ := core.GenerateChain(&config, genesis, konsensus.NewFaker(), db, 2, func(i int, b *core.BlockGen) {
+		parent := b.PrevBlock(i - 1)
+		vote, err := types.SignVote(types.NewVote(parent.Number(), parent.Hash(), 0, types.PreCommit), signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign vote: %v", err)
+		}
+		b.SetLastCommit(&types.Commit{PreCommits: types.Votes{vote}, FirstPreCommit: vote})
+		if i == 0 {
+			b.SetCoinbase(proposer)
+			b.AddTx(plainTx)
+			b.AddTx(stableTx)
+		}
+	})
+	chain, err := core.NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	if _, err := chain.InsertChain(blocks); err != nil {
+		t.Fatalf("failed to insert chain: %v", err)
+	}
+
+	// index the block as the rewards indexer does
+	indexer := &RewardsIndexer{db: db, chain: chain}
+	if err := indexer.Reset(0, common.Hash{}); err != nil {
+		t.Fatalf("failed to reset indexer: %v", err)
+	}
+	indexer.Process(blocks[0].Header())
+	if err := indexer.Commit(); err != nil {
+		t.Fatalf("failed to commit index: %v", err)
+	}
+	rewards := rawdb.ReadBlockRewards(db, blocks[0].Hash(), 1)
+	if rewards == nil {
+		t.Fatal("block rewards not indexed")
+	}
+
+	fees := new(big.Int).Mul(big.NewInt(2), new(big.Int).SetUint64(params.TxGas))
+	stableFee := new(big.Int).Mul(core.StableFeeGasPrice(big.NewInt(50), price, params.TxGas), new(big.Int).SetUint64(params.TxGas))
+	fees.Add(fees, stableFee)
+
+	if rewards.Proposer != proposer {
+		t.Errorf("proposer mismatch: have %x, want %x", rewards.Proposer, proposer)
+	}
+	if rewards.Reward.Cmp(konsensus.AndromedaBlockReward) != 0 {
+		t.Errorf("reward mismatch: have %v, want %v", rewards.Reward, konsensus.AndromedaBlockReward)
+	}
+	if rewards.Fees.Cmp(fees) != 0 {
+		t.Errorf("fees mismatch: have %v, want %v", rewards.Fees, fees)
+	}
+	if rewards.UnpricedTxs != 0 {
+		t.Errorf("unpriced transactions: have %d, want 0", rewards.UnpricedTxs)
+	}
+	// the pre-commits on the block are recorded against it, not its child
+	if len(rewards.Participants) != 1 || rewards.Participants[0] != sender {
+		t.Errorf("participants mismatch: have %x, want [%x]", rewards.Participants, sender)
+	}
+	head, err := blockRewards(chain, blocks[1].Header())
+	if err != nil {
+		t.Fatalf("failed to derive head rewards: %v", err)
+	}
+	if len(head.Participants) != 0 {
+		t.Errorf("uncommitted head participants: have %x, want none", head.Participants)
+	}
+
+	// the genesis block mints no reward
+	rewards, err = blockRewards(chain, genesis.Header())
+	if err != nil {
+		t.Fatalf("failed to derive genesis rewards: %v", err)
+	}
+	if rewards.Total().Sign() != 0 {
+		t.Errorf("genesis rewards: have %v, want 0", rewards.Total())
+	}
+}
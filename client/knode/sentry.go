@@ -0,0 +1,132 @@
+package knode
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/knode/validator"
+)
+
+// maxRelayLookahead is the number of elections past the next one whose
+// consensus messages a sentry relays, so that a sentry a block behind the
+// validators doesn't hold their messages back.
+const maxRelayLookahead = 1
+
+// electionRound identifies a round of an election.
+type electionRound struct {
+	blockNumber uint64
+	round       uint64
+}
+
+// sentryRelay checks the consensus messages that a sentry node relays for the
+// validators behind it, so that the sentry only relays the messages of the
+// validator set of the chain head.
+type sentryRelay struct {
+	chain  *core.BlockChain
+	signer types.Signer
+
+	lock       sync.Mutex
+	head       common.Hash                      // Block the validator set was read at
+	validators map[common.Address]bool          // Validator set of the head block
+	fragments  map[electionRound]*types.DataSet // Block fragments of the relayed proposals
+}
+
+func newSentryRelay(chain *core.BlockChain) *sentryRelay {
+	return &sentryRelay{
+		chain:     chain,
+		signer:    types.NewAndromedaSigner(chain.Config().ChainID),
+		fragments: make(map[electionRound]*types.DataSet),
+	}
+}
+
+// checkProposal checks that a proposal is signed by a validator, keeping its
+// block metadata to check the fragments of the block against.
+func (r *sentryRelay) checkProposal(proposal *types.Proposal) error {
+	proposer, err := types.ProposalSender(r.signer, proposal)
+	if err != nil {
+		return validator.ErrInvalidSignature
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if err := r.checkSender(proposal.BlockNumber(), proposer); err != nil {
+		return err
+	}
+	key := electionRound{proposal.BlockNumber().Uint64(), proposal.Round()}
+	if _, ok := r.fragments[key]; !ok && proposal.BlockMetadata() != nil {
+		r.fragments[key] = types.NewDataSetFromMeta(proposal.BlockMetadata())
+	}
+	return nil
+}
+
+// checkVote checks that a vote is signed by a validator.
+func (r *sentryRelay) checkVote(vote *types.Vote) error {
+	voter, err := types.VoteSender(r.signer, vote)
+	if err != nil {
+		return validator.ErrInvalidSignature
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return r.checkSender(vote.BlockNumber(), voter)
+}
+
+// checkFragment checks that a block fragment belongs to a relayed proposal and
+// matches its proof.
+func (r *sentryRelay) checkFragment(blockNumber *big.Int, round uint64, fragment *types.BlockFragment) error {
+	if blockNumber == nil {
+		return validator.ErrInvalidBlockFragment
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	fragments, ok := r.fragments[electionRound{blockNumber.Uint64(), round}]
+	if !ok {
+		return validator.ErrCantAddBlockFragmentNoProposal
+	}
+	if err := fragments.Add(fragment); err != nil {
+		return validator.ErrInvalidBlockFragment
+	}
+	return nil
+}
+
+// checkSender checks that a consensus message of the given election comes from
+// a validator. It must be called with the lock held.
+func (r *sentryRelay) checkSender(blockNumber *big.Int, sender common.Address) error {
+	head := r.chain.CurrentBlock()
+	if head.Hash() != r.head {
+		statedb, err := r.chain.StateAt(head.Root())
+		if err != nil {
+			return err
+		}
+		validators, err := core.ValidatorsAt(r.chain.Config(), head.Header(), statedb)
+		if err != nil {
+			return err
+		}
+		r.head = head.Hash()
+		r.validators = make(map[common.Address]bool, len(validators))
+		for _, address := range validators {
+			r.validators[address] = true
+		}
+		// the fragments of the past elections are never relayed again
+		for key := range r.fragments {
+			if key.blockNumber <= head.NumberU64() {
+				delete(r.fragments, key)
+			}
+		}
+	}
+
+	if blockNumber == nil || blockNumber.Uint64() <= head.NumberU64() || blockNumber.Uint64() > head.NumberU64()+1+maxRelayLookahead {
+		return validator.ErrCantAddMessageOtherElection
+	}
+	if !r.validators[sender] {
+		return validator.ErrNotVoter
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package knode
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/knode/validator"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+// sentryTestValidatorMgr is a minimal validator manager: called with a bare
+// selector it returns the validator count held in slot 0, otherwise it returns
+// the validator held in slot index+1.
+var sentryTestValidatorMgr = common.FromHex("600436146016576004356001015460005260206000f35b60005460005260206000f3")
+
+func TestSentryRelay(t *testing.T) {
+	var (
+		validatorKey, _ = crypto.GenerateKey()
+		outsiderKey, _  = crypto.GenerateKey()
+		mgr             = common.HexToAddress("0x161ad311f1d66381c17641b1b73042a4ca731f9f")
+		config          = *params.TestChainConfig
+		db              = kcoindb.NewMemDatabase()
+	)
+	config.ValidatorMgr = &mgr
+
+	gspec := &core.Genesis{
+		Config: &config,
+		Alloc: core.GenesisAlloc{mgr: {Code: sentryTestValidatorMgr, Balance: new(big.Int), Storage: map[common.Hash]common.Hash{
+			common.Hash{}:                   common.BigToHash(big.NewInt(1)),
+			common.BigToHash(big.NewInt(1)): crypto.PubkeyToAddress(validatorKey.PublicKey).Hash(),
+		}}},
+	}
+	gspec.MustCommit(db)
+	chain, err := core.NewBlockChain(db, nil, &config, konsensus.NewFaker(), vm.Config{})
+	if err != nil {
+		t.Fatalf("failed to create blockchain: %v", err)
+	}
+	defer chain.Stop()
+
+	relay := newSentryRelay(chain)
+	signer := types.NewAndromedaSigner(config.ChainID)
+
+	vote := func(number int64, key *ecdsa.PrivateKey) *types.Vote {
+		vote := types.NewVote(big.NewInt(number), common.Hash{}, 0, types.PreVote)
+		if key == nil {
+			return vote
+		}
+		signed, err := types.SignVote(vote, signer, key)
+		if err != nil {
+			t.Fatalf("failed to sign vote: %v", err)
+		}
+		return signed
+	}
+	tests := []struct {
+		vote *types.Vote
+		err  error
+	}{
+		{vote(1, validatorKey), nil},
+		{vote(2, validatorKey), nil},
+		{vote(1, outsiderKey), validator.ErrNotVoter},
+		{vote(1, nil), validator.ErrInvalidSignature},
+		{vote(0, validatorKey), validator.ErrCantAddMessageOtherElection},
+		{vote(3, validatorKey), validator.ErrCantAddMessageOtherElection},
+	}
+	for i, tt := range tests {
+		if err := relay.checkVote(tt.vote); err != tt.err {
+			t.Errorf("vote %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+
+	// The fragments are only relayed for the proposals of the validators
+	fragments := types.NewDataSetFromData([]byte("block data"), 4)
+	fragment := fragments.Get(0)
+	if err := relay.checkFragment(big.NewInt(1), 0, fragment); err != validator.ErrCantAddBlockFragmentNoProposal {
+		t.Errorf("fragment without proposal: error mismatch: have %v, want %v", err, validator.ErrCantAddBlockFragmentNoProposal)
+	}
+	proposal, err := types.SignProposal(types.NewProposal(big.NewInt(1), 0, fragments.Metadata(), 0, common.Hash{}), signer, outsiderKey)
+	if err != nil {
+		t.Fatalf("failed to sign proposal: %v", err)
+	}
+	if err := relay.checkProposal(proposal); err != validator.ErrNotVoter {
+		t.Errorf("proposal of a non-validator: error mismatch: have %v, want %v", err, validator.ErrNotVoter)
+	}
+	if err := relay.checkFragment(big.NewInt(1), 0, fragment); err != validator.ErrCantAddBlockFragmentNoProposal {
+		t.Errorf("fragment of a rejected proposal: error mismatch: have %v, want %v", err, validator.ErrCantAddBlockFragmentNoProposal)
+	}
+	proposal, err = types.SignProposal(types.NewProposal(big.NewInt(1), 0, fragments.Metadata(), 0, common.Hash{}), signer, validatorKey)
+	if err != nil {
+		t.Fatalf("failed to sign proposal: %v", err)
+	}
+	if err := relay.checkProposal(proposal); err != nil {
+		t.Fatalf("failed to check proposal: %v", err)
+	}
+	if err := relay.checkFragment(big.NewInt(1), 0, fragment); err != nil {
+		t.Errorf("failed to check fragment: %v", err)
+	}
+	forged := &types.BlockFragment{Index: 1, Data: []byte("forged"), Proof: fragment.Proof}
+	if err := relay.checkFragment(big.NewInt(1), 0, forged); err != validator.ErrInvalidBlockFragment {
+		t.Errorf("forged fragment: error mismatch: have %v, want %v", err, validator.ErrInvalidBlockFragment)
+	}
+}
@@ -0,0 +1,16 @@
+package validator
+
+import "time"
+
+// Clock is the time source of the consensus state machine. Simulations replace
+// the wall clock to drive the election timeouts.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the wall clock.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/client/core"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/event"
@@ -28,6 +29,9 @@ type VotingState struct {
 
 	start time.Time // used to sync the validator nodes
 
+	roundStart time.Time // start of the current round (metrics)
+	rounds     int       // number of rounds started for the current block (metrics)
+
 	commitRound int
 
 	// inputs
@@ -67,6 +71,7 @@ type VotingSystem struct {
 	electionNumber *big.Int // election number
 	round          uint64
 	votesPerRound  map[uint64]VotingTables
+	voted          [2]map[common.Address]bool // voters per vote type, any round
 
 	eventMux *event.TypeMux
 }
@@ -78,6 +83,7 @@ func NewVotingSystem(eventMux *event.TypeMux, electionNumber *big.Int, voters ty
 		electionNumber: electionNumber,
 		round:          0,
 		votesPerRound:  make(map[uint64]VotingTables),
+		voted:          [2]map[common.Address]bool{make(map[common.Address]bool), make(map[common.Address]bool)},
 		eventMux:       eventMux,
 	}
 
@@ -109,12 +115,22 @@ func (vs *VotingSystem) Add(vote types.AddressVote) error {
 	if err != nil {
 		return err
 	}
+	vs.voted[vote.Vote().Type()][vote.Address()] = true
 
 	go vs.eventMux.Post(core.NewVoteEvent{Vote: vote.Vote()})
 
 	return nil
 }
 
+// Voted reports whether the given voter cast a vote of the given type in any
+// round of the election.
+func (vs *VotingSystem) Voted(voteType types.VoteType, address common.Address) bool {
+	if uint64(voteType) > uint64(len(vs.voted)-1) {
+		return false
+	}
+	return vs.voted[voteType][address]
+}
+
 func (vs *VotingSystem) getVoteSet(round uint64, voteType types.VoteType) (core.VotingTable, error) {
 	votingTables, ok := vs.votesPerRound[round]
 	if !ok {
@@ -79,3 +79,19 @@ func TestVotingSystem_AddVoteAddsVoteToTable(t *testing.T) {
 
 	addressVote.AssertExpectations(t)
 }
+
+func TestVotingSystem_VotedAnyRound(t *testing.T) {
+	address := common.HexToAddress("0x1000000000000000000000000000000000000000")
+	voters, err := types.NewVoters([]*types.Voter{types.NewVoter(address, common.Big0, big.NewInt(1))})
+	require.NoError(t, err)
+	addressVote := &mocks.AddressVote{}
+	addressVote.On("Vote").Return(types.NewVote(big.NewInt(1), common.Hash{}, 0, types.PreCommit))
+	addressVote.On("Address").Return(address)
+	votingSystem, err := NewVotingSystem(&event.TypeMux{}, big.NewInt(1), voters)
+	require.NoError(t, err)
+
+	require.NoError(t, votingSystem.Add(addressVote))
+
+	assert.True(t, votingSystem.Voted(types.PreCommit, address))
+	assert.False(t, votingSystem.Voted(types.PreVote, address))
+}
@@ -0,0 +1,296 @@
+package validator
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+const (
+	chainHeadChanSize = 10 // Size of the channel listening to the chain head events
+	redeemRetryBlocks = 10 // Blocks to wait for a redeem request to be mined before requesting it again
+)
+
+var (
+	ErrNoDepositAtStake    = errors.New("no deposit at stake")
+	ErrDepositUnchanged    = errors.New("new deposit matches the deposit at stake")
+	ErrDepositTooLow       = errors.New("new deposit below the minimum deposit")
+	ErrWithdrawalTooHigh   = errors.New("withdrawal exceeds the deposit at stake, stop the validator to withdraw the whole deposit")
+	ErrInsufficientBalance = errors.New("insufficient mUSD balance to lock the new deposit")
+	ErrLastValidator       = errors.New("the last validator can't leave the election to rejoin it")
+	ErrRebalancing         = errors.New("deposit rebalance already in progress")
+	errSubscriptionClosed  = errors.New("chain head subscription closed")
+)
+
+// rebalance is a change of the deposit at stake of a running validator.
+type rebalance struct {
+	previous *big.Int
+	deposit  *big.Int
+}
+
+// TopUpDeposit raises the deposit at stake of the running validator by the
+// given amount, returning the new deposit. The validator manager can't top up
+// a deposit, so the validator rejoins the election with a new deposit locked in
+// full from its balance, and the previous deposit is released after the freeze
+// period.
+func (val *validator) TopUpDeposit(amount *big.Int) (*big.Int, error) {
+	if !val.Validating() {
+		return nil, ErrIsNotRunning
+	}
+	current, err := val.stake()
+	if err != nil {
+		return nil, err
+	}
+	deposit := new(big.Int).Add(current, amount)
+	if err := val.SetDeposit(deposit); err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+// WithdrawDeposit lowers the deposit at stake of the running validator by the
+// given amount, returning the new deposit. The validator manager only releases
+// a deposit as a whole, so the validator rejoins the election with the lower
+// deposit locked in full from its balance, and the previous deposit is
+// released after the freeze period.
+func (val *validator) WithdrawDeposit(amount *big.Int) (*big.Int, error) {
+	if !val.Validating() {
+		return nil, ErrIsNotRunning
+	}
+	current, err := val.stake()
+	if err != nil {
+		return nil, err
+	}
+	if amount.Cmp(current) >= 0 {
+		return nil, ErrWithdrawalTooHigh
+	}
+	deposit := new(big.Int).Sub(current, amount)
+	if err := val.SetDeposit(deposit); err != nil {
+		return nil, err
+	}
+	return deposit, nil
+}
+
+// stake returns the deposit at stake of the validator, the latest deposit not
+// released by the validator manager.
+func (val *validator) stake() (*big.Int, error) {
+	if val.walletAccount == nil {
+		return nil, ErrIsNotRunning
+	}
+	deposits, err := val.consensus.Deposits(val.walletAccount.Account().Address)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(deposits) - 1; i >= 0; i-- {
+		if deposits[i].AvailableAtTimeUnix() == 0 {
+			return deposits[i].Amount(), nil
+		}
+	}
+	return nil, ErrNoDepositAtStake
+}
+
+// rebalanceDeposit leaves the election, the validator rejoining it with the new
+// deposit once out of the validator set. The validator manager doesn't allow
+// to change a deposit at stake, so the new deposit is locked in full from the
+// validator account, and the previous one released after the freeze period.
+// The validator only leaves if the validator set and its balance let it rejoin.
+func (val *validator) rebalanceDeposit(deposit *big.Int) error {
+	val.depositLock.Lock()
+	defer val.depositLock.Unlock()
+
+	if val.rebalance != nil {
+		return ErrRebalancing
+	}
+	current, err := val.stake()
+	if err != nil {
+		return err
+	}
+	if deposit.Cmp(current) == 0 {
+		return ErrDepositUnchanged
+	}
+	validators, err := val.consensus.Validators()
+	if err != nil {
+		return err
+	}
+	// the rejoin transaction is mined by the rest of the validators
+	if validators.Len() < 2 {
+		return ErrLastValidator
+	}
+	min, err := val.rejoinMinimum(validators)
+	if err != nil {
+		return err
+	}
+	if deposit.Cmp(min) < 0 {
+		return ErrDepositTooLow
+	}
+	balance, err := val.consensus.Token().BalanceOf(val.walletAccount.Account().Address)
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(deposit) < 0 {
+		return ErrInsufficientBalance
+	}
+
+	if err := val.consensus.Leave(val.walletAccount); err != nil {
+		return err
+	}
+	val.rebalance = &rebalance{previous: current, deposit: deposit}
+
+	log.Info("Rebalancing the deposit at stake", "previous", current, "deposit", deposit)
+	return nil
+}
+
+// rejoinMinimum returns the deposit that lets the validator rejoin the election
+// once out of the given validator set. A full set has a free position after the
+// validator leaves, but a candidate may take it first: the validator then has
+// to outbid the smallest bidder of the rest of the validators.
+func (val *validator) rejoinMinimum(validators types.Voters) (*big.Int, error) {
+	max, err := val.consensus.MaxValidators()
+	if err != nil {
+		return nil, err
+	}
+	if big.NewInt(int64(validators.Len())).Cmp(max) < 0 {
+		return val.consensus.MinimumDeposit()
+	}
+
+	var smallest *big.Int
+	for i := 0; i < validators.Len(); i++ {
+		validator := validators.At(i)
+		if validator.Address() == val.walletAccount.Account().Address {
+			continue
+		}
+		if smallest == nil || validator.Deposit().Cmp(smallest) < 0 {
+			smallest = validator.Deposit()
+		}
+	}
+	return new(big.Int).Add(smallest, common.Big1), nil
+}
+
+// takeRebalance returns the pending deposit rebalance, if any, and clears it.
+func (val *validator) takeRebalance() *rebalance {
+	val.depositLock.Lock()
+	defer val.depositLock.Unlock()
+
+	r := val.rebalance
+	val.rebalance = nil
+	return r
+}
+
+// rebalancing reports whether a deposit rebalance is pending.
+func (val *validator) rebalancing() bool {
+	val.depositLock.Lock()
+	defer val.depositLock.Unlock()
+
+	return val.rebalance != nil
+}
+
+// join makes a deposit to join the validator set and waits until the
+// registration is confirmed.
+func (val *validator) join(deposit *big.Int) error {
+	chainHeadCh := make(chan core.ChainHeadEvent)
+	chainHeadSub := val.chain.SubscribeChainHeadEvent(chainHeadCh)
+	defer chainHeadSub.Unsubscribe()
+
+	if err := val.consensus.Join(val.walletAccount, deposit); err != nil {
+		return err
+	}
+
+	log.Info("Waiting confirmation to participate in the consensus")
+	for {
+		if _, ok := <-chainHeadCh; !ok {
+			return errSubscriptionClosed
+		}
+		confirmed, err := val.consensus.IsValidator(val.walletAccount.Account().Address)
+		if err != nil {
+			log.Crit("Failed to verify the voter registration", "err", err)
+		}
+		if confirmed {
+			return nil
+		}
+	}
+}
+
+// SetAutoRedeem enables or disables the redemption of the deposits as soon as
+// their freeze period ends.
+func (val *validator) SetAutoRedeem(enabled bool) {
+	val.depositLock.Lock()
+	defer val.depositLock.Unlock()
+
+	if enabled == (val.autoRedeem != nil) {
+		return
+	}
+	if !enabled {
+		close(val.autoRedeem)
+		val.autoRedeem = nil
+		return
+	}
+	val.autoRedeem = make(chan struct{})
+	go val.redeemLoop(val.autoRedeem)
+}
+
+// AutoRedeem reports whether the deposits are redeemed as soon as their freeze
+// period ends.
+func (val *validator) AutoRedeem() bool {
+	val.depositLock.Lock()
+	defer val.depositLock.Unlock()
+
+	return val.autoRedeem != nil
+}
+
+// redeemLoop requests the redemption of the deposits of the validator account
+// past their freeze period, as the chain head moves.
+func (val *validator) redeemLoop(quit chan struct{}) {
+	chainHeadCh := make(chan core.ChainHeadEvent, chainHeadChanSize)
+	chainHeadSub := val.chain.SubscribeChainHeadEvent(chainHeadCh)
+	defer chainHeadSub.Unsubscribe()
+
+	var retry uint64 // block number before which a redemption isn't requested again
+	for {
+		select {
+		case ev := <-chainHeadCh:
+			if val.walletAccount == nil || ev.Block.NumberU64() < retry {
+				continue
+			}
+			address := val.walletAccount.Account().Address
+			deposits, err := val.consensus.Deposits(address)
+			if err != nil {
+				log.Warn("Failed to check the deposits to redeem", "err", err)
+				continue
+			}
+			amount := redeemable(deposits, ev.Block.Time().Int64())
+			if amount.Sign() == 0 {
+				continue
+			}
+			if err := val.consensus.RedeemDeposits(val.walletAccount); err != nil {
+				log.Error("Failed to redeem the deposits", "err", err)
+				continue
+			}
+			retry = ev.Block.NumberU64() + redeemRetryBlocks
+
+			log.Info("Redeeming the deposits past the freeze period", "amount", amount)
+			go val.eventMux.Post(core.DepositsRedeemedEvent{Validator: address, Amount: amount})
+
+		case <-chainHeadSub.Err():
+			return
+		case <-quit:
+			return
+		}
+	}
+}
+
+// redeemable returns the amount of the deposits released by the validator
+// manager at the given time, mirroring the release of the deposits in order.
+func redeemable(deposits []*types.Deposit, now int64) *big.Int {
+	amount := new(big.Int)
+	for _, deposit := range deposits {
+		if deposit.AvailableAtTimeUnix() == 0 || deposit.AvailableAtTimeUnix() > now {
+			break
+		}
+		amount.Add(amount, deposit.Amount())
+	}
+	return amount
+}
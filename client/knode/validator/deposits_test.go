@@ -0,0 +1,205 @@
+package validator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/token"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var depositAccount = accounts.Account{Address: common.HexToAddress("0x1000000000000000000000000000000000000000")}
+
+type depositWalletAccount struct {
+	accounts.Wallet
+}
+
+func (wa *depositWalletAccount) Account() accounts.Account {
+	return depositAccount
+}
+
+type depositToken struct {
+	token.Token
+	balance *big.Int
+}
+
+func (t *depositToken) BalanceOf(common.Address) (*big.Int, error) {
+	return t.balance, nil
+}
+
+type depositConsensus struct {
+	consensus.Consensus
+	deposits   []*types.Deposit
+	minimum    *big.Int
+	max        *big.Int
+	validators []*types.Voter
+	token      *depositToken
+	left       bool
+}
+
+func (c *depositConsensus) Validators() (types.Voters, error) {
+	return types.NewVoters(c.validators)
+}
+
+func (c *depositConsensus) MaxValidators() (*big.Int, error) {
+	return c.max, nil
+}
+
+func (c *depositConsensus) Deposits(common.Address) ([]*types.Deposit, error) {
+	return c.deposits, nil
+}
+
+func (c *depositConsensus) MinimumDeposit() (*big.Int, error) {
+	return c.minimum, nil
+}
+
+func (c *depositConsensus) Token() token.Token {
+	return c.token
+}
+
+func (c *depositConsensus) Leave(accounts.WalletAccount) error {
+	c.left = true
+	return nil
+}
+
+func newDepositValidator(deposits []*types.Deposit) (*validator, *depositConsensus) {
+	binding := &depositConsensus{
+		deposits: deposits,
+		minimum:  big.NewInt(100),
+		max:      big.NewInt(3),
+		validators: []*types.Voter{
+			types.NewVoter(otherValidator, big.NewInt(500), new(big.Int)),
+			types.NewVoter(depositAccount.Address, big.NewInt(200), new(big.Int)),
+		},
+		token: &depositToken{balance: big.NewInt(1000)},
+	}
+	return &validator{consensus: binding, walletAccount: &depositWalletAccount{}}, binding
+}
+
+func TestRedeemable(t *testing.T) {
+	deposits := []*types.Deposit{
+		types.NewDeposit(big.NewInt(1), 10),
+		types.NewDeposit(big.NewInt(2), 20),
+		types.NewDeposit(big.NewInt(4), 15), // released in order, after the previous one
+		types.NewDeposit(big.NewInt(8), 0),
+	}
+
+	assert.Equal(t, int64(0), redeemable(deposits, 5).Int64())
+	assert.Equal(t, int64(1), redeemable(deposits, 15).Int64())
+	assert.Equal(t, int64(7), redeemable(deposits, 20).Int64())
+	assert.Equal(t, int64(7), redeemable(deposits, 100).Int64())
+	assert.Equal(t, int64(0), redeemable(nil, 100).Int64())
+}
+
+func TestStake(t *testing.T) {
+	val, _ := newDepositValidator([]*types.Deposit{
+		types.NewDeposit(big.NewInt(100), 10),
+		types.NewDeposit(big.NewInt(200), 0),
+	})
+	stake, err := val.stake()
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(200), stake)
+
+	val, _ = newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(100), 10)})
+	_, err = val.stake()
+	assert.Equal(t, ErrNoDepositAtStake, err)
+}
+
+func TestRebalanceDeposit(t *testing.T) {
+	stake := []*types.Deposit{types.NewDeposit(big.NewInt(200), 0)}
+
+	tests := []struct {
+		name    string
+		deposit int64
+		err     error
+	}{
+		{"unchanged", 200, ErrDepositUnchanged},
+		{"above balance", 1001, ErrInsufficientBalance},
+		{"top up", 300, nil},
+		{"withdrawal", 100, nil},
+		{"withdrawal below minimum", 99, ErrDepositTooLow},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			val, binding := newDepositValidator(stake)
+
+			err := val.rebalanceDeposit(big.NewInt(test.deposit))
+			assert.Equal(t, test.err, err)
+			assert.Equal(t, test.err == nil, binding.left)
+			assert.Equal(t, test.err == nil, val.rebalancing())
+		})
+	}
+}
+
+func TestRebalanceDeposit_BelowMinimum(t *testing.T) {
+	// the minimum deposit rose past the deposit at stake and the top up
+	val, binding := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+	binding.minimum = big.NewInt(500)
+
+	assert.Equal(t, ErrDepositTooLow, val.rebalanceDeposit(big.NewInt(300)))
+	assert.False(t, binding.left)
+}
+
+func TestRebalanceDeposit_LastValidator(t *testing.T) {
+	val, binding := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+	binding.validators = binding.validators[1:]
+
+	assert.Equal(t, ErrLastValidator, val.rebalanceDeposit(big.NewInt(300)))
+	assert.False(t, binding.left)
+}
+
+func TestRebalanceDeposit_FullSet(t *testing.T) {
+	// a candidate may take the position released by the validator, which has
+	// to outbid the smallest of the other validators to rejoin
+	val, binding := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+	binding.max = big.NewInt(3)
+	binding.validators = []*types.Voter{
+		types.NewVoter(otherValidator, big.NewInt(500), new(big.Int)),
+		types.NewVoter(common.HexToAddress("0x3000000000000000000000000000000000000000"), big.NewInt(300), new(big.Int)),
+		types.NewVoter(depositAccount.Address, big.NewInt(200), new(big.Int)),
+	}
+
+	assert.Equal(t, ErrDepositTooLow, val.rebalanceDeposit(big.NewInt(300)))
+	assert.False(t, binding.left)
+	assert.NoError(t, val.rebalanceDeposit(big.NewInt(301)))
+	assert.True(t, binding.left)
+}
+
+func TestRebalanceDeposit_InProgress(t *testing.T) {
+	val, _ := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+	require.NoError(t, val.rebalanceDeposit(big.NewInt(300)))
+
+	assert.Equal(t, ErrRebalancing, val.rebalanceDeposit(big.NewInt(400)))
+
+	rebalance := val.takeRebalance()
+	require.NotNil(t, rebalance)
+	assert.Equal(t, big.NewInt(200), rebalance.previous)
+	assert.Equal(t, big.NewInt(300), rebalance.deposit)
+	assert.False(t, val.rebalancing())
+}
+
+func TestWithdrawDeposit(t *testing.T) {
+	val, binding := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+	val.validating = 1
+
+	_, err := val.WithdrawDeposit(big.NewInt(200))
+	assert.Equal(t, ErrWithdrawalTooHigh, err)
+
+	deposit, err := val.WithdrawDeposit(big.NewInt(50))
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(150), deposit)
+	assert.True(t, binding.left)
+	assert.Equal(t, big.NewInt(150), val.takeRebalance().deposit)
+}
+
+func TestWithdrawDeposit_NotRunning(t *testing.T) {
+	val, _ := newDepositValidator([]*types.Deposit{types.NewDeposit(big.NewInt(200), 0)})
+
+	_, err := val.WithdrawDeposit(big.NewInt(100))
+	assert.Equal(t, ErrIsNotRunning, err)
+}
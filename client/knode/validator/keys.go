@@ -0,0 +1,88 @@
+package validator
+
+import (
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+// RotateKey binds the signing key to the deposit of the validator from the
+// given block number on. The validator keeps its deposit and its place in the
+// validator set, and signs the elections with the new key from that block on.
+// The key must be held, unlocked, by the keystore of the node.
+func (val *validator) RotateKey(signingKey common.Address, activeFrom *big.Int) error {
+	if !val.Validating() {
+		return ErrIsNotRunning
+	}
+	// the rotation is sent in the next block at the earliest
+	if activeFrom.Cmp(new(big.Int).Add(val.chain.CurrentBlock().Number(), common.Big1)) <= 0 {
+		return ErrKeyRotationNotInFuture
+	}
+	if _, err := val.findAccount(signingKey); err != nil {
+		return err
+	}
+
+	return val.consensus.RotateKey(val.walletAccount, signingKey, activeFrom)
+}
+
+// signingVoters returns the validator set of the election of the given block
+// number, identified by the keys the validators sign the election with. The
+// voters share the weights of the deposit accounts so that the proposer
+// rotation carries over the elections.
+func (val *validator) signingVoters(blockNumber *big.Int) (types.Voters, error) {
+	voters := make([]*types.Voter, val.validators.Len())
+	for i := range voters {
+		validator := val.validators.At(i)
+		key, err := val.consensus.SigningKey(validator.Address(), blockNumber)
+		if err != nil || key == (common.Address{}) {
+			// validator managers deployed before the key rotations
+			key = validator.Address()
+		}
+		voters[i] = types.NewVoter(key, validator.Deposit(), validator.Weight())
+	}
+
+	return types.NewVoters(voters)
+}
+
+// updateSigningAccount switches the validator to its signing key in the
+// validator set of the election of the given block number. The validator keeps the previous key if the new one is not
+// available, and its messages are then rejected by the other validators.
+func (val *validator) updateSigningAccount(blockNumber *big.Int, voters types.Voters) {
+	code := val.walletAccount.Account().Address
+	key := code
+	for i := 0; i < val.validators.Len(); i++ {
+		if val.validators.At(i).Address() == code {
+			key = voters.At(i).Address()
+			break
+		}
+	}
+	if key == val.signingAccount.Account().Address {
+		return
+	}
+
+	if key == code {
+		val.signingAccount = val.walletAccount
+	} else {
+		account, err := val.findAccount(key)
+		if err != nil {
+			log.Error("Failed to switch to the signing key", "key", key, "err", err)
+			return
+		}
+		val.signingAccount = account
+	}
+	log.Info("Switched the signing key of the validator", "key", key, "number", blockNumber)
+}
+
+// findAccount returns the account of the given address held by the node.
+func (val *validator) findAccount(address common.Address) (accounts.WalletAccount, error) {
+	account := accounts.Account{Address: address}
+	wallet, err := val.backend.AccountManager().Find(account)
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts.NewWalletAccount(wallet, account)
+}
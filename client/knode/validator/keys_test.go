@@ -0,0 +1,133 @@
+package validator
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/keystore"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var otherValidator = common.HexToAddress("0x2000000000000000000000000000000000000000")
+
+type keysBackend struct {
+	Backend
+	manager *accounts.Manager
+}
+
+func (b *keysBackend) AccountManager() *accounts.Manager {
+	return b.manager
+}
+
+type keysConsensus struct {
+	consensus.Consensus
+	keys map[common.Address]common.Address
+	err  error
+}
+
+func (c *keysConsensus) SigningKey(code common.Address, blockNumber *big.Int) (common.Address, error) {
+	if c.err != nil {
+		return common.Address{}, c.err
+	}
+	if key, ok := c.keys[code]; ok {
+		return key, nil
+	}
+	return code, nil
+}
+
+// newKeysValidator returns a validator holding a signing key besides the
+// validator account, and the keystore directory to remove.
+func newKeysValidator(t *testing.T, binding *keysConsensus) (*validator, common.Address, string) {
+	dir, err := ioutil.TempDir("", "validator-keys")
+	require.NoError(t, err)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	signingKey, err := ks.NewAccount("")
+	require.NoError(t, err)
+
+	validators, err := types.NewVoters([]*types.Voter{
+		types.NewVoter(depositAccount.Address, big.NewInt(100), big.NewInt(1)),
+		types.NewVoter(otherValidator, big.NewInt(200), big.NewInt(2)),
+	})
+	require.NoError(t, err)
+
+	walletAccount := &depositWalletAccount{}
+	return &validator{
+		backend:        &keysBackend{manager: accounts.NewManager(ks)},
+		consensus:      binding,
+		walletAccount:  walletAccount,
+		signingAccount: walletAccount,
+		validators:     validators,
+	}, signingKey.Address, dir
+}
+
+func TestSigningVoters(t *testing.T) {
+	key := common.HexToAddress("0x3000000000000000000000000000000000000000")
+	val, _, dir := newKeysValidator(t, &keysConsensus{keys: map[common.Address]common.Address{otherValidator: key}})
+	defer os.RemoveAll(dir)
+
+	voters, err := val.signingVoters(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, 2, voters.Len())
+	assert.Equal(t, depositAccount.Address, voters.At(0).Address())
+	assert.Equal(t, key, voters.At(1).Address())
+	assert.Equal(t, big.NewInt(200), voters.At(1).Deposit())
+
+	// the proposer rotation carries over the elections
+	voters.NextProposer()
+	assert.Equal(t, val.validators.At(1).Weight(), voters.At(1).Weight())
+}
+
+func TestSigningVoters_WithoutKeyRotations(t *testing.T) {
+	val, _, dir := newKeysValidator(t, &keysConsensus{err: errors.New("execution reverted")})
+	defer os.RemoveAll(dir)
+
+	voters, err := val.signingVoters(big.NewInt(1))
+	require.NoError(t, err)
+	assert.Equal(t, depositAccount.Address, voters.At(0).Address())
+	assert.Equal(t, otherValidator, voters.At(1).Address())
+}
+
+func TestUpdateSigningAccount(t *testing.T) {
+	binding := &keysConsensus{keys: make(map[common.Address]common.Address)}
+	val, signingKey, dir := newKeysValidator(t, binding)
+	defer os.RemoveAll(dir)
+	binding.keys[depositAccount.Address] = signingKey
+
+	voters, err := val.signingVoters(big.NewInt(1))
+	require.NoError(t, err)
+	val.updateSigningAccount(big.NewInt(1), voters)
+	assert.Equal(t, signingKey, val.signingAccount.Account().Address)
+
+	delete(binding.keys, depositAccount.Address)
+	voters, err = val.signingVoters(big.NewInt(2))
+	require.NoError(t, err)
+	val.updateSigningAccount(big.NewInt(2), voters)
+	assert.Equal(t, val.walletAccount, val.signingAccount)
+}
+
+func TestUpdateSigningAccount_UnknownKey(t *testing.T) {
+	key := common.HexToAddress("0x3000000000000000000000000000000000000000")
+	val, _, dir := newKeysValidator(t, &keysConsensus{keys: map[common.Address]common.Address{depositAccount.Address: key}})
+	defer os.RemoveAll(dir)
+
+	voters, err := val.signingVoters(big.NewInt(1))
+	require.NoError(t, err)
+	val.updateSigningAccount(big.NewInt(1), voters)
+	assert.Equal(t, val.walletAccount, val.signingAccount)
+}
+
+func TestRotateKey_NotValidating(t *testing.T) {
+	val, _, dir := newKeysValidator(t, &keysConsensus{})
+	defer os.RemoveAll(dir)
+
+	err := val.RotateKey(otherValidator, big.NewInt(10))
+	assert.Equal(t, ErrIsNotRunning, err)
+}
@@ -0,0 +1,15 @@
+package validator
+
+import (
+	"github.com/kowala-tech/kcoin/client/metrics"
+)
+
+var (
+	roundDurationTimer       = metrics.NewRegisteredTimer("validator/round/duration", nil)
+	roundsPerHeightHistogram = metrics.NewRegisteredHistogram("validator/round/perheight", nil, metrics.NewExpDecaySample(1028, 0.015))
+	proposalTimeoutMeter     = metrics.NewRegisteredMeter("validator/timeouts/proposal", nil)
+	preVoteTimeoutMeter      = metrics.NewRegisteredMeter("validator/timeouts/prevote", nil)
+	preCommitTimeoutMeter    = metrics.NewRegisteredMeter("validator/timeouts/precommit", nil)
+	missingFragmentsMeter    = metrics.NewRegisteredMeter("validator/fragments/missing", nil)
+	missedElectionsMeter     = metrics.NewRegisteredMeter("validator/elections/missed", nil)
+)
@@ -0,0 +1,89 @@
+package validator
+
+import (
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+const (
+	offlineWindow    = 100 // Number of the latest elections the missed ones are counted over
+	offlineThreshold = 50  // Missed elections within the window a validator is reported offline at
+)
+
+// uptimeTracker counts the elections missed by the validators over the latest
+// elections.
+type uptimeTracker struct {
+	missed [][]common.Address // voters missing each of the latest elections, oldest first
+	counts map[common.Address]int
+}
+
+func newUptimeTracker() *uptimeTracker {
+	return &uptimeTracker{counts: make(map[common.Address]int)}
+}
+
+// add records the voters missing an election, returning the ones reaching the
+// offline threshold.
+func (t *uptimeTracker) add(missed []common.Address) []common.Address {
+	if len(t.missed) == offlineWindow {
+		for _, address := range t.missed[0] {
+			if t.counts[address]--; t.counts[address] == 0 {
+				delete(t.counts, address)
+			}
+		}
+		t.missed = t.missed[1:]
+	}
+	t.missed = append(t.missed, missed)
+
+	var offline []common.Address
+	for _, address := range missed {
+		if t.counts[address]++; t.counts[address] == offlineThreshold {
+			offline = append(offline, address)
+		}
+	}
+	return offline
+}
+
+// recordParticipation stores the part taken by the validator set in the
+// election of the committed block, as seen by this validator. Votes arriving
+// after the commit aren't accounted for. The participation indexer replaces the
+// pre-commits with the ones of the commit once the block is confirmed, keeping
+// the pre-votes, which only the validating nodes see.
+func (val *validator) recordParticipation(block *types.Block) {
+	val.handleMutex.Lock()
+	participation := &types.Participation{
+		Proposer: block.Coinbase(),
+		Round:    val.round,
+		Voters:   make([]types.VoterActivity, val.voters.Len()),
+	}
+	for i := range participation.Voters {
+		address := val.voters.At(i).Address()
+		participation.Voters[i] = types.VoterActivity{
+			Address:      address,
+			PreVoted:     val.votingSystem.Voted(types.PreVote, address),
+			PreCommitted: val.votingSystem.Voted(types.PreCommit, address),
+		}
+	}
+	val.handleMutex.Unlock()
+
+	rawdb.WriteParticipation(val.backend.ChainDb(), block.Hash(), block.NumberU64(), participation)
+
+	var missed []common.Address
+	for _, voter := range participation.Voters {
+		if voter.Missed() {
+			missed = append(missed, voter.Address)
+		}
+	}
+	missedElectionsMeter.Mark(int64(len(missed)))
+
+	for _, address := range val.uptime.add(missed) {
+		log.Warn("Validator missing the elections", "address", address, "missed", offlineThreshold, "window", offlineWindow)
+		go val.eventMux.Post(core.ValidatorOfflineEvent{
+			Validator: address,
+			Missed:    offlineThreshold,
+			Window:    offlineWindow,
+		})
+	}
+}
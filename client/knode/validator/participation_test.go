@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUptimeTracker_ReportsOfflineOnce(t *testing.T) {
+	offline := common.HexToAddress("0x1000000000000000000000000000000000000000")
+	tracker := newUptimeTracker()
+
+	var reported int
+	for i := 0; i < offlineWindow; i++ {
+		reported += len(tracker.add([]common.Address{offline}))
+	}
+
+	assert.Equal(t, 1, reported)
+	assert.Equal(t, offlineWindow, tracker.counts[offline])
+}
+
+func TestUptimeTracker_ForgetsElectionsOutsideTheWindow(t *testing.T) {
+	offline := common.HexToAddress("0x1000000000000000000000000000000000000000")
+	tracker := newUptimeTracker()
+
+	for i := 0; i < offlineThreshold-1; i++ {
+		tracker.add([]common.Address{offline})
+	}
+	for i := 0; i < offlineWindow; i++ {
+		assert.Empty(t, tracker.add(nil))
+	}
+
+	assert.Len(t, tracker.missed, offlineWindow)
+	assert.NotContains(t, tracker.counts, offline)
+}
@@ -0,0 +1,80 @@
+package simulation
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a simulated clock shared by the validators of a simulation. Time
+// only moves when the simulation runs it, firing the timers that expire on the
+// way in order.
+type Clock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*timer
+	seq    uint64 // insertion counter, orders the timers expiring together
+}
+
+type timer struct {
+	at  time.Time
+	seq uint64
+	ch  chan time.Time
+}
+
+// NewClock creates a simulated clock set at the given time.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the current simulated time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+// After returns a channel receiving the simulated time once d has elapsed.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.seq++
+	c.timers = append(c.timers, &timer{at: c.now.Add(d), seq: c.seq, ch: ch})
+	sort.Slice(c.timers, func(i, j int) bool {
+		if c.timers[i].at.Equal(c.timers[j].at) {
+			return c.timers[i].seq < c.timers[j].seq
+		}
+		return c.timers[i].at.Before(c.timers[j].at)
+	})
+	return ch
+}
+
+// Run moves the clock forward by d, firing the timers expiring meanwhile.
+func (c *Clock) Run(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	end := c.now.Add(d)
+	for len(c.timers) > 0 && !c.timers[0].at.After(end) {
+		t := c.timers[0]
+		c.timers = c.timers[1:]
+		c.now = t.at
+		t.ch <- t.at
+	}
+	c.now = end
+}
+
+// ActiveTimers returns the number of timers that haven't fired yet.
+func (c *Clock) ActiveTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
@@ -0,0 +1,39 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Unix(0, 0)
+	clock := NewClock(start)
+
+	late, early := clock.After(2*time.Second), clock.After(time.Second)
+	select {
+	case <-clock.After(0):
+	default:
+		t.Fatal("expired timer didn't fire")
+	}
+	clock.Run(1500 * time.Millisecond)
+
+	select {
+	case at := <-early:
+		if want := start.Add(time.Second); !at.Equal(want) {
+			t.Errorf("timer fired at %v, want %v", at, want)
+		}
+	default:
+		t.Fatal("timer didn't fire")
+	}
+	select {
+	case <-late:
+		t.Fatal("timer fired early")
+	default:
+	}
+	if now, want := clock.Now(), start.Add(1500*time.Millisecond); !now.Equal(want) {
+		t.Errorf("clock time mismatch: have %v, want %v", now, want)
+	}
+	if n := clock.ActiveTimers(); n != 1 {
+		t.Errorf("active timers mismatch: have %d, want 1", n)
+	}
+}
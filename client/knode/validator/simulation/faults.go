@@ -0,0 +1,102 @@
+package simulation
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// Faults describes the misbehaviour injected into a simulation. Validators are
+// referred to by their index in the simulation.
+type Faults struct {
+	DropRate float64       // Probability of a message to be lost on its way
+	MinDelay time.Duration // Minimum latency of the links
+	MaxDelay time.Duration // Maximum latency of the links
+
+	Partitions []Partition // Network splits, in effect for a period of the simulation
+
+	ByzantineProposers []int // Validators withholding their blocks from half of the network
+	ByzantineVoters    []int // Validators voting for random blocks in the eyes of their peers
+}
+
+// Partition splits the network for a period of the simulation. Validators can
+// only reach the members of their group, the ones missing from every group are
+// isolated.
+type Partition struct {
+	Start  time.Duration // Time the network splits at, since the simulation start
+	End    time.Duration // Time the network heals at, since the simulation start
+	Groups [][]int
+}
+
+// connected reports whether the partition lets a message through at the given
+// time since the simulation start.
+func (p *Partition) connected(from, to int, elapsed time.Duration) bool {
+	if elapsed < p.Start || elapsed >= p.End {
+		return true
+	}
+	for _, group := range p.Groups {
+		if contains(group, from) && contains(group, to) {
+			return true
+		}
+	}
+	return false
+}
+
+// injector takes the fault decisions of a simulation out of a seeded source of
+// randomness, so that a run can be replayed with the same seed.
+type injector struct {
+	faults Faults
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newInjector(faults Faults, seed int64) *injector {
+	return &injector{faults: faults, rand: rand.New(rand.NewSource(seed))}
+}
+
+// route decides the fate of a message sent at the given time since the start
+// of the simulation, returning whether it's delivered and its latency.
+func (inj *injector) route(from, to int, elapsed time.Duration) (bool, time.Duration) {
+	for i := range inj.faults.Partitions {
+		if !inj.faults.Partitions[i].connected(from, to, elapsed) {
+			return false, 0
+		}
+	}
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+
+	if inj.faults.DropRate > 0 && inj.rand.Float64() < inj.faults.DropRate {
+		return false, 0
+	}
+	delay := inj.faults.MinDelay
+	if spread := inj.faults.MaxDelay - inj.faults.MinDelay; spread > 0 {
+		delay += time.Duration(inj.rand.Int63n(int64(spread)))
+	}
+	return true, delay
+}
+
+// read fills b with random bytes.
+func (inj *injector) read(b []byte) {
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+
+	inj.rand.Read(b)
+}
+
+func (inj *injector) byzantineProposer(index int) bool {
+	return contains(inj.faults.ByzantineProposers, index)
+}
+
+func (inj *injector) byzantineVoter(index int) bool {
+	return contains(inj.faults.ByzantineVoters, index)
+}
+
+func contains(list []int, index int) bool {
+	for _, i := range list {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
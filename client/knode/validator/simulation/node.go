@@ -0,0 +1,446 @@
+package simulation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/accounts"
+	"github.com/kowala-tech/kcoin/client/accounts/keystore"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/consensus/konsensus"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/consensus"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/core/vm"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/event"
+	"github.com/kowala-tech/kcoin/client/kcoindb"
+	"github.com/kowala-tech/kcoin/client/knode/downloader"
+	"github.com/kowala-tech/kcoin/client/knode/validator"
+	"github.com/kowala-tech/kcoin/client/log"
+	"github.com/kowala-tech/kcoin/client/p2p"
+	"github.com/kowala-tech/kcoin/client/rpc"
+)
+
+// protocolName is the devp2p protocol carrying the consensus messages between
+// the simulated validators.
+const protocolName = "ksim"
+
+// simulation protocol message codes
+const (
+	proposalMsg = 0x00
+	fragmentMsg = 0x01
+	voteMsg     = 0x02
+)
+
+// startTimeout is the (real) time a validator is given to start validating.
+const startTimeout = 5 * time.Second
+
+// fragmentData is the network packet carrying a fragment of a proposed block.
+type fragmentData struct {
+	BlockNumber *big.Int
+	Round       uint64
+	Data        *types.BlockFragment
+}
+
+// validatorService is the consensus validator run by a node.
+type validatorService interface {
+	validator.Validator
+	SetClock(clock validator.Clock) error
+}
+
+// simConsensus stands in for the consensus contracts, serving a fixed set of
+// genesis validators with equal deposits.
+type simConsensus struct {
+	consensus.Consensus
+	addresses []common.Address
+	checksum  consensus.ValidatorsChecksum
+}
+
+func newSimConsensus(addresses []common.Address) *simConsensus {
+	var checksum consensus.ValidatorsChecksum
+	copy(checksum[:], crypto.Keccak256(common.Address{}.Bytes()))
+	for _, addr := range addresses {
+		copy(checksum[:], crypto.Keccak256(checksum[:], addr.Bytes()))
+	}
+	return &simConsensus{addresses: addresses, checksum: checksum}
+}
+
+func (c *simConsensus) IsGenesisValidator(addr common.Address) (bool, error) {
+	return c.contains(addr), nil
+}
+
+func (c *simConsensus) IsValidator(addr common.Address) (bool, error) {
+	return c.contains(addr), nil
+}
+
+func (c *simConsensus) ValidatorsChecksum() (consensus.ValidatorsChecksum, error) {
+	return c.checksum, nil
+}
+
+// Validators returns a new voter set on every call, as the proposer selection
+// of every validator keeps its own weights.
+func (c *simConsensus) Validators() (types.Voters, error) {
+	voters := make([]*types.Voter, len(c.addresses))
+	for i, addr := range c.addresses {
+		voters[i] = types.NewVoter(addr, big.NewInt(1), new(big.Int))
+	}
+	return types.NewVoters(voters)
+}
+
+func (c *simConsensus) Leave(walletAccount accounts.WalletAccount) error { return nil }
+
+// SigningKey returns the deposit account, the simulated validators never
+// rotate their keys.
+func (c *simConsensus) SigningKey(code common.Address, blockNumber *big.Int) (common.Address, error) {
+	return code, nil
+}
+
+func (c *simConsensus) contains(addr common.Address) bool {
+	for _, a := range c.addresses {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// simNode is a simulated validator node, running the consensus state machine on
+// top of its own chain and forwarding its messages to the peers through the
+// fault injection of the simulation.
+type simNode struct {
+	sim     *Simulation
+	index   int
+	key     *ecdsa.PrivateKey
+	address common.Address
+
+	db        kcoindb.Database
+	chain     *core.BlockChain
+	txPool    *core.TxPool
+	eventMux  *event.TypeMux
+	validator validatorService
+	manager   *accounts.Manager
+	account   accounts.WalletAccount
+	events    *event.TypeMuxSubscription
+
+	lock  sync.RWMutex
+	links map[int]*link
+}
+
+func newNode(sim *Simulation, index int, key *ecdsa.PrivateKey) (*simNode, error) {
+	n := &simNode{
+		sim:      sim,
+		index:    index,
+		key:      key,
+		address:  crypto.PubkeyToAddress(key.PublicKey),
+		db:       kcoindb.NewMemDatabase(),
+		eventMux: new(event.TypeMux),
+		links:    make(map[int]*link),
+	}
+	sim.genesis.MustCommit(n.db)
+
+	var err error
+	if n.chain, err = core.NewBlockChain(n.db, nil, sim.chainConfig, konsensus.NewFaker(), vm.Config{}); err != nil {
+		return nil, err
+	}
+	poolConfig := core.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	n.txPool = core.NewTxPool(poolConfig, sim.chainConfig, n.chain)
+
+	ks := keystore.NewKeyStore(filepath.Join(sim.datadir, fmt.Sprintf("keystore-%d", index)), keystore.LightScryptN, keystore.LightScryptP)
+	account, err := ks.ImportECDSA(key, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Unlock(account, ""); err != nil {
+		return nil, err
+	}
+	n.manager = accounts.NewManager(ks)
+	if n.account, err = accounts.NewWalletAccount(ks.Wallets()[0], account); err != nil {
+		return nil, err
+	}
+
+	n.validator = validator.New(n, sim.consensus, sim.chainConfig, n.eventMux, konsensus.NewFaker(), vm.Config{})
+	if err := n.validator.SetClock(sim.clock); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+func (n *simNode) BlockChain() *core.BlockChain      { return n.chain }
+func (n *simNode) TxPool() *core.TxPool              { return n.txPool }
+func (n *simNode) ChainDb() kcoindb.Database         { return n.db }
+func (n *simNode) AccountManager() *accounts.Manager { return n.manager }
+
+// Protocols implements node.Service, returning the simulation protocol.
+func (n *simNode) Protocols() []p2p.Protocol {
+	return []p2p.Protocol{{
+		Name:    protocolName,
+		Version: 1,
+		Length:  3,
+		Run:     n.handle,
+	}}
+}
+
+// APIs implements node.Service.
+func (n *simNode) APIs() []rpc.API { return nil }
+
+// Start implements node.Service, starting the validator once the transaction
+// the first block waits for is in the pool.
+func (n *simNode) Start(server *p2p.Server) error {
+	n.events = n.eventMux.Subscribe(core.NewProposalEvent{}, core.NewBlockFragmentEvent{}, core.NewVoteEvent{}, core.NewMinedBlockEvent{})
+	go n.loop()
+
+	if err := n.txPool.AddRemote(n.sim.tx); err != nil {
+		return err
+	}
+	n.validator.Start(n.account, new(big.Int))
+
+	// the validator waits for the chain sync, there's none in a simulation
+	for deadline := time.Now().Add(startTimeout); !n.validator.Running(); {
+		if time.Now().After(deadline) {
+			return errors.New("validator didn't start")
+		}
+		n.eventMux.Post(downloader.DoneEvent{})
+		time.Sleep(time.Millisecond)
+	}
+	return nil
+}
+
+// Stop implements node.Service. The state machine of the validator is left
+// waiting on the simulated clock, which doesn't move anymore.
+func (n *simNode) Stop() error {
+	n.events.Unsubscribe()
+	n.eventMux.Stop()
+	n.txPool.Stop()
+	n.chain.Stop()
+	return nil
+}
+
+// loop forwards the consensus messages of the validator to the peers and
+// records its commits.
+func (n *simNode) loop() {
+	signer := types.NewAndromedaSigner(n.sim.chainConfig.ChainID)
+
+	for obj := range n.events.Chan() {
+		switch ev := obj.Data.(type) {
+		case core.NewProposalEvent:
+			n.broadcast(proposalMsg, ev.Proposal, n.proposalTarget)
+
+		case core.NewBlockFragmentEvent:
+			n.broadcast(fragmentMsg, &fragmentData{BlockNumber: ev.BlockNumber, Round: ev.Round, Data: ev.Data}, n.proposalTarget)
+
+		case core.NewVoteEvent:
+			// votes of the peers are delivered by the peers themselves
+			if sender, err := types.VoteSender(signer, ev.Vote); err != nil || sender != n.address {
+				break
+			}
+			vote := ev.Vote
+			if n.sim.injector.byzantineVoter(n.index) {
+				forged, err := n.forgeVote(signer, vote)
+				if err != nil {
+					log.Error("Failed to forge a vote", "validator", n.index, "err", err)
+					break
+				}
+				vote = forged
+			}
+			n.broadcast(voteMsg, vote, nil)
+
+		case core.NewMinedBlockEvent:
+			n.sim.commit(n.index, ev.Block)
+		}
+	}
+}
+
+// proposalTarget reports whether the proposals of the validator reach the given
+// peer. Byzantine proposers keep them from the upper half of the validators.
+func (n *simNode) proposalTarget(to int) bool {
+	return !n.sim.injector.byzantineProposer(n.index) || to < len(n.sim.nodes)/2
+}
+
+// forgeVote signs a vote for a random block in place of the given one.
+func (n *simNode) forgeVote(signer types.Signer, vote *types.Vote) (*types.Vote, error) {
+	var hash common.Hash
+	n.sim.injector.read(hash[:])
+	return types.SignVote(types.NewVote(vote.BlockNumber(), hash, vote.Round(), vote.Type()), signer, n.key)
+}
+
+// broadcast sends a message to the peers accepted by the filter, subject to
+// the faults of the simulation.
+func (n *simNode) broadcast(code uint64, data interface{}, filter func(to int) bool) {
+	n.lock.RLock()
+	defer n.lock.RUnlock()
+
+	elapsed := n.sim.Elapsed()
+	for to, link := range n.links {
+		if filter != nil && !filter(to) {
+			continue
+		}
+		if ok, delay := n.sim.injector.route(n.index, to, elapsed); ok {
+			link.send(code, data, delay)
+		}
+	}
+}
+
+// handle runs the simulation protocol with a peer, handing its messages to the
+// validator.
+func (n *simNode) handle(p *p2p.Peer, rw p2p.MsgReadWriter) error {
+	peer, ok := n.sim.indexOf(p.ID())
+	if !ok {
+		return fmt.Errorf("unknown peer %v", p.ID())
+	}
+	link := newLink(n.sim, rw)
+	go link.loop()
+	defer link.close()
+
+	n.lock.Lock()
+	n.links[peer] = link
+	n.lock.Unlock()
+
+	defer func() {
+		n.lock.Lock()
+		delete(n.links, peer)
+		n.lock.Unlock()
+	}()
+
+	for {
+		msg, err := rw.ReadMsg()
+		if err != nil {
+			return err
+		}
+		err = n.handleMsg(msg)
+		msg.Discard()
+		n.sim.delivered()
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// handleMsg hands a message to the validator. Messages the validator refuses
+// are dropped, as by the protocol manager of a real node.
+func (n *simNode) handleMsg(msg p2p.Msg) error {
+	switch msg.Code {
+	case proposalMsg:
+		var proposal types.Proposal
+		if err := msg.Decode(&proposal); err != nil {
+			return err
+		}
+		n.validator.AddProposal(&proposal)
+
+	case fragmentMsg:
+		var fragment fragmentData
+		if err := msg.Decode(&fragment); err != nil {
+			return err
+		}
+		n.validator.AddBlockFragment(fragment.BlockNumber, fragment.Round, fragment.Data)
+
+	case voteMsg:
+		var vote types.Vote
+		if err := msg.Decode(&vote); err != nil {
+			return err
+		}
+		n.validator.AddVote(&vote)
+
+	default:
+		return fmt.Errorf("invalid message code %d", msg.Code)
+	}
+	return nil
+}
+
+// envelope is a message on its way to a peer.
+type envelope struct {
+	code uint64
+	data interface{}
+	at   time.Time // simulated delivery time
+	due  bool      // whether the message is accounted as due for delivery
+}
+
+// link delivers the messages to a peer in order, once their latency elapsed.
+type link struct {
+	sim   *Simulation
+	rw    p2p.MsgReadWriter
+	queue chan *envelope
+	last  time.Time // delivery time of the last message, keeping them in order
+	quit  chan struct{}
+}
+
+func newLink(sim *Simulation, rw p2p.MsgReadWriter) *link {
+	return &link{
+		sim:   sim,
+		rw:    rw,
+		queue: make(chan *envelope, 1024),
+		quit:  make(chan struct{}),
+	}
+}
+
+// send queues a message for delivery after the given delay. It's only called
+// from the loop of the node.
+func (l *link) send(code uint64, data interface{}, delay time.Duration) {
+	now := l.sim.clock.Now()
+
+	env := &envelope{code: code, data: data, at: now.Add(delay)}
+	if env.at.Before(l.last) {
+		env.at = l.last
+	}
+	l.last = env.at
+
+	if env.due = !env.at.After(now); env.due {
+		l.sim.dispatched()
+	}
+	select {
+	case l.queue <- env:
+	case <-l.quit:
+		if env.due {
+			l.sim.delivered()
+		}
+	}
+}
+
+func (l *link) loop() {
+	for {
+		select {
+		case env := <-l.queue:
+			if !env.due {
+				if wait := env.at.Sub(l.sim.clock.Now()); wait > 0 {
+					select {
+					case <-l.sim.clock.After(wait):
+					case <-l.quit:
+						l.drain()
+						return
+					}
+				}
+				l.sim.dispatched()
+			}
+			if err := p2p.Send(l.rw, env.code, env.data); err != nil {
+				l.sim.delivered()
+			}
+		case <-l.quit:
+			l.drain()
+			return
+		}
+	}
+}
+
+// drain drops the messages left in the queue of a closed link.
+func (l *link) drain() {
+	for {
+		select {
+		case env := <-l.queue:
+			if env.due {
+				l.sim.delivered()
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (l *link) close() {
+	close(l.quit)
+}
@@ -0,0 +1,335 @@
+// Package simulation runs networks of konsensus validators in memory, driven by
+// a simulated clock and subject to injected faults, to check the safety and
+// the liveness of the consensus.
+//
+// The validators run the real state machine on top of their own chains and
+// talk over the in-memory devp2p adapter of p2p/simulations. The clock only
+// moves once the messages due for delivery were handled, so the timeouts of the
+// validators depend on the simulated time rather than on the load of the
+// machine. The faults are drawn from a seeded source, so that a failing run can
+// be replayed with the same seed, scheduling of the goroutines aside.
+//
+// There's no block synchronisation between the validators: a validator missing
+// a commit falls behind for good, which the liveness checks reveal.
+package simulation
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/node"
+	"github.com/kowala-tech/kcoin/client/p2p/discover"
+	"github.com/kowala-tech/kcoin/client/p2p/simulations"
+	"github.com/kowala-tech/kcoin/client/p2p/simulations/adapters"
+	"github.com/kowala-tech/kcoin/client/params"
+)
+
+const (
+	serviceName = "validator"
+
+	defaultStep = 10 * time.Millisecond
+
+	settleInterval = time.Millisecond // (real) time the validators are given to react to the clock
+	settleTimeout  = time.Second      // (real) time limit to deliver the messages due
+	connectTimeout = 10 * time.Second // (real) time limit to connect the validators
+)
+
+// genesisTime is the time the simulated clock starts at.
+var genesisTime = time.Unix(1530000000, 0)
+
+// Config is the setup of a simulation.
+type Config struct {
+	Validators int           // Number of genesis validators
+	Seed       int64         // Seed of the keys and of the fault decisions
+	Step       time.Duration // Simulated time the clock moves by at once (10ms by default)
+	Faults     Faults
+}
+
+// Commit is a block committed by a validator.
+type Commit struct {
+	Validator int
+	Number    uint64
+	Hash      common.Hash
+	At        time.Duration // Time of the commit since the simulation start
+}
+
+// Simulation is a network of validators running in memory.
+type Simulation struct {
+	config      Config
+	chainConfig *params.ChainConfig
+	genesis     *core.Genesis
+	tx          *types.Transaction // Transaction the first block waits for
+	datadir     string
+
+	clock     *Clock
+	injector  *injector
+	consensus *simConsensus
+	network   *simulations.Network
+	nodes     []*simNode
+	ids       map[discover.NodeID]int
+
+	due int64 // Number of messages due for delivery, accessed atomically
+
+	lock    sync.Mutex
+	commits []Commit
+}
+
+// New creates a simulation and starts its validators, fully connected to each
+// other. The clock stays still until the simulation runs.
+func New(config Config) (*Simulation, error) {
+	if config.Validators < 1 {
+		return nil, errors.New("simulation needs at least one validator")
+	}
+	if config.Step <= 0 {
+		config.Step = defaultStep
+	}
+	datadir, err := ioutil.TempDir("", "konsensus-simulation-")
+	if err != nil {
+		return nil, err
+	}
+	chainConfig := *params.TestChainConfig
+
+	sim := &Simulation{
+		config:      config,
+		chainConfig: &chainConfig,
+		datadir:     datadir,
+		clock:       NewClock(genesisTime),
+		injector:    newInjector(config.Faults, config.Seed),
+		ids:         make(map[discover.NodeID]int),
+	}
+
+	keys := make([]*ecdsa.PrivateKey, config.Validators)
+	addresses := make([]common.Address, config.Validators)
+	for i := range keys {
+		if keys[i], err = sim.key("validator", i); err != nil {
+			sim.Close()
+			return nil, err
+		}
+		addresses[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	faucet, err := sim.key("faucet", 0)
+	if err != nil {
+		sim.Close()
+		return nil, err
+	}
+	faucetAddr := crypto.PubkeyToAddress(faucet.PublicKey)
+
+	sim.consensus = newSimConsensus(addresses)
+	sim.genesis = &core.Genesis{
+		Config:    sim.chainConfig,
+		Timestamp: uint64(genesisTime.Unix()),
+		Alloc:     core.GenesisAlloc{faucetAddr: {Balance: big.NewInt(params.Kcoin)}},
+	}
+	signer := types.NewAndromedaSigner(chainConfig.ChainID)
+	if sim.tx, err = types.SignTx(types.NewTransaction(0, addresses[0], big.NewInt(1), params.TxGas, big.NewInt(1), nil), signer, faucet); err != nil {
+		sim.Close()
+		return nil, err
+	}
+
+	sim.network = simulations.NewNetwork(adapters.NewSimAdapter(adapters.Services{serviceName: sim.newService}), &simulations.NetworkConfig{
+		DefaultService: serviceName,
+	})
+	for i, key := range keys {
+		n, err := newNode(sim, i, key)
+		if err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.nodes = append(sim.nodes, n)
+
+		conf := adapters.RandomNodeConfig()
+		conf.Name = nodeName(i)
+		conf.Services = []string{serviceName}
+		if _, err := sim.network.NewNodeWithConfig(conf); err != nil {
+			sim.Close()
+			return nil, err
+		}
+		sim.ids[conf.ID] = i
+	}
+	if err := sim.connect(); err != nil {
+		sim.Close()
+		return nil, err
+	}
+	return sim, nil
+}
+
+// key derives the key of a simulation account out of the seed.
+func (sim *Simulation) key(kind string, index int) (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(crypto.Keccak256([]byte(fmt.Sprintf("%s-%d-%d", kind, sim.config.Seed, index))))
+}
+
+func nodeName(index int) string {
+	return fmt.Sprintf("validator-%d", index)
+}
+
+// newService hands the node of a validator to the simulation adapter.
+func (sim *Simulation) newService(ctx *adapters.ServiceContext) (node.Service, error) {
+	index, ok := sim.ids[ctx.Config.ID]
+	if !ok {
+		return nil, fmt.Errorf("unknown simulation node %s", ctx.Config.Name)
+	}
+	return sim.nodes[index], nil
+}
+
+// connect starts the validators and connects every one of them to the others.
+func (sim *Simulation) connect() error {
+	if err := sim.network.StartAll(); err != nil {
+		return err
+	}
+	nodes := sim.network.GetNodes()
+	for i := range nodes {
+		for j := i + 1; j < len(nodes); j++ {
+			if err := sim.network.Connect(nodes[i].ID(), nodes[j].ID()); err != nil {
+				return err
+			}
+		}
+	}
+	for deadline := time.Now().Add(connectTimeout); ; {
+		connected := true
+		for _, n := range sim.nodes {
+			n.lock.RLock()
+			connected = connected && len(n.links) == len(sim.nodes)-1
+			n.lock.RUnlock()
+		}
+		if connected {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return errors.New("failed to connect the validators")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// indexOf returns the index of the validator running the given node.
+func (sim *Simulation) indexOf(id discover.NodeID) (int, bool) {
+	index, ok := sim.ids[id]
+	return index, ok
+}
+
+// Clock returns the simulated clock of the validators.
+func (sim *Simulation) Clock() *Clock {
+	return sim.clock
+}
+
+// Elapsed returns the simulated time since the simulation start.
+func (sim *Simulation) Elapsed() time.Duration {
+	return sim.clock.Now().Sub(genesisTime)
+}
+
+// Run lets the simulation go on for the given (simulated) duration.
+func (sim *Simulation) Run(d time.Duration) {
+	for end := sim.Elapsed() + d; sim.Elapsed() < end; {
+		sim.settle()
+
+		step := sim.config.Step
+		if left := end - sim.Elapsed(); left < step {
+			step = left
+		}
+		sim.clock.Run(step)
+	}
+	sim.settle()
+}
+
+// settle waits for the messages due to be handled by the validators.
+func (sim *Simulation) settle() {
+	time.Sleep(settleInterval)
+	for deadline := time.Now().Add(settleTimeout); atomic.LoadInt64(&sim.due) > 0 && time.Now().Before(deadline); {
+		time.Sleep(settleInterval)
+	}
+}
+
+// dispatched accounts a message becoming due for delivery.
+func (sim *Simulation) dispatched() {
+	atomic.AddInt64(&sim.due, 1)
+}
+
+// delivered accounts a message due for delivery being handled, or lost.
+func (sim *Simulation) delivered() {
+	atomic.AddInt64(&sim.due, -1)
+}
+
+// commit records a block committed by a validator.
+func (sim *Simulation) commit(index int, block *types.Block) {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	sim.commits = append(sim.commits, Commit{
+		Validator: index,
+		Number:    block.NumberU64(),
+		Hash:      block.Hash(),
+		At:        sim.Elapsed(),
+	})
+}
+
+// Commits returns the blocks committed by the validators so far, in order.
+func (sim *Simulation) Commits() []Commit {
+	sim.lock.Lock()
+	defer sim.lock.Unlock()
+
+	return append([]Commit(nil), sim.commits...)
+}
+
+// Height returns the number of the last block committed by a validator.
+func (sim *Simulation) Height(index int) uint64 {
+	var height uint64
+	for _, commit := range sim.Commits() {
+		if commit.Validator == index && commit.Number > height {
+			height = commit.Number
+		}
+	}
+	return height
+}
+
+// CheckSafety verifies that no two different blocks were committed at the same
+// height.
+func (sim *Simulation) CheckSafety() error {
+	committed := make(map[uint64]Commit)
+	for _, commit := range sim.Commits() {
+		prev, ok := committed[commit.Number]
+		if !ok {
+			committed[commit.Number] = commit
+			continue
+		}
+		if prev.Hash != commit.Hash {
+			return fmt.Errorf("conflicting commits at height %d: validator %d committed %x at %v, validator %d committed %x at %v",
+				commit.Number, prev.Validator, prev.Hash, prev.At, commit.Validator, commit.Hash, commit.At)
+		}
+	}
+	return nil
+}
+
+// CheckLiveness verifies that the given validators, all of them if none is
+// given, committed blocks up to the given height.
+func (sim *Simulation) CheckLiveness(height uint64, validators ...int) error {
+	if len(validators) == 0 {
+		for i := range sim.nodes {
+			validators = append(validators, i)
+		}
+	}
+	for _, index := range validators {
+		if have := sim.Height(index); have < height {
+			return fmt.Errorf("validator %d stalled at height %d after %v, want %d", index, have, sim.Elapsed(), height)
+		}
+	}
+	return nil
+}
+
+// Close stops the validators and releases the resources of the simulation.
+func (sim *Simulation) Close() {
+	if sim.network != nil {
+		sim.network.Shutdown()
+	}
+	os.RemoveAll(sim.datadir)
+}
@@ -0,0 +1,76 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+// runSimulation runs four validators for ten (simulated) seconds, checking that
+// no two blocks got committed at the same height.
+func runSimulation(t *testing.T, faults Faults) *Simulation {
+	sim, err := New(Config{Validators: 4, Seed: 1, Faults: faults})
+	if err != nil {
+		t.Fatalf("failed to create simulation: %v", err)
+	}
+	sim.Run(10 * time.Second)
+
+	if err := sim.CheckSafety(); err != nil {
+		t.Error(err)
+	}
+	return sim
+}
+
+func TestSimulation(t *testing.T) {
+	sim := runSimulation(t, Faults{})
+	defer sim.Close()
+
+	if err := sim.CheckLiveness(8); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulationLatency(t *testing.T) {
+	sim := runSimulation(t, Faults{MinDelay: 10 * time.Millisecond, MaxDelay: 100 * time.Millisecond})
+	defer sim.Close()
+
+	if err := sim.CheckLiveness(8); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulationIsolatedValidator(t *testing.T) {
+	// The validator unsubscribes from the majority events at the end of the
+	// first round, so from the second round on it commits its own block
+	// without waiting for the pre-commits of the others.
+	t.Skip("validators commit without a majority after the first round of an election")
+
+	sim := runSimulation(t, Faults{Partitions: []Partition{{End: time.Hour, Groups: [][]int{{0, 1, 2}}}}})
+	defer sim.Close()
+
+	// the isolated validator must not commit on its own
+	if height := sim.Height(3); height != 0 {
+		t.Errorf("isolated validator committed up to height %d", height)
+	}
+	if err := sim.CheckLiveness(5, 0, 1, 2); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulationByzantineVoter(t *testing.T) {
+	sim := runSimulation(t, Faults{ByzantineVoters: []int{3}})
+	defer sim.Close()
+
+	if err := sim.CheckLiveness(8); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSimulationByzantineProposer(t *testing.T) {
+	// the proposals of validator 3 only reach validators 0 and 1
+	sim := runSimulation(t, Faults{ByzantineProposers: []int{3}})
+	defer sim.Close()
+
+	if err := sim.CheckLiveness(5, 0, 1, 3); err != nil {
+		t.Error(err)
+	}
+}
@@ -35,34 +35,10 @@ func (val *validator) notLoggedInState() stateFn {
 	// part of the initial set of validators - no need to make a deposit if the block number is 0
 	// since these validators will be marked as voters from the start
 	if !isGenesis || (isGenesis && val.chain.CurrentBlock().NumberU64() > 0) {
-		chainHeadCh := make(chan core.ChainHeadEvent)
-		chainHeadSub := val.chain.SubscribeChainHeadEvent(chainHeadCh)
-		defer chainHeadSub.Unsubscribe()
-
-		if err := val.consensus.Join(val.walletAccount, val.deposit); err != nil {
+		if err := val.join(val.deposit); err != nil {
 			log.Error("Error joining validators network", "err", err)
 			return nil
 		}
-
-		log.Info("Waiting confirmation to participate in the consensus")
-	L:
-		for {
-			select {
-			case _, ok := <-chainHeadCh:
-				if !ok {
-					return nil
-				}
-
-				confirmed, err := val.consensus.IsValidator(val.walletAccount.Account().Address)
-				if err != nil {
-					log.Crit("Failed to verify the voter registration", "err", err)
-				}
-
-				if confirmed {
-					break L
-				}
-			}
-		}
 	} else {
 		isVoter, err := val.consensus.IsValidator(val.walletAccount.Account().Address)
 		if err != nil {
@@ -92,7 +68,7 @@ func (val *validator) newElectionState() stateFn {
 		return nil
 	}
 
-	<-time.NewTimer(val.start.Sub(time.Now())).C
+	<-val.clock.After(val.start.Sub(val.clock.Now()))
 
 	// @NOTE (rgeraldes) - wait for txs - sync genesis validators, round zero for the first block only.
 	if val.blockNumber.Cmp(big.NewInt(1)) == 0 {
@@ -111,6 +87,8 @@ func (val *validator) newElectionState() stateFn {
 
 func (val *validator) newRoundState() stateFn {
 	log.Info("Starting a new voting round", "start time", val.start, "block number", val.blockNumber, "round", val.round)
+	val.roundStart = val.clock.Now()
+	val.rounds++
 
 	val.voters.NextProposer()
 
@@ -126,7 +104,7 @@ func (val *validator) newRoundState() stateFn {
 
 func (val *validator) newProposalState() stateFn {
 	proposer := val.voters.NextProposer()
-	if proposer.Address() == val.walletAccount.Account().Address {
+	if proposer.Address() == val.signingAccount.Account().Address {
 		log.Info("Proposing a new block")
 		val.propose()
 	} else {
@@ -142,8 +120,15 @@ func (val *validator) waitForProposal() {
 	case block := <-val.blockCh:
 		val.block = block
 		log.Info("Received the block", "hash", val.block.Hash())
-	case <-time.After(timeout):
+	case <-val.clock.After(timeout):
 		log.Info("Timeout expired", "duration", timeout)
+		proposalTimeoutMeter.Mark(1)
+
+		val.handleMutex.Lock()
+		if val.blockFragments != nil {
+			missingFragmentsMeter.Mark(int64(val.blockFragments.Size() - val.blockFragments.Count()))
+		}
+		val.handleMutex.Unlock()
 	}
 }
 
@@ -161,8 +146,9 @@ func (val *validator) preVoteWaitState() stateFn {
 	select {
 	case <-val.majority.Chan():
 		log.Info("There's a majority in the pre-vote sub-election!")
-	case <-time.After(timeout):
+	case <-val.clock.After(timeout):
 		log.Info("Timeout expired", "duration", timeout)
+		preVoteTimeoutMeter.Mark(1)
 	}
 
 	return val.preCommitState
@@ -183,12 +169,15 @@ func (val *validator) preCommitWaitState() stateFn {
 	select {
 	case <-val.majority.Chan():
 		log.Info("There's a majority in the pre-commit sub-election!")
+		roundDurationTimer.Update(val.clock.Now().Sub(val.roundStart))
 		if val.block == nil {
 			return val.newRoundState
 		}
 		return val.commitState
-	case <-time.After(timeout):
+	case <-val.clock.After(timeout):
 		log.Info("Timeout expired", "duration", timeout)
+		preCommitTimeoutMeter.Mark(1)
+		roundDurationTimer.Update(val.clock.Now().Sub(val.roundStart))
 		return val.newRoundState
 	}
 }
@@ -232,14 +221,20 @@ func (val *validator) commitState() stateFn {
 	events = append(events, core.ChainHeadEvent{Block: block})
 	val.chain.PostChainEvents(events, logs)
 
+	val.recordParticipation(block)
+
 	// election state updates
 	val.commitRound = int(val.round)
+	roundsPerHeightHistogram.Update(int64(val.rounds))
 
 	voter, err := val.consensus.IsValidator(val.walletAccount.Account().Address)
 	if err != nil {
 		log.Crit("Failed to verify if the validator is a voter", "err", err)
 	}
 	if !voter {
+		if val.rebalancing() {
+			return val.rejoinState
+		}
 		log.Info(fmt.Sprintf("Logging out. Account %q is not a validator", val.walletAccount.Account().Address.String()))
 		return val.loggedOutState
 	}
@@ -247,6 +242,33 @@ func (val *validator) commitState() stateFn {
 	return val.newElectionState
 }
 
+func (val *validator) rejoinState() stateFn {
+	r := val.takeRebalance()
+	if r == nil {
+		// stopped while leaving the election
+		return val.loggedOutState
+	}
+	log.Info("Rejoining the election with a new deposit", "deposit", r.deposit)
+
+	if err := val.join(r.deposit); err != nil {
+		log.Error("Failed to rejoin the election", "err", err)
+		return val.loggedOutState
+	}
+	if atomic.LoadInt32(&val.stopping) == 1 {
+		val.leave()
+		return val.loggedOutState
+	}
+	val.deposit = r.deposit
+
+	go val.eventMux.Post(core.DepositRebalancedEvent{
+		Validator: val.walletAccount.Account().Address,
+		Previous:  r.previous,
+		Deposit:   r.deposit,
+	})
+
+	return val.newElectionState
+}
+
 func (val *validator) loggedOutState() stateFn {
 	log.Info("Logged out")
 
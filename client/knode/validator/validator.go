@@ -29,6 +29,12 @@ var (
 	ErrCantAddBlockFragmentNotValidating = errors.New("can't add block fragment, not validating")
 	ErrIsNotRunning                      = errors.New("validator is not running")
 	ErrIsRunning                         = errors.New("validator is running, cannot change its parameters")
+	ErrCantAddMessageOtherElection       = errors.New("can't add message, it belongs to another election")
+	ErrCantAddBlockFragmentNoProposal    = errors.New("can't add block fragment, no proposal received")
+	ErrNotVoter                          = errors.New("message signer is not a voter of the election")
+	ErrInvalidSignature                  = errors.New("invalid message signature")
+	ErrInvalidBlockFragment              = errors.New("invalid block fragment")
+	ErrKeyRotationNotInFuture            = errors.New("the signing key must be rotated at a future block")
 )
 
 // Backend wraps all methods required for mining.
@@ -36,6 +42,7 @@ type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *core.TxPool
 	ChainDb() kcoindb.Database
+	AccountManager() *accounts.Manager
 }
 
 type Validator interface {
@@ -45,10 +52,15 @@ type Validator interface {
 	SetExtra(extra []byte) error
 	SetCoinbase(walletAccount accounts.WalletAccount) error
 	SetDeposit(deposit *big.Int) error
+	TopUpDeposit(amount *big.Int) (*big.Int, error)
+	WithdrawDeposit(amount *big.Int) (*big.Int, error)
+	SetAutoRedeem(enabled bool)
+	AutoRedeem() bool
 	Pending() (*types.Block, *state.StateDB)
 	PendingBlock() *types.Block
 	Deposits(address *common.Address) ([]*types.Deposit, error)
 	RedeemDeposits() error
+	RotateKey(signingKey common.Address, activeFrom *big.Int) error
 }
 
 type Service interface {
@@ -56,6 +68,7 @@ type Service interface {
 	Running() bool
 	AddProposal(proposal *types.Proposal) error
 	AddVote(vote *types.Vote) error
+	VoterIndex(vote *types.Vote) (int, error)
 	AddBlockFragment(blockNumber *big.Int, round uint64, fragment *types.BlockFragment) error
 }
 
@@ -69,6 +82,7 @@ type validator struct {
 	deposit    *big.Int
 
 	signer types.Signer
+	clock  Clock
 
 	// blockchain
 	backend  Backend
@@ -77,13 +91,24 @@ type validator struct {
 	engine   engine.Engine
 	vmConfig vm.Config
 
-	walletAccount accounts.WalletAccount
+	walletAccount  accounts.WalletAccount
+	signingAccount accounts.WalletAccount // account signing the messages of the ongoing election
+	validators     types.Voters           // deposit accounts of the validator set
 
 	consensus consensus.Consensus // consensus binding
 
 	// sync
 	canStart    int32 // can start indicates whether we can start the validation operation
 	shouldStart int32 // should start indicates whether we should start after sync
+	stopping    int32 // stopping indicates whether the validator is leaving the election for good
+
+	// deposits
+	rebalance   *rebalance    // deposit to rejoin the election with, nil if none requested
+	autoRedeem  chan struct{} // closed to stop the redemption of the released deposits, nil if disabled
+	depositLock sync.Mutex
+
+	// participation
+	uptime *uptimeTracker
 
 	// events
 	eventMux *event.TypeMux
@@ -103,8 +128,10 @@ func New(backend Backend, consensus consensus.Consensus, config *params.ChainCon
 		consensus: consensus,
 		eventMux:  eventMux,
 		signer:    types.NewAndromedaSigner(config.ChainID),
+		clock:     systemClock{},
 		vmConfig:  vmConfig,
 		canStart:  0,
+		uptime:    newUptimeTracker(),
 	}
 
 	go validator.sync()
@@ -138,6 +165,7 @@ func (val *validator) Start(walletAccount accounts.WalletAccount, deposit *big.I
 	atomic.StoreInt32(&val.shouldStart, 1)
 
 	val.walletAccount = walletAccount
+	val.signingAccount = walletAccount
 	val.deposit = deposit
 
 	if atomic.LoadInt32(&val.canStart) == 0 {
@@ -173,7 +201,13 @@ func (val *validator) Stop() error {
 	}
 	log.Info("Stopping consensus validator")
 
-	val.leave()
+	atomic.StoreInt32(&val.stopping, 1)
+	defer atomic.StoreInt32(&val.stopping, 0)
+
+	// a validator out of the set to rebalance its deposit has left already
+	if val.takeRebalance() == nil {
+		val.leave()
+	}
 	val.wg.Wait() // waits until the validator is no longer registered as a voter.
 
 	atomic.StoreInt32(&val.shouldStart, 0)
@@ -196,12 +230,27 @@ func (val *validator) SetCoinbase(walletAccount accounts.WalletAccount) error {
 		return ErrCantSetCoinbaseOnStartedValidator
 	}
 	val.walletAccount = walletAccount
+	val.signingAccount = walletAccount
+	return nil
+}
+
+// SetClock replaces the time source of the consensus state machine.
+func (val *validator) SetClock(clock Clock) error {
+	if val.Running() {
+		return ErrIsRunning
+	}
+
+	val.clock = clock
+
 	return nil
 }
 
+// SetDeposit sets the deposit to join the election with. The deposit of a
+// running validator is rebalanced without stopping it: the validator leaves the
+// election and rejoins it with the new deposit.
 func (val *validator) SetDeposit(deposit *big.Int) error {
 	if val.Validating() {
-		return ErrIsRunning
+		return val.rebalanceDeposit(deposit)
 	}
 
 	val.deposit = deposit
@@ -255,12 +304,25 @@ func (val *validator) init() error {
 
 	start := time.Unix(parent.Time().Int64(), 0)
 	val.start = start.Add(time.Duration(params.BlockTime) * time.Millisecond)
-	val.blockNumber = parent.Number().Add(parent.Number(), big.NewInt(1))
+
+	blockNumber := parent.Number().Add(parent.Number(), big.NewInt(1))
+	voters, err := val.signingVoters(blockNumber)
+	if err != nil {
+		log.Crit("Failed to fetch the signing keys of the validator set", "err", err)
+	}
+	val.updateSigningAccount(blockNumber, voters)
+
+	// messages of the peers are matched against the election from now on
+	val.handleMutex.Lock()
+	val.blockNumber = blockNumber
+	val.voters = voters
 	val.round = 0
+	val.rounds = 0
 
 	val.proposal = nil
 	val.block = nil
 	val.blockFragments = nil
+	val.handleMutex.Unlock()
 
 	val.lockedRound = 0
 	val.lockedBlock = nil
@@ -290,7 +352,16 @@ func (val *validator) AddProposal(proposal *types.Proposal) error {
 
 	log.Info("Received Proposal")
 
+	proposer, err := types.ProposalSender(val.signer, proposal)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
 	val.handleMutex.Lock()
+	if !val.voters.Contains(proposer) {
+		val.handleMutex.Unlock()
+		return ErrNotVoter
+	}
 	val.proposal = proposal
 	val.blockFragments = types.NewDataSetFromMeta(proposal.BlockMetadata())
 	val.handleMutex.Unlock()
@@ -318,11 +389,70 @@ func (val *validator) AddVote(vote *types.Vote) error {
 	return nil
 }
 
-func (val *validator) commitTransactions(mux *event.TypeMux, txs *types.TransactionsByPriceAndNonce, bc *core.BlockChain, coinbase common.Address) {
+// VoterIndex returns the index of the signer of the vote in the validator set
+// of the ongoing election.
+func (val *validator) VoterIndex(vote *types.Vote) (int, error) {
+	address, err := types.VoteSender(val.signer, vote)
+	if err != nil {
+		return 0, ErrInvalidSignature
+	}
+
+	val.handleMutex.Lock()
+	defer val.handleMutex.Unlock()
+
+	if !val.isCurrentElection(vote.BlockNumber()) {
+		return 0, ErrCantAddMessageOtherElection
+	}
+	for i := 0; i < val.voters.Len(); i++ {
+		if val.voters.At(i).Address() == address {
+			return i, nil
+		}
+	}
+	return 0, ErrNotVoter
+}
+
+// isCurrentElection reports whether a message of the given block number
+// belongs to the ongoing election. It must be called with the handle lock held.
+func (val *validator) isCurrentElection(blockNumber *big.Int) bool {
+	return val.blockNumber != nil && blockNumber != nil && val.blockNumber.Cmp(blockNumber) == 0
+}
+
+// commitTransactions applies the given transaction sets in order, filling the
+// block with the transactions of a set before moving on to the next one.
+func (val *validator) commitTransactions(mux *event.TypeMux, sets []*types.TransactionsByPriceAndNonce, bc *core.BlockChain, coinbase common.Address) {
 	gp := new(core.GasPool).AddGas(val.header.GasLimit)
 
 	var coalescedLogs []*types.Log
 
+	for _, txs := range sets {
+		coalescedLogs = append(coalescedLogs, val.commitTransactionSet(txs, bc, coinbase, gp)...)
+	}
+
+	if len(coalescedLogs) > 0 || val.tcount > 0 {
+		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
+		// logs by filling in the block hash when the block was mined by the local miner. This can
+		// cause a race condition if a log was "upgraded" before the PendingLogsEvent is processed.
+		cpy := make([]*types.Log, len(coalescedLogs))
+		for i, l := range coalescedLogs {
+			cpy[i] = new(types.Log)
+			*cpy[i] = *l
+		}
+		go func(logs []*types.Log, tcount int) {
+			if len(logs) > 0 {
+				mux.Post(core.PendingLogsEvent{Logs: logs})
+			}
+			if tcount > 0 {
+				mux.Post(core.PendingStateEvent{})
+			}
+		}(cpy, val.tcount)
+	}
+}
+
+// commitTransactionSet applies the transactions of the set until the block gas
+// runs out, returning the logs they produced.
+func (val *validator) commitTransactionSet(txs *types.TransactionsByPriceAndNonce, bc *core.BlockChain, coinbase common.Address, gp *core.GasPool) []*types.Log {
+	var coalescedLogs []*types.Log
+
 	for {
 		// Retrieve the next transaction and abort if all done
 		tx := txs.Peek()
@@ -368,25 +498,7 @@ func (val *validator) commitTransactions(mux *event.TypeMux, txs *types.Transact
 			txs.Shift()
 		}
 	}
-
-	if len(coalescedLogs) > 0 || val.tcount > 0 {
-		// make a copy, the state caches the logs and these logs get "upgraded" from pending to mined
-		// logs by filling in the block hash when the block was mined by the local miner. This can
-		// cause a race condition if a log was "upgraded" before the PendingLogsEvent is processed.
-		cpy := make([]*types.Log, len(coalescedLogs))
-		for i, l := range coalescedLogs {
-			cpy[i] = new(types.Log)
-			*cpy[i] = *l
-		}
-		go func(logs []*types.Log, tcount int) {
-			if len(logs) > 0 {
-				mux.Post(core.PendingLogsEvent{Logs: logs})
-			}
-			if tcount > 0 {
-				mux.Post(core.PendingStateEvent{})
-			}
-		}(cpy, val.tcount)
-	}
+	return coalescedLogs
 }
 
 func (val *validator) commitTransaction(tx *types.Transaction, bc *core.BlockChain, coinbase common.Address, gp *core.GasPool) (error, []*types.Log) {
@@ -423,7 +535,7 @@ func (val *validator) createBlock() *types.Block {
 	// new block header
 	parent := val.chain.CurrentBlock()
 	blockNumber := parent.Number()
-	tstart := time.Now()
+	tstart := val.clock.Now()
 	tstamp := tstart.Unix()
 	if parent.Time().Cmp(new(big.Int).SetInt64(tstamp)) >= 0 {
 		tstamp = parent.Time().Int64() + 1
@@ -464,8 +576,40 @@ func (val *validator) createBlock() *types.Block {
 		log.Crit("Failed to fetch pending transactions", "err", err)
 	}
 
-	txs := types.NewTransactionsByPriceAndNonce(val.signer, pending)
-	val.commitTransactions(val.eventMux, txs, val.chain, val.walletAccount.Account().Address)
+	// System transactions of validators go first, so that joining and leaving
+	// the validator set doesn't compete with the rest of the traffic
+	system, err := val.backend.TxPool().PendingSystem()
+	if err != nil {
+		log.Crit("Failed to fetch pending system transactions", "err", err)
+	}
+	for addr, txs := range system {
+		// the rest of the transactions of the account follow the system ones
+		last := txs[len(txs)-1].Nonce()
+		rest := pending[addr]
+		for len(rest) > 0 && rest[0].Nonce() <= last {
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			delete(pending, addr)
+		} else {
+			pending[addr] = rest
+		}
+	}
+
+	// stable fee transactions are sorted by the gas price that they pay at the
+	// current oracle price
+	var oraclePrice *big.Int
+	if val.config.OracleMgr != nil && val.config.IsTypedTx(header.Number) {
+		if oraclePrice, err = core.OraclePrice(val.config, header, val.state.Copy()); err != nil {
+			log.Warn("Failed to retrieve the oracle price", "err", err)
+		}
+	}
+	pricer := core.EffectiveGasPricer(oraclePrice)
+	sets := []*types.TransactionsByPriceAndNonce{
+		types.NewTransactionsByGasPricerAndNonce(val.signer, system, pricer),
+		types.NewTransactionsByGasPricerAndNonce(val.signer, pending, pricer),
+	}
+	val.commitTransactions(val.eventMux, sets, val.chain, val.walletAccount.Account().Address)
 
 	// Create the new block to seal with the consensus engine
 	var block *types.Block
@@ -489,7 +633,7 @@ func (val *validator) propose() {
 
 	proposal := types.NewProposal(val.blockNumber, val.round, fragments.Metadata(), lockedRound, lockedBlock)
 
-	signedProposal, err := val.walletAccount.SignProposal(val.walletAccount.Account(), proposal, val.config.ChainID)
+	signedProposal, err := val.signingAccount.SignProposal(val.signingAccount.Account(), proposal, val.config.ChainID)
 	if err != nil {
 		log.Crit("Failed to sign the proposal", "err", err)
 	}
@@ -497,7 +641,7 @@ func (val *validator) propose() {
 	val.proposal = signedProposal
 	val.block = block
 
-	val.eventMux.Post(core.NewProposalEvent{Proposal: proposal})
+	val.eventMux.Post(core.NewProposalEvent{Proposal: signedProposal})
 
 	for i := uint(0); i < fragments.Size(); i++ {
 		val.eventMux.Post(core.NewBlockFragmentEvent{
@@ -566,7 +710,7 @@ func (val *validator) preCommit() {
 }
 
 func (val *validator) vote(vote *types.Vote) {
-	signedVote, err := val.walletAccount.SignVote(val.walletAccount.Account(), vote, val.config.ChainID)
+	signedVote, err := val.signingAccount.SignVote(val.signingAccount.Account(), vote, val.config.ChainID)
 	if err != nil {
 		log.Crit("Failed to sign the vote", "err", err)
 	}
@@ -589,8 +733,8 @@ func (val *validator) AddBlockFragment(blockNumber *big.Int, round uint64, fragm
 	}
 
 	if err := val.blockFragments.Add(fragment); err != nil {
-		err = errors.New("Failed to add a new block fragment: " + err.Error())
-		return err
+		log.Debug("Failed to add a new block fragment", "err", err, "round", round, "block", blockNumber)
+		return ErrInvalidBlockFragment
 	}
 
 	if val.blockFragments.HasAll() {
@@ -678,7 +822,7 @@ func (val *validator) updateValidators(checksum [32]byte, genesis bool) error {
 		return err
 	}
 
-	val.voters = validators
+	val.validators = validators
 	val.votersChecksum = checksum
 
 	return nil
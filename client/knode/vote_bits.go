@@ -0,0 +1,144 @@
+package knode
+
+import (
+	"sync"
+
+	"github.com/kowala-tech/kcoin/client/core/types"
+)
+
+const (
+	maxVoteSetBits      = 4096 // Maximum number of voters a vote set bitmap may cover (prevent DOS)
+	maxKnownVoteSets    = 64   // Maximum vote sets to keep track of per peer (prevent DOS)
+	maxVoteSetElections = 2    // Number of the latest elections whose vote sets are kept
+)
+
+// voteSetKey identifies the votes of a kind cast in an election round.
+type voteSetKey struct {
+	blockNumber uint64
+	round       uint64
+	voteType    types.VoteType
+}
+
+// voteSetKeyOf returns the key of the vote set the vote belongs to.
+func voteSetKeyOf(vote *types.Vote) voteSetKey {
+	return voteSetKey{
+		blockNumber: vote.BlockNumber().Uint64(),
+		round:       vote.Round(),
+		voteType:    vote.Type(),
+	}
+}
+
+// voteBits is a bitmap of the votes of a vote set, the bit i standing for the
+// vote of the voter at index i of the validator set.
+type voteBits []byte
+
+// has reports whether the vote of the given voter is set.
+func (bits voteBits) has(index int) bool {
+	if index < 0 || index/8 >= len(bits) {
+		return false
+	}
+	return bits[index/8]&(1<<uint(index%8)) != 0
+}
+
+// set sets the vote of the given voter, growing the bitmap as needed.
+func (bits voteBits) set(index int) voteBits {
+	if index/8 >= len(bits) {
+		bits = append(bits, make([]byte, index/8+1-len(bits))...)
+	}
+	bits[index/8] |= 1 << uint(index%8)
+	return bits
+}
+
+// merge sets the votes set in other, growing the bitmap as needed.
+func (bits voteBits) merge(other voteBits) voteBits {
+	if len(other) > len(bits) {
+		bits = append(bits, make([]byte, len(other)-len(bits))...)
+	}
+	for i, b := range other {
+		bits[i] |= b
+	}
+	return bits
+}
+
+// voteSets keeps track of the votes known for the vote sets of the latest
+// elections. It is safe for concurrent use.
+type voteSets struct {
+	sets   map[voteSetKey]voteBits
+	latest uint64 // Latest election number seen
+	lock   sync.RWMutex
+}
+
+// newVoteSets creates an empty tracker of vote sets.
+func newVoteSets() *voteSets {
+	return &voteSets{
+		sets: make(map[voteSetKey]voteBits),
+	}
+}
+
+// has reports whether the vote of the given voter is known for the vote set.
+func (vs *voteSets) has(key voteSetKey, index int) bool {
+	vs.lock.RLock()
+	defer vs.lock.RUnlock()
+
+	return vs.sets[key].has(index)
+}
+
+// bits returns a copy of the bitmap of the known votes of the vote set.
+func (vs *voteSets) bits(key voteSetKey) voteBits {
+	vs.lock.RLock()
+	defer vs.lock.RUnlock()
+
+	return append(voteBits(nil), vs.sets[key]...)
+}
+
+// mark marks the vote of the given voter as known for the vote set, and
+// reports whether it was unknown so far.
+func (vs *voteSets) mark(key voteSetKey, index int) bool {
+	if index < 0 || index >= maxVoteSetBits {
+		return false
+	}
+	vs.lock.Lock()
+	defer vs.lock.Unlock()
+
+	bits, ok := vs.track(key)
+	if !ok || bits.has(index) {
+		return false
+	}
+	vs.sets[key] = bits.set(index)
+	return true
+}
+
+// merge marks all the votes of the bitmap as known for the vote set.
+func (vs *voteSets) merge(key voteSetKey, other voteBits) {
+	if len(other) > maxVoteSetBits/8 {
+		other = other[:maxVoteSetBits/8]
+	}
+	vs.lock.Lock()
+	defer vs.lock.Unlock()
+
+	if bits, ok := vs.track(key); ok {
+		vs.sets[key] = bits.merge(other)
+	}
+}
+
+// track returns the bitmap of the vote set, dropping the vote sets of the
+// elections that fell behind. Vote sets of stale elections, or beyond the
+// memory allowance, aren't tracked. It must be called with the lock held.
+func (vs *voteSets) track(key voteSetKey) (voteBits, bool) {
+	if key.blockNumber > vs.latest {
+		vs.latest = key.blockNumber
+		for k := range vs.sets {
+			if k.blockNumber+maxVoteSetElections <= vs.latest {
+				delete(vs.sets, k)
+			}
+		}
+	}
+	if key.blockNumber+maxVoteSetElections <= vs.latest {
+		return nil, false
+	}
+	bits, ok := vs.sets[key]
+	if !ok && len(vs.sets) >= maxKnownVoteSets {
+		return nil, false
+	}
+	return bits, true
+}
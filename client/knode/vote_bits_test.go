@@ -0,0 +1,172 @@
+package knode
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/knode/protocol"
+	"github.com/kowala-tech/kcoin/client/p2p"
+	"github.com/kowala-tech/kcoin/client/p2p/discover"
+)
+
+func TestVoteBits(t *testing.T) {
+	var bits voteBits
+	for _, index := range []int{0, 7, 8, 49} {
+		bits = bits.set(index)
+	}
+	if len(bits) != 7 {
+		t.Fatalf("bitmap size mismatch: have %d, want %d", len(bits), 7)
+	}
+	for index := -1; index < 64; index++ {
+		want := index == 0 || index == 7 || index == 8 || index == 49
+		if have := bits.has(index); have != want {
+			t.Errorf("vote %d: have %v, want %v", index, have, want)
+		}
+	}
+
+	merged := voteBits(nil).set(1).merge(bits)
+	for _, index := range []int{0, 1, 7, 8, 49} {
+		if !merged.has(index) {
+			t.Errorf("merged vote %d missing", index)
+		}
+	}
+	if bits.has(1) {
+		t.Errorf("merge modified its argument")
+	}
+}
+
+func TestVoteSetsMark(t *testing.T) {
+	sets := newVoteSets()
+	key := voteSetKey{blockNumber: 10, round: 1, voteType: types.PreVote}
+
+	if !sets.mark(key, 3) {
+		t.Fatalf("first mark of a vote reported as known")
+	}
+	if sets.mark(key, 3) {
+		t.Fatalf("second mark of a vote reported as unknown")
+	}
+	if !sets.has(key, 3) || sets.has(key, 4) {
+		t.Fatalf("marked votes mismatch: %08b", sets.bits(key))
+	}
+	other := voteSetKey{blockNumber: 10, round: 1, voteType: types.PreCommit}
+	if sets.has(other, 3) {
+		t.Fatalf("vote known for another vote set")
+	}
+	if sets.mark(key, maxVoteSetBits) {
+		t.Fatalf("vote beyond the bitmap allowance marked")
+	}
+
+	sets.merge(key, voteBits{0xf0})
+	for index := 3; index < 8; index++ {
+		if !sets.has(key, index) {
+			t.Errorf("merged vote %d missing", index)
+		}
+	}
+}
+
+func TestVoteSetsPrune(t *testing.T) {
+	sets := newVoteSets()
+	old := voteSetKey{blockNumber: 10, voteType: types.PreVote}
+	prev := voteSetKey{blockNumber: 11, voteType: types.PreVote}
+	latest := voteSetKey{blockNumber: 12, voteType: types.PreCommit}
+
+	sets.mark(old, 0)
+	sets.mark(prev, 0)
+	sets.mark(latest, 0)
+	if sets.has(old, 0) {
+		t.Fatalf("vote set of a stale election kept")
+	}
+	if !sets.has(prev, 0) || !sets.has(latest, 0) {
+		t.Fatalf("vote sets of the latest elections dropped")
+	}
+	if sets.mark(old, 1) {
+		t.Fatalf("vote of a stale election marked")
+	}
+}
+
+func TestVoteSetsAllowance(t *testing.T) {
+	sets := newVoteSets()
+	for round := uint64(0); round < maxKnownVoteSets; round++ {
+		if !sets.mark(voteSetKey{blockNumber: 1, round: round}, 0) {
+			t.Fatalf("vote of round %d not marked", round)
+		}
+	}
+	if sets.mark(voteSetKey{blockNumber: 1, round: maxKnownVoteSets}, 0) {
+		t.Fatalf("vote set beyond the memory allowance tracked")
+	}
+	// A new election frees the allowance
+	if !sets.mark(voteSetKey{blockNumber: 3}, 0) {
+		t.Fatalf("vote set of a new election not tracked")
+	}
+}
+
+func TestSendVoteSetBitsVersion(t *testing.T) {
+	key := voteSetKey{blockNumber: 10, round: 1, voteType: types.PreVote}
+	bits := voteBits(nil).set(2)
+
+	for _, version := range []int{protocol.Kcoin1, protocol.Kcoin2} {
+		app, net := p2p.MsgPipe()
+		p := newPeer(version, p2p.NewPeer(discover.NodeID{}, "peer", nil), net)
+
+		errc := make(chan error, 1)
+		go func() { errc <- p.SendVoteSetBits(key, bits) }()
+
+		received := make(chan p2p.Msg, 1)
+		go func() {
+			if msg, err := app.ReadMsg(); err == nil {
+				msg.Discard()
+				received <- msg
+			}
+		}()
+		select {
+		case msg := <-received:
+			if version < protocol.Kcoin2 {
+				t.Errorf("version %d: vote set bits sent", version)
+			} else if msg.Code != VoteSetBitsMsg {
+				t.Errorf("version %d: message code mismatch: have %x, want %x", version, msg.Code, VoteSetBitsMsg)
+			}
+		case <-time.After(100 * time.Millisecond):
+			if version >= protocol.Kcoin2 {
+				t.Errorf("version %d: vote set bits not sent", version)
+			}
+		}
+		if err := <-errc; err != nil {
+			t.Errorf("version %d: failed to send vote set bits: %v", version, err)
+		}
+		app.Close()
+	}
+}
+
+func TestSendVoteKnownAllowance(t *testing.T) {
+	app, net := p2p.MsgPipe()
+	defer app.Close()
+	go func() {
+		for {
+			msg, err := app.ReadMsg()
+			if err != nil {
+				return
+			}
+			msg.Discard()
+		}
+	}()
+	p := newPeer(protocol.Kcoin2, p2p.NewPeer(discover.NodeID{}, "peer", nil), net)
+
+	for i := 0; i < maxKnownVotes+10; i++ {
+		vote := types.NewVote(big.NewInt(int64(i)), common.Hash{}, 0, types.PreVote)
+		if err := p.SendVote(vote); err != nil {
+			t.Fatalf("failed to send vote: %v", err)
+		}
+		if err := p.SendNewProposal(types.NewProposal(big.NewInt(int64(i)), 0, nil, 0, common.Hash{})); err != nil {
+			t.Fatalf("failed to send proposal: %v", err)
+		}
+	}
+	if size := p.knownVotes.Size(); size > maxKnownVotes {
+		t.Errorf("known votes beyond the allowance: have %d, want at most %d", size, maxKnownVotes)
+	}
+	if size := p.knownProposals.Size(); size > maxKnownProposals {
+		t.Errorf("known proposals beyond the allowance: have %d, want at most %d", size, maxKnownProposals)
+	}
+}
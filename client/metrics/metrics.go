@@ -6,16 +6,12 @@
 package metrics
 
 import (
-	"net/http"
 	"os"
 	"runtime"
 	"strings"
 	"time"
 
-	prometheusmetrics "github.com/kowala-tech/go-metrics-prometheus"
 	"github.com/kowala-tech/kcoin/client/log"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // Enabled is checked by the constructor functions for all of the
@@ -52,24 +48,12 @@ func init() {
 
 // CollectProcessMetrics periodically collects various metrics about the running
 // process.
-func CollectProcessMetrics(refresh time.Duration, promAddr, promSubSys string) {
+func CollectProcessMetrics(refresh time.Duration) {
 	// Short circuit if the metrics system is disabled
 	if !Enabled {
 		return
 	}
 
-	// Set up Prometheus
-	go func() {
-		prometheusRegistry := prometheus.DefaultGatherer
-		metricsRegistry := DefaultRegistry
-		pClient := prometheusmetrics.NewPrometheusProvider(metricsRegistry, "eth", promSubSys, (prometheusRegistry).(*prometheus.Registry), refresh)
-		go pClient.UpdatePrometheusMetrics()
-
-		log.Info("Starting Prometheus metrics", "address", promAddr, "subsystem", promSubSys)
-		http.Handle("/metrics", promhttp.HandlerFor(prometheusRegistry, promhttp.HandlerOpts{}))
-		http.ListenAndServe(promAddr, nil)
-	}()
-
 	// Create the various data collectors
 	memstats := make([]*runtime.MemStats, 2)
 	diskstats := make([]*DiskStats, 2)
@@ -0,0 +1,129 @@
+// Package prometheus exposes go-metrics registries in the Prometheus text
+// exposition format, so that nodes can be scraped without an intermediate
+// reporter.
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/kowala-tech/kcoin/client/metrics"
+)
+
+const (
+	// Path is the path the metrics are served on.
+	Path = "/metrics"
+
+	// contentType is the Prometheus text exposition format content type.
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+var (
+	// quantiles reported for histograms and timers.
+	quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+	// resettingQuantiles reported for resetting timers, which use percentages.
+	resettingQuantiles = []float64{50, 75, 95, 99}
+)
+
+// Handler returns an HTTP handler serving every metric of the registry in the
+// Prometheus text format. Metric names are prefixed with the namespace, if any.
+func Handler(namespace string, r metrics.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write(Export(namespace, r))
+	})
+}
+
+// ListenAndServe serves the metrics of the registry on Path at the given
+// address, their names prefixed with the namespace.
+func ListenAndServe(address, namespace string, r metrics.Registry) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, Handler(namespace, r))
+	return http.ListenAndServe(address, mux)
+}
+
+// Export renders every metric of the registry in the Prometheus text format,
+// sorted by name.
+func Export(namespace string, r metrics.Registry) []byte {
+	all := make(map[string]interface{})
+	r.Each(func(name string, i interface{}) {
+		all[name] = i
+	})
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	for _, name := range names {
+		key := flattenKey(name)
+		if namespace != "" {
+			key = flattenKey(namespace) + "_" + key
+		}
+		switch metric := all[name].(type) {
+		case metrics.Counter:
+			writeValue(buf, key, "counter", float64(metric.Count()))
+		case metrics.Gauge:
+			writeValue(buf, key, "gauge", float64(metric.Value()))
+		case metrics.GaugeFloat64:
+			writeValue(buf, key, "gauge", metric.Value())
+		case metrics.Histogram:
+			h := metric.Snapshot()
+			writeSummary(buf, key, h.Percentiles(quantiles), float64(h.Sum()), h.Count())
+		case metrics.Meter:
+			writeValue(buf, key, "counter", float64(metric.Snapshot().Count()))
+		case metrics.Timer:
+			t := metric.Snapshot()
+			writeSummary(buf, key, t.Percentiles(quantiles), float64(t.Sum()), t.Count())
+		case metrics.ResettingTimer:
+			t := metric.Snapshot()
+			ps := t.Percentiles(resettingQuantiles)
+			values := make([]float64, len(ps))
+			for i, p := range ps {
+				values[i] = float64(p)
+			}
+			count := int64(len(t.Values()))
+			writeSummary(buf, key, values, t.Mean()*float64(count), count)
+		}
+	}
+	return buf.Bytes()
+}
+
+// writeValue writes a single sample metric along with its type.
+func writeValue(buf *bytes.Buffer, key, kind string, value float64) {
+	fmt.Fprintf(buf, "# TYPE %s %s\n", key, kind)
+	fmt.Fprintf(buf, "%s %s\n", key, formatFloat(value))
+}
+
+// writeSummary writes the quantiles, the sum and the count of a sampled metric.
+func writeSummary(buf *bytes.Buffer, key string, values []float64, sum float64, count int64) {
+	fmt.Fprintf(buf, "# TYPE %s summary\n", key)
+	for i, q := range quantiles[:len(values)] {
+		fmt.Fprintf(buf, "%s{quantile=\"%s\"} %s\n", key, formatFloat(q), formatFloat(values[i]))
+	}
+	fmt.Fprintf(buf, "%s_sum %s\n", key, formatFloat(sum))
+	fmt.Fprintf(buf, "%s_count %d\n", key, count)
+}
+
+// flattenKey converts a go-metrics name into a valid Prometheus metric name.
+func flattenKey(name string) string {
+	key := []byte(name)
+	for i, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == ':') {
+			key[i] = '_'
+		}
+	}
+	if len(key) > 0 && key[0] >= '0' && key[0] <= '9' {
+		return "_" + string(key)
+	}
+	return string(key)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,75 @@
+package prometheus
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/metrics"
+)
+
+func init() {
+	metrics.Enabled = true
+}
+
+func TestExport(t *testing.T) {
+	r := metrics.NewRegistry()
+
+	metrics.NewRegisteredCounter("validator/votes/in", r).Inc(3)
+	metrics.NewRegisteredGauge("chain/head", r).Update(42)
+	metrics.NewRegisteredMeter("validator/timeouts/proposal", r).Mark(2)
+
+	h := metrics.NewRegisteredHistogram("validator/rounds", r, metrics.NewUniformSample(10))
+	for i := int64(1); i <= 4; i++ {
+		h.Update(i)
+	}
+
+	want := strings.Join([]string{
+		"# TYPE kcoin_chain_head gauge",
+		"kcoin_chain_head 42",
+		"# TYPE kcoin_validator_rounds summary",
+		`kcoin_validator_rounds{quantile="0.5"} 2.5`,
+		`kcoin_validator_rounds{quantile="0.75"} 3.75`,
+		`kcoin_validator_rounds{quantile="0.95"} 4`,
+		`kcoin_validator_rounds{quantile="0.99"} 4`,
+		`kcoin_validator_rounds{quantile="0.999"} 4`,
+		"kcoin_validator_rounds_sum 10",
+		"kcoin_validator_rounds_count 4",
+		"# TYPE kcoin_validator_timeouts_proposal counter",
+		"kcoin_validator_timeouts_proposal 2",
+		"# TYPE kcoin_validator_votes_in counter",
+		"kcoin_validator_votes_in 3",
+		"",
+	}, "\n")
+	if have := string(Export("kcoin", r)); have != want {
+		t.Errorf("export mismatch:\nhave:\n%s\nwant:\n%s", have, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	metrics.NewRegisteredCounter("p2p/peers", r).Inc(1)
+
+	rec := httptest.NewRecorder()
+	Handler("", r).ServeHTTP(rec, httptest.NewRequest("GET", Path, nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != contentType {
+		t.Errorf("content type mismatch: have %q, want %q", ct, contentType)
+	}
+	if body := rec.Body.String(); body != "# TYPE p2p_peers counter\np2p_peers 1\n" {
+		t.Errorf("body mismatch: have %q", body)
+	}
+}
+
+func TestFlattenKey(t *testing.T) {
+	tests := map[string]string{
+		"eth/prop/txns/in":    "eth_prop_txns_in",
+		"system/memory.pause": "system_memory_pause",
+		"1st-metric":          "_1st_metric",
+	}
+	for name, want := range tests {
+		if have := flattenKey(name); have != want {
+			t.Errorf("%q: have %q, want %q", name, have, want)
+		}
+	}
+}
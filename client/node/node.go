@@ -557,6 +557,18 @@ func (n *Node) OpenDatabase(name string, cache, handles int) (kcoindb.Database,
 	return kcoindb.NewLDBDatabase(n.config.resolvePath(name), cache, handles)
 }
 
+// OpenDatabaseWithAncients opens an existing database with the given name (or
+// creates one if no previous can be found) from within the node's instance
+// directory, backed by an ancient store for immutable chain data. If the node
+// is ephemeral, a memory database is returned.
+func (n *Node) OpenDatabaseWithAncients(name string, cache, handles int, ancient string) (kcoindb.Database, error) {
+	if n.config.DataDir == "" {
+		return kcoindb.NewMemDatabase(), nil
+	}
+	file := n.config.resolvePath(name)
+	return kcoindb.NewLDBDatabaseWithAncients(file, n.config.resolveAncient(file, ancient), cache, handles)
+}
+
 // ResolvePath returns the absolute path of a resource in the instance directory.
 func (n *Node) ResolvePath(x string) string {
 	return n.config.resolvePath(x)
@@ -34,6 +34,23 @@ func (ctx *ServiceContext) OpenDatabase(name string, cache int, handles int) (kc
 	return db, nil
 }
 
+// OpenDatabaseWithAncients opens an existing database with the given name (or
+// creates one if no previous can be found) from within the node's data directory,
+// backed by an ancient store for immutable chain data. The ancient store lives
+// inside the database directory unless another directory is given. If the node
+// is an ephemeral one, a memory database is returned.
+func (ctx *ServiceContext) OpenDatabaseWithAncients(name string, cache int, handles int, ancient string) (kcoindb.Database, error) {
+	if ctx.config.DataDir == "" {
+		return kcoindb.NewMemDatabase(), nil
+	}
+	file := ctx.config.resolvePath(name)
+	db, err := kcoindb.NewLDBDatabaseWithAncients(file, ctx.config.resolveAncient(file, ancient), cache, handles)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
 // ResolvePath resolves a user path into the data directory if that was relative
 // and if the user actually uses persistent storage. It will return an empty string
 // for emphemeral storage and the user's own input for absolute paths.
@@ -88,6 +88,15 @@ type Config struct {
 	// allowed to connect, even above the peer limit.
 	TrustedNodes []*discover.Node
 
+	// SentryNodes shield a validator from the public network. If set, discovery
+	// is disabled, the sentries are the only nodes dialed and kept connected,
+	// and connections from any other node are refused.
+	SentryNodes []*discover.Node `toml:",omitempty"`
+
+	// PrivateNodes are the validators a sentry relays for. They are always
+	// allowed to connect, even above the peer limit.
+	PrivateNodes []*discover.Node `toml:",omitempty"`
+
 	// Connectivity can be restricted to certain IP networks.
 	// If this option is set to a non-nil value, only hosts which match one of the
 	// IP networks contained in the list are considered.
@@ -179,6 +188,7 @@ const (
 	staticDialedConn
 	inboundConn
 	trustedConn
+	sentryConn
 )
 
 // conn wraps a network connection with information gathered
@@ -221,6 +231,9 @@ func (f connFlag) String() string {
 	if f&trustedConn != 0 {
 		s += "-trusted"
 	}
+	if f&sentryConn != 0 {
+		s += "-sentry"
+	}
 	if f&dynDialedConn != 0 {
 		s += "-dyndial"
 	}
@@ -407,6 +420,12 @@ func (srv *Server) Start() (err error) {
 		unhandled chan discover.ReadPacket
 	)
 
+	// A validator behind sentries stays off the discovery networks
+	if len(srv.SentryNodes) > 0 {
+		srv.NoDiscovery = true
+		srv.DiscoveryV5 = false
+	}
+
 	if !srv.NoDiscovery || srv.DiscoveryV5 {
 		addr, err := net.ResolveUDPAddr("udp", srv.ListenAddr)
 		if err != nil {
@@ -470,7 +489,14 @@ func (srv *Server) Start() (err error) {
 	}
 
 	dynPeers := srv.maxDialedConns()
-	dialer := newDialState(srv.StaticNodes, srv.BootstrapNodes, srv.ntab, dynPeers, srv.NetRestrict)
+	static, bootnodes := srv.StaticNodes, srv.BootstrapNodes
+	if len(srv.SentryNodes) > 0 {
+		if len(static) > 0 {
+			srv.log.Warn("Ignoring static nodes, only the sentries are dialed", "static", len(static))
+		}
+		static, bootnodes = srv.SentryNodes, nil
+	}
+	dialer := newDialState(static, bootnodes, srv.ntab, dynPeers, srv.NetRestrict)
 
 	// handshake
 	srv.ourHandshake = &protoHandshake{Version: baseProtocolVersion, Name: srv.Name, ID: discover.PubkeyID(&srv.PrivateKey.PublicKey)}
@@ -527,7 +553,8 @@ func (srv *Server) run(dialstate dialer) {
 	var (
 		peers        = make(map[discover.NodeID]*Peer)
 		inboundCount = 0
-		trusted      = make(map[discover.NodeID]bool, len(srv.TrustedNodes))
+		trusted      = make(map[discover.NodeID]bool, len(srv.TrustedNodes)+len(srv.PrivateNodes))
+		sentries     = make(map[discover.NodeID]bool, len(srv.SentryNodes))
 		taskdone     = make(chan task, maxActiveDialTasks)
 		runningTasks []task
 		queuedTasks  []task // tasks that can't run yet
@@ -538,6 +565,15 @@ func (srv *Server) run(dialstate dialer) {
 	for _, n := range srv.TrustedNodes {
 		trusted[n.ID] = true
 	}
+	// The validators behind a sentry and the sentries of a validator are
+	// trusted as well.
+	for _, n := range srv.PrivateNodes {
+		trusted[n.ID] = true
+	}
+	for _, n := range srv.SentryNodes {
+		trusted[n.ID] = true
+		sentries[n.ID] = true
+	}
 
 	// removes t from runningTasks
 	delTask := func(t task) {
@@ -610,6 +646,9 @@ running:
 				// Ensure that the trusted flag is set before checking against MaxPeers.
 				c.flags |= trustedConn
 			}
+			if sentries[c.id] {
+				c.flags |= sentryConn
+			}
 			// TODO: track in-progress inbound node IDs (pre-Peer) to avoid dialing them.
 			select {
 			case c.cont <- srv.encHandshakeChecks(peers, inboundCount, c):
@@ -690,6 +729,9 @@ func (srv *Server) protoHandshakeChecks(peers map[discover.NodeID]*Peer, inbound
 
 func (srv *Server) encHandshakeChecks(peers map[discover.NodeID]*Peer, inboundCount int, c *conn) error {
 	switch {
+	case len(srv.SentryNodes) > 0 && !c.is(sentryConn):
+		// Validators behind sentries only talk to their sentries
+		return DiscUselessPeer
 	case !c.is(trustedConn|staticDialedConn) && len(peers) >= srv.MaxPeers:
 		return DiscTooManyPeers
 	case !c.is(trustedConn) && c.is(inboundConn) && inboundCount >= srv.maxInboundConns():
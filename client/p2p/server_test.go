@@ -350,6 +350,45 @@ func TestServerAtCap(t *testing.T) {
 
 }
 
+// This test checks that a validator behind sentries refuses the connections of
+// any node but its sentries, even when far from its peer limit.
+func TestServerSentries(t *testing.T) {
+	sentryID := randomID()
+	srv := &Server{
+		Config: Config{
+			PrivateKey:  newkey(),
+			MaxPeers:    10,
+			NoDial:      true,
+			SentryNodes: []*discover.Node{{ID: sentryID}},
+		},
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatalf("could not start: %v", err)
+	}
+	defer srv.Stop()
+
+	if !srv.NoDiscovery || srv.DiscoveryV5 {
+		t.Error("discovery enabled behind sentries")
+	}
+	newconn := func(id discover.NodeID) *conn {
+		fd, _ := net.Pipe()
+		tx := newTestTransport(id, fd)
+		return &conn{fd: fd, transport: tx, flags: inboundConn, id: id, cont: make(chan error)}
+	}
+
+	c := newconn(randomID())
+	if err := srv.checkpoint(c, srv.posthandshake); err != DiscUselessPeer {
+		t.Error("wrong error for non-sentry conn:", err)
+	}
+	c = newconn(sentryID)
+	if err := srv.checkpoint(c, srv.posthandshake); err != nil {
+		t.Error("unexpected error for sentry conn @posthandshake:", err)
+	}
+	if !c.is(sentryConn | trustedConn) {
+		t.Error("Server did not set sentry flags")
+	}
+}
+
 func TestServerSetupConn(t *testing.T) {
 	id := randomID()
 	srvkey := newkey()
@@ -20,11 +20,20 @@ var (
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the test network.
+	//
+	// The typed transactions aren't scheduled on the test network yet: its
+	// oracle manager is only set along with the TypedTxBlock.
 	TestnetChainConfig = &ChainConfig{
-		ChainID:   big.NewInt(2),
-		Konsensus: new(KonsensusConfig),
+		ChainID:      big.NewInt(2),
+		Konsensus:    new(KonsensusConfig),
+		ValidatorMgr: &testnetValidatorMgr,
+		MiningToken:  &testnetMiningToken,
 	}
 
+	// Addresses of the system contracts on the test network.
+	testnetValidatorMgr = common.HexToAddress("0x80eDa603028fe504B57D14d947c8087c1798D800")
+	testnetMiningToken  = common.HexToAddress("0x6f04441A6eD440Cc139a4E33402b438C27E97F4B")
+
 	// AllKonsensusProtocolChanges contains every protocol change (EIPs)
 	// introduced and accepted by the Kowala core developers.
 	//
@@ -33,8 +42,8 @@ var (
 	// means that all fields must be set at all times. This forces
 	// anyone adding flags to the config to also have to set these
 	// fields.
-	AllKonsensusProtocolChanges = &ChainConfig{big.NewInt(1337), new(KonsensusConfig)}
-	TestChainConfig             = &ChainConfig{big.NewInt(1), new(KonsensusConfig)}
+	AllKonsensusProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), new(KonsensusConfig), nil, nil, nil}
+	TestChainConfig             = &ChainConfig{big.NewInt(1), big.NewInt(0), new(KonsensusConfig), nil, nil, nil}
 	TestRules                   = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -46,8 +55,20 @@ var (
 type ChainConfig struct {
 	ChainID *big.Int `json:"chainID"` // Chain id identifies the current chain and is used for replay protection
 
+	TypedTxBlock *big.Int `json:"typedTxBlock,omitempty"` // TypedTx switch block (nil = no fork, 0 = already activated)
+
 	// Various consensus engines
 	Konsensus *KonsensusConfig `json:"konsensus,omitempty"`
+
+	// OracleMgr is the address of the oracle manager contract providing the
+	// kcoin price used to convert stable fees, nil disables stable fees.
+	OracleMgr *common.Address `json:"oracleMgr,omitempty"`
+
+	// ValidatorMgr and MiningToken are the addresses of the validator manager
+	// and of the mining token contracts. Calls to them from validators are
+	// system transactions, given reserved capacity in the transaction pool.
+	ValidatorMgr *common.Address `json:"validatorMgr,omitempty"`
+	MiningToken  *common.Address `json:"miningToken,omitempty"`
 }
 
 // KonsensusConfig is the consensus engine configs for proof-of-stake based sealing.
@@ -67,12 +88,20 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Engine: %v}",
+	return fmt.Sprintf("{ChainID: %v TypedTx: %v Engine: %v}",
 		c.ChainID,
+		c.TypedTxBlock,
 		engine,
 	)
 }
 
+// IsTypedTx returns whether num is either equal to the typed transactions fork
+// block or greater: the stable fee and sponsored transactions are valid from
+// that block on.
+func (c *ChainConfig) IsTypedTx(num *big.Int) bool {
+	return isForked(c.TypedTxBlock, num)
+}
+
 // GasTable returns the gas table corresponding to the current phase (andromeda).
 //
 // The returned GasTable's fields shouldn't, under any circumstances, be changed.
@@ -102,9 +131,26 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if !configNumEqual(c.ChainID, newcfg.ChainID) {
 		return newCompatError("Chain ID", c.ChainID, newcfg.ChainID)
 	}
+	if isForkIncompatible(c.TypedTxBlock, newcfg.TypedTxBlock, head) {
+		return newCompatError("TypedTx fork block", c.TypedTxBlock, newcfg.TypedTxBlock)
+	}
 	return nil
 }
 
+// isForkIncompatible returns true if a fork scheduled at s1 cannot be rescheduled to
+// block s2 because head is already past the fork.
+func isForkIncompatible(s1, s2, head *big.Int) bool {
+	return (isForked(s1, head) || isForked(s2, head)) && !configNumEqual(s1, s2)
+}
+
+// isForked returns whether a fork scheduled at block s is active at the given head block.
+func isForked(s, head *big.Int) bool {
+	if s == nil || head == nil {
+		return false
+	}
+	return s.Cmp(head) <= 0
+}
+
 func configNumEqual(x, y *big.Int) bool {
 	if x == nil {
 		return y == nil
@@ -152,7 +198,8 @@ func (err *ConfigCompatError) Error() string {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainId *big.Int
+	ChainId   *big.Int
+	IsTypedTx bool
 }
 
 func (c *ChainConfig) Rules(num *big.Int) Rules {
@@ -160,5 +207,5 @@ func (c *ChainConfig) Rules(num *big.Int) Rules {
 	if chainID == nil {
 		chainID = new(big.Int)
 	}
-	return Rules{ChainId: new(big.Int).Set(chainID)}
+	return Rules{ChainId: new(big.Int).Set(chainID), IsTypedTx: c.IsTypedTx(num)}
 }
@@ -28,6 +28,23 @@ func TestCheckCompatible(t *testing.T) {
 				RewindTo:     0,
 			},
 		},
+		{
+			stored:  &ChainConfig{ChainID: big.NewInt(1), TypedTxBlock: big.NewInt(10)},
+			new:     &ChainConfig{ChainID: big.NewInt(1), TypedTxBlock: big.NewInt(20)},
+			head:    9,
+			wantErr: nil,
+		},
+		{
+			stored: &ChainConfig{ChainID: big.NewInt(1), TypedTxBlock: big.NewInt(10)},
+			new:    &ChainConfig{ChainID: big.NewInt(1), TypedTxBlock: big.NewInt(20)},
+			head:   15,
+			wantErr: &ConfigCompatError{
+				What:         "TypedTx fork block",
+				StoredConfig: big.NewInt(10),
+				NewConfig:    big.NewInt(20),
+				RewindTo:     9,
+			},
+		},
 	}
 
 	for _, test := range tests {
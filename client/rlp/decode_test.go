@@ -311,6 +311,10 @@ type recstruct struct {
 	Child *recstruct `rlp:"nil"`
 }
 
+type optionalBigInt struct {
+	I *big.Int `rlp:"nil"`
+}
+
 type invalidTail1 struct {
 	A uint `rlp:"tail"`
 	B string
@@ -430,6 +434,16 @@ var decodeTests = []decodeTest{
 		ptr:   new(recstruct),
 		value: recstruct{1, &recstruct{2, &recstruct{3, nil}}},
 	},
+	{
+		input: "C180",
+		ptr:   new(optionalBigInt),
+		value: optionalBigInt{nil},
+	},
+	{
+		input: "C105",
+		ptr:   new(optionalBigInt),
+		value: optionalBigInt{big.NewInt(5)},
+	},
 
 	// struct errors
 	{
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/client/common/math"
 )
 
@@ -30,6 +31,7 @@ func TestBlockNumberJSONUnmarshal(t *testing.T) {
 		14: {`someString`, true, BlockNumber(0)},
 		15: {`""`, true, BlockNumber(0)},
 		16: {``, true, BlockNumber(0)},
+		17: {`"finalized"`, false, FinalizedBlockNumber},
 	}
 
 	for i, test := range tests {
@@ -48,3 +50,52 @@ func TestBlockNumberJSONUnmarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestBlockNumberOrHashJSONUnmarshal(t *testing.T) {
+	hash := common.HexToHash("0x5cb18cc3d3e61bd3f5b2b1a1cd53b2c6a8f2a28e3e0d47f94a4d3b7c6b1e3f4a")
+	tests := []struct {
+		input    string
+		mustFail bool
+		expected BlockNumberOrHash
+	}{
+		0:  {`"0x"`, true, BlockNumberOrHash{}},
+		1:  {`"0x0"`, false, BlockNumberOrHashWithNumber(0)},
+		2:  {`"0x12"`, false, BlockNumberOrHashWithNumber(18)},
+		3:  {`"pending"`, false, BlockNumberOrHashWithNumber(PendingBlockNumber)},
+		4:  {`"latest"`, false, BlockNumberOrHashWithNumber(LatestBlockNumber)},
+		5:  {`"finalized"`, false, BlockNumberOrHashWithNumber(FinalizedBlockNumber)},
+		6:  {`"` + hash.Hex() + `"`, false, BlockNumberOrHashWithHash(hash)},
+		7:  {`{"blockNumber":"0x1"}`, false, BlockNumberOrHashWithNumber(1)},
+		8:  {`{"blockNumber":"latest"}`, false, BlockNumberOrHashWithNumber(LatestBlockNumber)},
+		9:  {`{"blockHash":"` + hash.Hex() + `"}`, false, BlockNumberOrHashWithHash(hash)},
+		10: {`{"blockNumber":"0x1","blockHash":"` + hash.Hex() + `"}`, true, BlockNumberOrHash{}},
+		11: {`{}`, true, BlockNumberOrHash{}},
+		12: {`"0x5cb18cc3"`, false, BlockNumberOrHashWithNumber(0x5cb18cc3)},
+	}
+
+	for i, test := range tests {
+		var bnh BlockNumberOrHash
+		err := json.Unmarshal([]byte(test.input), &bnh)
+		if test.mustFail && err == nil {
+			t.Errorf("Test %d should fail", i)
+			continue
+		}
+		if !test.mustFail && err != nil {
+			t.Errorf("Test %d should pass but got err: %v", i, err)
+			continue
+		}
+		if test.mustFail {
+			continue
+		}
+		wantNum, wantIsNum := test.expected.Number()
+		gotNum, gotIsNum := bnh.Number()
+		if wantNum != gotNum || wantIsNum != gotIsNum {
+			t.Errorf("Test %d got unexpected number, want %d, got %d", i, wantNum, gotNum)
+		}
+		wantHash, wantIsHash := test.expected.Hash()
+		gotHash, gotIsHash := bnh.Hash()
+		if wantHash != gotHash || wantIsHash != gotIsHash {
+			t.Errorf("Test %d got unexpected hash, want %x, got %x", i, wantHash, gotHash)
+		}
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/kowala-tech/kcoin/client/consensus"
 	"github.com/kowala-tech/kcoin/client/contracts/bindings/oracle"
 	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/rawdb"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/event"
 	"github.com/kowala-tech/kcoin/client/knode"
@@ -448,23 +449,24 @@ func (s *Service) reportLatency(conn *websocket.Conn) error {
 
 // blockStats is the information to report about individual blocks.
 type blockStats struct {
-	Number         *big.Int       `json:"number"`
-	Hash           common.Hash    `json:"hash"`
-	ParentHash     common.Hash    `json:"parentHash"`
-	Timestamp      *big.Int       `json:"timestamp"`
-	Validator      common.Address `json:"validator"`
-	GasUsed        uint64         `json:"gasUsed"`
-	GasLimit       uint64         `json:"gasLimit"`
-	Txs            []txStats      `json:"transactions"`
-	TxHash         common.Hash    `json:"transactionsRoot"`
-	Root           common.Hash    `json:"stateRoot"`
-	MinDeposit     *big.Int       `json:"minDeposit"`
-	ValidatorCount *big.Int       `json:"validatorCount"`
-	MaxValidators  *big.Int       `json:"maxValidators"`
-	OracleCount    *big.Int       `json:"oracleCount"`
-	CurrencyPrice  *big.Int       `json:"currencyPrice"`
-	MintedReward   *big.Int       `json:"mintedReward"`
-	StabilityFee   *big.Int       `json:"stabilityFee"`
+	Number         *big.Int         `json:"number"`
+	Hash           common.Hash      `json:"hash"`
+	ParentHash     common.Hash      `json:"parentHash"`
+	Timestamp      *big.Int         `json:"timestamp"`
+	Validator      common.Address   `json:"validator"`
+	GasUsed        uint64           `json:"gasUsed"`
+	GasLimit       uint64           `json:"gasLimit"`
+	Txs            []txStats        `json:"transactions"`
+	TxHash         common.Hash      `json:"transactionsRoot"`
+	Root           common.Hash      `json:"stateRoot"`
+	MinDeposit     *big.Int         `json:"minDeposit"`
+	ValidatorCount *big.Int         `json:"validatorCount"`
+	MaxValidators  *big.Int         `json:"maxValidators"`
+	OracleCount    *big.Int         `json:"oracleCount"`
+	CurrencyPrice  *big.Int         `json:"currencyPrice"`
+	MintedReward   *big.Int         `json:"mintedReward"`
+	StabilityFee   *big.Int         `json:"stabilityFee"`
+	Missed         []common.Address `json:"missed,omitempty"` // Validators missing the election, if recorded
 }
 
 // txStats is the information to report about individual transactions.
@@ -555,6 +557,16 @@ func (s *Service) assembleBlockStats(block *types.Block) (*blockStats, error) {
 		return nil, err
 	}
 
+	// Validators missing the election, as recorded by a validating node
+	var missed []common.Address
+	if participation := rawdb.ReadParticipation(s.kcoin.ChainDb(), header.Hash(), header.Number.Uint64()); participation != nil {
+		for _, voter := range participation.Voters {
+			if voter.Missed() {
+				missed = append(missed, voter.Address)
+			}
+		}
+	}
+
 	return &blockStats{
 		Number:         header.Number,
 		Hash:           header.Hash(),
@@ -571,6 +583,7 @@ func (s *Service) assembleBlockStats(block *types.Block) (*blockStats, error) {
 		MaxValidators:  maxValidators,
 		OracleCount:    oracleCount,
 		CurrencyPrice:  currencyPrice,
+		Missed:         missed,
 	}, nil
 }
 
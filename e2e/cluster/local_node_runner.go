@@ -0,0 +1,416 @@
+package cluster
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/log"
+)
+
+// localStopTimeout is the time a local node is given to shut down gracefully
+// before it's killed.
+const localStopTimeout = 10 * time.Second
+
+// LocalImage describes how the containers of a docker image are run as local
+// processes.
+type LocalImage struct {
+	// Binary is the program the image runs. If empty, the first element of the
+	// node command is the program, as for images without an entrypoint.
+	Binary string
+
+	// PortFlag is the flag moving the P2P listener of the node onto a free port.
+	// The UDP port right after it is kept free too, for the v5 discovery.
+	PortFlag string
+
+	// ListenPort is the port of the container the service listens on. As local
+	// nodes share the ports of the machine, the service is moved onto the host
+	// port mapped to it with ListenFlag or ListenEnv, if any.
+	ListenPort int32
+	ListenFlag string
+	ListenEnv  string
+}
+
+// DefaultLocalImages are the images the local node runner knows how to run. The
+// images of the services building an "app" binary are run from binaries named
+// after them.
+var DefaultLocalImages = map[string]LocalImage{
+	"kowalatech/kusd:dev":                     {Binary: "kcoin", PortFlag: "--port"},
+	"kowalatech/bootnode:dev":                 {Binary: "bootnode"},
+	"kowalatech/faucet:dev":                   {Binary: "faucet", PortFlag: "--kcoinport", ListenPort: 8080, ListenFlag: "--apiport"},
+	"kowalatech/wallet_backend:dev":           {Binary: "wallet_backend", ListenPort: 8080, ListenFlag: "--node-port"},
+	"kowalatech/backend_api:dev":              {Binary: "backend_api", ListenPort: 3000, ListenEnv: "PORT"},
+	"kowalatech/transactions_persistance:dev": {Binary: "transactions_persistance"},
+	"kowalatech/transactions_publisher:dev":   {Binary: "transactions_publisher"},
+	"redis:alpine":                            {Binary: "redis-server"},
+	"nsqio/nsq":                               {},
+}
+
+// listenPort returns the port a local node of the image listens on, given the
+// port mapping of its container. Zero means the listener is left as is.
+func (image LocalImage) listenPort(portMapping map[int32]int32) (int32, error) {
+	var port int32
+	for hostPort, containerPort := range portMapping {
+		if hostPort == containerPort {
+			continue
+		}
+		movable := image.ListenFlag != "" || image.ListenEnv != ""
+		if containerPort != image.ListenPort || !movable {
+			return 0, fmt.Errorf("can't map port %d to %d of a local node", containerPort, hostPort)
+		}
+		port = hostPort
+	}
+	return port, nil
+}
+
+// localNode is a node running as a local process.
+type localNode struct {
+	image LocalImage
+	root  string // Directory standing in for the root of the container filesystem
+	env   []string
+	cmd   *exec.Cmd
+	out   *syncBuffer   // Combined output of the node, as served by Log
+	done  chan struct{} // Closed when the process exits
+}
+
+// localNodeRunner runs the nodes as processes of the local machine, all of
+// them listening on the loopback interface. The filesystem of every node is
+// emulated by a directory, which absolute paths in the node files, commands and
+// executed commands are mapped into. The HOME of the processes is the /root of
+// that filesystem, so default data directories end up there too.
+type localNodeRunner struct {
+	lock sync.Mutex
+
+	nodes        map[NodeID]*localNode
+	images       map[string]LocalImage
+	binDir       string
+	workDir      string
+	logsToStdout bool
+	logsDir      string
+	logPrefix    string
+}
+
+// NewLocalNodeRunner creates a node runner launching the binaries of the given
+// directory as local processes instead of docker containers. Binaries missing
+// from the directory are looked up in the PATH.
+func NewLocalNodeRunner(opts *NewNodeRunnerOpts, binDir string) (*localNodeRunner, error) {
+	workDir, err := ioutil.TempDir("", "kcoin-e2e-")
+	if err != nil {
+		return nil, err
+	}
+	images := make(map[string]LocalImage, len(DefaultLocalImages))
+	for name, image := range DefaultLocalImages {
+		images[name] = image
+	}
+	return &localNodeRunner{
+		nodes:        make(map[NodeID]*localNode),
+		images:       images,
+		binDir:       binDir,
+		workDir:      workDir,
+		logsToStdout: opts.LogsToStdout,
+		logsDir:      opts.LogsDir,
+		logPrefix:    opts.Prefix,
+	}, nil
+}
+
+func (runner *localNodeRunner) Run(node *NodeSpec) error {
+	image, ok := runner.images[node.Image]
+	if !ok {
+		return fmt.Errorf("image %q can't be run locally", node.Image)
+	}
+	// Ports are shared by all the local nodes, so only the listener of the
+	// service can be remapped, by moving it onto the host port
+	listenPort, err := image.listenPort(node.PortMapping)
+	if err != nil {
+		return err
+	}
+	program, args := image.Binary, node.Cmd
+	if program == "" {
+		if len(args) == 0 {
+			return fmt.Errorf("no command to run for image %q", node.Image)
+		}
+		program, args = args[0], args[1:]
+	}
+	binary, err := runner.binary(program)
+	if err != nil {
+		return err
+	}
+	root := filepath.Join(runner.workDir, node.ID.String())
+	if err := os.MkdirAll(filepath.Join(root, "root"), 0700); err != nil {
+		return err
+	}
+	for filename, contents := range node.Files {
+		path := localPath(root, filename)
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(path, contents, 0600); err != nil {
+			return err
+		}
+	}
+	args = localArgs(root, args)
+	if image.PortFlag != "" {
+		port, err := freePort()
+		if err != nil {
+			return err
+		}
+		args = append(args, image.PortFlag, strconv.Itoa(port))
+	}
+	env := append(append(os.Environ(), node.Env...), "HOME="+filepath.Join(root, "root"))
+	if listenPort != 0 {
+		if image.ListenFlag != "" {
+			args = append(args, image.ListenFlag, strconv.Itoa(int(listenPort)))
+		}
+		if image.ListenEnv != "" {
+			env = append(env, fmt.Sprintf("%s=%d", image.ListenEnv, listenPort))
+		}
+	}
+
+	logStream := os.Stdout
+	if !runner.logsToStdout {
+		logFilename := filepath.Join(runner.logsDir, fmt.Sprintf("%s-%v.log", runner.logPrefix, node.ID))
+		logFile, err := os.OpenFile(logFilename, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0777)
+		if err != nil {
+			log.Error(fmt.Sprintf("error creating node logs file %q: %s", logFilename, err))
+			return err
+		}
+		logStream = logFile
+	}
+	local := &localNode{
+		image: image,
+		root:  root,
+		env:   env,
+		out:   new(syncBuffer),
+		done:  make(chan struct{}),
+	}
+	local.cmd = exec.Command(binary, args...)
+	local.cmd.Dir = root
+	local.cmd.Env = local.env
+	local.cmd.Stdout = io.MultiWriter(local.out, logStream)
+	local.cmd.Stderr = local.cmd.Stdout
+
+	if err := local.cmd.Start(); err != nil {
+		if logStream != os.Stdout {
+			logStream.Close()
+		}
+		return err
+	}
+	runner.lock.Lock()
+	runner.nodes[node.ID] = local
+	runner.lock.Unlock()
+
+	go func() {
+		defer close(local.done)
+		if err := local.cmd.Wait(); err != nil {
+			log.Debug("local node exited", "id", node.ID, "err", err)
+		}
+		if logStream != os.Stdout {
+			logStream.Close()
+		}
+	}()
+
+	if node.IsReadyFn != nil {
+		return common.WaitFor("Node starts", 1*time.Second, 20*time.Second, func() error {
+			return node.IsReadyFn(runner)
+		})
+	}
+	return nil
+}
+
+func (runner *localNodeRunner) Stop(nodeID NodeID) error {
+	runner.lock.Lock()
+	node, ok := runner.nodes[nodeID]
+	delete(runner.nodes, nodeID)
+	runner.lock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("no such node: %s", nodeID)
+	}
+	node.cmd.Process.Signal(syscall.SIGINT)
+	select {
+	case <-node.done:
+	case <-time.After(localStopTimeout):
+		node.cmd.Process.Kill()
+		<-node.done
+	}
+	return os.RemoveAll(node.root)
+}
+
+func (runner *localNodeRunner) StopAll() error {
+	runner.lock.Lock()
+	ids := make([]NodeID, 0, len(runner.nodes))
+	for id := range runner.nodes {
+		ids = append(ids, id)
+	}
+	runner.lock.Unlock()
+
+	for _, id := range ids {
+		if err := runner.Stop(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (runner *localNodeRunner) Log(nodeID NodeID) (string, error) {
+	node, err := runner.node(nodeID)
+	if err != nil {
+		return "", err
+	}
+	return node.out.String(), nil
+}
+
+func (runner *localNodeRunner) HostIP() string {
+	return "127.0.0.1"
+}
+
+func (runner *localNodeRunner) IP(nodeID NodeID) (string, error) {
+	if _, err := runner.node(nodeID); err != nil {
+		return "", err
+	}
+	return "127.0.0.1", nil
+}
+
+// Exec runs the command next to the node, in the same environment. As for the
+// docker runner, a failing command is not an error, its output tells the story.
+func (runner *localNodeRunner) Exec(nodeID NodeID, command []string) (*ExecResponse, error) {
+	node, err := runner.node(nodeID)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-node.done:
+		return nil, fmt.Errorf("node %s is not running", nodeID)
+	default:
+	}
+	if len(command) == 0 {
+		return nil, errors.New("empty command")
+	}
+	binary, err := runner.binary(command[0])
+	if err != nil {
+		return nil, err
+	}
+	var stdOut, stdErr bytes.Buffer
+
+	cmd := exec.Command(binary, localArgs(node.root, command[1:])...)
+	cmd.Dir = node.root
+	cmd.Env = node.env
+	cmd.Stdout = &stdOut
+	cmd.Stderr = &stdErr
+
+	if err := cmd.Run(); err != nil {
+		if _, ok := err.(*exec.ExitError); !ok {
+			return nil, err
+		}
+	}
+	return &ExecResponse{
+		StdOut: strings.TrimSpace(stdOut.String()),
+		StdErr: strings.TrimSpace(stdErr.String()),
+	}, nil
+}
+
+// node returns the running node of the given id.
+func (runner *localNodeRunner) node(nodeID NodeID) (*localNode, error) {
+	runner.lock.Lock()
+	defer runner.lock.Unlock()
+
+	node, ok := runner.nodes[nodeID]
+	if !ok {
+		return nil, fmt.Errorf("no such node: %s", nodeID)
+	}
+	return node, nil
+}
+
+// binary resolves a program run by the images, such as "./kcoin" or "/nsqd",
+// to a local binary.
+func (runner *localNodeRunner) binary(program string) (string, error) {
+	name := filepath.Base(program)
+	if runner.binDir != "" {
+		path := filepath.Join(runner.binDir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return exec.LookPath(name)
+}
+
+// localPath maps an absolute path of the container filesystem into the root
+// directory of the local node.
+func localPath(root, path string) string {
+	return filepath.Join(root, filepath.FromSlash(path))
+}
+
+// localArgs maps the container paths referenced by command line arguments,
+// either bare or as the value of a --flag=value argument.
+func localArgs(root string, args []string) []string {
+	mapped := make([]string, len(args))
+	for i, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "/"):
+			mapped[i] = localPath(root, arg)
+		case strings.HasPrefix(arg, "-") && strings.Contains(arg, "=/"):
+			sep := strings.Index(arg, "=/")
+			mapped[i] = arg[:sep+1] + localPath(root, arg[sep+1:])
+		default:
+			mapped[i] = arg
+		}
+	}
+	return mapped
+}
+
+// freePort returns a TCP port that is free on all interfaces, along with the
+// UDP port itself and the one after it, used by the discovery protocols.
+func freePort() (int, error) {
+	for i := 0; i < 16; i++ {
+		listener, err := net.Listen("tcp", ":0")
+		if err != nil {
+			return 0, err
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+
+		if udpPortFree(port) && udpPortFree(port+1) {
+			return port, nil
+		}
+	}
+	return 0, errors.New("no free port found")
+}
+
+func udpPortFree(port int) bool {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: port})
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent use.
+type syncBuffer struct {
+	lock sync.Mutex
+	buf  bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buf.String()
+}
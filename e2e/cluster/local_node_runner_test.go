@@ -0,0 +1,101 @@
+package cluster
+
+import (
+	"net"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestLocalPath(t *testing.T) {
+	root := filepath.FromSlash("/tmp/kcoin-e2e/node")
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/genesis.json", "/tmp/kcoin-e2e/node/genesis.json"},
+		{"/faucet/account", "/tmp/kcoin-e2e/node/faucet/account"},
+		{"/root/.kcoin/", "/tmp/kcoin-e2e/node/root/.kcoin"},
+	}
+	for _, tt := range tests {
+		if have := localPath(root, tt.path); have != filepath.FromSlash(tt.want) {
+			t.Errorf("localPath(%q): have %q, want %q", tt.path, have, tt.want)
+		}
+	}
+}
+
+func TestLocalArgs(t *testing.T) {
+	root := filepath.FromSlash("/tmp/kcoin-e2e/node")
+
+	args := []string{
+		"--genesis", "/faucet/genesis.json",
+		"--datadir=/root/.kcoin",
+		"--verbosity", "6",
+		"--bootnodes", "enode://abc@127.0.0.1:30303",
+		"console.log(\"/not/a/path\")",
+	}
+	want := []string{
+		"--genesis", filepath.FromSlash("/tmp/kcoin-e2e/node/faucet/genesis.json"),
+		"--datadir=" + filepath.FromSlash("/tmp/kcoin-e2e/node/root/.kcoin"),
+		"--verbosity", "6",
+		"--bootnodes", "enode://abc@127.0.0.1:30303",
+		"console.log(\"/not/a/path\")",
+	}
+	if have := localArgs(root, args); !reflect.DeepEqual(have, want) {
+		t.Errorf("args mismatch:\nhave %q\nwant %q", have, want)
+	}
+	if args[1] != "/faucet/genesis.json" {
+		t.Errorf("original args modified: %q", args)
+	}
+}
+
+func TestFreePort(t *testing.T) {
+	port, err := freePort()
+	if err != nil {
+		t.Fatalf("failed to find a free port: %v", err)
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.Itoa(port)))
+	if err != nil {
+		t.Fatalf("TCP port %d not free: %v", port, err)
+	}
+	defer listener.Close()
+
+	for _, p := range []int{port, port + 1} {
+		conn, err := net.ListenUDP("udp", &net.UDPAddr{Port: p})
+		if err != nil {
+			t.Fatalf("UDP port %d not free: %v", p, err)
+		}
+		conn.Close()
+	}
+}
+
+func TestLocalImageListenPort(t *testing.T) {
+	faucet := DefaultLocalImages["kowalatech/faucet:dev"]
+	kcoin := DefaultLocalImages["kowalatech/kusd:dev"]
+
+	tests := []struct {
+		name    string
+		image   LocalImage
+		mapping map[int32]int32
+		want    int32
+		fail    bool
+	}{
+		{"unmapped", faucet, map[int32]int32{}, 0, false},
+		{"same port", faucet, map[int32]int32{8080: 8080}, 0, false},
+		{"moved listener", faucet, map[int32]int32{8081: 8080}, 8081, false},
+		{"other port", faucet, map[int32]int32{8081: 9090}, 0, true},
+		{"fixed listener", kcoin, map[int32]int32{8546: 8545}, 0, true},
+	}
+	for _, tt := range tests {
+		port, err := tt.image.listenPort(tt.mapping)
+		if (err != nil) != tt.fail {
+			t.Errorf("%s: error mismatch: have %v, want failure %v", tt.name, err, tt.fail)
+			continue
+		}
+		if port != tt.want {
+			t.Errorf("%s: port mismatch: have %d, want %d", tt.name, port, tt.want)
+		}
+	}
+}
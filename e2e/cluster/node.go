@@ -126,7 +126,7 @@ func FaucetSpec(nodeSuffix, bootnodes string, genesisContent, accountContent []b
 		},
 		Env: []string{},
 		PortMapping: map[int32]int32{
-			8080: port,
+			port: 8080,
 		},
 	}
 	spec.IsReadyFn = func(runner NodeRunner) error {
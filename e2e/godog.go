@@ -18,10 +18,11 @@ var (
 type FeatureContextOpts struct {
 	suite        *godog.Suite
 	logsToStdout bool
+	localBinDir  string
 }
 
 func FeatureContext(opts *FeatureContextOpts) {
-	context := impl.NewTestContext(chainID, opts.logsToStdout)
+	context := impl.NewTestContext(chainID, opts.logsToStdout, opts.localBinDir)
 	validationCtx := impl.NewValidationContext(context)
 	walletBackendCtx := impl.NewWalletBackendContext(context)
 	faucetCtx := impl.NewFaucetContext(context)
@@ -62,7 +62,12 @@ func (ctx *Context) initCluster() error {
 		nodeRunnerOpts.LogsDir = logsDir
 	}
 
-	if ctx.nodeRunner, err = cluster.NewDockerNodeRunner(nodeRunnerOpts); err != nil {
+	if ctx.localBinDir != "" {
+		ctx.nodeRunner, err = cluster.NewLocalNodeRunner(nodeRunnerOpts, ctx.localBinDir)
+	} else {
+		ctx.nodeRunner, err = cluster.NewDockerNodeRunner(nodeRunnerOpts)
+	}
+	if err != nil {
 		return err
 	}
 
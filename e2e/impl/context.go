@@ -25,6 +25,7 @@ type Context struct {
 	genesisOptions *e2eGenesisOptions
 
 	logsToStdout bool
+	localBinDir  string // Runs the nodes as local processes if set
 
 	// cluster config
 	genesis  []byte
@@ -65,12 +66,13 @@ func defaultGenesisOptions() *e2eGenesisOptions {
 	}
 }
 
-func NewTestContext(chainID *big.Int, logsToStdout bool) *Context {
+func NewTestContext(chainID *big.Int, logsToStdout bool, localBinDir string) *Context {
 	tmpdir, _ := ioutil.TempDir("", "eth-keystore-test")
 	accountsStorage := keystore.NewKeyStore(tmpdir, 2, 1)
 
 	ctx := &Context{
 		logsToStdout:    logsToStdout,
+		localBinDir:     localBinDir,
 		AccountsStorage: accountsStorage,
 		chainID:         chainID,
 
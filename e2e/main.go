@@ -22,11 +22,13 @@ func (i *stringArr) Set(value string) error {
 var (
 	featuresFlag   stringArr
 	stdErrLogsFlag bool
+	localBinFlag   string
 )
 
 func main() {
 	flag.Var(&featuresFlag, "features", "Specify the path to the features files. Supports multiple paths.")
 	flag.BoolVar(&stdErrLogsFlag, "stdout-logs", false, "Send logs to the standard output instead to the logs/ directory.")
+	flag.StringVar(&localBinFlag, "local-bin", "", "Run the nodes as local processes using the binaries of this directory instead of docker containers.")
 	flag.Parse()
 
 	if len(featuresFlag) == 0 {
@@ -37,6 +39,7 @@ func main() {
 		FeatureContext(&FeatureContextOpts{
 			suite:        s,
 			logsToStdout: stdErrLogsFlag,
+			localBinDir:  localBinFlag,
 		})
 	}, godog.Options{
 		Format: "progress",
@@ -8,6 +8,7 @@ import (
 
 	kcoinLib "github.com/kowala-tech/kcoin/client"
 	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/token"
 	"github.com/kowala-tech/kcoin/client/core/types"
 	"github.com/kowala-tech/kcoin/client/kcoinclient"
 	"github.com/kowala-tech/kcoin/client/rpc"
@@ -147,12 +148,19 @@ func (k *kcoin) wrapBlock(block *types.Block) *Block {
 			To:          to,
 			From:        from.String(),
 			Amount:      tx.Value().Int64(),
+			Value:       tx.Value().String(),
 			Hash:        tx.Hash().String(),
 			Timestamp:   block.Time().Int64(),
 			GasUsed:     int64(block.GasUsed()),
 			GasPrice:    tx.GasPrice().Int64(),
 			BlockHeight: block.Number().Int64(),
 		}
+
+		if tokenTo, tokenValue, ok := token.DecodeTransfer(tx.Data()); ok && !isContractCreationTx {
+			transactions[i].Token = to
+			transactions[i].TokenTo = tokenTo.String()
+			transactions[i].Value = tokenValue.String()
+		}
 	}
 	return &Block{
 		Number:       block.Number(),
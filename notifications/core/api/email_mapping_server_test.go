@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/kowala-tech/kcoin/notifications/keyvalue"
+	"github.com/kowala-tech/kcoin/notifications/persistence"
 	"github.com/stretchr/testify/require"
 	"github.com/yourheropaul/inj"
 	"golang.org/x/net/context"
@@ -171,6 +172,6 @@ func (*mockedPersistance) GetTxByHash(hash common.Hash) (*protocolbuffer.Transac
 	panic("implement me")
 }
 
-func (*mockedPersistance) GetTxsFromAccount(address common.Address) ([]*protocolbuffer.Transaction, error) {
+func (*mockedPersistance) FindTxs(filter persistence.TransactionFilter) ([]*protocolbuffer.Transaction, string, error) {
 	panic("implement me")
 }
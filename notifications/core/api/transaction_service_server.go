@@ -8,8 +8,12 @@ import (
 	"github.com/kowala-tech/kcoin/notifications/protocolbuffer"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+//MaxTransactionsPageSize is the maximum number of transactions returned in a single page.
+const MaxTransactionsPageSize = 1000
+
 type transactionServiceServer struct {
 	Persistence persistence.TransactionRepository `inj:""`
 
@@ -23,9 +27,26 @@ func NewTransactionServiceServer(logger *logrus.Entry) protocolbuffer.Transactio
 }
 
 func (s *transactionServiceServer) GetTransactions(ctx context.Context, data *protocolbuffer.GetTransactionsRequest) (*protocolbuffer.GetTransactionsReply, error) {
-	account := common.HexToAddress(data.GetAccount())
+	if data.GetLimit() < 0 || data.GetLimit() > MaxTransactionsPageSize {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 0 and %d", MaxTransactionsPageSize)
+	}
 
-	txs, err := s.Persistence.GetTxsFromAccount(account)
+	filter := persistence.TransactionFilter{
+		Account:       common.HexToAddress(data.GetAccount()),
+		Direction:     data.GetDirection(),
+		FromBlock:     data.GetFromBlock(),
+		ToBlock:       data.GetToBlock(),
+		FromTimestamp: data.GetFromTimestamp(),
+		ToTimestamp:   data.GetToTimestamp(),
+		Token:         data.GetToken(),
+		Cursor:        data.GetCursor(),
+		Limit:         int(data.GetLimit()),
+	}
+
+	txs, nextCursor, err := s.Persistence.FindTxs(filter)
+	if err == persistence.ErrInvalidCursor {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		s.logger.WithError(err).Error(codes.Internal, "Error getting transactions")
 		return &protocolbuffer.GetTransactionsReply{}, nil
@@ -33,5 +54,6 @@ func (s *transactionServiceServer) GetTransactions(ctx context.Context, data *pr
 
 	return &protocolbuffer.GetTransactionsReply{
 		Transactions: txs,
+		NextCursor:   nextCursor,
 	}, nil
 }
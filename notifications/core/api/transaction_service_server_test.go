@@ -0,0 +1,84 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/notifications/persistence"
+	"github.com/kowala-tech/kcoin/notifications/persistence/mocks"
+	"github.com/kowala-tech/kcoin/notifications/protocolbuffer"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTransactionService_GetTransactionsPassesFilter(t *testing.T) {
+	account := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+	token := "0x0000000000000000000000000000000000001234"
+
+	repository := &mocks.TransactionRepository{}
+	server := &transactionServiceServer{
+		Persistence: repository,
+		logger:      logger,
+	}
+
+	expectedFilter := persistence.TransactionFilter{
+		Account:       account,
+		Direction:     protocolbuffer.Direction_INCOMING,
+		FromBlock:     10,
+		ToBlock:       20,
+		FromTimestamp: 1000,
+		ToTimestamp:   2000,
+		Token:         token,
+		Cursor:        "00000000000000000015:0x01",
+		Limit:         5,
+	}
+	txs := []*protocolbuffer.Transaction{{Hash: "0x02", To: account.String()}}
+	repository.On("FindTxs", expectedFilter).Return(txs, "00000000000000000012:0x02", nil)
+
+	reply, err := server.GetTransactions(context.Background(), &protocolbuffer.GetTransactionsRequest{
+		Account:       account.String(),
+		Cursor:        "00000000000000000015:0x01",
+		Limit:         5,
+		Direction:     protocolbuffer.Direction_INCOMING,
+		FromBlock:     10,
+		ToBlock:       20,
+		FromTimestamp: 1000,
+		ToTimestamp:   2000,
+		Token:         token,
+	})
+	require.NoError(t, err)
+	require.Equal(t, txs, reply.GetTransactions())
+	require.Equal(t, "00000000000000000012:0x02", reply.GetNextCursor())
+	repository.AssertExpectations(t)
+}
+
+func TestTransactionService_GetTransactionsRejectsInvalidRequests(t *testing.T) {
+	account := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+
+	repository := &mocks.TransactionRepository{}
+	server := &transactionServiceServer{
+		Persistence: repository,
+		logger:      logger,
+	}
+
+	t.Run("Limit too big", func(t *testing.T) {
+		_, err := server.GetTransactions(context.Background(), &protocolbuffer.GetTransactionsRequest{
+			Account: account.String(),
+			Limit:   MaxTransactionsPageSize + 1,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		repository.On("FindTxs", persistence.TransactionFilter{Account: account, Cursor: "bad"}).
+			Return(nil, "", persistence.ErrInvalidCursor)
+
+		_, err := server.GetTransactions(context.Background(), &protocolbuffer.GetTransactionsRequest{
+			Account: account.String(),
+			Cursor:  "bad",
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
@@ -5,6 +5,7 @@ package mocks
 import common "github.com/kowala-tech/kcoin/client/common"
 import mock "github.com/stretchr/testify/mock"
 
+import persistence "github.com/kowala-tech/kcoin/notifications/persistence"
 import protocolbuffer "github.com/kowala-tech/kcoin/notifications/protocolbuffer"
 
 // TransactionRepository is an autogenerated mock type for the TransactionRepository type
@@ -12,45 +13,52 @@ type TransactionRepository struct {
 	mock.Mock
 }
 
-// GetTxByHash provides a mock function with given fields: hash
-func (_m *TransactionRepository) GetTxByHash(hash common.Hash) (*protocolbuffer.Transaction, error) {
-	ret := _m.Called(hash)
+// FindTxs provides a mock function with given fields: filter
+func (_m *TransactionRepository) FindTxs(filter persistence.TransactionFilter) ([]*protocolbuffer.Transaction, string, error) {
+	ret := _m.Called(filter)
 
-	var r0 *protocolbuffer.Transaction
-	if rf, ok := ret.Get(0).(func(common.Hash) *protocolbuffer.Transaction); ok {
-		r0 = rf(hash)
+	var r0 []*protocolbuffer.Transaction
+	if rf, ok := ret.Get(0).(func(persistence.TransactionFilter) []*protocolbuffer.Transaction); ok {
+		r0 = rf(filter)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*protocolbuffer.Transaction)
+			r0 = ret.Get(0).([]*protocolbuffer.Transaction)
 		}
 	}
 
-	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
-		r1 = rf(hash)
+	var r1 string
+	if rf, ok := ret.Get(1).(func(persistence.TransactionFilter) string); ok {
+		r1 = rf(filter)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(string)
 	}
 
-	return r0, r1
+	var r2 error
+	if rf, ok := ret.Get(2).(func(persistence.TransactionFilter) error); ok {
+		r2 = rf(filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// GetTxsFromAccount provides a mock function with given fields: address
-func (_m *TransactionRepository) GetTxsFromAccount(address common.Address) ([]*protocolbuffer.Transaction, error) {
-	ret := _m.Called(address)
+// GetTxByHash provides a mock function with given fields: hash
+func (_m *TransactionRepository) GetTxByHash(hash common.Hash) (*protocolbuffer.Transaction, error) {
+	ret := _m.Called(hash)
 
-	var r0 []*protocolbuffer.Transaction
-	if rf, ok := ret.Get(0).(func(common.Address) []*protocolbuffer.Transaction); ok {
-		r0 = rf(address)
+	var r0 *protocolbuffer.Transaction
+	if rf, ok := ret.Get(0).(func(common.Hash) *protocolbuffer.Transaction); ok {
+		r0 = rf(hash)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*protocolbuffer.Transaction)
+			r0 = ret.Get(0).(*protocolbuffer.Transaction)
 		}
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(common.Address) error); ok {
-		r1 = rf(address)
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(hash)
 	} else {
 		r1 = ret.Error(1)
 	}
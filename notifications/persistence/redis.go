@@ -1,7 +1,10 @@
 package persistence
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/go-redis/redis"
 	"github.com/gogo/protobuf/proto"
@@ -12,6 +15,17 @@ import (
 const TxKeyPrefix = "tx:"
 const TxKeyFromPrefix = "txfrom:"
 const TxKeyToPrefix = "txto:"
+const TxIndexKeyPrefix = "txidx:"
+const TxIndexKeyFromPrefix = "txfromidx:"
+const TxIndexKeyToPrefix = "txtoidx:"
+const TxIndexBackfillKeyPrefix = "txidxbackfill:"
+
+//txIndexBatchSize is the number of index entries fetched at once when no limit is given
+//or when filtered out transactions leave a page short.
+const txIndexBatchSize = 100
+
+//ErrInvalidCursor is returned when the cursor of a transaction filter is malformed.
+var ErrInvalidCursor = errors.New("invalid cursor")
 
 type redisPersistence struct {
 	client *redis.Client
@@ -47,6 +61,57 @@ func (p *redisPersistence) Save(tx *proto2.Transaction) error {
 		tx.GetHash(),
 	)
 
+	addIndexEntries(pipeline, tx)
+
+	_, err = pipeline.Exec()
+
+	return err
+}
+
+//addIndexEntries adds the transaction to the indexes of its sender and recipients. Index entries
+//share the same score so they are sorted lexicographically by block height and hash, which lets
+//us paginate and filter by block range.
+func addIndexEntries(pipeline redis.Pipeliner, tx *proto2.Transaction) {
+	member := getIndexMemberFromTx(tx)
+	recipients := []string{tx.GetTo()}
+	if tx.GetTokenTo() != "" {
+		recipients = append(recipients, tx.GetTokenTo())
+	}
+
+	pipeline.ZAdd(fmt.Sprintf("%s%s", TxIndexKeyFromPrefix, tx.GetFrom()), redis.Z{Member: member})
+	pipeline.ZAdd(fmt.Sprintf("%s%s", TxIndexKeyPrefix, tx.GetFrom()), redis.Z{Member: member})
+	for _, recipient := range recipients {
+		pipeline.ZAdd(fmt.Sprintf("%s%s", TxIndexKeyToPrefix, recipient), redis.Z{Member: member})
+		pipeline.ZAdd(fmt.Sprintf("%s%s", TxIndexKeyPrefix, recipient), redis.Z{Member: member})
+	}
+}
+
+//backfillIndexes adds the transactions saved before the account indexes existed to the indexes
+//of the account. It runs once per account, on its first query.
+func (p *redisPersistence) backfillIndexes(account common.Address) error {
+	doneKey := fmt.Sprintf("%s%s", TxIndexBackfillKeyPrefix, account.String())
+	done, err := p.client.Exists(doneKey).Result()
+	if err != nil {
+		return err
+	}
+
+	if done > 0 {
+		return nil
+	}
+
+	txs, err := p.getTxsOfAccount(account)
+	if err != nil {
+		return err
+	}
+
+	pipeline := p.client.TxPipeline()
+	for _, tx := range txs {
+		if tx != nil {
+			addIndexEntries(pipeline, tx)
+		}
+	}
+	pipeline.Set(doneKey, 1, 0)
+
 	_, err = pipeline.Exec()
 
 	return err
@@ -68,7 +133,7 @@ func (p *redisPersistence) GetTxByHash(hash common.Hash) (*proto2.Transaction, e
 	return &tx, nil
 }
 
-func (p *redisPersistence) GetTxsFromAccount(account common.Address) ([]*proto2.Transaction, error) {
+func (p *redisPersistence) getTxsOfAccount(account common.Address) ([]*proto2.Transaction, error) {
 	var txHashesFound []common.Hash
 
 	txsHashesFromAccount, err := p.getTransactionHashesComingFromAccount(account)
@@ -93,6 +158,71 @@ func (p *redisPersistence) GetTxsFromAccount(account common.Address) ([]*proto2.
 	return txs, nil
 }
 
+//FindTxs returns the transactions of an account matching the given filter, newest first, together
+//with the cursor to fetch the next page. The cursor is empty when there are no more transactions.
+func (p *redisPersistence) FindTxs(filter TransactionFilter) ([]*proto2.Transaction, string, error) {
+	rangeBy, err := getIndexRange(filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if err := p.backfillIndexes(filter.Account); err != nil {
+		return nil, "", err
+	}
+
+	batchSize := int64(txIndexBatchSize)
+	if filter.Limit > 0 && filter.Limit < txIndexBatchSize {
+		batchSize = int64(filter.Limit)
+	}
+	rangeBy.Count = batchSize
+
+	key := fmt.Sprintf("%s%s", getIndexKeyPrefix(filter.Direction), filter.Account.String())
+
+	var txs []*proto2.Transaction
+	for {
+		members, err := p.client.ZRevRangeByLex(key, rangeBy).Result()
+		if err != nil && err != redis.Nil {
+			return nil, "", err
+		}
+
+		for _, member := range members {
+			hash, err := getHashFromIndexMember(member)
+			if err != nil {
+				return nil, "", err
+			}
+
+			tx, err := p.GetTxByHash(hash)
+			if err != nil {
+				return nil, "", err
+			}
+
+			if tx == nil {
+				continue
+			}
+
+			// The index is sorted by height, so timestamps only decrease from here on.
+			if filter.FromTimestamp > 0 && tx.GetTimestamp() < filter.FromTimestamp {
+				return txs, "", nil
+			}
+
+			if !filter.Matches(tx) {
+				continue
+			}
+
+			txs = append(txs, tx)
+			if filter.Limit > 0 && len(txs) == filter.Limit {
+				return txs, member, nil
+			}
+		}
+
+		if int64(len(members)) < batchSize {
+			return txs, "", nil
+		}
+
+		rangeBy.Max = "(" + members[len(members)-1]
+	}
+}
+
 func (p *redisPersistence) getTransactionsByHashes(hashes []common.Hash) ([]*proto2.Transaction, error) {
 	var txs []*proto2.Transaction
 	for _, hash := range hashes {
@@ -148,3 +278,61 @@ func getKeyFromTx(tx *proto2.Transaction) string {
 func getKeyFromTxHash(hash string) string {
 	return fmt.Sprintf("%s%s", TxKeyPrefix, hash)
 }
+
+func getIndexMemberFromTx(tx *proto2.Transaction) string {
+	return fmt.Sprintf("%020d:%s", tx.GetBlockHeight(), tx.GetHash())
+}
+
+func getHashFromIndexMember(member string) (common.Hash, error) {
+	parts := strings.SplitN(member, ":", 2)
+	if len(parts) != 2 {
+		return common.Hash{}, ErrInvalidCursor
+	}
+
+	if _, err := strconv.ParseUint(parts[0], 10, 64); err != nil {
+		return common.Hash{}, ErrInvalidCursor
+	}
+
+	return common.HexToHash(parts[1]), nil
+}
+
+func getIndexKeyPrefix(direction proto2.Direction) string {
+	switch direction {
+	case proto2.Direction_INCOMING:
+		return TxIndexKeyToPrefix
+	case proto2.Direction_OUTGOING:
+		return TxIndexKeyFromPrefix
+	default:
+		return TxIndexKeyPrefix
+	}
+}
+
+//getIndexRange translates the block range and cursor of the filter into lexicographical
+//bounds of the account index. Bounds on the height are built with the characters right
+//before and after ':' so they include every hash of the boundary blocks.
+func getIndexRange(filter TransactionFilter) (redis.ZRangeBy, error) {
+	rangeBy := redis.ZRangeBy{
+		Min: "-",
+		Max: "+",
+	}
+
+	if filter.FromBlock > 0 {
+		rangeBy.Min = fmt.Sprintf("[%020d:", filter.FromBlock)
+	}
+
+	if filter.ToBlock > 0 {
+		rangeBy.Max = fmt.Sprintf("(%020d;", filter.ToBlock)
+	}
+
+	if filter.Cursor != "" {
+		if _, err := getHashFromIndexMember(filter.Cursor); err != nil {
+			return rangeBy, err
+		}
+
+		if rangeBy.Max == "+" || filter.Cursor < rangeBy.Max[1:] {
+			rangeBy.Max = "(" + filter.Cursor
+		}
+	}
+
+	return rangeBy, nil
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/go-redis/redis"
+	"github.com/gogo/protobuf/proto"
 	"github.com/kowala-tech/kcoin/client/common"
 	"github.com/kowala-tech/kcoin/notifications/environment"
 	"github.com/kowala-tech/kcoin/notifications/protocolbuffer"
@@ -76,7 +77,7 @@ func TestGetTransactionsFromAccount(t *testing.T) {
 	t.Run("Get transactions from account with no transactions", func(t *testing.T) {
 		var expectedTransactions []*protocolbuffer.Transaction
 
-		txs, err := p.GetTxsFromAccount(targetAccount)
+		txs, err := p.getTxsOfAccount(targetAccount)
 		if err != nil {
 			t.Fatalf("Error getting transactions by account: %s", err)
 		}
@@ -107,7 +108,7 @@ func TestGetTransactionsFromAccount(t *testing.T) {
 			fromAccountTransaction,
 		}
 
-		txs, err := p.GetTxsFromAccount(targetAccount)
+		txs, err := p.getTxsOfAccount(targetAccount)
 		if err != nil {
 			t.Fatalf("Error getting transactions from account: %s", err)
 		}
@@ -137,7 +138,7 @@ func TestGetTransactionsFromAccount(t *testing.T) {
 			toAccountTransaction,
 		}
 
-		txs, err := p.GetTxsFromAccount(targetAccount)
+		txs, err := p.getTxsOfAccount(targetAccount)
 		if err != nil {
 			t.Fatalf("Error getting transactions: %s", err)
 		}
@@ -149,6 +150,148 @@ func TestGetTransactionsFromAccount(t *testing.T) {
 	assert.NoError(t, p.client.FlushAll().Err())
 }
 
+func TestFindTransactions(t *testing.T) {
+	p := redisPersistence{
+		client: getRedisClient(t),
+	}
+
+	targetAccount := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+	otherAccount := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1bcaca")
+	token := common.HexToAddress("0x0000000000000000000000000000000000001234")
+
+	var txs []*protocolbuffer.Transaction
+	for i := int64(1); i <= 5; i++ {
+		tx := &protocolbuffer.Transaction{
+			Hash:        common.BigToHash(big.NewInt(i)).String(),
+			Amount:      i,
+			From:        targetAccount.String(),
+			To:          otherAccount.String(),
+			BlockHeight: i * 10,
+			Timestamp:   i * 1000,
+		}
+		if i%2 == 0 {
+			tx.From, tx.To = tx.To, tx.From
+			tx.Token = token.String()
+		}
+		assert.NoError(t, p.Save(tx))
+		txs = append(txs, tx)
+	}
+
+	t.Run("Paginate all transactions newest first", func(t *testing.T) {
+		page, cursor, err := p.FindTxs(TransactionFilter{Account: targetAccount, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[4], txs[3]}, page)
+		assert.NotEmpty(t, cursor)
+
+		page, cursor, err = p.FindTxs(TransactionFilter{Account: targetAccount, Limit: 2, Cursor: cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[2], txs[1]}, page)
+
+		page, cursor, err = p.FindTxs(TransactionFilter{Account: targetAccount, Limit: 2, Cursor: cursor})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[0]}, page)
+		assert.Empty(t, cursor)
+	})
+
+	t.Run("Filter by direction", func(t *testing.T) {
+		page, _, err := p.FindTxs(TransactionFilter{Account: targetAccount, Direction: protocolbuffer.Direction_INCOMING})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[3], txs[1]}, page)
+	})
+
+	t.Run("Filter by block and time range", func(t *testing.T) {
+		page, _, err := p.FindTxs(TransactionFilter{Account: targetAccount, FromBlock: 20, ToBlock: 40, ToTimestamp: 3000})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[2], txs[1]}, page)
+	})
+
+	t.Run("Filter by token", func(t *testing.T) {
+		page, _, err := p.FindTxs(TransactionFilter{Account: targetAccount, Token: token.String()})
+		assert.NoError(t, err)
+		assert.Equal(t, []*protocolbuffer.Transaction{txs[3], txs[1]}, page)
+	})
+
+	t.Run("Invalid cursor", func(t *testing.T) {
+		_, _, err := p.FindTxs(TransactionFilter{Account: targetAccount, Cursor: "invalid"})
+		assert.Equal(t, ErrInvalidCursor, err)
+	})
+
+	// Teardown
+	assert.NoError(t, p.client.FlushAll().Err())
+}
+
+func TestFindTransactionsSavedBeforeIndexes(t *testing.T) {
+	p := redisPersistence{
+		client: getRedisClient(t),
+	}
+
+	targetAccount := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+	otherAccount := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1bcaca")
+
+	var txs []*protocolbuffer.Transaction
+	for i := int64(1); i <= 3; i++ {
+		tx := &protocolbuffer.Transaction{
+			Hash:        common.BigToHash(big.NewInt(i)).String(),
+			From:        targetAccount.String(),
+			To:          otherAccount.String(),
+			BlockHeight: i * 10,
+		}
+		if i == 2 {
+			tx.From, tx.To = tx.To, tx.From
+		}
+		txs = append(txs, tx)
+	}
+
+	// the first two transactions were saved without the account indexes
+	for _, tx := range txs[:2] {
+		enc, err := proto.Marshal(tx)
+		assert.NoError(t, err)
+		assert.NoError(t, p.client.Set(getKeyFromTx(tx), enc, 0).Err())
+		assert.NoError(t, p.client.SAdd(TxKeyFromPrefix+tx.From, tx.Hash).Err())
+		assert.NoError(t, p.client.SAdd(TxKeyToPrefix+tx.To, tx.Hash).Err())
+	}
+	assert.NoError(t, p.Save(txs[2]))
+
+	page, _, err := p.FindTxs(TransactionFilter{Account: targetAccount})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protocolbuffer.Transaction{txs[2], txs[1], txs[0]}, page)
+
+	page, _, err = p.FindTxs(TransactionFilter{Account: targetAccount, Direction: protocolbuffer.Direction_INCOMING})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protocolbuffer.Transaction{txs[1]}, page)
+
+	// Teardown
+	assert.NoError(t, p.client.FlushAll().Err())
+}
+
+func TestFindTokenTransfers(t *testing.T) {
+	p := redisPersistence{
+		client: getRedisClient(t),
+	}
+
+	sender := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+	recipient := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1bcaca")
+	token := common.HexToAddress("0x0000000000000000000000000000000000001234")
+
+	tx := &protocolbuffer.Transaction{
+		Hash:        common.BigToHash(big.NewInt(1)).String(),
+		From:        sender.String(),
+		To:          token.String(),
+		Token:       token.String(),
+		TokenTo:     recipient.String(),
+		Value:       "100000000000000000000000",
+		BlockHeight: 10,
+	}
+	assert.NoError(t, p.Save(tx))
+
+	page, _, err := p.FindTxs(TransactionFilter{Account: recipient, Direction: protocolbuffer.Direction_INCOMING})
+	assert.NoError(t, err)
+	assert.Equal(t, []*protocolbuffer.Transaction{tx}, page)
+
+	// Teardown
+	assert.NoError(t, p.client.FlushAll().Err())
+}
+
 func getRedisClient(t *testing.T) *redis.Client {
 	envReader := environment.NewReaderOs()
 	redisAddr := envReader.Read(RedisServerEnvKey)
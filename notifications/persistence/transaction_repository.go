@@ -9,5 +9,37 @@ import (
 type TransactionRepository interface {
 	Save(tx *protocolbuffer.Transaction) error
 	GetTxByHash(hash common.Hash) (*protocolbuffer.Transaction, error)
-	GetTxsFromAccount(address common.Address) ([]*protocolbuffer.Transaction, error)
+	FindTxs(filter TransactionFilter) ([]*protocolbuffer.Transaction, string, error)
+}
+
+//TransactionFilter selects a page of the transactions of an account. Zero values
+//disable the corresponding filter, a zero Limit returns every matching transaction.
+type TransactionFilter struct {
+	Account       common.Address
+	Direction     protocolbuffer.Direction
+	FromBlock     int64
+	ToBlock       int64
+	FromTimestamp int64
+	ToTimestamp   int64
+	Token         string
+	Cursor        string
+	Limit         int
+}
+
+//Matches returns true if the transaction passes the filters that cannot be
+//resolved with the account indexes.
+func (f TransactionFilter) Matches(tx *protocolbuffer.Transaction) bool {
+	if f.FromTimestamp > 0 && tx.GetTimestamp() < f.FromTimestamp {
+		return false
+	}
+
+	if f.ToTimestamp > 0 && tx.GetTimestamp() > f.ToTimestamp {
+		return false
+	}
+
+	if f.Token != "" && common.HexToAddress(f.Token) != common.HexToAddress(tx.GetToken()) {
+		return false
+	}
+
+	return true
 }
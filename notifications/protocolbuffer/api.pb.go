@@ -23,6 +23,32 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type Direction int32
+
+const (
+	Direction_ANY      Direction = 0
+	Direction_INCOMING Direction = 1
+	Direction_OUTGOING Direction = 2
+)
+
+var Direction_name = map[int32]string{
+	0: "ANY",
+	1: "INCOMING",
+	2: "OUTGOING",
+}
+var Direction_value = map[string]int32{
+	"ANY":      0,
+	"INCOMING": 1,
+	"OUTGOING": 2,
+}
+
+func (x Direction) String() string {
+	return proto.EnumName(Direction_name, int32(x))
+}
+func (Direction) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{0}
+}
+
 type RegisterRequest struct {
 	Wallet               string   `protobuf:"bytes,1,opt,name=wallet,proto3" json:"wallet,omitempty"`
 	Email                string   `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
@@ -35,7 +61,7 @@ func (m *RegisterRequest) Reset()         { *m = RegisterRequest{} }
 func (m *RegisterRequest) String() string { return proto.CompactTextString(m) }
 func (*RegisterRequest) ProtoMessage()    {}
 func (*RegisterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{0}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{0}
 }
 func (m *RegisterRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterRequest.Unmarshal(m, b)
@@ -80,7 +106,7 @@ func (m *UnregisterRequest) Reset()         { *m = UnregisterRequest{} }
 func (m *UnregisterRequest) String() string { return proto.CompactTextString(m) }
 func (*UnregisterRequest) ProtoMessage()    {}
 func (*UnregisterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{1}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{1}
 }
 func (m *UnregisterRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnregisterRequest.Unmarshal(m, b)
@@ -117,7 +143,7 @@ func (m *RegisterReply) Reset()         { *m = RegisterReply{} }
 func (m *RegisterReply) String() string { return proto.CompactTextString(m) }
 func (*RegisterReply) ProtoMessage()    {}
 func (*RegisterReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{2}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{2}
 }
 func (m *RegisterReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RegisterReply.Unmarshal(m, b)
@@ -147,7 +173,7 @@ func (m *UnregisterReply) Reset()         { *m = UnregisterReply{} }
 func (m *UnregisterReply) String() string { return proto.CompactTextString(m) }
 func (*UnregisterReply) ProtoMessage()    {}
 func (*UnregisterReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{3}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{3}
 }
 func (m *UnregisterReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_UnregisterReply.Unmarshal(m, b)
@@ -168,7 +194,18 @@ func (m *UnregisterReply) XXX_DiscardUnknown() {
 var xxx_messageInfo_UnregisterReply proto.InternalMessageInfo
 
 type GetTransactionsRequest struct {
-	Account              string   `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	Account string `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	// next_cursor of a previous reply, empty to start from the newest transaction.
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// maximum number of transactions to return, 0 returns all of them.
+	Limit         int32     `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Direction     Direction `protobuf:"varint,4,opt,name=direction,proto3,enum=protocolbuffer.Direction" json:"direction,omitempty"`
+	FromBlock     int64     `protobuf:"varint,5,opt,name=from_block,json=fromBlock,proto3" json:"from_block,omitempty"`
+	ToBlock       int64     `protobuf:"varint,6,opt,name=to_block,json=toBlock,proto3" json:"to_block,omitempty"`
+	FromTimestamp int64     `protobuf:"varint,7,opt,name=from_timestamp,json=fromTimestamp,proto3" json:"from_timestamp,omitempty"`
+	ToTimestamp   int64     `protobuf:"varint,8,opt,name=to_timestamp,json=toTimestamp,proto3" json:"to_timestamp,omitempty"`
+	// token contract address, empty matches every transaction.
+	Token                string   `protobuf:"bytes,9,opt,name=token,proto3" json:"token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -178,7 +215,7 @@ func (m *GetTransactionsRequest) Reset()         { *m = GetTransactionsRequest{}
 func (m *GetTransactionsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetTransactionsRequest) ProtoMessage()    {}
 func (*GetTransactionsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{4}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{4}
 }
 func (m *GetTransactionsRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetTransactionsRequest.Unmarshal(m, b)
@@ -205,18 +242,76 @@ func (m *GetTransactionsRequest) GetAccount() string {
 	return ""
 }
 
+func (m *GetTransactionsRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+func (m *GetTransactionsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetDirection() Direction {
+	if m != nil {
+		return m.Direction
+	}
+	return Direction_ANY
+}
+
+func (m *GetTransactionsRequest) GetFromBlock() int64 {
+	if m != nil {
+		return m.FromBlock
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetToBlock() int64 {
+	if m != nil {
+		return m.ToBlock
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetFromTimestamp() int64 {
+	if m != nil {
+		return m.FromTimestamp
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetToTimestamp() int64 {
+	if m != nil {
+		return m.ToTimestamp
+	}
+	return 0
+}
+
+func (m *GetTransactionsRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
 type GetTransactionsReply struct {
-	Transactions         []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+	Transactions []*Transaction `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	// empty when there are no more transactions.
+	NextCursor           string   `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *GetTransactionsReply) Reset()         { *m = GetTransactionsReply{} }
 func (m *GetTransactionsReply) String() string { return proto.CompactTextString(m) }
 func (*GetTransactionsReply) ProtoMessage()    {}
 func (*GetTransactionsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{5}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{5}
 }
 func (m *GetTransactionsReply) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_GetTransactionsReply.Unmarshal(m, b)
@@ -243,6 +338,13 @@ func (m *GetTransactionsReply) GetTransactions() []*Transaction {
 	return nil
 }
 
+func (m *GetTransactionsReply) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
 type Transaction struct {
 	To                   string   `protobuf:"bytes,1,opt,name=to,proto3" json:"to,omitempty"`
 	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
@@ -252,6 +354,11 @@ type Transaction struct {
 	BlockHeight          int64    `protobuf:"varint,6,opt,name=block_height,json=blockHeight,proto3" json:"block_height,omitempty"`
 	GasUsed              int64    `protobuf:"varint,7,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
 	GasPrice             int64    `protobuf:"varint,8,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	Token                string   `protobuf:"bytes,9,opt,name=token,proto3" json:"token,omitempty"`
+	// amount transferred as a decimal string, in token units for token transfers.
+	Value                string   `protobuf:"bytes,10,opt,name=value,proto3" json:"value,omitempty"`
+	// recipient of a token transfer, to holds the token contract.
+	TokenTo              string   `protobuf:"bytes,11,opt,name=token_to,json=tokenTo,proto3" json:"token_to,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -261,7 +368,7 @@ func (m *Transaction) Reset()         { *m = Transaction{} }
 func (m *Transaction) String() string { return proto.CompactTextString(m) }
 func (*Transaction) ProtoMessage()    {}
 func (*Transaction) Descriptor() ([]byte, []int) {
-	return fileDescriptor_api_1932a18a4b3013da, []int{6}
+	return fileDescriptor_api_ac0f88cb5d0d1f2f, []int{6}
 }
 func (m *Transaction) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Transaction.Unmarshal(m, b)
@@ -337,6 +444,27 @@ func (m *Transaction) GetGasPrice() int64 {
 	return 0
 }
 
+func (m *Transaction) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *Transaction) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *Transaction) GetTokenTo() string {
+	if m != nil {
+		return m.TokenTo
+	}
+	return ""
+}
+
 func init() {
 	proto.RegisterType((*RegisterRequest)(nil), "protocolbuffer.RegisterRequest")
 	proto.RegisterType((*UnregisterRequest)(nil), "protocolbuffer.UnregisterRequest")
@@ -345,6 +473,7 @@ func init() {
 	proto.RegisterType((*GetTransactionsRequest)(nil), "protocolbuffer.GetTransactionsRequest")
 	proto.RegisterType((*GetTransactionsReply)(nil), "protocolbuffer.GetTransactionsReply")
 	proto.RegisterType((*Transaction)(nil), "protocolbuffer.Transaction")
+	proto.RegisterEnum("protocolbuffer.Direction", Direction_name, Direction_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -516,34 +645,45 @@ var _TransactionService_serviceDesc = grpc.ServiceDesc{
 	Metadata: "api.proto",
 }
 
-func init() { proto.RegisterFile("api.proto", fileDescriptor_api_1932a18a4b3013da) }
-
-var fileDescriptor_api_1932a18a4b3013da = []byte{
-	// 405 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x52, 0x5d, 0x8f, 0xd2, 0x40,
-	0x14, 0xb5, 0xb0, 0x0b, 0xf4, 0x82, 0xdb, 0xec, 0xcd, 0x66, 0x33, 0xb2, 0x9a, 0x5d, 0x1a, 0x63,
-	0x48, 0x4c, 0x78, 0xa8, 0x3f, 0x80, 0x27, 0xa3, 0x0f, 0x9a, 0x98, 0x2a, 0xf1, 0x91, 0x0c, 0x65,
-	0x68, 0x27, 0xb6, 0x9d, 0x71, 0x66, 0x2a, 0xe1, 0x5f, 0xf9, 0x6b, 0xfc, 0x3d, 0x66, 0x06, 0x1a,
-	0x4a, 0xf1, 0x63, 0x9f, 0x98, 0x73, 0xee, 0xb9, 0xe7, 0xd2, 0x7b, 0x2e, 0xf8, 0x54, 0xf2, 0x99,
-	0x54, 0xc2, 0x08, 0xbc, 0x72, 0x3f, 0x89, 0xc8, 0x57, 0xd5, 0x66, 0xc3, 0x54, 0x38, 0x87, 0x20,
-	0x66, 0x29, 0xd7, 0x86, 0xa9, 0x98, 0x7d, 0xaf, 0x98, 0x36, 0x78, 0x0b, 0xbd, 0x2d, 0xcd, 0x73,
-	0x66, 0x88, 0xf7, 0xe0, 0x4d, 0xfd, 0xf8, 0x80, 0xf0, 0x06, 0x2e, 0x59, 0x41, 0x79, 0x4e, 0x3a,
-	0x8e, 0xde, 0x83, 0xf0, 0x35, 0x5c, 0x2f, 0x4a, 0xf5, 0x38, 0x8b, 0x30, 0x80, 0xa7, 0xc7, 0x69,
-	0x32, 0xdf, 0x85, 0xd7, 0x10, 0x34, 0xbb, 0x2d, 0x15, 0xc1, 0xed, 0x3b, 0x66, 0xbe, 0x28, 0x5a,
-	0x6a, 0x9a, 0x18, 0x2e, 0x4a, 0x5d, 0xbb, 0x12, 0xe8, 0xd3, 0x24, 0x11, 0x55, 0x59, 0xdb, 0xd6,
-	0x30, 0xfc, 0x0a, 0x37, 0x67, 0x3d, 0x32, 0xdf, 0xe1, 0x1c, 0x46, 0xa6, 0x41, 0x12, 0xef, 0xa1,
-	0x3b, 0x1d, 0x46, 0x77, 0xb3, 0xd3, 0x25, 0xcc, 0x1a, 0x8d, 0xf1, 0x49, 0x43, 0xf8, 0xcb, 0x83,
-	0x61, 0xa3, 0x8a, 0x57, 0xd0, 0x31, 0xe2, 0x30, 0xbd, 0x63, 0x84, 0xfd, 0x50, 0x5a, 0xb8, 0x7f,
-	0x64, 0x97, 0xd2, 0x8d, 0x0f, 0x08, 0x11, 0x2e, 0x36, 0x4a, 0x14, 0xa4, 0xeb, 0x94, 0xee, 0x6d,
-	0xb9, 0x8c, 0xea, 0x8c, 0x5c, 0xec, 0x39, 0xfb, 0xc6, 0xe7, 0xe0, 0x1b, 0x5e, 0x30, 0x6d, 0x68,
-	0x21, 0xc9, 0xa5, 0xb3, 0x38, 0x12, 0x38, 0x81, 0xd1, 0x2a, 0x17, 0xc9, 0xb7, 0x65, 0xc6, 0x78,
-	0x9a, 0x19, 0xd2, 0x73, 0x82, 0xa1, 0xe3, 0xde, 0x3b, 0x0a, 0x9f, 0xc1, 0x20, 0xa5, 0x7a, 0x59,
-	0x69, 0xb6, 0x26, 0x7d, 0x57, 0xee, 0xa7, 0x54, 0x2f, 0x34, 0x5b, 0xe3, 0x1d, 0xf8, 0xb6, 0x24,
-	0x15, 0x4f, 0x18, 0x19, 0xb8, 0x9a, 0xd5, 0x7e, 0xb2, 0x38, 0xfa, 0xe9, 0xc1, 0xe8, 0xad, 0x0d,
-	0xf0, 0x23, 0x95, 0x92, 0x97, 0x29, 0x7e, 0x80, 0x41, 0x1d, 0x0d, 0xde, 0xb7, 0x17, 0xd4, 0x3a,
-	0x91, 0xf1, 0x8b, 0xbf, 0x0b, 0x6c, 0x84, 0x4f, 0x30, 0x06, 0x38, 0xe6, 0x8a, 0x93, 0xb6, 0xfc,
-	0xec, 0x62, 0xc6, 0xf7, 0xff, 0x92, 0x38, 0xcf, 0x68, 0x0b, 0xd8, 0x88, 0xe2, 0x33, 0x53, 0x3f,
-	0x78, 0xc2, 0x90, 0x42, 0xd0, 0x8a, 0x1e, 0x5f, 0xb5, 0xbd, 0xfe, 0x7c, 0x4f, 0xe3, 0x97, 0xff,
-	0xd5, 0xb9, 0xc1, 0xab, 0x9e, 0x93, 0xbd, 0xf9, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x53, 0xf2, 0x8d,
-	0xc8, 0x47, 0x03, 0x00, 0x00,
+func init() { proto.RegisterFile("api.proto", fileDescriptor_api_ac0f88cb5d0d1f2f) }
+
+var fileDescriptor_api_ac0f88cb5d0d1f2f = []byte{
+	// 588 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x53, 0x5d, 0x6f, 0xda, 0x30,
+	0x14, 0x6d, 0x42, 0xf9, 0xc8, 0x0d, 0x85, 0xd6, 0x42, 0x55, 0x4a, 0x57, 0x41, 0xa3, 0x6d, 0x42,
+	0x9b, 0x84, 0x26, 0xf6, 0xb0, 0xc7, 0x6a, 0xeb, 0x26, 0x56, 0x69, 0x85, 0x29, 0x83, 0x87, 0x3d,
+	0x21, 0x13, 0x0c, 0x44, 0x24, 0x71, 0x96, 0x38, 0xfd, 0xf8, 0x31, 0xfb, 0x0f, 0x93, 0xf6, 0x07,
+	0x27, 0xdf, 0x24, 0x23, 0xa4, 0xdd, 0xc7, 0x53, 0x72, 0x8e, 0xcf, 0xbd, 0xb6, 0xcf, 0xf1, 0x05,
+	0x8d, 0x06, 0x4e, 0x3f, 0x08, 0xb9, 0xe0, 0xa4, 0x81, 0x1f, 0x9b, 0xbb, 0xf3, 0x78, 0xb9, 0x64,
+	0xa1, 0x79, 0x01, 0x4d, 0x8b, 0xad, 0x9c, 0x48, 0xb0, 0xd0, 0x62, 0xdf, 0x62, 0x16, 0x09, 0x72,
+	0x0c, 0x95, 0x5b, 0xea, 0xba, 0x4c, 0x18, 0x4a, 0x57, 0xe9, 0x69, 0x56, 0x8a, 0x48, 0x0b, 0xca,
+	0xcc, 0xa3, 0x8e, 0x6b, 0xa8, 0x48, 0x27, 0xc0, 0x7c, 0x09, 0x47, 0x53, 0x3f, 0xfc, 0xbf, 0x16,
+	0x66, 0x13, 0x0e, 0xb6, 0xbb, 0x05, 0xee, 0xbd, 0x79, 0x04, 0xcd, 0x7c, 0xb5, 0xa4, 0x7e, 0xaa,
+	0x70, 0x3c, 0x64, 0x62, 0x12, 0x52, 0x3f, 0xa2, 0xb6, 0x70, 0xb8, 0x1f, 0x65, 0x6d, 0x0d, 0xa8,
+	0x52, 0xdb, 0xe6, 0xb1, 0x9f, 0xf5, 0xcd, 0xa0, 0xdc, 0xd0, 0x8e, 0xc3, 0x88, 0x87, 0xe9, 0xe1,
+	0x52, 0x24, 0xcf, 0xec, 0x3a, 0x9e, 0x23, 0x8c, 0x52, 0x57, 0xe9, 0x95, 0xad, 0x04, 0x90, 0x37,
+	0xa0, 0x2d, 0x9c, 0x90, 0x61, 0x73, 0x63, 0xbf, 0xab, 0xf4, 0x1a, 0x83, 0x93, 0xfe, 0xae, 0x31,
+	0xfd, 0xf7, 0x99, 0xc0, 0xda, 0x6a, 0xc9, 0x19, 0xc0, 0x32, 0xe4, 0xde, 0x6c, 0xee, 0x72, 0x7b,
+	0x63, 0x94, 0xbb, 0x4a, 0xaf, 0x64, 0x69, 0x92, 0x79, 0x27, 0x09, 0x72, 0x02, 0x35, 0xc1, 0xd3,
+	0xc5, 0x0a, 0x2e, 0x56, 0x05, 0x4f, 0x96, 0x9e, 0x41, 0x03, 0x2b, 0x85, 0xe3, 0xb1, 0x48, 0x50,
+	0x2f, 0x30, 0xaa, 0x28, 0x38, 0x90, 0xec, 0x24, 0x23, 0xc9, 0x39, 0xd4, 0x05, 0xcf, 0x89, 0x6a,
+	0x28, 0xd2, 0x05, 0xdf, 0x4a, 0x5a, 0x50, 0x16, 0x7c, 0xc3, 0x7c, 0x43, 0x4b, 0x62, 0x40, 0x60,
+	0xde, 0x41, 0xeb, 0x81, 0x69, 0x81, 0x7b, 0x4f, 0x2e, 0xa0, 0x2e, 0x72, 0xa4, 0xa1, 0x74, 0x4b,
+	0x3d, 0x7d, 0x70, 0x5a, 0xbc, 0x6d, 0xae, 0xd0, 0xda, 0x29, 0x20, 0x1d, 0xd0, 0x7d, 0x76, 0x27,
+	0x66, 0x3b, 0xf6, 0x82, 0xa4, 0x2e, 0x91, 0x31, 0xbf, 0xab, 0xa0, 0xe7, 0xca, 0x49, 0x03, 0x54,
+	0xc1, 0xd3, 0x7c, 0x54, 0xc1, 0x65, 0x34, 0xd4, 0xc3, 0xcc, 0x54, 0xbc, 0x4c, 0x8a, 0x08, 0x81,
+	0x7d, 0x79, 0x77, 0x4c, 0x46, 0xb3, 0xf0, 0x5f, 0x72, 0x6b, 0x1a, 0xad, 0x31, 0x13, 0xcd, 0xc2,
+	0x7f, 0xf2, 0x04, 0xb4, 0xad, 0x1f, 0xa9, 0xe5, 0x22, 0x6f, 0x18, 0xfa, 0x3d, 0x5b, 0x33, 0x67,
+	0xb5, 0x16, 0xa9, 0xed, 0x3a, 0x72, 0x1f, 0x91, 0x92, 0xa9, 0xac, 0x68, 0x34, 0x8b, 0x23, 0xb6,
+	0x48, 0x4d, 0xaf, 0xae, 0x68, 0x34, 0x8d, 0xd8, 0x82, 0x9c, 0x82, 0x26, 0x97, 0x82, 0xd0, 0xb1,
+	0x59, 0xea, 0xb5, 0xd4, 0x7e, 0x96, 0xf8, 0x71, 0xa3, 0x25, 0x7b, 0x43, 0xdd, 0x98, 0x19, 0x90,
+	0xb0, 0x08, 0x92, 0xe4, 0x37, 0xcc, 0x9f, 0x09, 0x6e, 0xe8, 0xc9, 0xd3, 0x44, 0x3c, 0xe1, 0x2f,
+	0x5e, 0x81, 0xf6, 0xfb, 0x2d, 0x91, 0x2a, 0x94, 0xde, 0x8e, 0xbe, 0x1e, 0xee, 0x91, 0x3a, 0xd4,
+	0xae, 0x46, 0x97, 0xe3, 0xeb, 0xab, 0xd1, 0xf0, 0x50, 0x91, 0x68, 0x3c, 0x9d, 0x0c, 0xc7, 0x12,
+	0xa9, 0x83, 0x1f, 0x0a, 0xd4, 0x3f, 0xc8, 0xe1, 0xba, 0xa6, 0x41, 0xe0, 0xf8, 0x2b, 0xf2, 0x09,
+	0x6a, 0xd9, 0xd8, 0x90, 0x4e, 0x31, 0xba, 0xc2, 0xf8, 0xb6, 0xcf, 0xfe, 0x2c, 0x90, 0xe3, 0xb5,
+	0x47, 0x2c, 0x80, 0xed, 0xcc, 0x91, 0xf3, 0xa2, 0xfc, 0xc1, 0x34, 0xb7, 0x3b, 0x7f, 0x93, 0x60,
+	0xcf, 0xc1, 0x2d, 0x90, 0xdc, 0x1b, 0xf8, 0xc2, 0xc2, 0x1b, 0xe9, 0x20, 0x85, 0x66, 0xe1, 0x51,
+	0x92, 0xe7, 0xc5, 0x5e, 0x8f, 0x8f, 0x7a, 0xfb, 0xe9, 0x3f, 0x75, 0xb8, 0xf1, 0xbc, 0x82, 0xb2,
+	0xd7, 0xbf, 0x06, 0x00, 0x5a, 0xc1, 0xd5, 0xf2, 0xe3, 0x04, 0x00, 0x00,
 }
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	"github.com/kowala-tech/kcoin/wallet-backend/application/command"
+)
+
+//NewPreflightTransactionHandler creates an http.Handler for the use case of checking a signed transaction
+//before broadcasting it.
+func NewPreflightTransactionHandler(log log.Logger, cmd command.PreflightTransactionHandler) http.Handler {
+	return setHandlerCors(
+		&preflightTransactionHandler{
+			logger:                  log,
+			preflightTransactionCmd: cmd,
+		},
+	)
+}
+
+type preflightTransactionHandler struct {
+	logger                  log.Logger
+	preflightTransactionCmd command.PreflightTransactionHandler
+}
+
+func (h *preflightTransactionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+
+	v := mux.Vars(r)
+
+	rawTx, ok := v["rawtx"]
+	if !ok {
+		h.logger.Log(
+			"type",
+			"alert",
+			"msg",
+			"raw transaction required",
+			"action",
+			"preflighttx",
+		)
+		json.NewEncoder(w).Encode(getErrorResponse(errors.New("raw transaction required")))
+		return
+	}
+
+	rawTxAsBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		h.logger.Log(
+			"type",
+			"alert",
+			"msg",
+			fmt.Sprintf("Error with signed transaction: %s", err),
+			"action",
+			"preflighttx",
+		)
+		json.NewEncoder(w).Encode(getErrorResponse(errors.New("error with signed transaction")))
+		return
+	}
+
+	resp, err := h.preflightTransactionCmd.Handle(ctx, rawTxAsBytes)
+	if err != nil {
+		h.logger.Log(
+			"type",
+			"alert",
+			"msg",
+			fmt.Sprintf("Error checking transaction: %s", err),
+			"action",
+			"preflighttx",
+		)
+		json.NewEncoder(w).Encode(getErrorResponse(fmt.Errorf("error checking transaction: %s", err)))
+		return
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
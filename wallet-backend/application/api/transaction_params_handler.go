@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/mux"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/wallet-backend/application/command"
+)
+
+//NewTransactionParamsHandler returns an http.Handler for the use case of suggesting the gas price and nonce
+//of the next transaction of an account.
+func NewTransactionParamsHandler(log log.Logger, cmd command.GetTransactionParamsHandler) http.Handler {
+	return setHandlerCors(
+		&transactionParamsHandler{
+			logger:               log,
+			transactionParamsCmd: cmd,
+		},
+	)
+}
+
+type transactionParamsHandler struct {
+	logger               log.Logger
+	transactionParamsCmd command.GetTransactionParamsHandler
+}
+
+func (h *transactionParamsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := context.Background()
+
+	v := mux.Vars(r)
+
+	account, ok := v["account"]
+	if !ok {
+		h.logger.Log(
+			"type",
+			"alert",
+			"msg",
+			"account required",
+			"action",
+			"txparams",
+		)
+		json.NewEncoder(w).Encode(getErrorResponse(errors.New("account required")))
+		return
+	}
+
+	resp, err := h.transactionParamsCmd.Handle(ctx, common.HexToAddress(account))
+	if err != nil {
+		h.logger.Log(
+			"type",
+			"alert",
+			"msg",
+			"error getting transaction params "+err.Error(),
+			"action",
+			"txparams",
+		)
+		json.NewEncoder(w).Encode(getErrorResponse(err))
+		return
+	}
+
+	json.NewEncoder(w).Encode(resp)
+}
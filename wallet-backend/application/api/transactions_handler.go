@@ -17,8 +17,14 @@ import (
 )
 
 const (
-	fromBlockRequestVar = "fromblock"
-	toBlockRequestVar   = "toblock"
+	fromBlockRequestVar     = "fromblock"
+	toBlockRequestVar       = "toblock"
+	fromTimestampRequestVar = "fromtimestamp"
+	toTimestampRequestVar   = "totimestamp"
+	directionRequestVar     = "direction"
+	tokenRequestVar         = "token"
+	cursorRequestVar        = "cursor"
+	limitRequestVar         = "limit"
 )
 
 //NewGetTransactionsHandler returns an http.Handler for the use case of getting transactions of a given
@@ -56,16 +62,52 @@ func (h *getTransactionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	q := r.URL.Query()
+
+	// The block range can be given in the path or in the query string.
+	for _, key := range []string{fromBlockRequestVar, toBlockRequestVar} {
+		if v[key] == "" {
+			v[key] = q.Get(key)
+		}
+	}
+
 	from, to, err := h.parseRange(v)
 	if err != nil {
 		json.NewEncoder(w).Encode(getErrorResponse(err))
 		return
 	}
 
+	fromTimestamp, err := parseOptionalInt(q.Get(fromTimestampRequestVar), "invalid fromtimestamp field")
+	if err != nil {
+		json.NewEncoder(w).Encode(getErrorResponse(err))
+		return
+	}
+
+	toTimestamp, err := parseOptionalInt(q.Get(toTimestampRequestVar), "invalid totimestamp field")
+	if err != nil {
+		json.NewEncoder(w).Encode(getErrorResponse(err))
+		return
+	}
+
+	limit := 0
+	if q.Get(limitRequestVar) != "" {
+		limit, err = strconv.Atoi(q.Get(limitRequestVar))
+		if err != nil || limit < 0 {
+			json.NewEncoder(w).Encode(getErrorResponse(errors.New("invalid limit field")))
+			return
+		}
+	}
+
 	cmd := command.GetTransactions{
-		Address: common.HexToAddress(account),
-		From:    from,
-		To:      to,
+		Address:       common.HexToAddress(account),
+		From:          from,
+		To:            to,
+		FromTimestamp: fromTimestamp,
+		ToTimestamp:   toTimestamp,
+		Direction:     q.Get(directionRequestVar),
+		Token:         q.Get(tokenRequestVar),
+		Cursor:        q.Get(cursorRequestVar),
+		Limit:         limit,
 	}
 
 	resp, err := h.getTransactionsCmd.Handle(ctx, cmd)
@@ -85,25 +127,32 @@ func (h *getTransactionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(resp)
 }
 
-//parseRange parses from request vars the from and to values to use as range
-//to get transactions from.
-func (h *getTransactionsHandler) parseRange(vars map[string]string) (from *big.Int, to *big.Int, err error) {
-	if vars[fromBlockRequestVar] == "" || vars[toBlockRequestVar] == "" {
-		return from, to, nil
+//parseOptionalInt parses an optional integer request value, returning nil if it is empty.
+func parseOptionalInt(value string, errMsg string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
 	}
 
-	f, err := strconv.Atoi(vars[fromBlockRequestVar])
+	i, err := strconv.ParseInt(value, 10, 64)
 	if err != nil {
-		return nil, nil, errors.New("invalid from field")
+		return nil, errors.New(errMsg)
 	}
 
-	t, err := strconv.Atoi(vars[toBlockRequestVar])
+	return big.NewInt(i), nil
+}
+
+//parseRange parses from request vars the from and to values to use as range
+//to get transactions from. Any of them can be omitted to leave the range open.
+func (h *getTransactionsHandler) parseRange(vars map[string]string) (from *big.Int, to *big.Int, err error) {
+	from, err = parseOptionalInt(vars[fromBlockRequestVar], "invalid from field")
 	if err != nil {
-		return nil, nil, errors.New("invalid to field")
+		return nil, nil, err
 	}
 
-	from = big.NewInt(int64(f))
-	to = big.NewInt(int64(t))
+	to, err = parseOptionalInt(vars[toBlockRequestVar], "invalid to field")
+	if err != nil {
+		return nil, nil, err
+	}
 
 	return from, to, nil
 }
@@ -0,0 +1,212 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	kowala "github.com/kowala-tech/kcoin/client"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/rlp"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain"
+)
+
+//Codes of the problems found while checking a transaction before broadcasting it.
+const (
+	PreflightInvalidTransaction       = "invalid_transaction"
+	PreflightWrongChainID             = "wrong_chain_id"
+	PreflightInvalidSender            = "invalid_sender"
+	PreflightInvalidSponsor           = "invalid_sponsor"
+	PreflightNonceTooLow              = "nonce_too_low"
+	PreflightInsufficientFunds        = "insufficient_funds"
+	PreflightInsufficientSponsorFunds = "insufficient_sponsor_funds"
+	PreflightGasTooLow                = "gas_too_low"
+	PreflightExecutionFailed          = "execution_failed"
+)
+
+//Codes of the warnings about a transaction that is valid but won't be mined right away.
+const (
+	PreflightNonceTooHigh = "nonce_too_high"
+)
+
+//PreflightTransactionHandler represents the use case of checking a signed transaction against the current
+//state of the blockchain before broadcasting it.
+type PreflightTransactionHandler struct {
+	Client blockchain.Client
+}
+
+//Handle decodes the signed raw transaction and checks its chain ID, sender, sponsor, nonce, balance and gas.
+//Problems with the transaction are reported in the PreflightTransactionResponse, the error is only returned if
+//the blockchain could not be queried.
+func (h *PreflightTransactionHandler) Handle(ctx context.Context, rawTx []byte) (*PreflightTransactionResponse, error) {
+	resp := &PreflightTransactionResponse{}
+
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(rawTx, tx); err != nil {
+		return resp.withError(PreflightInvalidTransaction, err.Error()), nil
+	}
+	resp.Hash = tx.Hash().String()
+	resp.Nonce = tx.Nonce()
+	resp.Gas = tx.Gas()
+	resp.FeeCap = tx.FeeCap()
+	resp.Sponsored = tx.Sponsored()
+
+	chainID, err := h.Client.NetworkID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	//unprotected transactions can be replayed on any chain
+	if tx.Protected() && tx.ChainID().Cmp(chainID) != 0 {
+		return resp.withError(PreflightWrongChainID, fmt.Sprintf("chain ID %s doesn't match the network chain ID %s", tx.ChainID(), chainID)), nil
+	}
+	signer := types.NewAndromedaSigner(chainID)
+
+	from, err := types.TxSender(signer, tx)
+	if err != nil {
+		return resp.withError(PreflightInvalidSender, err.Error()), nil
+	}
+	resp.From = from.String()
+
+	var sponsor common.Address
+	if tx.Sponsored() {
+		if sponsor, err = types.TxSponsor(signer, tx); err != nil {
+			return resp.withError(PreflightInvalidSponsor, err.Error()), nil
+		}
+		resp.Sponsor = sponsor.String()
+	}
+
+	nonce, err := h.Client.PendingNonceAt(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	resp.ExpectedNonce = nonce
+
+	switch {
+	case tx.Nonce() < nonce:
+		resp.withError(PreflightNonceTooLow, fmt.Sprintf("nonce %d is lower than the next nonce %d", tx.Nonce(), nonce))
+	case tx.Nonce() > nonce:
+		resp.withWarning(PreflightNonceTooHigh, fmt.Sprintf("nonce %d is higher than the next nonce %d, the transaction is queued until the gap is filled", tx.Nonce(), nonce))
+	}
+
+	msg := kowala.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	if tx.FeeCap() != nil {
+		msg.FeeCap = tx.FeeCap()
+	} else {
+		msg.GasPrice = tx.GasPrice()
+	}
+	//the sponsor pays the fee of the estimated gas, like it does once the transaction is mined
+	if tx.Sponsored() {
+		msg.Sponsor = &sponsor
+	}
+
+	//the gas price of stable fee transactions is derived from the fee cap at the oracle price, their fee is
+	//unknown if the estimate fails
+	gasPrice := tx.GasPrice()
+	var estimateErr error
+	if tx.FeeCap() != nil {
+		gasPrice = nil
+		estimate, err := h.Client.EstimateFee(ctx, msg)
+		if err != nil {
+			estimateErr = err
+		} else {
+			resp.EstimatedGas = estimate.Gas
+			gasPrice = core.StableFeeGasPrice(tx.FeeCap(), estimate.OraclePrice, tx.Gas())
+		}
+	} else {
+		resp.EstimatedGas, estimateErr = h.Client.EstimateGas(ctx, msg)
+	}
+
+	resp.Cost = tx.Value()
+	if gasPrice != nil {
+		resp.GasPrice = gasPrice
+		resp.Fee = new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tx.Gas()))
+		if !tx.Sponsored() {
+			resp.Cost.Add(resp.Cost, resp.Fee)
+		}
+	}
+
+	balance, err := h.Client.BalanceAt(ctx, from, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp.Balance = balance
+
+	if balance.Cmp(resp.Cost) < 0 {
+		resp.withError(PreflightInsufficientFunds, fmt.Sprintf("balance %s is lower than the cost %s", balance, resp.Cost))
+	}
+
+	if tx.Sponsored() {
+		sponsorBalance, err := h.Client.BalanceAt(ctx, sponsor, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp.SponsorBalance = sponsorBalance
+
+		if resp.Fee != nil && sponsorBalance.Cmp(resp.Fee) < 0 {
+			resp.withError(PreflightInsufficientSponsorFunds, fmt.Sprintf("sponsor balance %s is lower than the fee %s", sponsorBalance, resp.Fee))
+		}
+	}
+
+	if estimateErr != nil {
+		resp.withError(PreflightExecutionFailed, estimateErr.Error())
+	} else if resp.EstimatedGas > tx.Gas() {
+		resp.withError(PreflightGasTooLow, fmt.Sprintf("gas %d is lower than the estimated %d", tx.Gas(), resp.EstimatedGas))
+	}
+
+	resp.Valid = len(resp.Errors) == 0
+
+	return resp, nil
+}
+
+//PreflightError describes a problem that would make the transaction fail, or a warning about a transaction that
+//would stay pending.
+type PreflightError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+//PreflightTransactionResponse represents the response of the use case of checking a signed transaction.
+type PreflightTransactionResponse struct {
+	Valid          bool             `json:"valid"`
+	Hash           string           `json:"hash,omitempty"`
+	From           string           `json:"from,omitempty"`
+	Sponsored      bool             `json:"sponsored"`
+	Sponsor        string           `json:"sponsor,omitempty"`
+	Nonce          uint64           `json:"nonce"`
+	ExpectedNonce  uint64           `json:"expected_nonce"`
+	Gas            uint64           `json:"gas"`
+	EstimatedGas   uint64           `json:"estimated_gas"`
+	GasPrice       *big.Int         `json:"gas_price,omitempty"`
+	FeeCap         *big.Int         `json:"fee_cap,omitempty"`
+	Fee            *big.Int         `json:"fee,omitempty"`
+	Cost           *big.Int         `json:"cost,omitempty"`
+	Balance        *big.Int         `json:"balance,omitempty"`
+	SponsorBalance *big.Int         `json:"sponsor_balance,omitempty"`
+	Errors         []PreflightError `json:"errors,omitempty"`
+	Warnings       []PreflightError `json:"warnings,omitempty"`
+}
+
+func (r *PreflightTransactionResponse) withError(code string, message string) *PreflightTransactionResponse {
+	r.Errors = append(r.Errors, PreflightError{
+		Code:    code,
+		Message: message,
+	})
+
+	return r
+}
+
+func (r *PreflightTransactionResponse) withWarning(code string, message string) *PreflightTransactionResponse {
+	r.Warnings = append(r.Warnings, PreflightError{
+		Code:    code,
+		Message: message,
+	})
+
+	return r
+}
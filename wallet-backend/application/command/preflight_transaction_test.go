@@ -0,0 +1,216 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	kowala "github.com/kowala-tech/kcoin/client"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/kowala-tech/kcoin/client/kcoinclient"
+	"github.com/kowala-tech/kcoin/client/rlp"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightTransaction(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+
+	tx, err := types.SignTx(
+		types.NewTransaction(5, to, big.NewInt(1000), 21000, big.NewInt(1), nil),
+		types.NewAndromedaSigner(big.NewInt(1)),
+		key,
+	)
+	require.NoError(t, err)
+	rawTx, err := rlp.EncodeToBytes(tx)
+	require.NoError(t, err)
+
+	msg := kowala.CallMsg{
+		From:     from,
+		To:       &to,
+		GasPrice: big.NewInt(1),
+		Value:    big.NewInt(1000),
+		Data:     []byte{},
+	}
+
+	t.Run("Valid transaction", func(t *testing.T) {
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(5), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(1000000), nil)
+		mockedClient.On("EstimateGas", ctx, msg).Return(uint64(21000), nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		assert.True(t, resp.Valid)
+		assert.Empty(t, resp.Errors)
+		assert.Equal(t, from.String(), resp.From)
+		assert.Equal(t, tx.Hash().String(), resp.Hash)
+		assert.Equal(t, uint64(21000), resp.EstimatedGas)
+		assert.Equal(t, big.NewInt(22000), resp.Cost)
+	})
+
+	t.Run("Nonce too high is a warning", func(t *testing.T) {
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(4), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(1000000), nil)
+		mockedClient.On("EstimateGas", ctx, msg).Return(uint64(21000), nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		assert.True(t, resp.Valid)
+		assert.Empty(t, resp.Errors)
+		require.Len(t, resp.Warnings, 1)
+		assert.Equal(t, PreflightNonceTooHigh, resp.Warnings[0].Code)
+	})
+
+	t.Run("Wrong chain ID", func(t *testing.T) {
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(2), nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, PreflightWrongChainID, resp.Errors[0].Code)
+		mockedClient.AssertExpectations(t)
+	})
+
+	t.Run("Every problem is reported", func(t *testing.T) {
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(6), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(10), nil)
+		mockedClient.On("EstimateGas", ctx, msg).Return(uint64(30000), nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		assert.False(t, resp.Valid)
+		codes := make([]string, 0)
+		for _, e := range resp.Errors {
+			codes = append(codes, e.Code)
+		}
+		assert.Equal(t, []string{PreflightNonceTooLow, PreflightInsufficientFunds, PreflightGasTooLow}, codes)
+	})
+
+	t.Run("Failed execution", func(t *testing.T) {
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(5), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(1000000), nil)
+		mockedClient.On("EstimateGas", ctx, msg).Return(uint64(0), errors.New("gas required exceeds allowance or always failing transaction"))
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, PreflightExecutionFailed, resp.Errors[0].Code)
+	})
+
+	t.Run("Sponsored transaction", func(t *testing.T) {
+		sponsorKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		sponsor := crypto.PubkeyToAddress(sponsorKey.PublicKey)
+		signer := types.NewAndromedaSigner(big.NewInt(1))
+
+		tx, err := types.SignTx(types.NewSponsoredTransaction(5, &to, big.NewInt(1000), 21000, big.NewInt(2), nil, nil), signer, key)
+		require.NoError(t, err)
+		tx, err = types.SignSponsorTx(tx, signer, sponsorKey)
+		require.NoError(t, err)
+		rawTx, err := rlp.EncodeToBytes(tx)
+		require.NoError(t, err)
+
+		sponsoredMsg := msg
+		sponsoredMsg.GasPrice = big.NewInt(2)
+		sponsoredMsg.Sponsor = &sponsor
+
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(5), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(1000), nil)
+		mockedClient.On("BalanceAt", ctx, sponsor, (*big.Int)(nil)).Return(big.NewInt(20000), nil)
+		mockedClient.On("EstimateGas", ctx, sponsoredMsg).Return(uint64(21000), nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		// the gas is estimated with the sponsor paying, the sender only pays the value and the sponsor can't
+		// pay the fee
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, PreflightInsufficientSponsorFunds, resp.Errors[0].Code)
+		assert.Equal(t, sponsor.String(), resp.Sponsor)
+		assert.Equal(t, big.NewInt(1000), resp.Cost)
+		assert.Equal(t, big.NewInt(42000), resp.Fee)
+	})
+
+	t.Run("Stable fee transaction", func(t *testing.T) {
+		feeCap := big.NewInt(2)
+		oraclePrice := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+		tx, err := types.SignTx(types.NewStableFeeTransaction(5, &to, big.NewInt(1000), 21000, feeCap, nil), types.NewAndromedaSigner(big.NewInt(1)), key)
+		require.NoError(t, err)
+		rawTx, err := rlp.EncodeToBytes(tx)
+		require.NoError(t, err)
+
+		stableMsg := msg
+		stableMsg.GasPrice = nil
+		stableMsg.FeeCap = feeCap
+
+		mockedClient := &mocks.Client{}
+		mockedClient.On("NetworkID", ctx).Return(big.NewInt(1), nil)
+		mockedClient.On("PendingNonceAt", ctx, from).Return(uint64(5), nil)
+		mockedClient.On("BalanceAt", ctx, from, (*big.Int)(nil)).Return(big.NewInt(1000), nil)
+		mockedClient.On("EstimateFee", ctx, stableMsg).Return(&kcoinclient.FeeEstimate{Gas: 21000, OraclePrice: oraclePrice}, nil)
+
+		hdl := PreflightTransactionHandler{Client: mockedClient}
+
+		resp, err := hdl.Handle(ctx, rawTx)
+		require.NoError(t, err)
+
+		// two cents at one USD per kcoin, at the gas price rounded down
+		fee, _ := new(big.Int).SetString("19999999999980000", 10)
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, PreflightInsufficientFunds, resp.Errors[0].Code)
+		assert.Equal(t, fee, resp.Fee)
+		assert.Equal(t, new(big.Int).Add(fee, big.NewInt(1000)), resp.Cost)
+	})
+
+	t.Run("Invalid raw transaction", func(t *testing.T) {
+		hdl := PreflightTransactionHandler{Client: &mocks.Client{}}
+
+		resp, err := hdl.Handle(ctx, []byte("invalid"))
+		require.NoError(t, err)
+
+		assert.False(t, resp.Valid)
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, PreflightInvalidTransaction, resp.Errors[0].Code)
+	})
+}
@@ -0,0 +1,40 @@
+package command
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain"
+)
+
+//GetTransactionParamsHandler represents the use case of suggesting the gas price and nonce that an account
+//should use to sign its next transaction.
+type GetTransactionParamsHandler struct {
+	Client blockchain.Client
+}
+
+//Handle executes the use case and returns a TransactionParamsResponse with the suggestions, or an error if
+//there was a problem querying the blockchain.
+func (h *GetTransactionParamsHandler) Handle(ctx context.Context, address common.Address) (*TransactionParamsResponse, error) {
+	gasPrice, err := h.Client.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := h.Client.PendingNonceAt(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransactionParamsResponse{
+		GasPrice: gasPrice,
+		Nonce:    nonce,
+	}, nil
+}
+
+//TransactionParamsResponse represents the response of the use case of suggesting transaction params.
+type TransactionParamsResponse struct {
+	GasPrice *big.Int `json:"gas_price"`
+	Nonce    uint64   `json:"nonce"`
+}
@@ -0,0 +1,28 @@
+package command
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactionParams(t *testing.T) {
+	ctx := context.Background()
+	addr := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	mockedClient := &mocks.Client{}
+	mockedClient.On("SuggestGasPrice", ctx).Return(big.NewInt(2), nil)
+	mockedClient.On("PendingNonceAt", ctx, addr).Return(uint64(7), nil)
+
+	hdl := GetTransactionParamsHandler{Client: mockedClient}
+
+	resp, err := hdl.Handle(ctx, addr)
+	require.NoError(t, err)
+
+	assert.Equal(t, &TransactionParamsResponse{GasPrice: big.NewInt(2), Nonce: 7}, resp)
+}
@@ -2,6 +2,7 @@ package command
 
 import (
 	"context"
+	"errors"
 	"math/big"
 
 	"github.com/kowala-tech/kcoin/client/common"
@@ -9,12 +10,28 @@ import (
 	"github.com/kowala-tech/kcoin/wallet-backend/protocolbuffer"
 )
 
+//Transaction directions relative to the requested account.
+const (
+	DirectionAny      = ""
+	DirectionIncoming = "in"
+	DirectionOutgoing = "out"
+)
+
+//ErrInvalidDirection is returned when the direction of the transactions is not in or out.
+var ErrInvalidDirection = errors.New("invalid direction, must be in or out")
+
 //GetTransactions represents the parameters needed to perform the use case for getting the transactions of a
-//given account.
+//given account. Nil or empty values disable the corresponding filter.
 type GetTransactions struct {
-	Address common.Address
-	From    *big.Int
-	To      *big.Int
+	Address       common.Address
+	From          *big.Int
+	To            *big.Int
+	FromTimestamp *big.Int
+	ToTimestamp   *big.Int
+	Direction     string
+	Token         string
+	Cursor        string
+	Limit         int
 }
 
 //GetTransactionsHandler represents the use case of getting the transactions sent or received from a given account.
@@ -27,6 +44,33 @@ type GetTransactionsHandler struct {
 func (h *GetTransactionsHandler) Handle(ctx context.Context, cmd GetTransactions) (*TransactionsResponse, error) {
 	req := &protocolbuffer.GetTransactionsRequest{
 		Account: cmd.Address.String(),
+		Cursor:  cmd.Cursor,
+		Limit:   int32(cmd.Limit),
+		Token:   cmd.Token,
+	}
+
+	switch cmd.Direction {
+	case DirectionAny:
+		req.Direction = protocolbuffer.Direction_ANY
+	case DirectionIncoming:
+		req.Direction = protocolbuffer.Direction_INCOMING
+	case DirectionOutgoing:
+		req.Direction = protocolbuffer.Direction_OUTGOING
+	default:
+		return nil, ErrInvalidDirection
+	}
+
+	if cmd.From != nil {
+		req.FromBlock = cmd.From.Int64()
+	}
+	if cmd.To != nil {
+		req.ToBlock = cmd.To.Int64()
+	}
+	if cmd.FromTimestamp != nil {
+		req.FromTimestamp = cmd.FromTimestamp.Int64()
+	}
+	if cmd.ToTimestamp != nil {
+		req.ToTimestamp = cmd.ToTimestamp.Int64()
 	}
 
 	txsResp, err := h.Client.GetTransactions(ctx, req)
@@ -42,43 +86,38 @@ func (h *GetTransactionsHandler) Handle(ctx context.Context, cmd GetTransactions
 				Hash:        tx.Hash,
 				From:        tx.From,
 				To:          tx.To,
-				Amount:      big.NewInt(tx.Amount),
+				Amount:      getAmount(tx),
 				Timestamp:   big.NewInt(tx.Timestamp),
 				BlockHeight: big.NewInt(tx.BlockHeight),
 				GasUsed:     big.NewInt(tx.GasUsed),
 				GasPrice:    big.NewInt(tx.GasPrice),
+				Token:       tx.Token,
+				TokenTo:     tx.TokenTo,
 			},
 		)
 	}
 
-	rangeIsSpecified := cmd.From != nil && cmd.To != nil
-	if rangeIsSpecified {
-		txs = filterTxsByRange(txs, cmd.From, cmd.To)
-	}
-
 	resp := &TransactionsResponse{
 		Transactions: txs,
+		NextCursor:   txsResp.NextCursor,
 	}
 
 	return resp, nil
 }
 
-func filterTxsByRange(txs []*blockchain.Transaction, from *big.Int, to *big.Int) []*blockchain.Transaction {
-	filteredTransactions := make([]*blockchain.Transaction, 0)
-
-	for _, tx := range txs {
-		isBiggerOrEqualThanFrom := tx.BlockHeight.Cmp(from) >= 0
-		isSmallerOrEqualThanTo := tx.BlockHeight.Cmp(to) <= 0
-
-		if isBiggerOrEqualThanFrom && isSmallerOrEqualThanTo {
-			filteredTransactions = append(filteredTransactions, tx)
-		}
+//getAmount returns the amount transferred, which is only available as an integer for the
+//transactions saved before the exact amount was.
+func getAmount(tx *protocolbuffer.Transaction) *big.Int {
+	if amount, ok := new(big.Int).SetString(tx.Value, 10); ok {
+		return amount
 	}
 
-	return filteredTransactions
+	return big.NewInt(tx.Amount)
 }
 
 //TransactionsResponse represents the response with the transactions sent or received from a given account.
+//NextCursor is empty when there are no more transactions to fetch.
 type TransactionsResponse struct {
 	Transactions []*blockchain.Transaction `json:"transactions"`
+	NextCursor   string                    `json:"next_cursor,omitempty"`
 }
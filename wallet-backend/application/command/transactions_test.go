@@ -69,26 +69,18 @@ func TestGetTransactions(t *testing.T) {
 		}
 
 		req := &protocolbuffer.GetTransactionsRequest{
-			Account: addr.String(),
+			Account:   addr.String(),
+			FromBlock: 100,
+			ToBlock:   150,
 		}
 
 		mockedResponse := &protocolbuffer.GetTransactionsReply{
 			Transactions: []*protocolbuffer.Transaction{
-				{
-					From:        addr.String(),
-					To:          "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a",
-					BlockHeight: 99,
-				},
 				{
 					From:        addr.String(),
 					To:          "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a",
 					BlockHeight: 102,
 				},
-				{
-					From:        addr.String(),
-					To:          "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a",
-					BlockHeight: 151,
-				},
 			},
 		}
 
@@ -107,4 +99,103 @@ func TestGetTransactions(t *testing.T) {
 		assert.Equal(t, "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a", tx.To)
 		assert.Equal(t, big.NewInt(102), tx.BlockHeight)
 	})
+
+	t.Run("Paginate and filter transactions", func(t *testing.T) {
+		mockedClient := &mocks.TransactionServiceClient{}
+
+		handl := GetTransactionsHandler{
+			Client: mockedClient,
+		}
+
+		cmd := GetTransactions{
+			Address:       addr,
+			FromTimestamp: big.NewInt(1000),
+			ToTimestamp:   big.NewInt(2000),
+			Direction:     DirectionOutgoing,
+			Token:         "0x0000000000000000000000000000000000001234",
+			Cursor:        "00000000000000000102:0x01",
+			Limit:         1,
+		}
+
+		req := &protocolbuffer.GetTransactionsRequest{
+			Account:       addr.String(),
+			Cursor:        "00000000000000000102:0x01",
+			Limit:         1,
+			Direction:     protocolbuffer.Direction_OUTGOING,
+			FromTimestamp: 1000,
+			ToTimestamp:   2000,
+			Token:         "0x0000000000000000000000000000000000001234",
+		}
+
+		mockedResponse := &protocolbuffer.GetTransactionsReply{
+			Transactions: []*protocolbuffer.Transaction{
+				{
+					From:        addr.String(),
+					To:          "0x0000000000000000000000000000000000001234",
+					BlockHeight: 99,
+					Token:       "0x0000000000000000000000000000000000001234",
+					TokenTo:     "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a",
+					Value:       "100000000000000000000000",
+				},
+			},
+			NextCursor: "00000000000000000099:0x02",
+		}
+
+		mockedClient.On("GetTransactions", context.Background(), req).
+			Return(mockedResponse, nil)
+
+		resp, err := handl.Handle(context.Background(), cmd)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		assert.Len(t, resp.Transactions, 1)
+		tx := resp.Transactions[0]
+		amount, _ := new(big.Int).SetString("100000000000000000000000", 10)
+
+		assert.Equal(t, "0x0000000000000000000000000000000000001234", tx.Token)
+		assert.Equal(t, "0xdbdfdbce9a34c3ac5546657f651146d88d1b639a", tx.TokenTo)
+		assert.Equal(t, amount, tx.Amount)
+		assert.Equal(t, "00000000000000000099:0x02", resp.NextCursor)
+	})
+
+	t.Run("Transactions saved without the exact amount", func(t *testing.T) {
+		mockedClient := &mocks.TransactionServiceClient{}
+
+		handl := GetTransactionsHandler{
+			Client: mockedClient,
+		}
+
+		req := &protocolbuffer.GetTransactionsRequest{
+			Account: addr.String(),
+		}
+
+		mockedResponse := &protocolbuffer.GetTransactionsReply{
+			Transactions: []*protocolbuffer.Transaction{
+				{
+					From:   addr.String(),
+					Amount: 1000,
+				},
+			},
+		}
+
+		mockedClient.On("GetTransactions", context.Background(), req).
+			Return(mockedResponse, nil)
+
+		resp, err := handl.Handle(context.Background(), GetTransactions{Address: addr})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		assert.Equal(t, big.NewInt(1000), resp.Transactions[0].Amount)
+	})
+
+	t.Run("Invalid direction", func(t *testing.T) {
+		handl := GetTransactionsHandler{
+			Client: &mocks.TransactionServiceClient{},
+		}
+
+		_, err := handl.Handle(context.Background(), GetTransactions{Address: addr, Direction: "sideways"})
+		assert.Equal(t, ErrInvalidDirection, err)
+	})
 }
@@ -1,30 +1,33 @@
 package websocket
 
 import (
+	"context"
+	"encoding/hex"
 	"fmt"
 	"net/http"
 
-	"encoding/json"
-
-	"context"
-
-	"bytes"
-
 	"github.com/go-kit/kit/log"
 	"github.com/gorilla/websocket"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/event"
 	"github.com/kowala-tech/kcoin/wallet-backend/application/command"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain"
 )
 
+//BlockFeed is a source of new blocks used to push notifications to the subscribers.
+type BlockFeed interface {
+	SubscribeBlocks(ch chan<- *blockchain.Block) event.Subscription
+}
+
 //Handler is an http.Handler used to bind a websocket connection to all use cases that are sent
 //through websocket.
 type Handler struct {
-	Logger      log.Logger
-	GetBlockCmd command.GetBlockHeightHandler
-}
-
-//Request encapsulates a request sent through a websocket to the handler.
-type Request struct {
-	Action string `json:"action"`
+	Logger             log.Logger
+	GetBlockCmd        command.GetBlockHeightHandler
+	GetBalanceCmd      command.GetBalanceHandler
+	GetTransactionsCmd command.GetTransactionsHandler
+	BroadcastTxCmd     command.BroadcastTransactionHandler
+	Blocks             BlockFeed
 }
 
 func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -40,12 +43,50 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	defer conn.Close()
 
-	h.readAndHandle(conn)
+	s := newSession(conn)
+
+	if h.Blocks != nil {
+		blocks := make(chan *blockchain.Block, 16)
+		sub := h.Blocks.SubscribeBlocks(blocks)
+		defer sub.Unsubscribe()
+
+		go h.pushNotifications(s, blocks, sub)
+	}
+
+	h.readAndHandle(s)
+}
+
+func (h *Handler) pushNotifications(s *session, blocks <-chan *blockchain.Block, sub event.Subscription) {
+	for {
+		select {
+		case block := <-blocks:
+			if err := s.notifyBlock(block); err != nil {
+				h.Logger.Log(
+					"type",
+					"alert",
+					"msg",
+					fmt.Sprintf("Error pushing notification: %s", err),
+				)
+			}
+		case err := <-sub.Err():
+			//a session that can't keep up with the blocks is closed rather than holding the other ones back
+			if err != nil {
+				h.Logger.Log(
+					"type",
+					"alert",
+					"msg",
+					fmt.Sprintf("Closing socket: %s", err),
+				)
+				s.conn.Close()
+			}
+			return
+		}
+	}
 }
 
-func (h *Handler) readAndHandle(conn *websocket.Conn) {
+func (h *Handler) readAndHandle(s *session) {
 	for {
-		_, msg, err := conn.ReadMessage()
+		_, msg, err := s.conn.ReadMessage()
 		if err != nil {
 			h.Logger.Log(
 				"type",
@@ -64,58 +105,111 @@ func (h *Handler) readAndHandle(conn *websocket.Conn) {
 				"msg",
 				fmt.Sprintf("Invalid request: %s", err),
 			)
-			break
+			s.write(&Response{Error: errInvalidRequest.Error()})
+			continue
 		}
 
-		if wsReq.Action == "" {
+		result, err := h.executeAction(s, wsReq)
+		if err != nil {
 			h.Logger.Log(
 				"type",
 				"alert",
 				"msg",
-				"Invalid request action",
+				fmt.Sprintf("Error executing action %s: %s", wsReq.Action, err),
 			)
-			break
+			s.write(&Response{ID: wsReq.ID, Action: wsReq.Action, Error: err.Error()})
+			continue
 		}
 
-		resp, err := h.executeAction(wsReq)
-		if err != nil {
-			h.Logger.Log(
-				"type",
-				"alert",
-				"msg",
-				fmt.Sprintf("Error getting block: %s", err),
-			)
-			break
+		if wsReq.ID == "" {
+			s.write(result)
+			continue
 		}
 
-		conn.WriteMessage(websocket.TextMessage, resp)
+		s.write(&Response{ID: wsReq.ID, Action: wsReq.Action, Result: result})
 	}
 }
 
-func (h *Handler) executeAction(request *Request) ([]byte, error) {
+func (h *Handler) executeAction(s *session, request *Request) (interface{}, error) {
 	ctx := context.Background()
-	var response []byte
-	b := bytes.NewBuffer(response)
 
-	if request.Action == "blockheight" {
-		blockHeight, err := h.GetBlockCmd.Handle(ctx)
+	switch request.Action {
+	case ActionBlockHeight:
+		return h.GetBlockCmd.Handle(ctx)
+
+	case ActionBalance:
+		var params AccountParams
+		if err := parseParams(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Account == "" {
+			return nil, errAccountRequired
+		}
+
+		return h.GetBalanceCmd.Handle(ctx, common.HexToAddress(params.Account))
+
+	case ActionTransactions:
+		var params TransactionsParams
+		if err := parseParams(request, &params); err != nil {
+			return nil, err
+		}
+		if params.Account == "" {
+			return nil, errAccountRequired
+		}
+
+		return h.GetTransactionsCmd.Handle(ctx, command.GetTransactions{
+			Address:       common.HexToAddress(params.Account),
+			From:          params.FromBlock,
+			To:            params.ToBlock,
+			FromTimestamp: params.FromTimestamp,
+			ToTimestamp:   params.ToTimestamp,
+			Direction:     params.Direction,
+			Token:         params.Token,
+			Cursor:        params.Cursor,
+			Limit:         params.Limit,
+		})
+
+	case ActionBroadcast:
+		var params BroadcastParams
+		if err := parseParams(request, &params); err != nil {
+			return nil, err
+		}
+		if params.RawTx == "" {
+			return nil, errRawTxRequired
+		}
+
+		rawTx, err := hex.DecodeString(params.RawTx)
 		if err != nil {
+			return nil, errInvalidParams
+		}
+
+		return h.BroadcastTxCmd.Handle(ctx, rawTx)
+
+	case ActionSubscribe:
+		var params SubscribeParams
+		if err := parseParams(request, &params); err != nil {
 			return nil, err
 		}
 
-		json.NewEncoder(b).Encode(blockHeight)
-	}
+		id, err := s.subscribe(params)
+		if err != nil {
+			return nil, err
+		}
 
-	return b.Bytes(), nil
-}
+		return &SubscribeResult{Subscription: id}, nil
 
-func parseMsg(msg []byte) (*Request, error) {
-	var r Request
+	case ActionUnsubscribe:
+		var params UnsubscribeParams
+		if err := parseParams(request, &params); err != nil {
+			return nil, err
+		}
 
-	err := json.Unmarshal(msg, &r)
-	if err != nil {
-		return nil, err
+		if err := s.unsubscribe(params.Subscription); err != nil {
+			return nil, err
+		}
+
+		return &SubscribeResult{Subscription: params.Subscription}, nil
 	}
 
-	return &r, nil
+	return nil, errInvalidAction
 }
@@ -0,0 +1,152 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-kit/kit/log"
+	"github.com/gorilla/websocket"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/event"
+	"github.com/kowala-tech/kcoin/wallet-backend/application/command"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type testBlockFeed struct {
+	feed event.Feed
+}
+
+func (f *testBlockFeed) SubscribeBlocks(ch chan<- *blockchain.Block) event.Subscription {
+	return f.feed.Subscribe(ch)
+}
+
+func setup(t *testing.T, client *mocks.Client, feed BlockFeed) (*websocket.Conn, func()) {
+	handler := &Handler{
+		Logger:         log.NewLogfmtLogger(os.Stderr),
+		GetBlockCmd:    command.GetBlockHeightHandler{Client: client},
+		GetBalanceCmd:  command.GetBalanceHandler{Client: client},
+		BroadcastTxCmd: command.BroadcastTransactionHandler{Client: client},
+		Blocks:         feed,
+	}
+
+	server := httptest.NewServer(handler)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+
+	return conn, func() {
+		conn.Close()
+		server.Close()
+	}
+}
+
+func readMessage(t *testing.T, conn *websocket.Conn) map[string]interface{} {
+	var msg map[string]interface{}
+	require.NoError(t, conn.ReadJSON(&msg))
+	return msg
+}
+
+func TestHandler_Requests(t *testing.T) {
+	addr := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+
+	client := &mocks.Client{}
+	client.On("BlockNumber", mock.Anything).Return(big.NewInt(10), nil)
+	client.On("BalanceAt", mock.Anything, addr, (*big.Int)(nil)).Return(big.NewInt(100), nil)
+	client.On("SendRawTransaction", mock.Anything, []byte{0x01}).Return(errors.New("invalid tx"))
+
+	conn, teardown := setup(t, client, nil)
+	defer teardown()
+
+	t.Run("Legacy request without id gets the bare result", func(t *testing.T) {
+		require.NoError(t, conn.WriteJSON(&Request{Action: ActionBlockHeight}))
+		assert.Equal(t, map[string]interface{}{"block_height": float64(10)}, readMessage(t, conn))
+	})
+
+	t.Run("Request with id gets a response", func(t *testing.T) {
+		params, _ := json.Marshal(&AccountParams{Account: addr.String()})
+		require.NoError(t, conn.WriteJSON(&Request{ID: "1", Action: ActionBalance, Params: params}))
+
+		msg := readMessage(t, conn)
+		assert.Equal(t, "1", msg["id"])
+		assert.Equal(t, map[string]interface{}{"balance": float64(100)}, msg["result"])
+	})
+
+	t.Run("Errors do not close the socket", func(t *testing.T) {
+		params, _ := json.Marshal(&BroadcastParams{RawTx: "01"})
+		require.NoError(t, conn.WriteJSON(&Request{ID: "2", Action: ActionBroadcast, Params: params}))
+		msg := readMessage(t, conn)
+		assert.Equal(t, "2", msg["id"])
+		assert.Equal(t, "invalid tx", msg["error"])
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("not json")))
+		assert.Equal(t, errInvalidRequest.Error(), readMessage(t, conn)["error"])
+
+		require.NoError(t, conn.WriteJSON(&Request{ID: "3", Action: "unknown"}))
+		assert.Equal(t, errInvalidAction.Error(), readMessage(t, conn)["error"])
+
+		require.NoError(t, conn.WriteJSON(&Request{ID: "4", Action: ActionBlockHeight}))
+		assert.Equal(t, "4", readMessage(t, conn)["id"])
+	})
+}
+
+func TestHandler_Subscriptions(t *testing.T) {
+	watched := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+	other := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+
+	feed := &testBlockFeed{}
+	conn, teardown := setup(t, &mocks.Client{}, feed)
+	defer teardown()
+
+	params, _ := json.Marshal(&SubscribeParams{
+		Channel:   ChannelTransactions,
+		Addresses: []string{strings.ToLower(watched.String())},
+		Direction: command.DirectionIncoming,
+	})
+	require.NoError(t, conn.WriteJSON(&Request{ID: "1", Action: ActionSubscribe, Params: params}))
+	msg := readMessage(t, conn)
+	require.Empty(t, msg["error"])
+	subID := msg["result"].(map[string]interface{})["subscription"]
+
+	params, _ = json.Marshal(&SubscribeParams{Channel: "unknown"})
+	require.NoError(t, conn.WriteJSON(&Request{ID: "2", Action: ActionSubscribe, Params: params}))
+	assert.Equal(t, errInvalidChannel.Error(), readMessage(t, conn)["error"])
+
+	feed.feed.Send(&blockchain.Block{
+		Number: big.NewInt(5),
+		Transactions: []*blockchain.Transaction{
+			{Hash: "0x01", From: watched.String(), To: other.String()},
+			{Hash: "0x02", From: other.String(), To: watched.String()},
+			{Hash: "0x03", From: other.String(), To: other.String(), Token: other.String(), TokenTo: watched.String()},
+		},
+	})
+
+	msg = readMessage(t, conn)
+	assert.Equal(t, subID, msg["subscription"])
+	assert.Equal(t, ChannelTransactions, msg["channel"])
+	assert.Equal(t, "0x02", msg["data"].(map[string]interface{})["hash"])
+
+	msg = readMessage(t, conn)
+	assert.Equal(t, "0x03", msg["data"].(map[string]interface{})["hash"])
+
+	params, _ = json.Marshal(&UnsubscribeParams{Subscription: subID.(string)})
+	require.NoError(t, conn.WriteJSON(&Request{ID: "3", Action: ActionUnsubscribe, Params: params}))
+	assert.Empty(t, readMessage(t, conn)["error"])
+
+	params, _ = json.Marshal(&SubscribeParams{Channel: ChannelNewBlocks})
+	require.NoError(t, conn.WriteJSON(&Request{ID: "4", Action: ActionSubscribe, Params: params}))
+	assert.Empty(t, readMessage(t, conn)["error"])
+
+	feed.feed.Send(&blockchain.Block{Number: big.NewInt(6)})
+
+	msg = readMessage(t, conn)
+	assert.Equal(t, ChannelNewBlocks, msg["channel"])
+	assert.Equal(t, map[string]interface{}{"block_height": float64(6)}, msg["data"])
+}
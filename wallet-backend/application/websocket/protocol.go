@@ -0,0 +1,122 @@
+package websocket
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+)
+
+//Actions that can be requested through the websocket.
+const (
+	ActionBlockHeight  = "blockheight"
+	ActionBalance      = "balance"
+	ActionTransactions = "transactions"
+	ActionBroadcast    = "broadcast"
+	ActionSubscribe    = "subscribe"
+	ActionUnsubscribe  = "unsubscribe"
+)
+
+//Channels that can be subscribed to through the websocket.
+const (
+	ChannelNewBlocks    = "newblocks"
+	ChannelTransactions = "transactions"
+)
+
+var (
+	errInvalidRequest      = errors.New("invalid request")
+	errInvalidAction       = errors.New("invalid request action")
+	errInvalidParams       = errors.New("invalid request params")
+	errAccountRequired     = errors.New("account required")
+	errRawTxRequired       = errors.New("raw transaction required")
+	errInvalidChannel      = errors.New("invalid subscription channel")
+	errAddressesRequired   = errors.New("addresses required")
+	errUnknownSubscription = errors.New("unknown subscription")
+)
+
+//Request encapsulates a request sent through a websocket to the handler. Requests without an ID
+//are answered with the bare result, as the first version of the protocol did.
+type Request struct {
+	ID     string          `json:"id,omitempty"`
+	Action string          `json:"action"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+//Response is sent back for every request with the same ID and either a result or an error.
+type Response struct {
+	ID     string      `json:"id,omitempty"`
+	Action string      `json:"action"`
+	Result interface{} `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+//Notification is pushed to the client for every event matching one of its subscriptions.
+type Notification struct {
+	Subscription string      `json:"subscription"`
+	Channel      string      `json:"channel"`
+	Data         interface{} `json:"data"`
+}
+
+//AccountParams are the params of the balance action.
+type AccountParams struct {
+	Account string `json:"account"`
+}
+
+//TransactionsParams are the params of the transactions action, they match the query
+//parameters of the transactions endpoint of the api.
+type TransactionsParams struct {
+	Account       string   `json:"account"`
+	FromBlock     *big.Int `json:"fromblock,omitempty"`
+	ToBlock       *big.Int `json:"toblock,omitempty"`
+	FromTimestamp *big.Int `json:"fromtimestamp,omitempty"`
+	ToTimestamp   *big.Int `json:"totimestamp,omitempty"`
+	Direction     string   `json:"direction,omitempty"`
+	Token         string   `json:"token,omitempty"`
+	Cursor        string   `json:"cursor,omitempty"`
+	Limit         int      `json:"limit,omitempty"`
+}
+
+//BroadcastParams are the params of the broadcast action, RawTx is hex encoded.
+type BroadcastParams struct {
+	RawTx string `json:"rawtx"`
+}
+
+//SubscribeParams are the params of the subscribe action. Addresses and Direction are only
+//used by the transactions channel.
+type SubscribeParams struct {
+	Channel   string   `json:"channel"`
+	Addresses []string `json:"addresses,omitempty"`
+	Direction string   `json:"direction,omitempty"`
+}
+
+//UnsubscribeParams are the params of the unsubscribe action.
+type UnsubscribeParams struct {
+	Subscription string `json:"subscription"`
+}
+
+//SubscribeResult is the result of the subscribe action.
+type SubscribeResult struct {
+	Subscription string `json:"subscription"`
+}
+
+func parseMsg(msg []byte) (*Request, error) {
+	var r Request
+
+	err := json.Unmarshal(msg, &r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+func parseParams(request *Request, params interface{}) error {
+	if len(request.Params) == 0 {
+		return errInvalidParams
+	}
+
+	if err := json.Unmarshal(request.Params, params); err != nil {
+		return errInvalidParams
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package websocket
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/wallet-backend/application/command"
+	"github.com/kowala-tech/kcoin/wallet-backend/blockchain"
+)
+
+const writeTimeout = 10 * time.Second
+
+type subscription struct {
+	id        string
+	channel   string
+	direction string
+	addresses map[string]struct{}
+}
+
+//matches returns true if the transaction involves one of the watched addresses in
+//the direction of the subscription. The recipient of a token transfer receives it
+//just like the token contract does.
+func (s *subscription) matches(tx *blockchain.Transaction) bool {
+	_, isFrom := s.addresses[strings.ToLower(tx.From)]
+	_, isTo := s.addresses[strings.ToLower(tx.To)]
+	if tx.TokenTo != "" {
+		_, isTokenTo := s.addresses[strings.ToLower(tx.TokenTo)]
+		isTo = isTo || isTokenTo
+	}
+
+	switch s.direction {
+	case command.DirectionIncoming:
+		return isTo
+	case command.DirectionOutgoing:
+		return isFrom
+	default:
+		return isFrom || isTo
+	}
+}
+
+//session holds the state of a websocket connection. Writes are serialized because
+//responses and notifications are sent from different goroutines.
+type session struct {
+	conn *websocket.Conn
+
+	writeMu sync.Mutex
+
+	mu     sync.Mutex
+	subs   map[string]*subscription
+	lastID uint64
+}
+
+func newSession(conn *websocket.Conn) *session {
+	return &session{
+		conn: conn,
+		subs: make(map[string]*subscription),
+	}
+}
+
+func (s *session) write(v interface{}) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	s.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return s.conn.WriteJSON(v)
+}
+
+func (s *session) subscribe(params SubscribeParams) (string, error) {
+	sub := &subscription{
+		channel:   params.Channel,
+		direction: params.Direction,
+		addresses: make(map[string]struct{}),
+	}
+
+	switch params.Channel {
+	case ChannelNewBlocks:
+	case ChannelTransactions:
+		if len(params.Addresses) == 0 {
+			return "", errAddressesRequired
+		}
+
+		switch params.Direction {
+		case command.DirectionAny, command.DirectionIncoming, command.DirectionOutgoing:
+		default:
+			return "", command.ErrInvalidDirection
+		}
+
+		for _, addr := range params.Addresses {
+			if !common.IsHexAddress(addr) {
+				return "", errInvalidParams
+			}
+			sub.addresses[strings.ToLower(common.HexToAddress(addr).String())] = struct{}{}
+		}
+	default:
+		return "", errInvalidChannel
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	sub.id = strconv.FormatUint(s.lastID, 10)
+	s.subs[sub.id] = sub
+
+	return sub.id, nil
+}
+
+func (s *session) unsubscribe(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.subs[id]; !ok {
+		return errUnknownSubscription
+	}
+	delete(s.subs, id)
+
+	return nil
+}
+
+//notifyBlock pushes the block and its transactions to the matching subscriptions.
+func (s *session) notifyBlock(block *blockchain.Block) error {
+	var notifications []*Notification
+
+	s.mu.Lock()
+	for _, sub := range s.subs {
+		switch sub.channel {
+		case ChannelNewBlocks:
+			notifications = append(notifications, &Notification{
+				Subscription: sub.id,
+				Channel:      sub.channel,
+				Data:         block,
+			})
+		case ChannelTransactions:
+			for _, tx := range block.Transactions {
+				if sub.matches(tx) {
+					notifications = append(notifications, &Notification{
+						Subscription: sub.id,
+						Channel:      sub.channel,
+						Data:         tx,
+					})
+				}
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	for _, n := range notifications {
+		if err := s.write(n); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/contracts/bindings/token"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/event"
+)
+
+//Block represents a new block inside the domain of the wallet backend.
+type Block struct {
+	Number       *big.Int       `json:"block_height"`
+	Transactions []*Transaction `json:"-"`
+}
+
+//ErrSlowSubscriber is sent on the error channel of a subscription dropped because its channel was full.
+var ErrSlowSubscriber = errors.New("block subscriber dropped, its channel is full")
+
+//BlockWatcher polls a blockchain client for new blocks and sends them to its subscribers.
+type BlockWatcher struct {
+	client   Client
+	interval time.Duration
+
+	mu   sync.Mutex
+	subs map[chan<- *Block]chan struct{}
+}
+
+//NewBlockWatcher returns a BlockWatcher that asks the client for new blocks every interval.
+func NewBlockWatcher(client Client, interval time.Duration) *BlockWatcher {
+	return &BlockWatcher{
+		client:   client,
+		interval: interval,
+		subs:     make(map[chan<- *Block]chan struct{}),
+	}
+}
+
+//SubscribeBlocks registers a channel to receive every new block. Blocks are never waited on: a subscriber
+//whose channel is full is dropped, and ErrSlowSubscriber is sent on the error channel of its subscription.
+func (w *BlockWatcher) SubscribeBlocks(ch chan<- *Block) event.Subscription {
+	dropped := make(chan struct{})
+
+	w.mu.Lock()
+	w.subs[ch] = dropped
+	w.mu.Unlock()
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		select {
+		case <-quit:
+			w.mu.Lock()
+			if w.subs[ch] == dropped {
+				delete(w.subs, ch)
+			}
+			w.mu.Unlock()
+			return nil
+		case <-dropped:
+			return ErrSlowSubscriber
+		}
+	})
+}
+
+//send delivers the block to every subscriber that has room for it and drops the others.
+func (w *BlockWatcher) send(block *Block) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for ch, dropped := range w.subs {
+		select {
+		case ch <- block:
+		default:
+			delete(w.subs, ch)
+			close(dropped)
+		}
+	}
+}
+
+//Run polls for new blocks until the context is cancelled. The first block sent is the one
+//that follows the block height at the time Run is called.
+func (w *BlockWatcher) Run(ctx context.Context) error {
+	latest, err := w.client.BlockNumber(ctx)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		current, err := w.client.BlockNumber(ctx)
+		if err != nil {
+			continue
+		}
+
+		for latest.Cmp(current) < 0 {
+			next := new(big.Int).Add(latest, common.Big1)
+
+			block, err := w.client.BlockByNumber(ctx, next)
+			if err != nil {
+				break
+			}
+
+			w.send(wrapBlock(block))
+			latest = next
+		}
+	}
+}
+
+func wrapBlock(block *types.Block) *Block {
+	txs := make([]*Transaction, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		from, err := tx.From()
+		if err != nil {
+			continue
+		}
+
+		to := "0x0"
+		if tx.To() != nil {
+			to = tx.To().String()
+		}
+
+		wrapped := &Transaction{
+			Hash:        tx.Hash().String(),
+			From:        from.String(),
+			To:          to,
+			Amount:      tx.Value(),
+			Timestamp:   block.Time(),
+			BlockHeight: block.Number(),
+			GasUsed:     new(big.Int).SetUint64(block.GasUsed()),
+			GasPrice:    tx.GasPrice(),
+		}
+
+		//token transfers keep the contract as recipient, like the transactions saved by the notifications service
+		if tokenTo, tokenValue, ok := token.DecodeTransfer(tx.Data()); ok && tx.To() != nil {
+			wrapped.Token = to
+			wrapped.TokenTo = tokenTo.String()
+			wrapped.Amount = tokenValue
+		}
+
+		txs = append(txs, wrapped)
+	}
+
+	return &Block{
+		Number:       block.Number(),
+		Transactions: txs,
+	}
+}
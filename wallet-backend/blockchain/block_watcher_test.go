@@ -0,0 +1,71 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockWatcher_DropsSlowSubscribers(t *testing.T) {
+	w := NewBlockWatcher(nil, 0)
+
+	fast := make(chan *Block, 2)
+	fastSub := w.SubscribeBlocks(fast)
+	defer fastSub.Unsubscribe()
+
+	slow := make(chan *Block, 1)
+	slowSub := w.SubscribeBlocks(slow)
+	defer slowSub.Unsubscribe()
+
+	w.send(&Block{Number: big.NewInt(1)})
+	w.send(&Block{Number: big.NewInt(2)})
+
+	assert.Equal(t, ErrSlowSubscriber, <-slowSub.Err())
+	assert.Len(t, slow, 1)
+	assert.Len(t, fast, 2)
+
+	fastSub.Unsubscribe()
+	w.send(&Block{Number: big.NewInt(3)})
+	assert.Len(t, fast, 2)
+	assert.Empty(t, w.subs)
+}
+
+func TestWrapBlock_TokenTransfer(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	contract := common.HexToAddress("0xdbdfdbce9a34c3ac5546657f651146d88d1b639a")
+	recipient := common.HexToAddress("0xd6e579085c82329c89fca7a9f012be59028ed53f")
+	value, _ := new(big.Int).SetString("12345678901234567890123", 10)
+
+	data := append([]byte{}, crypto.Keccak256([]byte("transfer(address,uint256)"))[:4]...)
+	data = append(data, common.LeftPadBytes(recipient.Bytes(), common.HashLength)...)
+	data = append(data, common.LeftPadBytes(value.Bytes(), common.HashLength)...)
+
+	signer := types.NewAndromedaSigner(big.NewInt(1))
+	transfer, err := types.SignTx(types.NewTransaction(0, contract, new(big.Int), 60000, big.NewInt(1), data), signer, key)
+	require.NoError(t, err)
+	payment, err := types.SignTx(types.NewTransaction(1, recipient, big.NewInt(1000), 21000, big.NewInt(1), nil), signer, key)
+	require.NoError(t, err)
+
+	block := wrapBlock(types.NewBlock(&types.Header{Number: big.NewInt(5)}, []*types.Transaction{transfer, payment}, nil, nil))
+	require.Len(t, block.Transactions, 2)
+
+	tx := block.Transactions[0]
+	assert.Equal(t, from.String(), tx.From)
+	assert.Equal(t, contract.String(), tx.To)
+	assert.Equal(t, contract.String(), tx.Token)
+	assert.Equal(t, recipient.String(), tx.TokenTo)
+	assert.Equal(t, value, tx.Amount)
+
+	tx = block.Transactions[1]
+	assert.Equal(t, recipient.String(), tx.To)
+	assert.Empty(t, tx.Token)
+	assert.Empty(t, tx.TokenTo)
+	assert.Equal(t, big.NewInt(1000), tx.Amount)
+}
@@ -4,12 +4,21 @@ import (
 	"context"
 	"math/big"
 
+	kowala "github.com/kowala-tech/kcoin/client"
 	"github.com/kowala-tech/kcoin/client/common"
+	"github.com/kowala-tech/kcoin/client/core/types"
+	"github.com/kowala-tech/kcoin/client/kcoinclient"
 )
 
 //Client is an interface of a generic client to connect to a blockchain instance.
 type Client interface {
 	BlockNumber(ctx context.Context) (*big.Int, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
 	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
 	SendRawTransaction(ctx context.Context, rawTx []byte) error
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	EstimateGas(ctx context.Context, msg kowala.CallMsg) (uint64, error)
+	EstimateFee(ctx context.Context, msg kowala.CallMsg) (*kcoinclient.FeeEstimate, error)
+	NetworkID(ctx context.Context) (*big.Int, error)
 }
@@ -4,7 +4,10 @@ import big "math/big"
 
 import common "github.com/kowala-tech/kcoin/client/common"
 import context "context"
+import kcoinclient "github.com/kowala-tech/kcoin/client/kcoinclient"
+import kowala "github.com/kowala-tech/kcoin/client"
 import mock "github.com/stretchr/testify/mock"
+import types "github.com/kowala-tech/kcoin/client/core/types"
 
 // Client is an autogenerated mock type for the Client type
 type Client struct {
@@ -34,6 +37,29 @@ func (_m *Client) BalanceAt(ctx context.Context, account common.Address, blockNu
 	return r0, r1
 }
 
+// BlockByNumber provides a mock function with given fields: ctx, number
+func (_m *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	ret := _m.Called(ctx, number)
+
+	var r0 *types.Block
+	if rf, ok := ret.Get(0).(func(context.Context, *big.Int) *types.Block); ok {
+		r0 = rf(ctx, number)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Block)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *big.Int) error); ok {
+		r1 = rf(ctx, number)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // BlockNumber provides a mock function with given fields: ctx
 func (_m *Client) BlockNumber(ctx context.Context) (*big.Int, error) {
 	ret := _m.Called(ctx)
@@ -57,6 +83,94 @@ func (_m *Client) BlockNumber(ctx context.Context) (*big.Int, error) {
 	return r0, r1
 }
 
+// EstimateFee provides a mock function with given fields: ctx, msg
+func (_m *Client) EstimateFee(ctx context.Context, msg kowala.CallMsg) (*kcoinclient.FeeEstimate, error) {
+	ret := _m.Called(ctx, msg)
+
+	var r0 *kcoinclient.FeeEstimate
+	if rf, ok := ret.Get(0).(func(context.Context, kowala.CallMsg) *kcoinclient.FeeEstimate); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*kcoinclient.FeeEstimate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, kowala.CallMsg) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateGas provides a mock function with given fields: ctx, msg
+func (_m *Client) EstimateGas(ctx context.Context, msg kowala.CallMsg) (uint64, error) {
+	ret := _m.Called(ctx, msg)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, kowala.CallMsg) uint64); ok {
+		r0 = rf(ctx, msg)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, kowala.CallMsg) error); ok {
+		r1 = rf(ctx, msg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NetworkID provides a mock function with given fields: ctx
+func (_m *Client) NetworkID(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PendingNonceAt provides a mock function with given fields: ctx, account
+func (_m *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) uint64); ok {
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SendRawTransaction provides a mock function with given fields: ctx, rawTx
 func (_m *Client) SendRawTransaction(ctx context.Context, rawTx []byte) error {
 	ret := _m.Called(ctx, rawTx)
@@ -70,3 +184,26 @@ func (_m *Client) SendRawTransaction(ctx context.Context, rawTx []byte) error {
 
 	return r0
 }
+
+// SuggestGasPrice provides a mock function with given fields: ctx
+func (_m *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *big.Int
+	if rf, ok := ret.Get(0).(func(context.Context) *big.Int); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*big.Int)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
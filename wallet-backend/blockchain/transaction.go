@@ -12,4 +12,6 @@ type Transaction struct {
 	BlockHeight *big.Int `json:"block_height"`
 	GasUsed     *big.Int `json:"gas_used"`
 	GasPrice    *big.Int `json:"gas_price"`
+	Token       string   `json:"token,omitempty"`
+	TokenTo     string   `json:"token_to,omitempty"`
 }
@@ -33,11 +33,13 @@ const (
 	nodePortConfigKey                    = "node-port"
 	nodeLogzTokenConfigKey               = "logz-token"
 	nodeDefaultNotificationsRPCConfigKey = "notifications-endpoint"
+	nodePollingIntervalConfigKey         = "polling-interval"
 
 	nodeDefaultEndpoint                 = "http://rpcnode.testnet.kowala.io:30503"
 	nodeDefaultPort                     = "8080"
 	nodeDefaultLogzToken                = ""
 	nodeDefaultEndpointNotificationsRPC = "api:3000"
+	nodeDefaultPollingInterval          = 5
 )
 
 var rootCmd *cobra.Command
@@ -84,6 +86,10 @@ func init() {
 	viper.BindPFlag(nodeDefaultNotificationsRPCConfigKey, rootCmd.Flags().Lookup(nodeDefaultNotificationsRPCConfigKey))
 	viper.BindEnv(nodeDefaultNotificationsRPCConfigKey, "TX_MS_ENDPOINT")
 
+	rootCmd.Flags().IntP(nodePollingIntervalConfigKey, "i", nodeDefaultPollingInterval, "Seconds between checks for new blocks to notify websocket subscribers.")
+	viper.BindPFlag(nodePollingIntervalConfigKey, rootCmd.Flags().Lookup(nodePollingIntervalConfigKey))
+	viper.BindEnv(nodePollingIntervalConfigKey, "POLLING_INTERVAL")
+
 	endChan = make(chan bool)
 }
 
@@ -158,7 +164,7 @@ func createRouter(l log.Logger) http.Handler {
 	notificationsConn := createTransactionServiceClient(viper.GetString(nodeDefaultNotificationsRPCConfigKey))
 
 	// Websocket
-	r.Methods("GET").PathPrefix("/ws").Handler(createWebsocketHandler(l, nodeConnection))
+	r.Methods("GET").PathPrefix("/ws").Handler(createWebsocketHandler(l, nodeConnection, notificationsConn))
 
 	r.Methods("GET").Path("/api/blockheight").Handler(createAPIBlockHeightHandler(l, nodeConnection))
 	r.Methods("GET").Path("/api/balance/{account}").Handler(createAPIBalanceHandler(l, nodeConnection))
@@ -167,6 +173,8 @@ func createRouter(l log.Logger) http.Handler {
 	r.Methods("GET").Path("/api/transactions/{account}").Handler(getTransactionsHandler)
 	r.Methods("GET").Path("/api/transactions/{account}/from/{fromblock}/to/{toblock}").Handler(getTransactionsHandler)
 	r.Methods("GET").Path("/api/broadcasttx/{rawtx}").Handler(createBroadcastTransactionHandler(l, nodeConnection))
+	r.Methods("GET").Path("/api/preflighttx/{rawtx}").Handler(createPreflightTransactionHandler(l, nodeConnection))
+	r.Methods("GET").Path("/api/txparams/{account}").Handler(createTransactionParamsHandler(l, nodeConnection))
 
 	return r
 }
@@ -207,6 +215,24 @@ func createBroadcastTransactionHandler(l log.Logger, client blockchain.Client) h
 	)
 }
 
+func createPreflightTransactionHandler(l log.Logger, client blockchain.Client) http.Handler {
+	return api.NewPreflightTransactionHandler(
+		l,
+		command.PreflightTransactionHandler{
+			Client: client,
+		},
+	)
+}
+
+func createTransactionParamsHandler(l log.Logger, client blockchain.Client) http.Handler {
+	return api.NewTransactionParamsHandler(
+		l,
+		command.GetTransactionParamsHandler{
+			Client: client,
+		},
+	)
+}
+
 func createNodeConnection(endpoint string) blockchain.Client {
 	client, err := kcoinclient.Dial(endpoint)
 	if err != nil {
@@ -235,13 +261,45 @@ func heartBeatNodeConnection(client blockchain.Client) {
 	}
 }
 
-func createWebsocketHandler(l log.Logger, client blockchain.Client) *websocket.Handler {
+func createWebsocketHandler(l log.Logger, client blockchain.Client, txClient protocolbuffer.TransactionServiceClient) *websocket.Handler {
 	wsHandler := &websocket.Handler{
 		Logger: l,
 		GetBlockCmd: command.GetBlockHeightHandler{
 			Client: client,
 		},
+		GetBalanceCmd: command.GetBalanceHandler{
+			Client: client,
+		},
+		GetTransactionsCmd: command.GetTransactionsHandler{
+			Client: txClient,
+		},
+		BroadcastTxCmd: command.BroadcastTransactionHandler{
+			Client: client,
+		},
+		Blocks: createBlockWatcher(l, client),
 	}
 
 	return wsHandler
 }
+
+func createBlockWatcher(l log.Logger, client blockchain.Client) *blockchain.BlockWatcher {
+	watcher := blockchain.NewBlockWatcher(
+		client,
+		time.Duration(viper.GetInt(nodePollingIntervalConfigKey))*time.Second,
+	)
+
+	go func() {
+		for {
+			err := watcher.Run(context.Background())
+			l.Log(
+				"type",
+				"alert",
+				"msg",
+				fmt.Sprintf("Error watching new blocks: %s", err),
+			)
+			time.Sleep(10 * time.Second)
+		}
+	}()
+
+	return watcher
+}